@@ -0,0 +1,61 @@
+package types
+
+import "time"
+
+// AutoscalerDecision is the latest decision made by the autoscaler for an
+// inference, together with the inputs used to compute it.
+type AutoscalerDecision struct {
+	// Name is the name of the inference.
+	Name string `json:"name"`
+	// Namespace is the namespace of the inference.
+	Namespace string `json:"namespace"`
+	// Timestamp is the time when the decision is made.
+	Timestamp time.Time `json:"timestamp"`
+
+	Inputs AutoscalerDecisionInputs `json:"inputs"`
+
+	// Replicas is the current number of replicas.
+	Replicas int32 `json:"replicas"`
+	// AvailableReplicas is the current number of available replicas.
+	AvailableReplicas int32 `json:"available_replicas"`
+	// ExpectedReplicas is the number of replicas computed by the autoscaler.
+	ExpectedReplicas int32 `json:"expected_replicas"`
+
+	// Action is the action taken by the autoscaler.
+	Action AutoscalerAction `json:"action"`
+	// Reason is a human readable message explaining the action.
+	Reason string `json:"reason,omitempty"`
+}
+
+// AutoscalerDecisionInputs are the inputs of an autoscaling decision.
+type AutoscalerDecisionInputs struct {
+	// CurrentLoad is the current number of inflight requests.
+	CurrentLoad float64 `json:"current_load"`
+	// CurrentStartedRequests is the number of requests started recently.
+	CurrentStartedRequests float64 `json:"current_started_requests"`
+	// TargetLoad is the expected number of inflight requests per replica.
+	TargetLoad  int32 `json:"target_load"`
+	MinReplicas int32 `json:"min_replicas"`
+	MaxReplicas int32 `json:"max_replicas"`
+	// ZeroDuration is the duration (in seconds) of zero load before scaling
+	// down to zero.
+	ZeroDuration int32 `json:"zero_duration"`
+	// IdleSince is the time since which the inference has been idle.
+	IdleSince *time.Time `json:"idle_since,omitempty"`
+	// CrashLoopCount is the number of instances in CrashLoopBackOff.
+	CrashLoopCount int `json:"crash_loop_count"`
+	// CustomMetricValue is the current value of the custom metric.
+	CustomMetricValue float64 `json:"custom_metric_value,omitempty"`
+	// CustomMetricTarget is the target value of the custom metric per replica.
+	CustomMetricTarget float64 `json:"custom_metric_target,omitempty"`
+}
+
+type AutoscalerAction string
+
+const (
+	AutoscalerActionNone      AutoscalerAction = "none"
+	AutoscalerActionScaleUp   AutoscalerAction = "scale-up"
+	AutoscalerActionScaleDown AutoscalerAction = "scale-down"
+	AutoscalerActionSkip      AutoscalerAction = "skip"
+	AutoscalerActionError     AutoscalerAction = "error"
+)
@@ -0,0 +1,136 @@
+package types
+
+import "time"
+
+// BatchInference runs the model server offline over the items of a dataset.
+type BatchInference struct {
+	Spec   BatchInferenceSpec   `json:"spec"`
+	Status BatchInferenceStatus `json:"status,omitempty"`
+}
+
+type BatchInferenceSpec struct {
+	// Name is the name of the batch inference.
+	Name string `json:"name"`
+
+	// Namespace for the batch inference.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Inference is the name of the inference in the same namespace, its
+	// image, framework, port, command, env vars, secrets and resources are
+	// used. The fields set below override them.
+	Inference string `json:"inference,omitempty"`
+
+	// Image is the image of the model server. It is required if the
+	// inference is not set.
+	Image string `json:"image,omitempty"`
+
+	// Framework provides the default port, command and probe path.
+	Framework Framework `json:"framework,omitempty"`
+
+	// Port is the port of the model server.
+	Port *int32 `json:"port,omitempty"`
+
+	// Command starts the model server.
+	Command *string `json:"command,omitempty"`
+
+	// HTTPProbePath is polled until the model server is ready.
+	HTTPProbePath *string `json:"http_probe_path,omitempty"`
+
+	// EnvVars are set in the container of the model server.
+	EnvVars map[string]string `json:"envVars,omitempty"`
+
+	// Secrets are exposed as the env vars.
+	Secrets []string `json:"secrets,omitempty"`
+
+	// Resources are the compute resource requirements of each pod.
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Input is the dataset.
+	Input BatchInput `json:"input"`
+
+	// Output is where the responses are written, one object per item.
+	Output BatchLocation `json:"output"`
+
+	// Path is the HTTP path of the model endpoint. Default is /.
+	Path string `json:"path,omitempty"`
+
+	// Parallelism is the number of the shards processed in parallel.
+	Parallelism *int32 `json:"parallelism,omitempty"`
+
+	// MaxRetries is the retries of each item before it is failed.
+	MaxRetries *int32 `json:"max_retries,omitempty"`
+
+	// BackoffLimit is the retries of the failed pods.
+	BackoffLimit *int32 `json:"backoff_limit,omitempty"`
+
+	// Cancel stops the batch inference, the written responses are kept.
+	Cancel bool `json:"cancel,omitempty"`
+}
+
+// BatchLocation is a location on the object store or a volume.
+type BatchLocation struct {
+	// URI is s3://<bucket>/<prefix> on the object store, or the path in
+	// the volume claim.
+	URI string `json:"uri"`
+
+	// Endpoint is the endpoint of the S3 compatible storage.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// VolumeClaim is the persistent volume claim which contains the path.
+	VolumeClaim string `json:"volume_claim,omitempty"`
+}
+
+// BatchInput is either a JSONL file or a list of files under the location.
+type BatchInput struct {
+	BatchLocation `json:",inline"`
+
+	// JSONL is the name of the JSONL file, each line is an item.
+	JSONL string `json:"jsonl,omitempty"`
+
+	// Files are the names of the files, each file is an item.
+	Files []string `json:"files,omitempty"`
+}
+
+type BatchInferenceStatus struct {
+	Phase BatchInferencePhase `json:"phase,omitempty"`
+
+	// Shards is the number of the shards.
+	Shards int32 `json:"shards,omitempty"`
+
+	// ActiveShards is the number of the shards being processed.
+	ActiveShards int32 `json:"activeShards,omitempty"`
+
+	// CompletedShards is the number of the shards processed successfully.
+	CompletedShards int32 `json:"completedShards,omitempty"`
+
+	// Total is the number of the items in the started shards.
+	Total int32 `json:"total,omitempty"`
+
+	// Succeeded is the number of the items processed successfully.
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// Failed is the number of the items failed after the retries.
+	Failed int32 `json:"failed,omitempty"`
+
+	// CreatedAt is the time when the batch inference is created.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// StartTime is the time when the job is created.
+	StartTime *time.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the batch inference is finished.
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+
+	// Message is the human readable reason of the phase.
+	Message string `json:"message,omitempty"`
+}
+
+type BatchInferencePhase string
+
+const (
+	BatchInferencePhasePending   BatchInferencePhase = "Pending"
+	BatchInferencePhaseRunning   BatchInferencePhase = "Running"
+	BatchInferencePhaseSucceeded BatchInferencePhase = "Succeeded"
+	BatchInferencePhaseFailed    BatchInferencePhase = "Failed"
+	BatchInferencePhaseCancelled BatchInferencePhase = "Cancelled"
+)
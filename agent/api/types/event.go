@@ -22,3 +22,22 @@ type DeploymentEvent struct {
 	EventType    string    `json:"event_type"`
 	Message      string    `json:"message"`
 }
+
+const (
+	InferenceEventTypeNormal  = "Normal"
+	InferenceEventTypeWarning = "Warning"
+)
+
+// InferenceEvent is a kubernetes event recorded on the inference, it is
+// used by other components (e.g. the autoscaler) to report problems of the
+// inference to the users.
+type InferenceEvent struct {
+	// Type is the type of the event, Normal or Warning.
+	Type string `json:"type"`
+	// Reason is a short, machine understandable string of the event.
+	Reason string `json:"reason"`
+	// Message is a human readable description of the event.
+	Message string `json:"message"`
+	// Source is the component reporting the event.
+	Source string `json:"source,omitempty"`
+}
@@ -1,5 +1,7 @@
 package types
 
+import "github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
+
 // InferenceDeployment represents a request to create or update a Model.
 type InferenceDeployment struct {
 	Spec   InferenceDeploymentSpec   `json:"spec"`
@@ -28,6 +30,10 @@ type InferenceDeploymentSpec struct {
 	// HTTPProbePath is the path of the http probe.
 	HTTPProbePath *string `json:"http_probe_path,omitempty"`
 
+	// Probes configures the startup, readiness and liveness probes
+	// independently. The unset settings use the defaults of the cluster.
+	Probes *Probes `json:"probes,omitempty"`
+
 	// Command to run when starting the
 	Command *string `json:"command,omitempty"`
 
@@ -48,19 +54,46 @@ type InferenceDeploymentSpec struct {
 
 	// Resources are the compute resource requirements.
 	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Model is the source of the model artifacts. They are downloaded into
+	// the cache of the node before the inference starts. The progress is
+	// shown in the instance status, it is patched on the pod if its service
+	// account could patch the pods, otherwise it is read from the logs.
+	Model *ModelSource `json:"model,omitempty"`
+
+	// Scheduling is the placement of the inference across the nodes.
+	Scheduling *SchedulingConfig `json:"scheduling,omitempty"`
+
+	// Rollout checks the health of the rolling update, the inference is
+	// rolled back to the previous revision if the check fails.
+	Rollout *RolloutPolicy `json:"rollout,omitempty"`
+
+	// Termination configures the graceful shutdown of the replicas.
+	Termination *TerminationConfig `json:"termination,omitempty"`
+
+	// DisruptionBudget limits the replicas evicted at the same time by
+	// node drains. It only applies if the min replicas is greater than 1.
+	DisruptionBudget *DisruptionBudgetConfig `json:"disruption_budget,omitempty"`
 }
 
-// Framework is the inference framework. It is only used to set the default port
-// and command. For example, if the framework is "gradio", the default port is
-// 7860 and the default command is "python app.py". You could override these
-// defaults by setting the port and command fields and framework to `other`.
+// Framework is the inference framework. It is only used to set the defaults,
+// e.g. the port, command, env vars and probe path, and the proxy behavior,
+// which are declared in the framework registry of modelzetes. For example, if
+// the framework is "gradio", the default port is 7860. You could override
+// these defaults by setting the port and command fields and framework to
+// `other`. The operators could register the custom frameworks besides the
+// builtin ones below, whose names are declared by the registry.
 type Framework string
 
 const (
-	FrameworkGradio    Framework = "gradio"
-	FrameworkStreamlit Framework = "streamlit"
-	FrameworkMosec     Framework = "mosec"
-	FrameworkOther     Framework = "other"
+	FrameworkGradio    Framework = framework.Gradio
+	FrameworkStreamlit Framework = framework.Streamlit
+	FrameworkMosec     Framework = framework.Mosec
+	FrameworkVLLM      Framework = framework.VLLM
+	FrameworkTGI       Framework = framework.TGI
+	FrameworkTriton    Framework = framework.Triton
+	FrameworkJupyter   Framework = framework.Jupyter
+	FrameworkOther     Framework = framework.Other
 )
 
 type ScalingConfig struct {
@@ -73,12 +106,14 @@ type ScalingConfig struct {
 	MaxReplicas *int32 `json:"max_replicas,omitempty"`
 	// TargetLoad is the target load. In capacity mode, it is the expected number of the inflight requests per replica.
 	TargetLoad *int32 `json:"target_load,omitempty"`
-	// Type is the scaling type. It can be either "capacity" or "rps". Default is "capacity".
+	// Type is the scaling type. It can be "capacity", "rps" or "custom". Default is "capacity".
 	Type *ScalingType `json:"type,omitempty"`
 	// ZeroDuration is the duration (in seconds) of zero load before scaling down to zero. Default is 5 minutes.
 	ZeroDuration *int32 `json:"zero_duration,omitempty"`
 	// StartupDuration is the duration (in seconds) of startup time.
 	StartupDuration *int32 `json:"startup_duration,omitempty"`
+	// CustomMetric is the metric used to scale the inference in custom mode.
+	CustomMetric *CustomMetric `json:"custom_metric,omitempty"`
 }
 
 type ScalingType string
@@ -86,8 +121,253 @@ type ScalingType string
 const (
 	ScalingTypeCapacity ScalingType = "capacity"
 	ScalingTypeRPS      ScalingType = "rps"
+	ScalingTypeCustom   ScalingType = "custom"
 )
 
+// CustomMetric is a prometheus metric used to scale the inference.
+type CustomMetric struct {
+	// Query is the PromQL expression template. {{.Name}} and {{.Namespace}}
+	// are replaced with the name and namespace of the inference.
+	Query string `json:"query"`
+	// TargetValue is the expected value of the metric per replica.
+	TargetValue Quantity `json:"target_value"`
+}
+
+type ModelSourceType string
+
+const (
+	ModelSourceHuggingFace ModelSourceType = "huggingface"
+	ModelSourceS3          ModelSourceType = "s3"
+	ModelSourceHTTP        ModelSourceType = "http"
+)
+
+// ModelSource is the source of the model artifacts, which are mounted
+// read-only into the inference.
+type ModelSource struct {
+	// Type is the type of the source. It can be "huggingface", "s3" or "http".
+	Type ModelSourceType `json:"type"`
+	// URI is the repository ID in huggingface, the s3://bucket/path URL in
+	// s3, or the URL of the tarball in http.
+	URI string `json:"uri"`
+	// Revision is the branch, tag or commit of the huggingface repository.
+	Revision string `json:"revision,omitempty"`
+	// Endpoint is the endpoint of the S3 compatible storage.
+	Endpoint string `json:"endpoint,omitempty"`
+	// MountPath is the path of the model in the inference. Default is /models.
+	MountPath string `json:"mount_path,omitempty"`
+}
+
+// SchedulingConfig is the placement of the inference. It is applied in
+// addition to the constraints.
+type SchedulingConfig struct {
+	// NodeAffinity constrains the nodes which the inference could be
+	// scheduled on.
+	NodeAffinity *NodeAffinity `json:"node_affinity,omitempty"`
+	// Tolerations are added to the inference, in addition to the GPU
+	// tolerations if GPU is requested.
+	Tolerations []Toleration `json:"tolerations,omitempty"`
+	// PodAntiAffinity keeps the replicas of the inference apart.
+	PodAntiAffinity *PodAntiAffinity `json:"pod_anti_affinity,omitempty"`
+	// TopologySpreadConstraints spread the replicas of the inference
+	// across the topology domains, e.g. nodes or zones.
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topology_spread_constraints,omitempty"`
+}
+
+// NodeAffinity is the node affinity of the inference.
+type NodeAffinity struct {
+	// Required terms must be satisfied to schedule the inference. The terms
+	// are ORed, and the expressions in one term are ANDed.
+	Required []NodeSelectorTerm `json:"required,omitempty"`
+	// Preferred terms are satisfied by the scheduler if possible.
+	Preferred []PreferredSchedulingTerm `json:"preferred,omitempty"`
+}
+
+// NodeSelectorTerm is a list of the node selector requirements, which are
+// ANDed.
+type NodeSelectorTerm struct {
+	MatchExpressions []NodeSelectorRequirement `json:"match_expressions,omitempty"`
+}
+
+// NodeSelectorRequirement is an expression on the node labels.
+type NodeSelectorRequirement struct {
+	// Key is the label key.
+	Key string `json:"key"`
+	// Operator is In, NotIn, Exists, DoesNotExist, Gt or Lt.
+	Operator NodeSelectorOperator `json:"operator"`
+	// Values must be empty for Exists and DoesNotExist, and have a single
+	// integer for Gt and Lt.
+	Values []string `json:"values,omitempty"`
+}
+
+type NodeSelectorOperator string
+
+const (
+	NodeSelectorOpIn           NodeSelectorOperator = "In"
+	NodeSelectorOpNotIn        NodeSelectorOperator = "NotIn"
+	NodeSelectorOpExists       NodeSelectorOperator = "Exists"
+	NodeSelectorOpDoesNotExist NodeSelectorOperator = "DoesNotExist"
+	NodeSelectorOpGt           NodeSelectorOperator = "Gt"
+	NodeSelectorOpLt           NodeSelectorOperator = "Lt"
+)
+
+// PreferredSchedulingTerm is a node selector term with the weight.
+type PreferredSchedulingTerm struct {
+	// Weight is in the range 1-100.
+	Weight     int32            `json:"weight"`
+	Preference NodeSelectorTerm `json:"preference"`
+}
+
+// Toleration tolerates the taints of the nodes.
+type Toleration struct {
+	// Key is the taint key. Empty key with operator Exists tolerates all
+	// taints.
+	Key string `json:"key,omitempty"`
+	// Operator is Equal or Exists. Default is Equal.
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	// Effect is NoSchedule, PreferNoSchedule or NoExecute. Empty matches
+	// all effects.
+	Effect string `json:"effect,omitempty"`
+	// TolerationSeconds is how long the inference stays on the node after
+	// the NoExecute taint is added.
+	TolerationSeconds *int64 `json:"toleration_seconds,omitempty"`
+}
+
+// PodAntiAffinity keeps the replicas of the inference in different
+// topology domains.
+type PodAntiAffinity struct {
+	// TopologyKey is the node label of the topology domain, e.g.
+	// kubernetes.io/hostname or topology.kubernetes.io/zone.
+	TopologyKey string `json:"topology_key"`
+	// Required makes the inference unschedulable if the anti affinity
+	// cannot be satisfied. Otherwise it is preferred.
+	Required bool `json:"required,omitempty"`
+	// Weight of the preferred anti affinity in the range 1-100. Default is
+	// 100.
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// TopologySpreadConstraint spreads the replicas of the inference across the
+// topology domains.
+type TopologySpreadConstraint struct {
+	// TopologyKey is the node label of the topology domain.
+	TopologyKey string `json:"topology_key"`
+	// MaxSkew is the maximum difference of the number of replicas between
+	// two domains. Default is 1.
+	MaxSkew int32 `json:"max_skew,omitempty"`
+	// WhenUnsatisfiable is DoNotSchedule or ScheduleAnyway. Default is
+	// ScheduleAnyway.
+	WhenUnsatisfiable string `json:"when_unsatisfiable,omitempty"`
+}
+
+// RolloutPolicy is the health check of the rolling update.
+type RolloutPolicy struct {
+	// ProgressDeadlineSeconds is the maximum duration of the rollout.
+	// Default is 600.
+	ProgressDeadlineSeconds int32 `json:"progress_deadline_seconds,omitempty"`
+	// MinReadyPercent is the percentage of the expected replicas which must
+	// be ready before the deadline. Default is 100.
+	MinReadyPercent int32 `json:"min_ready_percent,omitempty"`
+	// ErrorRate is the optional check of the requests during the rollout.
+	ErrorRate *ErrorRateCheck `json:"error_rate,omitempty"`
+}
+
+// ErrorRateCheck fails the rollout if the percentage of the requests
+// responded with 5xx exceeds the threshold.
+type ErrorRateCheck struct {
+	// MaxErrorPercent is the maximum percentage of the failed requests.
+	MaxErrorPercent int32 `json:"max_error_percent"`
+	// WindowSeconds is the duration of the requests to check. Default is 60.
+	WindowSeconds int32 `json:"window_seconds,omitempty"`
+	// MinRequests is the minimum number of the requests in the window.
+	// Default is 10.
+	MinRequests int32 `json:"min_requests,omitempty"`
+}
+
+// TerminationConfig is the graceful shutdown of the replicas. The unset
+// fields use the defaults of the cluster.
+type TerminationConfig struct {
+	// GracePeriodSeconds is the duration from the start of the termination
+	// to SIGKILL, the in-flight requests should finish in it.
+	GracePeriodSeconds *int64 `json:"grace_period_seconds,omitempty"`
+	// PreStopSleepSeconds delays SIGTERM until the replica is removed from
+	// the endpoints. It runs sleep with sh, the image must contain a shell.
+	// 0 disables it.
+	PreStopSleepSeconds *int32 `json:"pre_stop_sleep_seconds,omitempty"`
+}
+
+// Probes are the health checks of the replicas.
+type Probes struct {
+	// Startup checks if the inference is started, the other probes are
+	// disabled until it succeeds.
+	Startup *Probe `json:"startup,omitempty"`
+	// Readiness checks if the replica is ready to serve the requests.
+	Readiness *Probe `json:"readiness,omitempty"`
+	// Liveness checks if the replica should be restarted.
+	Liveness *Probe `json:"liveness,omitempty"`
+}
+
+// Probe is a health check of the replicas. At most one handler can be set,
+// the http GET of the http probe path is used if none is set.
+type Probe struct {
+	// HTTPGet sends a http GET request to the replica.
+	HTTPGet *HTTPGetAction `json:"http_get,omitempty"`
+	// TCPSocket opens a TCP connection to the replica.
+	TCPSocket *TCPSocketAction `json:"tcp_socket,omitempty"`
+	// GRPC calls the gRPC health checking service of the replica.
+	GRPC *GRPCAction `json:"grpc,omitempty"`
+	// Exec runs the command in the replica.
+	Exec *ExecAction `json:"exec,omitempty"`
+
+	// InitialDelaySeconds is the delay before the first probe.
+	InitialDelaySeconds *int32 `json:"initial_delay_seconds,omitempty"`
+	// PeriodSeconds is the interval between the probes.
+	PeriodSeconds *int32 `json:"period_seconds,omitempty"`
+	// TimeoutSeconds is the timeout of each probe.
+	TimeoutSeconds *int32 `json:"timeout_seconds,omitempty"`
+	// SuccessThreshold is the number of the consecutive successes to be
+	// considered healthy. It must be 1 for the startup and liveness probes.
+	SuccessThreshold *int32 `json:"success_threshold,omitempty"`
+	// FailureThreshold is the number of the consecutive failures to be
+	// considered unhealthy. The startup duration is used for the startup
+	// probe if it is not set.
+	FailureThreshold *int32 `json:"failure_threshold,omitempty"`
+}
+
+// HTTPGetAction is the http GET probe. The path defaults to the http probe
+// path and the port defaults to the port of the inference.
+type HTTPGetAction struct {
+	Path string `json:"path,omitempty"`
+	Port *int32 `json:"port,omitempty"`
+}
+
+// TCPSocketAction is the TCP probe. The port defaults to the port of the
+// inference.
+type TCPSocketAction struct {
+	Port *int32 `json:"port,omitempty"`
+}
+
+// GRPCAction is the gRPC health checking probe. The port defaults to the
+// port of the inference.
+type GRPCAction struct {
+	Port    *int32  `json:"port,omitempty"`
+	Service *string `json:"service,omitempty"`
+}
+
+// ExecAction is the command probe, it is not executed within a shell.
+type ExecAction struct {
+	Command []string `json:"command"`
+}
+
+// DisruptionBudgetConfig is the PodDisruptionBudget of the inference.
+type DisruptionBudgetConfig struct {
+	// Disabled skips creating the budget.
+	Disabled bool `json:"disabled,omitempty"`
+	// MaxUnavailable is the maximum number of the replicas evicted at the
+	// same time. Default is 1.
+	MaxUnavailable *int32 `json:"max_unavailable,omitempty"`
+}
+
 // ResourceRequirements describes the compute resource requirements.
 type ResourceRequirements struct {
 	// Limits describes the maximum amount of compute resources allowed.
@@ -111,6 +391,15 @@ const (
 	ResourceCPU    ResourceName = "cpu"
 	ResourceMemory ResourceName = "memory"
 	ResourceGPU    ResourceName = "gpu"
+	// ResourceGPUShared is the time-sliced GPU shared with other inferences.
+	ResourceGPUShared ResourceName = "gpu.shared"
+	// ResourceMIGPrefix is the prefix of the MIG devices, the name is the
+	// prefix followed by the MIG profile, e.g. mig-1g.10gb.
+	ResourceMIGPrefix = "mig-"
+	// ResourceAMDGPU is the GPU of AMD ROCm.
+	ResourceAMDGPU ResourceName = "amd.com/gpu"
+	// ResourceIntelGPU is the GPU of Intel.
+	ResourceIntelGPU ResourceName = "gpu.intel.com/i915"
 )
 
 type Quantity string
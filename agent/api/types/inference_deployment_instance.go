@@ -18,6 +18,8 @@ type InferenceDeploymentInstanceStatus struct {
 	StartTime time.Time     `json:"createdAt,omitempty"`
 	Reason    string        `json:"reason,omitempty"`
 	Message   string        `json:"message,omitempty"`
+	// RestartCount is the number of times the instance has been restarted.
+	RestartCount int32 `json:"restartCount,omitempty"`
 }
 
 type InstancePhase string
@@ -31,4 +33,7 @@ const (
 	InstancePhaseUnknown      InstancePhase = "Unknown"
 	InstancePhaseCreating     InstancePhase = "Creating"
 	InstancePhaseInitializing InstancePhase = "Initializing"
+	// InstancePhaseDownloading means the model of the inference is being
+	// downloaded, the message is the progress of the download.
+	InstancePhaseDownloading InstancePhase = "Downloading"
 )
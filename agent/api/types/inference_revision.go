@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// InferenceRevision is a recorded change of the inference spec.
+type InferenceRevision struct {
+	// Revision is the number of the revision, it increases with every
+	// change of the spec.
+	Revision int64 `json:"revision"`
+	// CreatedAt is the time when the revision is recorded.
+	CreatedAt time.Time `json:"createdAt"`
+	// Author is the user who changed the spec.
+	Author string `json:"author,omitempty"`
+	// ChangeCause is the human readable cause of the change, e.g. the
+	// rollback.
+	ChangeCause string `json:"changeCause,omitempty"`
+	// Diff is the changed fields compared with the previous revision, in
+	// the format of "<field>: <old> -> <new>".
+	Diff []string `json:"diff,omitempty"`
+	// Spec is the spec of the inference in the revision.
+	Spec InferenceDeploymentSpec `json:"spec"`
+}
@@ -27,6 +27,62 @@ type InferenceDeploymentStatus struct {
 
 	// EventMessage record human readable message indicating details about the event of deployment.
 	EventMessage string `json:"eventMessage,omitempty"`
+
+	// CrashLoop is set by the autoscaler when the instances keep crashing.
+	CrashLoop *CrashLoopState `json:"crashLoop,omitempty"`
+
+	// PendingReason is set by the autoscaler when the expected replicas
+	// cannot be scheduled, e.g. there is no enough GPU in the cluster.
+	PendingReason string `json:"pendingReason,omitempty"`
+
+	// Conditions are the latest observations of the inference reported by
+	// the controller.
+	Conditions []InferenceCondition `json:"conditions,omitempty"`
+
+	// Rollout is the status of the latest rollout, it is only set if the
+	// rollout policy is specified.
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// RolloutStatus is the status of the rollout of the inference.
+type RolloutStatus struct {
+	// Phase is Progressing, Succeeded, Failed or RolledBack.
+	Phase string `json:"phase"`
+	// StartedAt is the time when the rollout starts.
+	StartedAt time.Time `json:"startedAt,omitempty"`
+	// ReadyReplicas is the number of the ready replicas of the new version.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Message is the human readable details of the phase.
+	Message string `json:"message,omitempty"`
+}
+
+// InferenceCondition describes one aspect of the state of the inference,
+// e.g. Ready, Progressing, Degraded, ScaledToZero or ImagePullFailing.
+type InferenceCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// CrashLoopState records that the autoscaler suppresses the inference because
+// its instances keep crashing. The scaling config of the inference is kept
+// as it is, the min replicas is overridden until the instances are healthy.
+type CrashLoopState struct {
+	// OriginalMinReplicas is the min replicas in the scaling config, it is
+	// applied again after the inference recovers.
+	OriginalMinReplicas int32 `json:"originalMinReplicas"`
+	// MinReplicas is the min replicas applied during the suppression.
+	MinReplicas int32 `json:"minReplicas"`
+	// Since is the time when the suppression starts.
+	Since time.Time `json:"since"`
+	// Attempts is the number of recovery attempts.
+	Attempts int32 `json:"attempts"`
+	// LastAttempt is the time of the ongoing recovery attempt.
+	LastAttempt *time.Time `json:"lastAttempt,omitempty"`
+	// NextAttempt is the time of the next recovery attempt.
+	NextAttempt time.Time `json:"nextAttempt"`
 }
 
 type Phase string
@@ -2,4 +2,43 @@ package types
 
 type NamespaceRequest struct {
 	Name string `json:"name,omitempty"`
+
+	// Quota limits the resources used in the namespace. The namespace is
+	// not limited if it is not set.
+	Quota *NamespaceQuota `json:"quota,omitempty"`
+}
+
+// NamespaceQuota limits the resources requested by the inferences and the
+// batch inferences in the namespace. The unset resources are not limited.
+type NamespaceQuota struct {
+	// GPU is the max number of the accelerator devices requested in total,
+	// i.e. the whole GPUs of all the vendors, the shared GPUs and the MIG
+	// devices, each device counts as one. The cluster limits each of the
+	// accelerator resources to the total separately. The total itself is
+	// only checked by the agent against the min replicas of the inferences
+	// created or updated through it, the replicas scaled up by the
+	// autoscaler and the inferences applied to the cluster directly may
+	// exceed it in total.
+	GPU Quantity `json:"gpu,omitempty"`
+
+	// CPU is the max CPU requested.
+	CPU Quantity `json:"cpu,omitempty"`
+
+	// Memory is the max memory requested.
+	Memory Quantity `json:"memory,omitempty"`
+
+	// Inferences is the max number of the inferences.
+	Inferences *int32 `json:"inferences,omitempty"`
+}
+
+// NamespaceQuotaStatus is the usage of the namespace against the quota.
+type NamespaceQuotaStatus struct {
+	// Name of the namespace.
+	Name string `json:"name"`
+
+	// Hard is the quota, it is empty if the namespace is not limited.
+	Hard NamespaceQuota `json:"hard"`
+
+	// Used is the resources requested in the namespace.
+	Used NamespaceQuota `json:"used"`
 }
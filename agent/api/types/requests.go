@@ -15,3 +15,10 @@ type ScaleServiceRequest struct {
 type DeleteFunctionRequest struct {
 	FunctionName string `json:"functionName"`
 }
+
+// PendingReasonRequest updates the pending reason of the inference.
+type PendingReasonRequest struct {
+	// Reason is the reason why the replicas cannot be scheduled,
+	// it is cleared if empty.
+	Reason string `json:"reason"`
+}
@@ -14,4 +14,8 @@ type Secret struct {
 	// RawValue can be used to provide binary data when
 	// Value is not set
 	RawValue []byte `json:"rawValue,omitempty"`
+
+	// Data holds multiple values by the keys, e.g. the entries of
+	// an env file. Value and RawValue are ignored if it is set.
+	Data map[string][]byte `json:"data,omitempty"`
 }
@@ -8,13 +8,42 @@ type Server struct {
 type ServerSpec struct {
 	Name   string            `json:"name,omitempty"`
 	Labels map[string]string `json:"labels,omitempty"`
+	// NodeLabels are all the labels of the node, they are used to match
+	// the constraints of the inferences.
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+	// Taints are the taints of the node, the inferences are not scheduled
+	// on the node unless they tolerate the taints.
+	Taints []Taint `json:"taints,omitempty"`
+}
+
+// Taint is a taint of the node.
+type Taint struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	// Effect is NoSchedule, PreferNoSchedule or NoExecute.
+	Effect string `json:"effect"`
 }
 
 type ServerStatus struct {
-	Allocatable ResourceList   `json:"allocatable,omitempty"`
-	Capacity    ResourceList   `json:"capacity,omitempty"`
-	Phase       string         `json:"phase,omitempty"`
-	System      NodeSystemInfo `json:"system,omitempty"`
+	Allocatable ResourceList `json:"allocatable,omitempty"`
+	Capacity    ResourceList `json:"capacity,omitempty"`
+	// Allocated is the sum of the resource requests of the pods on the server.
+	Allocated ResourceList   `json:"allocated,omitempty"`
+	Phase     string         `json:"phase,omitempty"`
+	System    NodeSystemInfo `json:"system,omitempty"`
+	// Accelerator is the accelerator of the server, it is nil if the
+	// server has no accelerators.
+	Accelerator *ServerAccelerator `json:"accelerator,omitempty"`
+}
+
+// ServerAccelerator is the accelerator detected by the device plugins.
+type ServerAccelerator struct {
+	// Vendor is the vendor of the accelerator, e.g. nvidia, amd or intel.
+	Vendor string `json:"vendor"`
+	// Model is the product name from the node labels of the vendor's
+	// feature discovery. It is empty if the feature discovery is not
+	// deployed.
+	Model string `json:"model,omitempty"`
 }
 
 // NodeSystemInfo is a set of ids/uuids to uniquely identify the node.
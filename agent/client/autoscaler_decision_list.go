@@ -0,0 +1,30 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// AutoscalerDecisionList lists the latest autoscaling decisions.
+// The client should be created with the autoscaler host.
+func (cli *Client) AutoscalerDecisionList(ctx context.Context) (
+	[]types.AutoscalerDecision, error) {
+	resp, err := cli.get(ctx, autoscalerDecisionPath, nil, nil)
+	defer ensureReaderClosed(resp)
+
+	if err != nil {
+		return nil,
+			wrapResponseError(err, resp, "autoscaler", "decisions")
+	}
+
+	var decisions []types.AutoscalerDecision
+	err = json.NewDecoder(resp.body).Decode(&decisions)
+
+	return decisions, wrapResponseError(err, resp, "autoscaler", "decisions")
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// BatchInferenceCreate submits the batch inference in the namespace.
+func (cli *Client) BatchInferenceCreate(ctx context.Context,
+	namespace string, req types.BatchInference) (types.BatchInference, error) {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	resp, err := cli.post(ctx, gatewayBatchInferenceControlPlanePath, urlValues, req, nil)
+	defer ensureReaderClosed(resp)
+
+	if err != nil {
+		return types.BatchInference{}, wrapResponseError(err, resp, "batch inference", req.Spec.Name)
+	}
+
+	var bi types.BatchInference
+	err = json.NewDecoder(resp.body).Decode(&bi)
+	return bi, wrapResponseError(err, resp, "batch inference", req.Spec.Name)
+}
+
+// BatchInferenceList lists the batch inferences in the namespace.
+func (cli *Client) BatchInferenceList(ctx context.Context,
+	namespace string) ([]types.BatchInference, error) {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	resp, err := cli.get(ctx, gatewayBatchInferenceControlPlanePath, urlValues, nil)
+	defer ensureReaderClosed(resp)
+
+	if err != nil {
+		return nil, wrapResponseError(err, resp, "namespace", namespace)
+	}
+
+	var list []types.BatchInference
+	err = json.NewDecoder(resp.body).Decode(&list)
+	return list, wrapResponseError(err, resp, "namespace", namespace)
+}
+
+// BatchInferenceGet gets the batch inference with the progress.
+func (cli *Client) BatchInferenceGet(ctx context.Context,
+	namespace, name string) (types.BatchInference, error) {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	resp, err := cli.get(ctx,
+		fmt.Sprintf(gatewayBatchInferenceInstanceControlPlanePath, name), urlValues, nil)
+	defer ensureReaderClosed(resp)
+
+	if err != nil {
+		return types.BatchInference{}, wrapResponseError(err, resp, "batch inference", name)
+	}
+
+	var bi types.BatchInference
+	err = json.NewDecoder(resp.body).Decode(&bi)
+	return bi, wrapResponseError(err, resp, "batch inference", name)
+}
+
+// BatchInferenceCancel cancels the batch inference.
+func (cli *Client) BatchInferenceCancel(ctx context.Context,
+	namespace, name string) (types.BatchInference, error) {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	resp, err := cli.post(ctx,
+		fmt.Sprintf(gatewayBatchInferenceCancelControlPlanePath, name), urlValues, nil, nil)
+	defer ensureReaderClosed(resp)
+
+	if err != nil {
+		return types.BatchInference{}, wrapResponseError(err, resp, "batch inference", name)
+	}
+
+	var bi types.BatchInference
+	err = json.NewDecoder(resp.body).Decode(&bi)
+	return bi, wrapResponseError(err, resp, "batch inference", name)
+}
@@ -17,10 +17,20 @@ const (
 	gatewayInferScaleControlPath                      = "/system/scale-inference"
 	gatewayInferInstanceControlPlanePath              = "/system/inference/%s/instances"
 	gatewayInferInstanceExecControlPlanePath          = "/system/inference/%s/instance/%s/exec"
+	gatewayInferEventControlPlanePath                 = "/system/inference/%s/events"
+	gatewayInferCrashLoopControlPlanePath             = "/system/inference/%s/crash-loop"
+	gatewayInferPendingReasonControlPlanePath         = "/system/inference/%s/pending-reason"
+	gatewayInferRevisionControlPlanePath              = "/system/inference/%s/revisions"
+	gatewayInferRollbackControlPlanePath              = "/system/inference/%s/rollback"
 	gatewayServerControlPlanePath                     = "/system/servers"
 	gatewayServerLabelCreateControlPlanePath          = "/system/server/%s/labels"
 	gatewayServerNodeDeleteControlPlanePath           = "/system/server/%s/delete"
 	gatewayNamespaceControlPlanePath                  = "/system/namespaces"
+	gatewayNamespaceQuotaControlPlanePath             = "/system/namespaces/%s/quota"
+	gatewaySecretControlPlanePath                     = "/system/secrets"
+	gatewayBatchInferenceControlPlanePath             = "/system/batch-inferences"
+	gatewayBatchInferenceInstanceControlPlanePath     = "/system/batch-inferences/%s"
+	gatewayBatchInferenceCancelControlPlanePath       = "/system/batch-inferences/%s/cancel"
 	gatewayBuildControlPlanePath                      = "/system/build"
 	gatewayBuildInstanceControlPlanePath              = "/system/build/%s"
 	gatewayImageCacheControlPlanePath                 = "/system/image-cache"
@@ -30,6 +40,7 @@ const (
 	modelzCloudClusterNamespaceControlPlanePath       = "/api/v1/users/%s/clusters/%s/namespaces"
 	modelzCloudClusterDeploymentControlPlanePath      = "/api/v1/users/%s/clusters/%s/deployments/%s/agent"
 	modelzCloudClusterDeploymentEventControlPlanePath = "/api/v1/users/%s/clusters/%s/deployments/%s/event"
+	autoscalerDecisionPath                            = "/system/decisions"
 )
 
 const (
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// InferenceCrashLoopUpdate records the crash loop state of the inference,
+// the state is cleared if it is nil.
+func (cli *Client) InferenceCrashLoopUpdate(ctx context.Context,
+	namespace, name string, state *types.CrashLoopState) error {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	urlPath := fmt.Sprintf(gatewayInferCrashLoopControlPlanePath, name)
+
+	var resp serverResponse
+	var err error
+	if state == nil {
+		resp, err = cli.delete(ctx, urlPath, urlValues, nil, nil)
+	} else {
+		resp, err = cli.put(ctx, urlPath, urlValues, state, nil)
+	}
+	defer ensureReaderClosed(resp)
+	return wrapResponseError(err, resp, "inference", name)
+}
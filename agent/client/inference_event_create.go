@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// InferenceEventCreate records an event on the inference.
+func (cli *Client) InferenceEventCreate(ctx context.Context,
+	namespace, name string, event types.InferenceEvent) error {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	urlPath := fmt.Sprintf(gatewayInferEventControlPlanePath, name)
+
+	resp, err := cli.post(ctx, urlPath, urlValues, event, nil)
+	defer ensureReaderClosed(resp)
+	return wrapResponseError(err, resp, "inference", name)
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// InferencePendingReasonUpdate records why the replicas of the inference
+// cannot be scheduled, the reason is cleared if it is empty.
+func (cli *Client) InferencePendingReasonUpdate(ctx context.Context,
+	namespace, name, reason string) error {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	urlPath := fmt.Sprintf(gatewayInferPendingReasonControlPlanePath, name)
+
+	resp, err := cli.put(ctx, urlPath, urlValues, types.PendingReasonRequest{
+		Reason: reason,
+	}, nil)
+	defer ensureReaderClosed(resp)
+	return wrapResponseError(err, resp, "inference", name)
+}
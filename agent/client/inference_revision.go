@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// InferenceRevisionList lists the revisions of the inference.
+func (cli *Client) InferenceRevisionList(ctx context.Context,
+	namespace, name string) ([]types.InferenceRevision, error) {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	urlPath := fmt.Sprintf(gatewayInferRevisionControlPlanePath, name)
+
+	resp, err := cli.get(ctx, urlPath, urlValues, nil)
+	defer ensureReaderClosed(resp)
+
+	if err != nil {
+		return nil, wrapResponseError(err, resp, "inference", name)
+	}
+
+	var revisions []types.InferenceRevision
+	err = json.NewDecoder(resp.body).Decode(&revisions)
+
+	return revisions, wrapResponseError(err, resp, "inference", name)
+}
+
+// InferenceRollback rolls back the inference to the revision, the previous
+// revision is used if it is 0.
+func (cli *Client) InferenceRollback(ctx context.Context,
+	namespace, name string, revision int64) (types.InferenceRevision, error) {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+	if revision != 0 {
+		urlValues.Add("revision", strconv.FormatInt(revision, 10))
+	}
+
+	urlPath := fmt.Sprintf(gatewayInferRollbackControlPlanePath, name)
+
+	resp, err := cli.post(ctx, urlPath, urlValues, nil, nil)
+	defer ensureReaderClosed(resp)
+
+	if err != nil {
+		return types.InferenceRevision{},
+			wrapResponseError(err, resp, "inference", name)
+	}
+
+	var res types.InferenceRevision
+	err = json.NewDecoder(resp.body).Decode(&res)
+
+	return res, wrapResponseError(err, resp, "inference", name)
+}
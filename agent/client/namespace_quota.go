@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// NamespaceQuotaGet gets the quota of the namespace and the usage.
+func (cli *Client) NamespaceQuotaGet(ctx context.Context,
+	namespace string) (types.NamespaceQuotaStatus, error) {
+	resp, err := cli.get(ctx,
+		fmt.Sprintf(gatewayNamespaceQuotaControlPlanePath, namespace), url.Values{}, nil)
+	defer ensureReaderClosed(resp)
+
+	if err != nil {
+		return types.NamespaceQuotaStatus{}, wrapResponseError(err, resp, "namespace", namespace)
+	}
+
+	var status types.NamespaceQuotaStatus
+	err = json.NewDecoder(resp.body).Decode(&status)
+	return status, wrapResponseError(err, resp, "namespace", namespace)
+}
+
+// NamespaceQuotaUpdate replaces the quota of the namespace, the namespace
+// is not limited if the quota is empty.
+func (cli *Client) NamespaceQuotaUpdate(ctx context.Context,
+	namespace string, quota types.NamespaceQuota) error {
+	resp, err := cli.put(ctx,
+		fmt.Sprintf(gatewayNamespaceQuotaControlPlanePath, namespace), url.Values{}, quota, nil)
+	defer ensureReaderClosed(resp)
+
+	return wrapResponseError(err, resp, "namespace", namespace)
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// SecretList lists the secrets in the namespace. Only the names are returned.
+func (cli *Client) SecretList(ctx context.Context,
+	namespace string) ([]types.Secret, error) {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	resp, err := cli.get(ctx, gatewaySecretControlPlanePath, urlValues, nil)
+	defer ensureReaderClosed(resp)
+
+	if err != nil {
+		return nil, wrapResponseError(err, resp, "namespace", namespace)
+	}
+
+	var secrets []types.Secret
+	err = json.NewDecoder(resp.body).Decode(&secrets)
+
+	return secrets, wrapResponseError(err, resp, "namespace", namespace)
+}
+
+// SecretCreate creates the secret in the namespace.
+func (cli *Client) SecretCreate(ctx context.Context,
+	namespace string, secret types.Secret) error {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	resp, err := cli.post(ctx, gatewaySecretControlPlanePath, urlValues, secret, nil)
+	defer ensureReaderClosed(resp)
+
+	return wrapResponseError(err, resp, "secret", secret.Name)
+}
+
+// SecretUpdate replaces the values of the secret in the namespace.
+func (cli *Client) SecretUpdate(ctx context.Context,
+	namespace string, secret types.Secret) error {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	resp, err := cli.put(ctx, gatewaySecretControlPlanePath, urlValues, secret, nil)
+	defer ensureReaderClosed(resp)
+
+	return wrapResponseError(err, resp, "secret", secret.Name)
+}
+
+// SecretDelete deletes the secret in the namespace.
+func (cli *Client) SecretDelete(ctx context.Context,
+	namespace, name string) error {
+	urlValues := url.Values{}
+	urlValues.Add("namespace", namespace)
+
+	req := types.Secret{
+		Name: name,
+	}
+
+	resp, err := cli.delete(ctx, gatewaySecretControlPlanePath, urlValues, req, nil)
+	defer ensureReaderClosed(resp)
+
+	return wrapResponseError(err, resp, "secret", name)
+}
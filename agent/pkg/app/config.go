@@ -32,6 +32,7 @@ func configFromCLI(c *cli.Context) config.Config {
 	// inference
 	cfg.Inference.LogTimeout = c.Duration(flagInferenceLogTimeout)
 	cfg.Inference.CacheTTL = c.Duration(flagInferenceCacheTTL)
+	cfg.Inference.FrameworkConfig = c.String(flagInferenceFramework)
 
 	// build
 	cfg.Build.BuildEnabled = c.Bool(flagBuildEnabled)
@@ -14,6 +14,7 @@ import (
 
 	"github.com/tensorchord/openmodelz/agent/pkg/server"
 	"github.com/tensorchord/openmodelz/agent/pkg/version"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
 )
 
 const (
@@ -42,6 +43,7 @@ const (
 	// inference
 	flagInferenceLogTimeout = "inference-log-timeout"
 	flagInferenceCacheTTL   = "inference-cache-ttl"
+	flagInferenceFramework  = "inference-framework-config"
 
 	// build
 	flagBuildEnabled         = "build-enabled"
@@ -207,6 +209,12 @@ func New() App {
 			EnvVars: []string{"MODELZ_AGENT_INFERENCE_CACHE_TTL"},
 			Aliases: []string{"ict"},
 		},
+		&cli.StringFlag{
+			Name: flagInferenceFramework,
+			Usage: "Path to the YAML file of the custom inference frameworks. " +
+				"It should be the same as the one of modelzetes.",
+			EnvVars: []string{"MODELZ_AGENT_INFERENCE_FRAMEWORK_CONFIG"},
+		},
 		&cli.BoolFlag{
 			Name:   flagBuildEnabled,
 			Hidden: true,
@@ -424,6 +432,12 @@ func runServer(clicontext *cli.Context) error {
 		}
 	}
 
+	if c.Inference.FrameworkConfig != "" {
+		if err := framework.LoadFile(c.Inference.FrameworkConfig); err != nil {
+			return errors.Wrap(err, "failed to load the inference frameworks")
+		}
+	}
+
 	s, err := server.New(c)
 	if err != nil {
 		return errors.Wrap(err, "failed to create server")
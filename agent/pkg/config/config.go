@@ -70,6 +70,8 @@ type BuildConfig struct {
 type InferenceConfig struct {
 	LogTimeout time.Duration `json:"log_timeout,omitempty"`
 	CacheTTL   time.Duration `json:"cache_ttl,omitempty"`
+	// FrameworkConfig is the path to the file of the custom frameworks.
+	FrameworkConfig string `json:"framework_config,omitempty"`
 }
 
 type IngressConfig struct {
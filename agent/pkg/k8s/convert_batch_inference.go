@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+)
+
+func AsBatchInference(bi *v2alpha1.BatchInference) types.BatchInference {
+	res := types.BatchInference{
+		Spec: types.BatchInferenceSpec{
+			Name:          bi.Name,
+			Namespace:     bi.Namespace,
+			Inference:     bi.Spec.Inference,
+			Image:         bi.Spec.Image,
+			Framework:     types.Framework(bi.Spec.Framework),
+			Port:          bi.Spec.Port,
+			Command:       bi.Spec.Command,
+			HTTPProbePath: bi.Spec.HTTPProbePath,
+			EnvVars:       bi.Spec.EnvVars,
+			Secrets:       bi.Spec.Secrets,
+			Input: types.BatchInput{
+				BatchLocation: asBatchLocation(bi.Spec.Input.BatchLocation),
+				JSONL:         bi.Spec.Input.JSONL,
+				Files:         bi.Spec.Input.Files,
+			},
+			Output:       asBatchLocation(bi.Spec.Output),
+			Path:         bi.Spec.Path,
+			Parallelism:  bi.Spec.Parallelism,
+			MaxRetries:   bi.Spec.MaxRetries,
+			BackoffLimit: bi.Spec.BackoffLimit,
+			Cancel:       bi.Spec.Cancel,
+		},
+		Status: types.BatchInferenceStatus{
+			Phase:           types.BatchInferencePhase(bi.Status.Phase),
+			Shards:          bi.Status.Shards,
+			ActiveShards:    bi.Status.ActiveShards,
+			CompletedShards: bi.Status.CompletedShards,
+			Total:           bi.Status.Total,
+			Succeeded:       bi.Status.Succeeded,
+			Failed:          bi.Status.Failed,
+			CreatedAt:       &bi.CreationTimestamp.Time,
+			Message:         bi.Status.Message,
+		},
+	}
+
+	if bi.Spec.Resources != nil {
+		res.Spec.Resources = &types.ResourceRequirements{
+			Limits:   AsResourceList(bi.Spec.Resources.Limits),
+			Requests: AsResourceList(bi.Spec.Resources.Requests),
+		}
+	}
+	if bi.Status.Phase == "" {
+		res.Status.Phase = types.BatchInferencePhasePending
+	}
+	if bi.Status.StartTime != nil {
+		res.Status.StartTime = &bi.Status.StartTime.Time
+	}
+	if bi.Status.CompletionTime != nil {
+		res.Status.CompletionTime = &bi.Status.CompletionTime.Time
+	}
+	return res
+}
+
+func asBatchLocation(l v2alpha1.BatchLocation) types.BatchLocation {
+	return types.BatchLocation{
+		URI:         l.URI,
+		Endpoint:    l.Endpoint,
+		VolumeClaim: l.VolumeClaim,
+	}
+}
@@ -1,7 +1,10 @@
 package k8s
 
 import (
+	"encoding/json"
+
 	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/accelerator"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
 	appsv1 "k8s.io/api/apps/v1"
@@ -42,12 +45,76 @@ func AsInferenceDeployment(inf *v2alpha1.Inference, item *appsv1.Deployment) *ty
 			typ := types.ScalingType(*inf.Spec.Scaling.Type)
 			res.Spec.Scaling.Type = &typ
 		}
+		if inf.Spec.Scaling.CustomMetric != nil {
+			res.Spec.Scaling.CustomMetric = &types.CustomMetric{
+				Query:       inf.Spec.Scaling.CustomMetric.Query,
+				TargetValue: types.Quantity(inf.Spec.Scaling.CustomMetric.TargetValue.String()),
+			}
+		}
 	}
 
 	if inf.Spec.Port != nil {
 		res.Spec.Port = inf.Spec.Port
 	}
 
+	if inf.Spec.Model != nil {
+		res.Spec.Model = &types.ModelSource{
+			Type:      types.ModelSourceType(inf.Spec.Model.Type),
+			URI:       inf.Spec.Model.URI,
+			Revision:  inf.Spec.Model.Revision,
+			Endpoint:  inf.Spec.Model.Endpoint,
+			MountPath: inf.Spec.Model.MountPath,
+		}
+	}
+
+	if inf.Spec.Scheduling != nil {
+		res.Spec.Scheduling = asScheduling(inf.Spec.Scheduling)
+	}
+
+	if inf.Spec.Rollout != nil {
+		res.Spec.Rollout = &types.RolloutPolicy{
+			ProgressDeadlineSeconds: inf.Spec.Rollout.ProgressDeadlineSeconds,
+			MinReadyPercent:         inf.Spec.Rollout.MinReadyPercent,
+		}
+		if c := inf.Spec.Rollout.ErrorRate; c != nil {
+			res.Spec.Rollout.ErrorRate = &types.ErrorRateCheck{
+				MaxErrorPercent: c.MaxErrorPercent,
+				WindowSeconds:   c.WindowSeconds,
+				MinRequests:     c.MinRequests,
+			}
+		}
+	}
+
+	if p := inf.Spec.Probes; p != nil {
+		res.Spec.Probes = &types.Probes{
+			Startup:   asProbe(p.Startup),
+			Readiness: asProbe(p.Readiness),
+			Liveness:  asProbe(p.Liveness),
+		}
+	}
+
+	if t := inf.Spec.Termination; t != nil {
+		res.Spec.Termination = &types.TerminationConfig{
+			GracePeriodSeconds:  t.GracePeriodSeconds,
+			PreStopSleepSeconds: t.PreStopSleepSeconds,
+		}
+	}
+
+	if b := inf.Spec.DisruptionBudget; b != nil {
+		res.Spec.DisruptionBudget = &types.DisruptionBudgetConfig{
+			Disabled:       b.Disabled,
+			MaxUnavailable: b.MaxUnavailable,
+		}
+	}
+
+	if value, ok := inf.Annotations[consts.AnnotationCrashLoop]; ok {
+		state := types.CrashLoopState{}
+		if err := json.Unmarshal([]byte(value), &state); err == nil {
+			res.Status.CrashLoop = &state
+		}
+	}
+	res.Status.PendingReason = inf.Annotations[consts.AnnotationPendingReason]
+
 	var replicas int32 = 0
 	// Get status according to the deployment.
 	if item != nil {
@@ -60,14 +127,52 @@ func AsInferenceDeployment(inf *v2alpha1.Inference, item *appsv1.Deployment) *ty
 		res.Status.AvailableReplicas = item.Status.AvailableReplicas
 		res.Status.Phase = AsStatusPhase(item)
 	}
+
+	// Prefer the status maintained by the controller if it is up to date.
+	if inf.Generation > 0 && inf.Status.ObservedGeneration == inf.Generation {
+		res.Status.AvailableReplicas = inf.Status.AvailableReplicas
+		if res.Status.Phase != types.PhaseTerminating {
+			res.Status.Phase = AsInferencePhase(inf.Status.Phase)
+		}
+		for _, c := range inf.Status.Conditions {
+			res.Status.Conditions = append(res.Status.Conditions, types.InferenceCondition{
+				Type:               c.Type,
+				Status:             string(c.Status),
+				Reason:             c.Reason,
+				Message:            c.Message,
+				LastTransitionTime: c.LastTransitionTime.Time,
+			})
+		}
+	}
+
+	// The rollout status is kept until the next rollout, it is not bound
+	// to the generation.
+	if r := inf.Status.Rollout; r != nil {
+		res.Status.Rollout = &types.RolloutStatus{
+			Phase:         string(r.Phase),
+			StartedAt:     r.StartedAt.Time,
+			ReadyReplicas: r.ReadyReplicas,
+			Message:       r.Message,
+		}
+	}
 	return res
 }
 
+func AsInferencePhase(phase v2alpha1.InferencePhase) types.Phase {
+	switch phase {
+	case v2alpha1.InferencePhaseReady:
+		return types.PhaseReady
+	case v2alpha1.InferencePhaseScaling:
+		return types.PhaseScaling
+	case v2alpha1.InferencePhaseScaledToZero:
+		return types.PhaseNoReplicas
+	default:
+		return types.PhaseNotReady
+	}
+}
+
 func AsResourceList(resources v1.ResourceList) types.ResourceList {
 	res := types.ResourceList{}
-	gpuResource := resources[consts.ResourceNvidiaGPU]
-	gpuPtr := &gpuResource
-
 	if !resources.Cpu().IsZero() {
 		res[types.ResourceCPU] = types.Quantity(
 			resources.Cpu().String())
@@ -76,9 +181,93 @@ func AsResourceList(resources v1.ResourceList) types.ResourceList {
 		res[types.ResourceMemory] = types.Quantity(
 			resources.Memory().String())
 	}
-	if !gpuPtr.IsZero() {
-		res[types.ResourceGPU] = types.Quantity(
-			gpuPtr.String())
+	for name, qty := range resources {
+		if accelerator.IsResource(name) && !qty.IsZero() {
+			res[types.ResourceName(accelerator.ShortName(name))] = types.Quantity(qty.String())
+		}
+	}
+	return res
+}
+
+func asScheduling(scheduling *v2alpha1.SchedulingConfig) *types.SchedulingConfig {
+	res := &types.SchedulingConfig{}
+	if scheduling.NodeAffinity != nil {
+		res.NodeAffinity = &types.NodeAffinity{}
+		for _, term := range scheduling.NodeAffinity.Required {
+			res.NodeAffinity.Required = append(res.NodeAffinity.Required,
+				asNodeSelectorTerm(term))
+		}
+		for _, term := range scheduling.NodeAffinity.Preferred {
+			res.NodeAffinity.Preferred = append(res.NodeAffinity.Preferred,
+				types.PreferredSchedulingTerm{
+					Weight:     term.Weight,
+					Preference: asNodeSelectorTerm(term.Preference),
+				})
+		}
+	}
+	for _, t := range scheduling.Tolerations {
+		res.Tolerations = append(res.Tolerations, types.Toleration{
+			Key:               t.Key,
+			Operator:          string(t.Operator),
+			Value:             t.Value,
+			Effect:            string(t.Effect),
+			TolerationSeconds: t.TolerationSeconds,
+		})
+	}
+	if a := scheduling.PodAntiAffinity; a != nil {
+		res.PodAntiAffinity = &types.PodAntiAffinity{
+			TopologyKey: a.TopologyKey,
+			Required:    a.Required,
+			Weight:      a.Weight,
+		}
+	}
+	for _, c := range scheduling.TopologySpreadConstraints {
+		res.TopologySpreadConstraints = append(res.TopologySpreadConstraints,
+			types.TopologySpreadConstraint{
+				TopologyKey:       c.TopologyKey,
+				MaxSkew:           c.MaxSkew,
+				WhenUnsatisfiable: string(c.WhenUnsatisfiable),
+			})
+	}
+	return res
+}
+
+// asNodeSelectorTerm converts the expressions of the term, the field
+// selectors are not exposed by the agent.
+func asProbe(p *v2alpha1.Probe) *types.Probe {
+	if p == nil {
+		return nil
+	}
+	res := &types.Probe{
+		InitialDelaySeconds: p.InitialDelaySeconds,
+		PeriodSeconds:       p.PeriodSeconds,
+		TimeoutSeconds:      p.TimeoutSeconds,
+		SuccessThreshold:    p.SuccessThreshold,
+		FailureThreshold:    p.FailureThreshold,
+	}
+	if p.HTTPGet != nil {
+		res.HTTPGet = &types.HTTPGetAction{Path: p.HTTPGet.Path, Port: p.HTTPGet.Port}
+	}
+	if p.TCPSocket != nil {
+		res.TCPSocket = &types.TCPSocketAction{Port: p.TCPSocket.Port}
+	}
+	if p.GRPC != nil {
+		res.GRPC = &types.GRPCAction{Port: p.GRPC.Port, Service: p.GRPC.Service}
+	}
+	if p.Exec != nil {
+		res.Exec = &types.ExecAction{Command: p.Exec.Command}
+	}
+	return res
+}
+
+func asNodeSelectorTerm(term v1.NodeSelectorTerm) types.NodeSelectorTerm {
+	res := types.NodeSelectorTerm{}
+	for _, r := range term.MatchExpressions {
+		res.MatchExpressions = append(res.MatchExpressions, types.NodeSelectorRequirement{
+			Key:      r.Key,
+			Operator: types.NodeSelectorOperator(r.Operator),
+			Values:   r.Values,
+		})
 	}
 	return res
 }
@@ -61,6 +61,16 @@ var _ = Describe("agent/pkg/k8s/convert_inference", func() {
 					types.ResourceCPU: types.Quantity("100m"),
 				},
 			},
+			{
+				resource: map[v1types.ResourceName]resource.Quantity{
+					consts.ResourceNvidiaMIGPrefix + "1g.10gb": resource.MustParse("1"),
+					consts.ResourceNvidiaGPUShared:             resource.MustParse("2"),
+				},
+				expect: types.ResourceList{
+					types.ResourceMIGPrefix + "1g.10gb": types.Quantity("1"),
+					types.ResourceGPUShared:             types.Quantity("2"),
+				},
+			},
 		}
 		for _, tc := range tcs {
 			value := AsResourceList(tc.resource)
@@ -122,6 +132,262 @@ var _ = Describe("agent/pkg/k8s/convert_inference", func() {
 					},
 				}),
 			},
+			{
+				inf: Ptr(v2alpha1.Inference{
+					Spec: v2alpha1.InferenceSpec{
+						Scaling: Ptr(v2alpha1.ScalingConfig{
+							Type: Ptr(v2alpha1.ScalingTypeCustom),
+							CustomMetric: Ptr(v2alpha1.CustomMetric{
+								Query:       `vllm_num_requests_waiting{inference="{{.Name}}"}`,
+								TargetValue: resource.MustParse("0.5"),
+							}),
+						}),
+					},
+				}),
+				deployment: nil,
+				expect: Ptr(types.InferenceDeployment{
+					Spec: types.InferenceDeploymentSpec{
+						Scaling: Ptr(types.ScalingConfig{
+							Type: Ptr(types.ScalingTypeCustom),
+							CustomMetric: Ptr(types.CustomMetric{
+								Query:       `vllm_num_requests_waiting{inference="{{.Name}}"}`,
+								TargetValue: types.Quantity("500m"),
+							}),
+						}),
+					},
+					Status: types.InferenceDeploymentStatus{
+						Phase: types.PhaseNoReplicas,
+					},
+				}),
+			},
+			{
+				inf: Ptr(v2alpha1.Inference{
+					Spec: v2alpha1.InferenceSpec{
+						Scheduling: Ptr(v2alpha1.SchedulingConfig{
+							NodeAffinity: Ptr(v2alpha1.NodeAffinity{
+								Preferred: []v1.PreferredSchedulingTerm{{
+									Weight: 50,
+									Preference: v1.NodeSelectorTerm{
+										MatchExpressions: []v1.NodeSelectorRequirement{{
+											Key:      "tensorchord.ai/gpu",
+											Operator: v1.NodeSelectorOpIn,
+											Values:   []string{"a100"},
+										}},
+									},
+								}},
+							}),
+							Tolerations: []v1.Toleration{{
+								Key:      "dedicated",
+								Operator: v1.TolerationOpExists,
+								Effect:   v1.TaintEffectNoSchedule,
+							}},
+							TopologySpreadConstraints: []v2alpha1.TopologySpreadConstraint{{
+								TopologyKey:       "topology.kubernetes.io/zone",
+								WhenUnsatisfiable: v1.DoNotSchedule,
+							}},
+						}),
+					},
+				}),
+				deployment: nil,
+				expect: Ptr(types.InferenceDeployment{
+					Spec: types.InferenceDeploymentSpec{
+						Scheduling: Ptr(types.SchedulingConfig{
+							NodeAffinity: Ptr(types.NodeAffinity{
+								Preferred: []types.PreferredSchedulingTerm{{
+									Weight: 50,
+									Preference: types.NodeSelectorTerm{
+										MatchExpressions: []types.NodeSelectorRequirement{{
+											Key:      "tensorchord.ai/gpu",
+											Operator: types.NodeSelectorOpIn,
+											Values:   []string{"a100"},
+										}},
+									},
+								}},
+							}),
+							Tolerations: []types.Toleration{{
+								Key:      "dedicated",
+								Operator: "Exists",
+								Effect:   "NoSchedule",
+							}},
+							TopologySpreadConstraints: []types.TopologySpreadConstraint{{
+								TopologyKey:       "topology.kubernetes.io/zone",
+								WhenUnsatisfiable: "DoNotSchedule",
+							}},
+						}),
+					},
+					Status: types.InferenceDeploymentStatus{
+						Phase: types.PhaseNoReplicas,
+					},
+				}),
+			},
+			{
+				inf: Ptr(v2alpha1.Inference{
+					Spec: v2alpha1.InferenceSpec{
+						Rollout: Ptr(v2alpha1.RolloutPolicy{
+							ProgressDeadlineSeconds: 300,
+							ErrorRate: Ptr(v2alpha1.ErrorRateCheck{
+								MaxErrorPercent: 5,
+							}),
+						}),
+					},
+					Status: v2alpha1.InferenceStatus{
+						Rollout: Ptr(v2alpha1.RolloutStatus{
+							ReplicaSet:    "bloomz-abcde",
+							Phase:         v2alpha1.RolloutPhaseProgressing,
+							StartedAt:     metav1.Time{Time: mockTime},
+							ReadyReplicas: 1,
+						}),
+					},
+				}),
+				deployment: nil,
+				expect: Ptr(types.InferenceDeployment{
+					Spec: types.InferenceDeploymentSpec{
+						Rollout: Ptr(types.RolloutPolicy{
+							ProgressDeadlineSeconds: 300,
+							ErrorRate: Ptr(types.ErrorRateCheck{
+								MaxErrorPercent: 5,
+							}),
+						}),
+					},
+					Status: types.InferenceDeploymentStatus{
+						Phase: types.PhaseNoReplicas,
+						Rollout: Ptr(types.RolloutStatus{
+							Phase:         "Progressing",
+							StartedAt:     mockTime,
+							ReadyReplicas: 1,
+						}),
+					},
+				}),
+			},
+			{
+				inf: Ptr(v2alpha1.Inference{
+					Spec: v2alpha1.InferenceSpec{
+						Termination: Ptr(v2alpha1.TerminationConfig{
+							GracePeriodSeconds:  Ptr(int64(300)),
+							PreStopSleepSeconds: Ptr(int32(10)),
+						}),
+						DisruptionBudget: Ptr(v2alpha1.DisruptionBudgetConfig{
+							MaxUnavailable: Ptr(int32(2)),
+						}),
+					},
+				}),
+				deployment: nil,
+				expect: Ptr(types.InferenceDeployment{
+					Spec: types.InferenceDeploymentSpec{
+						Termination: Ptr(types.TerminationConfig{
+							GracePeriodSeconds:  Ptr(int64(300)),
+							PreStopSleepSeconds: Ptr(int32(10)),
+						}),
+						DisruptionBudget: Ptr(types.DisruptionBudgetConfig{
+							MaxUnavailable: Ptr(int32(2)),
+						}),
+					},
+					Status: types.InferenceDeploymentStatus{
+						Phase: types.PhaseNoReplicas,
+					},
+				}),
+			},
+			{
+				inf: Ptr(v2alpha1.Inference{
+					Spec: v2alpha1.InferenceSpec{
+						Probes: Ptr(v2alpha1.Probes{
+							Startup: Ptr(v2alpha1.Probe{
+								GRPC:             Ptr(v2alpha1.GRPCAction{Port: Ptr(int32(9000))}),
+								FailureThreshold: Ptr(int32(60)),
+							}),
+							Liveness: Ptr(v2alpha1.Probe{
+								Exec: Ptr(v2alpha1.ExecAction{Command: []string{"cat", "/tmp/healthy"}}),
+							}),
+						}),
+					},
+				}),
+				deployment: nil,
+				expect: Ptr(types.InferenceDeployment{
+					Spec: types.InferenceDeploymentSpec{
+						Probes: Ptr(types.Probes{
+							Startup: Ptr(types.Probe{
+								GRPC:             Ptr(types.GRPCAction{Port: Ptr(int32(9000))}),
+								FailureThreshold: Ptr(int32(60)),
+							}),
+							Liveness: Ptr(types.Probe{
+								Exec: Ptr(types.ExecAction{Command: []string{"cat", "/tmp/healthy"}}),
+							}),
+						}),
+					},
+					Status: types.InferenceDeploymentStatus{
+						Phase: types.PhaseNoReplicas,
+					},
+				}),
+			},
+			{
+				inf: Ptr(v2alpha1.Inference{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							consts.AnnotationCrashLoop: `{"originalMinReplicas":2,"minReplicas":0,"since":"2023-09-07T00:00:00Z","attempts":1,"nextAttempt":"2023-09-07T00:02:00Z"}`,
+						},
+					},
+				}),
+				deployment: nil,
+				expect: Ptr(types.InferenceDeployment{
+					Status: types.InferenceDeploymentStatus{
+						Phase: types.PhaseNoReplicas,
+						CrashLoop: Ptr(types.CrashLoopState{
+							OriginalMinReplicas: 2,
+							MinReplicas:         0,
+							Since:               mockTime,
+							Attempts:            1,
+							NextAttempt:         mockTime.Add(2 * time.Minute),
+						}),
+					},
+				}),
+			},
+			{
+				inf: Ptr(v2alpha1.Inference{
+					ObjectMeta: metav1.ObjectMeta{
+						Generation: 2,
+					},
+					Status: v2alpha1.InferenceStatus{
+						ObservedGeneration: 2,
+						Replicas:           1,
+						AvailableReplicas:  1,
+						Phase:              v2alpha1.InferencePhaseReady,
+						Conditions: []metav1.Condition{
+							{
+								Type:               v2alpha1.InferenceConditionReady,
+								Status:             metav1.ConditionTrue,
+								Reason:             "MinimumReplicasAvailable",
+								LastTransitionTime: metav1.Time{Time: mockTime},
+							},
+						},
+					},
+				}),
+				deployment: Ptr(appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						CreationTimestamp: metav1.Time{
+							Time: mockTime,
+						},
+					},
+					Spec: appsv1.DeploymentSpec{
+						Replicas: Ptr(int32(1)),
+					},
+				}),
+				expect: Ptr(types.InferenceDeployment{
+					Status: types.InferenceDeploymentStatus{
+						Phase:             types.PhaseReady,
+						Replicas:          1,
+						AvailableReplicas: 1,
+						CreatedAt:         Ptr(mockTime),
+						Conditions: []types.InferenceCondition{
+							{
+								Type:               v2alpha1.InferenceConditionReady,
+								Status:             "True",
+								Reason:             "MinimumReplicasAvailable",
+								LastTransitionTime: mockTime,
+							},
+						},
+					},
+				}),
+			},
 		}
 		for _, tc := range tcs {
 			value := AsInferenceDeployment(tc.inf, tc.deployment)
@@ -7,6 +7,15 @@ import (
 	"github.com/tensorchord/openmodelz/agent/api/types"
 )
 
+// ReasonDownloadingModel is the reason of the instances which are
+// downloading the model.
+const ReasonDownloadingModel = "DownloadingModel"
+
+// MessageDownloadingModel is the message of the instances which are
+// downloading the model, it is replaced by the progress reported by the
+// model downloader.
+const MessageDownloadingModel = "Downloading the model"
+
 func MakeLabelSelector(name string) map[string]string {
 	return map[string]string{
 		"app": name,
@@ -55,6 +64,7 @@ func InstanceFromPod(pod v1.Pod) *types.InferenceDeploymentInstance {
 	}
 
 	if len(pod.Status.ContainerStatuses) != 0 {
+		i.Status.RestartCount = pod.Status.ContainerStatuses[0].RestartCount
 		if pod.Status.ContainerStatuses[0].Started != nil &&
 			!*pod.Status.ContainerStatuses[0].Started {
 			i.Status.Phase = types.InstancePhaseCreating
@@ -72,5 +82,28 @@ func InstanceFromPod(pod v1.Pod) *types.InferenceDeploymentInstance {
 			}
 		}
 	}
+
+	// The inference container waits for the model to be downloaded.
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.Name != consts.ModelDownloaderContainerName {
+			continue
+		}
+		if cs.State.Running != nil {
+			i.Status.Phase = types.InstancePhaseDownloading
+			i.Status.Reason = ReasonDownloadingModel
+			i.Status.Message = MessageDownloadingModel
+			if progress := pod.Annotations[consts.AnnotationDownloadProgress]; progress != "" {
+				i.Status.Message = progress
+			}
+		} else if cs.State.Waiting != nil && cs.State.Waiting.Reason != "PodInitializing" {
+			i.Status.Phase = types.InstancePhase(cs.State.Waiting.Reason)
+			i.Status.Reason = cs.State.Waiting.Reason
+			i.Status.Message = cs.State.Waiting.Message
+		} else if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+			i.Status.Phase = types.InstancePhaseFailed
+			i.Status.Reason = cs.State.Terminated.Reason
+			i.Status.Message = cs.State.Terminated.Message
+		}
+	}
 	return i
 }
@@ -4,10 +4,12 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sirupsen/logrus"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
 	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
 
 	"github.com/tensorchord/openmodelz/agent/api/types"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("agent/pkg/k8s/convert_pod", func() {
@@ -208,6 +210,132 @@ var _ = Describe("agent/pkg/k8s/convert_pod", func() {
 					},
 				),
 			},
+			{
+				desc: "restarted pod",
+				pod: v1.Pod{
+					Status: v1.PodStatus{
+						Phase: v1.PodRunning,
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Started:      Ptr(true),
+								RestartCount: 3,
+							},
+						},
+					},
+				},
+				expect: Ptr(
+					types.InferenceDeploymentInstance{
+						Status: types.InferenceDeploymentInstanceStatus{
+							Phase:        types.InstancePhaseRunning,
+							RestartCount: 3,
+						},
+					},
+				),
+			},
+			{
+				desc: "downloading model",
+				pod: v1.Pod{
+					Status: v1.PodStatus{
+						Phase: v1.PodPending,
+						InitContainerStatuses: []v1.ContainerStatus{
+							{
+								Name: consts.ModelDownloaderContainerName,
+								State: v1.ContainerState{
+									Running: Ptr(v1.ContainerStateRunning{}),
+								},
+							},
+						},
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Started: Ptr(false),
+								State: v1.ContainerState{
+									Waiting: Ptr(v1.ContainerStateWaiting{
+										Reason: "PodInitializing",
+									}),
+								},
+							},
+						},
+					},
+				},
+				expect: Ptr(
+					types.InferenceDeploymentInstance{
+						Status: types.InferenceDeploymentInstanceStatus{
+							Phase:   types.InstancePhaseDownloading,
+							Reason:  ReasonDownloadingModel,
+							Message: "Downloading the model",
+						},
+					},
+				),
+			},
+			{
+				desc: "downloading model with progress",
+				pod: v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							consts.AnnotationDownloadProgress: "Downloading bigscience/bloomz: 1.0GiB/4.0GiB (25%), 1/4 files",
+						},
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodPending,
+						InitContainerStatuses: []v1.ContainerStatus{
+							{
+								Name: consts.ModelDownloaderContainerName,
+								State: v1.ContainerState{
+									Running: Ptr(v1.ContainerStateRunning{}),
+								},
+							},
+						},
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Started: Ptr(false),
+								State: v1.ContainerState{
+									Waiting: Ptr(v1.ContainerStateWaiting{
+										Reason: "PodInitializing",
+									}),
+								},
+							},
+						},
+					},
+				},
+				expect: Ptr(
+					types.InferenceDeploymentInstance{
+						Status: types.InferenceDeploymentInstanceStatus{
+							Phase:   types.InstancePhaseDownloading,
+							Reason:  ReasonDownloadingModel,
+							Message: "Downloading bigscience/bloomz: 1.0GiB/4.0GiB (25%), 1/4 files",
+						},
+					},
+				),
+			},
+			{
+				desc: "model download failed",
+				pod: v1.Pod{
+					Status: v1.PodStatus{
+						Phase: v1.PodPending,
+						InitContainerStatuses: []v1.ContainerStatus{
+							{
+								Name: consts.ModelDownloaderContainerName,
+								State: v1.ContainerState{
+									Terminated: Ptr(v1.ContainerStateTerminated{
+										ExitCode: 1,
+										Reason:   "Error",
+										Message:  "failed to download the model",
+									}),
+								},
+							},
+						},
+					},
+				},
+				expect: Ptr(
+					types.InferenceDeploymentInstance{
+						Status: types.InferenceDeploymentInstanceStatus{
+							Phase:   types.InstancePhaseFailed,
+							Reason:  "Error",
+							Message: "failed to download the model",
+						},
+					},
+				),
+			},
 		}
 		for _, tc := range tcs {
 			logrus.Info(tc.desc)
@@ -0,0 +1,275 @@
+package k8s
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/accelerator"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/validation"
+)
+
+// MakeInferenceSpec converts the spec of the request into the spec of the
+// inference. It is used by the runtime to create the inference and by the
+// validator to validate the request with the rules of the webhook.
+func MakeInferenceSpec(request types.InferenceDeployment) (v2alpha1.InferenceSpec, error) {
+	spec := v2alpha1.InferenceSpec{
+		Name:             request.Spec.Name,
+		Image:            request.Spec.Image,
+		Framework:        v2alpha1.Framework(request.Spec.Framework),
+		Port:             request.Spec.Port,
+		Command:          request.Spec.Command,
+		EnvVars:          request.Spec.EnvVars,
+		Secrets:          request.Spec.Secrets,
+		Constraints:      request.Spec.Constraints,
+		Labels:           request.Spec.Labels,
+		Annotations:      request.Spec.Annotations,
+		HTTPProbePath:    request.Spec.HTTPProbePath,
+		Probes:           MakeProbes(request.Spec.Probes),
+		Model:            MakeModelSource(request.Spec.Model),
+		Scheduling:       MakeScheduling(request.Spec.Scheduling),
+		Rollout:          MakeRollout(request.Spec.Rollout),
+		Termination:      MakeTermination(request.Spec.Termination),
+		DisruptionBudget: MakeDisruptionBudget(request.Spec.DisruptionBudget),
+	}
+
+	if request.Spec.Scaling != nil {
+		spec.Scaling = &v2alpha1.ScalingConfig{
+			MinReplicas:     request.Spec.Scaling.MinReplicas,
+			MaxReplicas:     request.Spec.Scaling.MaxReplicas,
+			TargetLoad:      request.Spec.Scaling.TargetLoad,
+			ZeroDuration:    request.Spec.Scaling.ZeroDuration,
+			StartupDuration: request.Spec.Scaling.StartupDuration,
+		}
+		if request.Spec.Scaling.Type != nil {
+			buf := v2alpha1.ScalingType(*request.Spec.Scaling.Type)
+			spec.Scaling.Type = &buf
+		}
+		if request.Spec.Scaling.CustomMetric != nil {
+			metric, err := MakeCustomMetric(*request.Spec.Scaling.CustomMetric)
+			if err != nil {
+				return spec, err
+			}
+			spec.Scaling.CustomMetric = metric
+		}
+	}
+
+	rr, err := MakeResourceRequirements(request.Spec.Resources)
+	if err != nil {
+		return spec, err
+	}
+	spec.Resources = &rr
+	return spec, nil
+}
+
+// MakeResourceRequirements converts the resources of the request, the
+// limits and requests are empty if it is nil. The accelerators are converted
+// to the names of the device plugins, e.g. gpu is nvidia.com/gpu.
+func MakeResourceRequirements(requirements *types.ResourceRequirements) (
+	corev1.ResourceRequirements, error) {
+	resources := corev1.ResourceRequirements{
+		Limits:   corev1.ResourceList{},
+		Requests: corev1.ResourceList{},
+	}
+
+	if requirements == nil {
+		return resources, nil
+	}
+
+	if err := setResources("limits", resources.Limits, requirements.Limits); err != nil {
+		return resources, err
+	}
+	if err := setResources("requests", resources.Requests, requirements.Requests); err != nil {
+		return resources, err
+	}
+	return resources, nil
+}
+
+// setResources sets the CPU, the memory and the accelerators, including the
+// shared GPUs, the MIG devices and the accelerators of the other vendors.
+func setResources(field string, res corev1.ResourceList, list types.ResourceList) error {
+	for name, value := range list {
+		if value == "" {
+			continue
+		}
+		var resourceName corev1.ResourceName
+		switch name {
+		case types.ResourceCPU:
+			resourceName = corev1.ResourceCPU
+		case types.ResourceMemory:
+			resourceName = corev1.ResourceMemory
+		default:
+			var ok bool
+			if resourceName, ok = accelerator.ResourceName(string(name)); !ok {
+				continue
+			}
+		}
+		qty, err := validation.ParseQuantity(
+			fmt.Sprintf("resources.%s.%s", field, name), string(value))
+		if err != nil {
+			return err
+		}
+		res[resourceName] = qty
+	}
+	return nil
+}
+
+func MakeCustomMetric(metric types.CustomMetric) (*v2alpha1.CustomMetric, error) {
+	qty, err := validation.ParseQuantity(
+		"custom_metric.target_value", string(metric.TargetValue))
+	if err != nil {
+		return nil, err
+	}
+	return &v2alpha1.CustomMetric{
+		Query:       metric.Query,
+		TargetValue: qty,
+	}, nil
+}
+
+func MakeModelSource(model *types.ModelSource) *v2alpha1.ModelSource {
+	if model == nil {
+		return nil
+	}
+	return &v2alpha1.ModelSource{
+		Type:      v2alpha1.ModelSourceType(model.Type),
+		URI:       model.URI,
+		Revision:  model.Revision,
+		Endpoint:  model.Endpoint,
+		MountPath: model.MountPath,
+	}
+}
+
+func MakeScheduling(scheduling *types.SchedulingConfig) *v2alpha1.SchedulingConfig {
+	if scheduling == nil {
+		return nil
+	}
+	res := &v2alpha1.SchedulingConfig{}
+	if scheduling.NodeAffinity != nil {
+		res.NodeAffinity = &v2alpha1.NodeAffinity{}
+		for _, term := range scheduling.NodeAffinity.Required {
+			res.NodeAffinity.Required = append(res.NodeAffinity.Required,
+				makeNodeSelectorTerm(term))
+		}
+		for _, term := range scheduling.NodeAffinity.Preferred {
+			res.NodeAffinity.Preferred = append(res.NodeAffinity.Preferred,
+				corev1.PreferredSchedulingTerm{
+					Weight:     term.Weight,
+					Preference: makeNodeSelectorTerm(term.Preference),
+				})
+		}
+	}
+	for _, t := range scheduling.Tolerations {
+		res.Tolerations = append(res.Tolerations, corev1.Toleration{
+			Key:               t.Key,
+			Operator:          corev1.TolerationOperator(t.Operator),
+			Value:             t.Value,
+			Effect:            corev1.TaintEffect(t.Effect),
+			TolerationSeconds: t.TolerationSeconds,
+		})
+	}
+	if a := scheduling.PodAntiAffinity; a != nil {
+		res.PodAntiAffinity = &v2alpha1.PodAntiAffinity{
+			TopologyKey: a.TopologyKey,
+			Required:    a.Required,
+			Weight:      a.Weight,
+		}
+	}
+	for _, c := range scheduling.TopologySpreadConstraints {
+		res.TopologySpreadConstraints = append(res.TopologySpreadConstraints,
+			v2alpha1.TopologySpreadConstraint{
+				TopologyKey:       c.TopologyKey,
+				MaxSkew:           c.MaxSkew,
+				WhenUnsatisfiable: corev1.UnsatisfiableConstraintAction(c.WhenUnsatisfiable),
+			})
+	}
+	return res
+}
+
+func makeNodeSelectorTerm(term types.NodeSelectorTerm) corev1.NodeSelectorTerm {
+	res := corev1.NodeSelectorTerm{}
+	for _, r := range term.MatchExpressions {
+		res.MatchExpressions = append(res.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      r.Key,
+			Operator: corev1.NodeSelectorOperator(r.Operator),
+			Values:   r.Values,
+		})
+	}
+	return res
+}
+
+func MakeTermination(t *types.TerminationConfig) *v2alpha1.TerminationConfig {
+	if t == nil {
+		return nil
+	}
+	return &v2alpha1.TerminationConfig{
+		GracePeriodSeconds:  t.GracePeriodSeconds,
+		PreStopSleepSeconds: t.PreStopSleepSeconds,
+	}
+}
+
+func MakeProbes(p *types.Probes) *v2alpha1.Probes {
+	if p == nil {
+		return nil
+	}
+	return &v2alpha1.Probes{
+		Startup:   makeProbe(p.Startup),
+		Readiness: makeProbe(p.Readiness),
+		Liveness:  makeProbe(p.Liveness),
+	}
+}
+
+func makeProbe(p *types.Probe) *v2alpha1.Probe {
+	if p == nil {
+		return nil
+	}
+	res := &v2alpha1.Probe{
+		InitialDelaySeconds: p.InitialDelaySeconds,
+		PeriodSeconds:       p.PeriodSeconds,
+		TimeoutSeconds:      p.TimeoutSeconds,
+		SuccessThreshold:    p.SuccessThreshold,
+		FailureThreshold:    p.FailureThreshold,
+	}
+	if p.HTTPGet != nil {
+		res.HTTPGet = &v2alpha1.HTTPGetAction{Path: p.HTTPGet.Path, Port: p.HTTPGet.Port}
+	}
+	if p.TCPSocket != nil {
+		res.TCPSocket = &v2alpha1.TCPSocketAction{Port: p.TCPSocket.Port}
+	}
+	if p.GRPC != nil {
+		res.GRPC = &v2alpha1.GRPCAction{Port: p.GRPC.Port, Service: p.GRPC.Service}
+	}
+	if p.Exec != nil {
+		res.Exec = &v2alpha1.ExecAction{Command: p.Exec.Command}
+	}
+	return res
+}
+
+func MakeDisruptionBudget(b *types.DisruptionBudgetConfig) *v2alpha1.DisruptionBudgetConfig {
+	if b == nil {
+		return nil
+	}
+	return &v2alpha1.DisruptionBudgetConfig{
+		Disabled:       b.Disabled,
+		MaxUnavailable: b.MaxUnavailable,
+	}
+}
+
+func MakeRollout(rollout *types.RolloutPolicy) *v2alpha1.RolloutPolicy {
+	if rollout == nil {
+		return nil
+	}
+	res := &v2alpha1.RolloutPolicy{
+		ProgressDeadlineSeconds: rollout.ProgressDeadlineSeconds,
+		MinReadyPercent:         rollout.MinReadyPercent,
+	}
+	if c := rollout.ErrorRate; c != nil {
+		res.ErrorRate = &v2alpha1.ErrorRateCheck{
+			MaxErrorPercent: c.MaxErrorPercent,
+			WindowSeconds:   c.WindowSeconds,
+			MinRequests:     c.MinRequests,
+		}
+	}
+	return res
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/phayes/freeport"
 	"github.com/sirupsen/logrus"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -33,9 +34,11 @@ func NewPortForwardingResolver(cfg *rest.Config, cli kubernetes.Interface) Resol
 	}
 }
 
-func NewEndpointResolver(lister corelister.EndpointsLister) Resolver {
+func NewEndpointResolver(lister corelister.EndpointsLister,
+	podLister corelister.PodLister) Resolver {
 	return &EndpointResolver{
 		EndpointLister: lister,
+		PodLister:      podLister,
 	}
 }
 
@@ -105,6 +108,9 @@ func (e *PortForwardingResolver) Close(url url.URL) {
 
 type EndpointResolver struct {
 	EndpointLister corelister.EndpointsLister
+	// PodLister is used to skip the terminating pods, which are not removed
+	// from the endpoints yet.
+	PodLister corelister.PodLister
 }
 
 func (e EndpointResolver) Resolve(namespace, name string) (url.URL, error) {
@@ -123,15 +129,15 @@ func (e EndpointResolver) Resolve(namespace, name string) (url.URL, error) {
 			fmt.Errorf("no subsets for \"%s.%s\"", svcName, namespace))
 	}
 
-	all := len(svc.Subsets[0].Addresses)
-	if len(svc.Subsets[0].Addresses) == 0 {
+	addresses := e.activeAddresses(namespace, svc.Subsets[0].Addresses)
+	if len(addresses) == 0 {
 		return url.URL{}, errdefs.NotFound(
 			fmt.Errorf("no addresses for \"%s.%s\"", svcName, namespace))
 	}
 
-	target := rand.Intn(all)
+	target := rand.Intn(len(addresses))
 
-	serviceIP := svc.Subsets[0].Addresses[target].IP
+	serviceIP := addresses[target].IP
 	servicePort := svc.Subsets[0].Ports[0].Port
 
 	urlStr := fmt.Sprintf("http://%s:%d", serviceIP, servicePort)
@@ -144,6 +150,27 @@ func (e EndpointResolver) Resolve(namespace, name string) (url.URL, error) {
 	return *urlRes, nil
 }
 
+// activeAddresses filters out the addresses of the terminating pods. The
+// endpoints are updated after the pods start terminating, thus the requests
+// may be routed to the pods which are shutting down in the meantime.
+func (e EndpointResolver) activeAddresses(namespace string,
+	addresses []v1.EndpointAddress) []v1.EndpointAddress {
+	if e.PodLister == nil {
+		return addresses
+	}
+	res := make([]v1.EndpointAddress, 0, len(addresses))
+	for _, address := range addresses {
+		if ref := address.TargetRef; ref != nil && ref.Kind == "Pod" {
+			pod, err := e.PodLister.Pods(namespace).Get(ref.Name)
+			if err == nil && pod.DeletionTimestamp != nil {
+				continue
+			}
+		}
+		res = append(res, address)
+	}
+	return res
+}
+
 func (e EndpointResolver) Close(url.URL) {
 	// do nothing
 }
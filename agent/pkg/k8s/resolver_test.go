@@ -0,0 +1,56 @@
+package k8s
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("agent/pkg/k8s/resolver", func() {
+	It("EndpointResolver skips the terminating pods", func() {
+		now := metav1.Now()
+		endpointIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		Expect(endpointIndexer.Add(&v1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      consts.DefaultServicePrefix + "bloomz",
+				Namespace: "default",
+			},
+			Subsets: []v1.EndpointSubset{{
+				Addresses: []v1.EndpointAddress{
+					{IP: "10.0.0.1", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "bloomz-1"}},
+					{IP: "10.0.0.2", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "bloomz-2"}},
+				},
+				Ports: []v1.EndpointPort{{Port: 8080}},
+			}},
+		})).To(Succeed())
+		Expect(podIndexer.Add(&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "bloomz-1", Namespace: "default"},
+		})).To(Succeed())
+		Expect(podIndexer.Add(&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "bloomz-2", Namespace: "default", DeletionTimestamp: &now,
+			},
+		})).To(Succeed())
+
+		resolver := NewEndpointResolver(corelister.NewEndpointsLister(endpointIndexer),
+			corelister.NewPodLister(podIndexer))
+		for i := 0; i < 10; i++ {
+			u, err := resolver.Resolve("default", "bloomz")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(u.Host).To(Equal("10.0.0.1:8080"))
+		}
+
+		Expect(podIndexer.Update(&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "bloomz-1", Namespace: "default", DeletionTimestamp: &now,
+			},
+		})).To(Succeed())
+		_, err := resolver.Resolve("default", "bloomz")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,141 @@
+package runtime
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	"github.com/tensorchord/openmodelz/agent/pkg/k8s"
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+)
+
+// BatchInferenceCreate creates the batch inference, the job is created by
+// the controller.
+func (r generalRuntime) BatchInferenceCreate(ctx context.Context,
+	req types.BatchInference) (types.BatchInference, error) {
+	bi, err := makeBatchInference(req)
+	if err != nil {
+		return types.BatchInference{}, err
+	}
+
+	created, err := r.inferenceClient.TensorchordV2alpha1().
+		BatchInferences(req.Spec.Namespace).Create(ctx, bi, metav1.CreateOptions{})
+	if err != nil {
+		return types.BatchInference{}, batchInferenceError(err)
+	}
+	return k8s.AsBatchInference(created), nil
+}
+
+// BatchInferenceList returns the batch inferences in the namespace.
+func (r generalRuntime) BatchInferenceList(ctx context.Context,
+	namespace string) ([]types.BatchInference, error) {
+	list, err := r.inferenceClient.TensorchordV2alpha1().
+		BatchInferences(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, batchInferenceError(err)
+	}
+
+	res := make([]types.BatchInference, len(list.Items))
+	for i := range list.Items {
+		res[i] = k8s.AsBatchInference(&list.Items[i])
+	}
+	return res, nil
+}
+
+// BatchInferenceGet returns the batch inference with the progress.
+func (r generalRuntime) BatchInferenceGet(ctx context.Context,
+	namespace, name string) (types.BatchInference, error) {
+	bi, err := r.inferenceClient.TensorchordV2alpha1().
+		BatchInferences(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return types.BatchInference{}, batchInferenceError(err)
+	}
+	return k8s.AsBatchInference(bi), nil
+}
+
+// BatchInferenceCancel marks the batch inference as cancelled, the
+// controller deletes the job. The written responses are kept.
+func (r generalRuntime) BatchInferenceCancel(ctx context.Context,
+	namespace, name string) (types.BatchInference, error) {
+	actual, err := r.inferenceClient.TensorchordV2alpha1().
+		BatchInferences(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return types.BatchInference{}, batchInferenceError(err)
+	}
+	if actual.Spec.Cancel {
+		return k8s.AsBatchInference(actual), nil
+	}
+
+	expected := actual.DeepCopy()
+	expected.Spec.Cancel = true
+	updated, err := r.inferenceClient.TensorchordV2alpha1().
+		BatchInferences(namespace).Update(ctx, expected, metav1.UpdateOptions{})
+	if err != nil {
+		return types.BatchInference{}, batchInferenceError(err)
+	}
+	return k8s.AsBatchInference(updated), nil
+}
+
+func makeBatchInference(req types.BatchInference) (*v2alpha1.BatchInference, error) {
+	bi := &v2alpha1.BatchInference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Spec.Name,
+			Namespace: req.Spec.Namespace,
+		},
+		Spec: v2alpha1.BatchInferenceSpec{
+			Inference:     req.Spec.Inference,
+			Image:         req.Spec.Image,
+			Framework:     v2alpha1.Framework(req.Spec.Framework),
+			Port:          req.Spec.Port,
+			Command:       req.Spec.Command,
+			HTTPProbePath: req.Spec.HTTPProbePath,
+			EnvVars:       req.Spec.EnvVars,
+			Secrets:       req.Spec.Secrets,
+			Input: v2alpha1.BatchInput{
+				BatchLocation: makeBatchLocation(req.Spec.Input.BatchLocation),
+				JSONL:         req.Spec.Input.JSONL,
+				Files:         req.Spec.Input.Files,
+			},
+			Output:       makeBatchLocation(req.Spec.Output),
+			Path:         req.Spec.Path,
+			Parallelism:  req.Spec.Parallelism,
+			MaxRetries:   req.Spec.MaxRetries,
+			BackoffLimit: req.Spec.BackoffLimit,
+			Cancel:       req.Spec.Cancel,
+		},
+	}
+
+	// The resources of the inference are used if they are not set.
+	if req.Spec.Resources != nil {
+		rr, err := k8s.MakeResourceRequirements(req.Spec.Resources)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+		bi.Spec.Resources = &rr
+	}
+	return bi, nil
+}
+
+func makeBatchLocation(l types.BatchLocation) v2alpha1.BatchLocation {
+	return v2alpha1.BatchLocation{
+		URI:         l.URI,
+		Endpoint:    l.Endpoint,
+		VolumeClaim: l.VolumeClaim,
+	}
+}
+
+func batchInferenceError(err error) error {
+	switch {
+	case k8serrors.IsNotFound(err):
+		return errdefs.NotFound(err)
+	case k8serrors.IsAlreadyExists(err), k8serrors.IsConflict(err):
+		return errdefs.Conflict(err)
+	case k8serrors.IsInvalid(err), k8serrors.IsBadRequest(err):
+		return errdefs.InvalidParameter(err)
+	default:
+		return errdefs.System(err)
+	}
+}
@@ -0,0 +1,28 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+// InferenceCrashLoopUpdate records the crash loop state in the inference
+// annotations, the state is removed if it is nil.
+func (r generalRuntime) InferenceCrashLoopUpdate(ctx context.Context,
+	namespace, name string, state *types.CrashLoopState) error {
+	if state == nil {
+		return r.updateInferenceAnnotation(ctx, namespace, name,
+			consts.AnnotationCrashLoop, nil)
+	}
+
+	value, err := json.Marshal(state)
+	if err != nil {
+		return errdefs.InvalidParameter(err)
+	}
+	str := string(value)
+	return r.updateInferenceAnnotation(ctx, namespace, name,
+		consts.AnnotationCrashLoop, &str)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/tensorchord/openmodelz/agent/errdefs"
 	"github.com/tensorchord/openmodelz/agent/pkg/config"
 	localconsts "github.com/tensorchord/openmodelz/agent/pkg/consts"
+	"github.com/tensorchord/openmodelz/agent/pkg/k8s"
 	ingressv1 "github.com/tensorchord/openmodelz/ingress-operator/pkg/apis/modelzetes/v1"
 	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
@@ -33,6 +34,7 @@ func (r generalRuntime) InferenceCreate(ctx context.Context,
 	if err != nil {
 		return err
 	}
+	recordChange(inf, "")
 
 	// Create the ingress
 	// TODO(gaocegege): Check if the domain is already used.
@@ -90,6 +92,7 @@ func (r generalRuntime) InferenceCreate(ctx context.Context,
 				return errdefs.System(err)
 			}
 		}
+		r.limitInferenceGPUResources(ctx, namespace, inf.Spec)
 
 		cfg.Domain = domain
 		ingress, err := makeIngress(req, cfg)
@@ -128,6 +131,7 @@ func (r generalRuntime) InferenceCreate(ctx context.Context,
 				return errdefs.System(err)
 			}
 		}
+		r.limitInferenceGPUResources(ctx, namespace, inf.Spec)
 	}
 	return nil
 }
@@ -141,41 +145,13 @@ func makeInference(request types.InferenceDeployment) (*v2alpha1.Inference, erro
 				consts.LabelInferenceName: request.Spec.Name,
 			},
 		},
-		Spec: v2alpha1.InferenceSpec{
-			Name:          request.Spec.Name,
-			Image:         request.Spec.Image,
-			Framework:     v2alpha1.Framework(request.Spec.Framework),
-			Port:          request.Spec.Port,
-			Command:       request.Spec.Command,
-			EnvVars:       request.Spec.EnvVars,
-			Secrets:       request.Spec.Secrets,
-			Constraints:   request.Spec.Constraints,
-			Labels:        request.Spec.Labels,
-			Annotations:   request.Spec.Annotations,
-			HTTPProbePath: request.Spec.HTTPProbePath,
-		},
-	}
-
-	if request.Spec.Scaling != nil {
-		is.Spec.Scaling = &v2alpha1.ScalingConfig{
-			MinReplicas:     request.Spec.Scaling.MinReplicas,
-			MaxReplicas:     request.Spec.Scaling.MaxReplicas,
-			TargetLoad:      request.Spec.Scaling.TargetLoad,
-			ZeroDuration:    request.Spec.Scaling.ZeroDuration,
-			StartupDuration: request.Spec.Scaling.StartupDuration,
-		}
-		if request.Spec.Scaling.Type != nil {
-			buf := v2alpha1.ScalingType(*request.Spec.Scaling.Type)
-			is.Spec.Scaling.Type = &buf
-		}
 	}
 
-	rr, err := createResources(request)
+	spec, err := k8s.MakeInferenceSpec(request)
 	if err != nil {
 		return nil, errdefs.InvalidParameter(err)
 	}
-
-	is.Spec.Resources = &rr
+	is.Spec = spec
 	return is, nil
 }
 
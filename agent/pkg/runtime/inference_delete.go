@@ -63,13 +63,11 @@ func deleteInference(ctx context.Context,
 		}
 	}
 
+	// The ingress may be deleted by the finalizer of the inference already.
 	if ingressEnabled && ingressClient != nil {
-		if err := ingressClient.TensorchordV1().InferenceIngresses(baseNamespace).Delete(ctx, inferenceName, *opts); err != nil {
-			if k8serrors.IsNotFound(err) {
-				return errdefs.NotFound(err)
-			} else {
-				return errdefs.System(err)
-			}
+		if err := ingressClient.TensorchordV1().InferenceIngresses(baseNamespace).Delete(ctx, inferenceName, *opts); err != nil &&
+			!k8serrors.IsNotFound(err) {
+			return errdefs.System(err)
 		}
 	}
 
@@ -0,0 +1,58 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+)
+
+const defaultEventSource = "modelz-agent"
+
+// InferenceEventCreate records a kubernetes event on the inference.
+func (r generalRuntime) InferenceEventCreate(ctx context.Context,
+	namespace, name string, event types.InferenceEvent) error {
+	inference, err := r.InferenceGetCRD(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	source := event.Source
+	if source == "" {
+		source = defaultEventSource
+	}
+
+	now := metav1.Now()
+	e := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s.", name),
+			Namespace:    namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:            "Inference",
+			APIVersion:      v2alpha1.SchemeGroupVersion.String(),
+			Name:            inference.Name,
+			Namespace:       inference.Namespace,
+			UID:             inference.UID,
+			ResourceVersion: inference.ResourceVersion,
+		},
+		Type:           event.Type,
+		Reason:         event.Reason,
+		Message:        event.Message,
+		Source:         corev1.EventSource{Component: source},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	if _, err := r.kubeClient.CoreV1().Events(namespace).
+		Create(ctx, e, metav1.CreateOptions{}); err != nil {
+		return errdefs.System(err)
+	}
+	return nil
+}
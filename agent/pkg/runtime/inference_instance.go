@@ -1,6 +1,11 @@
 package runtime
 
 import (
+	"context"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	v1 "k8s.io/client-go/listers/core/v1"
@@ -8,11 +13,48 @@ import (
 	"github.com/tensorchord/openmodelz/agent/api/types"
 	"github.com/tensorchord/openmodelz/agent/errdefs"
 	"github.com/tensorchord/openmodelz/agent/pkg/k8s"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
 )
 
 func (r generalRuntime) InferenceInstanceList(namespace, inferenceName string) (
 	[]types.InferenceDeploymentInstance, error) {
-	return getInstances(namespace, inferenceName, r.podInformer.Lister())
+	instances, err := getInstances(namespace, inferenceName, r.podInformer.Lister())
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range instances {
+		// The progress is read from the logs if the model downloader could
+		// not report it in the pod annotation.
+		if instances[i].Status.Phase != types.InstancePhaseDownloading ||
+			instances[i].Status.Message != k8s.MessageDownloadingModel {
+			continue
+		}
+		if progress := r.downloadProgress(instances[i].Spec.Namespace,
+			instances[i].Spec.Name); progress != "" {
+			instances[i].Status.Message = progress
+		}
+	}
+	return instances, nil
+}
+
+// downloadProgress returns the last line logged by the model downloader,
+// which is the progress of the download.
+func (r generalRuntime) downloadProgress(namespace, pod string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	tail := int64(1)
+	raw, err := r.kubeClient.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container: consts.ModelDownloaderContainerName,
+		TailLines: &tail,
+	}).DoRaw(ctx)
+	if err != nil {
+		r.logger.WithError(err).WithField("pod", pod).
+			Debug("failed to get the progress of the model download")
+		return ""
+	}
+	return strings.TrimSpace(string(raw))
 }
 
 func getInstances(functionNamespace string, functionName string,
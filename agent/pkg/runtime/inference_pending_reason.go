@@ -0,0 +1,19 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+// InferencePendingReasonUpdate records the reason why the replicas of the
+// inference cannot be scheduled, the reason is removed if it is empty.
+func (r generalRuntime) InferencePendingReasonUpdate(ctx context.Context,
+	namespace, name, reason string) error {
+	if reason == "" {
+		return r.updateInferenceAnnotation(ctx, namespace, name,
+			consts.AnnotationPendingReason, nil)
+	}
+	return r.updateInferenceAnnotation(ctx, namespace, name,
+		consts.AnnotationPendingReason, &reason)
+}
@@ -30,6 +30,10 @@ func (r generalRuntime) InferenceScale(ctx context.Context, namespace string,
 
 	if inf.Spec.Scaling != nil {
 		minReplicas := *inf.Spec.Scaling.MinReplicas
+		if inf.Status.CrashLoop != nil && inf.Status.CrashLoop.MinReplicas < minReplicas {
+			// The autoscaler suppresses the inference since its instances keep crashing.
+			minReplicas = inf.Status.CrashLoop.MinReplicas
+		}
 		if replicas < minReplicas {
 			replicas = minReplicas
 		}
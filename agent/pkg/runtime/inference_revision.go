@@ -0,0 +1,162 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	"github.com/tensorchord/openmodelz/agent/pkg/k8s"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/revision"
+)
+
+// changeAuthor is the author of the revisions changed through the agent.
+const changeAuthor = "modelz-agent"
+
+// InferenceRevisionList returns the revisions of the inference recorded by
+// modelzetes, sorted by the revision number in ascending order.
+func (r generalRuntime) InferenceRevisionList(ctx context.Context,
+	namespace, name string) ([]types.InferenceRevision, error) {
+	inference, revisions, err := r.inferenceRevisions(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []types.InferenceRevision{}
+	var prev *v2alpha1.InferenceSpec
+	for _, rev := range revisions {
+		spec, err := revision.Spec(rev)
+		if err != nil {
+			return nil, errdefs.System(err)
+		}
+		item := asInferenceRevision(inference, rev, spec)
+		// The diff of the first revision is unknown if the previous ones
+		// are pruned.
+		if prev != nil {
+			if item.Diff, err = revision.Diff(prev, spec); err != nil {
+				return nil, errdefs.System(err)
+			}
+		}
+		res = append(res, item)
+		prev = spec
+	}
+	return res, nil
+}
+
+// InferenceRollback restores the spec of the inference to the revision. The
+// previous revision is used if the revision is 0. A new revision is recorded
+// by modelzetes with the rollback as the change cause.
+func (r generalRuntime) InferenceRollback(ctx context.Context,
+	namespace, name string, target int64) (*types.InferenceRevision, error) {
+	inference, revisions, err := r.inferenceRevisions(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *appsv1.ControllerRevision
+	if target == 0 {
+		if len(revisions) < 2 {
+			return nil, errdefs.InvalidParameter(
+				fmt.Errorf("no previous revision of inference %s", name))
+		}
+		found = revisions[len(revisions)-2]
+	}
+	for _, rev := range revisions {
+		if rev.Revision == target {
+			found = rev
+		}
+	}
+	if found == nil {
+		return nil, errdefs.NotFound(
+			fmt.Errorf("revision %d of inference %s not found", target, name))
+	}
+
+	spec, err := revision.Spec(found)
+	if err != nil {
+		return nil, errdefs.System(err)
+	}
+	res := asInferenceRevision(inference, found, spec)
+	if equality.Semantic.DeepEqual(*spec, inference.Spec) {
+		return &res, nil
+	}
+
+	expected := inference.DeepCopy()
+	expected.Spec = *spec
+	recordChange(expected, fmt.Sprintf("Rollback to revision %d", found.Revision))
+	if _, err := r.inferenceClient.TensorchordV2alpha1().
+		Inferences(namespace).Update(ctx, expected, metav1.UpdateOptions{}); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, errdefs.NotFound(err)
+		}
+		return nil, errdefs.System(err)
+	}
+	return &res, nil
+}
+
+// recordChange sets the author and the cause of the spec change made by the
+// agent, the cause of the previous change is removed if it is empty. The
+// admission webhook overrides the author with the user of the request if it
+// is enabled.
+func recordChange(inference *v2alpha1.Inference, cause string) {
+	if inference.Annotations == nil {
+		inference.Annotations = map[string]string{}
+	}
+	inference.Annotations[consts.AnnotationChangeAuthor] = changeAuthor
+	if cause == "" {
+		delete(inference.Annotations, consts.AnnotationChangeCause)
+	} else {
+		inference.Annotations[consts.AnnotationChangeCause] = cause
+	}
+}
+
+// inferenceRevisions returns the inference and its revisions.
+func (r generalRuntime) inferenceRevisions(ctx context.Context,
+	namespace, name string) (*v2alpha1.Inference, []*appsv1.ControllerRevision, error) {
+	inference, err := r.inferenceClient.TensorchordV2alpha1().
+		Inferences(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil, errdefs.NotFound(err)
+		}
+		return nil, nil, errdefs.System(err)
+	}
+
+	list, err := r.kubeClient.AppsV1().ControllerRevisions(namespace).List(ctx,
+		metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(revision.Labels(inference)).String(),
+		})
+	if err != nil {
+		return nil, nil, errdefs.System(err)
+	}
+	items := make([]*appsv1.ControllerRevision, 0, len(list.Items))
+	for i := range list.Items {
+		items = append(items, &list.Items[i])
+	}
+	return inference, revision.Owned(inference, items), nil
+}
+
+func asInferenceRevision(inference *v2alpha1.Inference,
+	rev *appsv1.ControllerRevision, spec *v2alpha1.InferenceSpec) types.InferenceRevision {
+	inf := k8s.AsInferenceDeployment(&v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      inference.Name,
+			Namespace: inference.Namespace,
+		},
+		Spec: *spec,
+	}, nil)
+	return types.InferenceRevision{
+		Revision:    rev.Revision,
+		CreatedAt:   rev.CreationTimestamp.Time,
+		Author:      rev.Annotations[consts.AnnotationChangeAuthor],
+		ChangeCause: rev.Annotations[consts.AnnotationChangeCause],
+		Spec:        inf.Spec,
+	}
+}
@@ -5,11 +5,13 @@ import (
 
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
 	inferenceclientset "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/tensorchord/openmodelz/agent/api/types"
 	"github.com/tensorchord/openmodelz/agent/errdefs"
+	"github.com/tensorchord/openmodelz/agent/pkg/k8s"
 )
 
 func (r generalRuntime) InferenceUpdate(ctx context.Context, namespace string,
@@ -22,9 +24,11 @@ func (r generalRuntime) InferenceUpdate(ctx context.Context, namespace string,
 		}
 	}
 
-	if err = updateInference(ctx, namespace, r.inferenceClient, req); err != nil {
+	inf, err := updateInference(ctx, namespace, r.inferenceClient, req)
+	if err != nil {
 		return err
 	}
+	r.limitInferenceGPUResources(ctx, namespace, inf.Spec)
 	return nil
 }
 
@@ -32,26 +36,54 @@ func updateInference(
 	ctx context.Context,
 	functionNamespace string,
 	inferenceClient inferenceclientset.Interface,
-	request types.InferenceDeployment) (err error) {
+	request types.InferenceDeployment) (*v2alpha1.Inference, error) {
 
 	actual, err := inferenceClient.TensorchordV2alpha1().
 		Inferences(functionNamespace).Get(
 		ctx, request.Spec.Name, metav1.GetOptions{})
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
-			return errdefs.NotFound(err)
+			return nil, errdefs.NotFound(err)
 		} else {
-			return errdefs.System(err)
+			return nil, errdefs.System(err)
 		}
 	}
 
 	expected := actual.DeepCopy()
+	spec, err := mergeInferenceSpec(actual.Spec, request)
+	if err != nil {
+		return nil, err
+	}
+	expected.Spec = *spec
+	if !equality.Semantic.DeepEqual(actual.Spec, expected.Spec) {
+		recordChange(expected, "")
+	}
+
+	updated, err := inferenceClient.TensorchordV2alpha1().
+		Inferences(functionNamespace).Update(
+		ctx, expected, metav1.UpdateOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, errdefs.NotFound(err)
+		} else {
+			return nil, errdefs.System(err)
+		}
+	}
+
+	return updated, nil
+}
+
+// mergeInferenceSpec returns the spec with the fields set in the request,
+// the fields not set are kept.
+func mergeInferenceSpec(actual v2alpha1.InferenceSpec,
+	request types.InferenceDeployment) (*v2alpha1.InferenceSpec, error) {
+	spec := actual.DeepCopy()
 
 	if request.Spec.Image != "" {
-		expected.Spec.Image = request.Spec.Image
+		spec.Image = request.Spec.Image
 	}
 	if request.Spec.Scaling != nil {
-		expected.Spec.Scaling = &v2alpha1.ScalingConfig{
+		spec.Scaling = &v2alpha1.ScalingConfig{
 			MinReplicas:     request.Spec.Scaling.MinReplicas,
 			MaxReplicas:     request.Spec.Scaling.MaxReplicas,
 			TargetLoad:      request.Spec.Scaling.TargetLoad,
@@ -59,42 +91,56 @@ func updateInference(
 			StartupDuration: request.Spec.Scaling.StartupDuration,
 		}
 		if request.Spec.Scaling.Type != nil {
-			expected.Spec.Scaling.Type = new(v2alpha1.ScalingType)
-			*expected.Spec.Scaling.Type = v2alpha1.ScalingType(*request.Spec.Scaling.Type)
+			spec.Scaling.Type = new(v2alpha1.ScalingType)
+			*spec.Scaling.Type = v2alpha1.ScalingType(*request.Spec.Scaling.Type)
+		}
+		if request.Spec.Scaling.CustomMetric != nil {
+			metric, err := k8s.MakeCustomMetric(*request.Spec.Scaling.CustomMetric)
+			if err != nil {
+				return nil, errdefs.InvalidParameter(err)
+			}
+			spec.Scaling.CustomMetric = metric
 		}
 	}
 	if request.Spec.EnvVars != nil {
-		expected.Spec.EnvVars = request.Spec.EnvVars
+		spec.EnvVars = request.Spec.EnvVars
 	}
 	if request.Spec.Secrets != nil {
-		expected.Spec.Secrets = request.Spec.Secrets
+		spec.Secrets = request.Spec.Secrets
 	}
 	if request.Spec.Constraints != nil {
-		expected.Spec.Constraints = request.Spec.Constraints
+		spec.Constraints = request.Spec.Constraints
 	}
 	if request.Spec.Labels != nil {
-		expected.Spec.Labels = request.Spec.Labels
+		spec.Labels = request.Spec.Labels
 	}
 	if request.Spec.Annotations != nil {
-		expected.Spec.Annotations = request.Spec.Annotations
+		spec.Annotations = request.Spec.Annotations
+	}
+	if request.Spec.Model != nil {
+		spec.Model = k8s.MakeModelSource(request.Spec.Model)
+	}
+	if request.Spec.Scheduling != nil {
+		spec.Scheduling = k8s.MakeScheduling(request.Spec.Scheduling)
+	}
+	if request.Spec.Rollout != nil {
+		spec.Rollout = k8s.MakeRollout(request.Spec.Rollout)
+	}
+	if request.Spec.Probes != nil {
+		spec.Probes = k8s.MakeProbes(request.Spec.Probes)
+	}
+	if request.Spec.Termination != nil {
+		spec.Termination = k8s.MakeTermination(request.Spec.Termination)
+	}
+	if request.Spec.DisruptionBudget != nil {
+		spec.DisruptionBudget = k8s.MakeDisruptionBudget(request.Spec.DisruptionBudget)
 	}
 	if request.Spec.Resources != nil {
-		rr, err := createResources(request)
+		rr, err := k8s.MakeResourceRequirements(request.Spec.Resources)
 		if err != nil {
-			return errdefs.InvalidParameter(err)
-		}
-		expected.Spec.Resources = &rr
-	}
-
-	if _, err := inferenceClient.TensorchordV2alpha1().
-		Inferences(functionNamespace).Update(
-		ctx, expected, metav1.UpdateOptions{}); err != nil {
-		if k8serrors.IsNotFound(err) {
-			return errdefs.NotFound(err)
-		} else {
-			return errdefs.System(err)
+			return nil, errdefs.InvalidParameter(err)
 		}
+		spec.Resources = &rr
 	}
-
-	return nil
+	return spec, nil
 }
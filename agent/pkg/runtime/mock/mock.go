@@ -38,6 +38,66 @@ func (m *MockRuntime) EXPECT() *MockRuntimeMockRecorder {
 	return m.recorder
 }
 
+// BatchInferenceCancel mocks base method.
+func (m *MockRuntime) BatchInferenceCancel(ctx context.Context, namespace, name string) (types.BatchInference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchInferenceCancel", ctx, namespace, name)
+	ret0, _ := ret[0].(types.BatchInference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchInferenceCancel indicates an expected call of BatchInferenceCancel.
+func (mr *MockRuntimeMockRecorder) BatchInferenceCancel(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInferenceCancel", reflect.TypeOf((*MockRuntime)(nil).BatchInferenceCancel), ctx, namespace, name)
+}
+
+// BatchInferenceCreate mocks base method.
+func (m *MockRuntime) BatchInferenceCreate(ctx context.Context, req types.BatchInference) (types.BatchInference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchInferenceCreate", ctx, req)
+	ret0, _ := ret[0].(types.BatchInference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchInferenceCreate indicates an expected call of BatchInferenceCreate.
+func (mr *MockRuntimeMockRecorder) BatchInferenceCreate(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInferenceCreate", reflect.TypeOf((*MockRuntime)(nil).BatchInferenceCreate), ctx, req)
+}
+
+// BatchInferenceGet mocks base method.
+func (m *MockRuntime) BatchInferenceGet(ctx context.Context, namespace, name string) (types.BatchInference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchInferenceGet", ctx, namespace, name)
+	ret0, _ := ret[0].(types.BatchInference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchInferenceGet indicates an expected call of BatchInferenceGet.
+func (mr *MockRuntimeMockRecorder) BatchInferenceGet(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInferenceGet", reflect.TypeOf((*MockRuntime)(nil).BatchInferenceGet), ctx, namespace, name)
+}
+
+// BatchInferenceList mocks base method.
+func (m *MockRuntime) BatchInferenceList(ctx context.Context, namespace string) ([]types.BatchInference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchInferenceList", ctx, namespace)
+	ret0, _ := ret[0].([]types.BatchInference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchInferenceList indicates an expected call of BatchInferenceList.
+func (mr *MockRuntimeMockRecorder) BatchInferenceList(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInferenceList", reflect.TypeOf((*MockRuntime)(nil).BatchInferenceList), ctx, namespace)
+}
+
 // BuildCreate mocks base method.
 func (m *MockRuntime) BuildCreate(ctx context.Context, req types.Build, inference *v2alpha1.Inference, builderImage, buildkitdAddress, buildCtlBin, secret string) error {
 	m.ctrl.T.Helper()
@@ -124,6 +184,20 @@ func (mr *MockRuntimeMockRecorder) InferenceCreate(ctx, req, cfg, event, serverP
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InferenceCreate", reflect.TypeOf((*MockRuntime)(nil).InferenceCreate), ctx, req, cfg, event, serverPort)
 }
 
+// InferenceCrashLoopUpdate mocks base method.
+func (m *MockRuntime) InferenceCrashLoopUpdate(ctx context.Context, namespace, name string, state *types.CrashLoopState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InferenceCrashLoopUpdate", ctx, namespace, name, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InferenceCrashLoopUpdate indicates an expected call of InferenceCrashLoopUpdate.
+func (mr *MockRuntimeMockRecorder) InferenceCrashLoopUpdate(ctx, namespace, name, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InferenceCrashLoopUpdate", reflect.TypeOf((*MockRuntime)(nil).InferenceCrashLoopUpdate), ctx, namespace, name, state)
+}
+
 // InferenceDelete mocks base method.
 func (m *MockRuntime) InferenceDelete(ctx context.Context, namespace, inferenceName, ingressNamespace, event string) error {
 	m.ctrl.T.Helper()
@@ -138,6 +212,20 @@ func (mr *MockRuntimeMockRecorder) InferenceDelete(ctx, namespace, inferenceName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InferenceDelete", reflect.TypeOf((*MockRuntime)(nil).InferenceDelete), ctx, namespace, inferenceName, ingressNamespace, event)
 }
 
+// InferenceEventCreate mocks base method.
+func (m *MockRuntime) InferenceEventCreate(ctx context.Context, namespace, name string, event types.InferenceEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InferenceEventCreate", ctx, namespace, name, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InferenceEventCreate indicates an expected call of InferenceEventCreate.
+func (mr *MockRuntimeMockRecorder) InferenceEventCreate(ctx, namespace, name, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InferenceEventCreate", reflect.TypeOf((*MockRuntime)(nil).InferenceEventCreate), ctx, namespace, name, event)
+}
+
 // InferenceExec mocks base method.
 func (m *MockRuntime) InferenceExec(ctx *gin.Context, namespace, instance string, commands []string, tty bool) error {
 	m.ctrl.T.Helper()
@@ -212,6 +300,64 @@ func (mr *MockRuntimeMockRecorder) InferenceList(namespace interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InferenceList", reflect.TypeOf((*MockRuntime)(nil).InferenceList), namespace)
 }
 
+// InferencePendingReasonUpdate mocks base method.
+func (m *MockRuntime) InferencePendingReasonUpdate(ctx context.Context, namespace, name, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InferencePendingReasonUpdate", ctx, namespace, name, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InferencePendingReasonUpdate indicates an expected call of InferencePendingReasonUpdate.
+func (mr *MockRuntimeMockRecorder) InferencePendingReasonUpdate(ctx, namespace, name, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InferencePendingReasonUpdate", reflect.TypeOf((*MockRuntime)(nil).InferencePendingReasonUpdate), ctx, namespace, name, reason)
+}
+
+// InferenceQuotaCheck mocks base method.
+func (m *MockRuntime) InferenceQuotaCheck(ctx context.Context, namespace string, req types.InferenceDeployment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InferenceQuotaCheck", ctx, namespace, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InferenceQuotaCheck indicates an expected call of InferenceQuotaCheck.
+func (mr *MockRuntimeMockRecorder) InferenceQuotaCheck(ctx, namespace, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InferenceQuotaCheck", reflect.TypeOf((*MockRuntime)(nil).InferenceQuotaCheck), ctx, namespace, req)
+}
+
+// InferenceRevisionList mocks base method.
+func (m *MockRuntime) InferenceRevisionList(ctx context.Context, namespace, name string) ([]types.InferenceRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InferenceRevisionList", ctx, namespace, name)
+	ret0, _ := ret[0].([]types.InferenceRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InferenceRevisionList indicates an expected call of InferenceRevisionList.
+func (mr *MockRuntimeMockRecorder) InferenceRevisionList(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InferenceRevisionList", reflect.TypeOf((*MockRuntime)(nil).InferenceRevisionList), ctx, namespace, name)
+}
+
+// InferenceRollback mocks base method.
+func (m *MockRuntime) InferenceRollback(ctx context.Context, namespace, name string, revision int64) (*types.InferenceRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InferenceRollback", ctx, namespace, name, revision)
+	ret0, _ := ret[0].(*types.InferenceRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InferenceRollback indicates an expected call of InferenceRollback.
+func (mr *MockRuntimeMockRecorder) InferenceRollback(ctx, namespace, name, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InferenceRollback", reflect.TypeOf((*MockRuntime)(nil).InferenceRollback), ctx, namespace, name, revision)
+}
+
 // InferenceScale mocks base method.
 func (m *MockRuntime) InferenceScale(ctx context.Context, namespace string, req types.ScaleServiceRequest, inf *types.InferenceDeployment) error {
 	m.ctrl.T.Helper()
@@ -297,6 +443,92 @@ func (mr *MockRuntimeMockRecorder) NamespaceList(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamespaceList", reflect.TypeOf((*MockRuntime)(nil).NamespaceList), ctx)
 }
 
+// NamespaceQuotaGet mocks base method.
+func (m *MockRuntime) NamespaceQuotaGet(ctx context.Context, name string) (types.NamespaceQuotaStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamespaceQuotaGet", ctx, name)
+	ret0, _ := ret[0].(types.NamespaceQuotaStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NamespaceQuotaGet indicates an expected call of NamespaceQuotaGet.
+func (mr *MockRuntimeMockRecorder) NamespaceQuotaGet(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamespaceQuotaGet", reflect.TypeOf((*MockRuntime)(nil).NamespaceQuotaGet), ctx, name)
+}
+
+// NamespaceQuotaUpdate mocks base method.
+func (m *MockRuntime) NamespaceQuotaUpdate(ctx context.Context, name string, quota types.NamespaceQuota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamespaceQuotaUpdate", ctx, name, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NamespaceQuotaUpdate indicates an expected call of NamespaceQuotaUpdate.
+func (mr *MockRuntimeMockRecorder) NamespaceQuotaUpdate(ctx, name, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamespaceQuotaUpdate", reflect.TypeOf((*MockRuntime)(nil).NamespaceQuotaUpdate), ctx, name, quota)
+}
+
+// SecretCreate mocks base method.
+func (m *MockRuntime) SecretCreate(ctx context.Context, secret types.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretCreate", ctx, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecretCreate indicates an expected call of SecretCreate.
+func (mr *MockRuntimeMockRecorder) SecretCreate(ctx, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretCreate", reflect.TypeOf((*MockRuntime)(nil).SecretCreate), ctx, secret)
+}
+
+// SecretDelete mocks base method.
+func (m *MockRuntime) SecretDelete(ctx context.Context, namespace, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretDelete", ctx, namespace, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecretDelete indicates an expected call of SecretDelete.
+func (mr *MockRuntimeMockRecorder) SecretDelete(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretDelete", reflect.TypeOf((*MockRuntime)(nil).SecretDelete), ctx, namespace, name)
+}
+
+// SecretList mocks base method.
+func (m *MockRuntime) SecretList(ctx context.Context, namespace string) ([]types.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretList", ctx, namespace)
+	ret0, _ := ret[0].([]types.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretList indicates an expected call of SecretList.
+func (mr *MockRuntimeMockRecorder) SecretList(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretList", reflect.TypeOf((*MockRuntime)(nil).SecretList), ctx, namespace)
+}
+
+// SecretUpdate mocks base method.
+func (m *MockRuntime) SecretUpdate(ctx context.Context, secret types.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretUpdate", ctx, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecretUpdate indicates an expected call of SecretUpdate.
+func (mr *MockRuntimeMockRecorder) SecretUpdate(ctx, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretUpdate", reflect.TypeOf((*MockRuntime)(nil).SecretUpdate), ctx, secret)
+}
+
 // ServerDeleteNode mocks base method.
 func (m *MockRuntime) ServerDeleteNode(ctx context.Context, name string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,583 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	"github.com/tensorchord/openmodelz/agent/pkg/k8s"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/accelerator"
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+const (
+	// namespaceQuotaName is the name of the resource quota managed by the
+	// agent in the namespace.
+	namespaceQuotaName = "modelz-quota"
+	// namespaceLimitRangeName is the name of the limit range which sets
+	// the default requests, the pods without the requests are rejected by
+	// the quota otherwise.
+	namespaceLimitRangeName = "modelz-limits"
+
+	defaultRequestCPU    = "100m"
+	defaultRequestMemory = "128Mi"
+
+	// resourceInferences is the object count quota of the inferences.
+	resourceInferences corev1.ResourceName = "count/inferences.tensorchord.ai"
+
+	// annotationGPUQuota is set on the resource quota to the max number of
+	// the accelerator devices requested in total, i.e. the whole GPUs of all
+	// the vendors, the shared GPUs and the MIG devices. The resource quota
+	// limits each resource separately, thus each of them is limited to the
+	// total. The total is only checked by the agent on the inference
+	// requests, it is not a hard limit of the cluster.
+	annotationGPUQuota = "modelz.tensorchord.ai/gpu-quota"
+
+	// rollingUpdateMaxSurgePercent is the max surge of the deployments of
+	// the inferences created by modelzetes.
+	rollingUpdateMaxSurgePercent = 10
+)
+
+// NamespaceQuotaGet returns the quota of the namespace and the usage. The
+// quota is empty if the namespace is not limited.
+func (r generalRuntime) NamespaceQuotaGet(ctx context.Context,
+	name string) (types.NamespaceQuotaStatus, error) {
+	res := types.NamespaceQuotaStatus{Name: name}
+	quota, err := r.kubeClient.CoreV1().ResourceQuotas(name).
+		Get(ctx, namespaceQuotaName, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return res, errdefs.System(err)
+		}
+		if !r.NamespaceGet(ctx, name) {
+			return res, errdefs.NotFound(fmt.Errorf("namespace %s not found", name))
+		}
+		return res, nil
+	}
+
+	return asNamespaceQuotaStatus(name, quota), nil
+}
+
+// NamespaceQuotaUpdate creates or replaces the resource quota and the limit
+// range of the namespace. They are removed if the quota is empty.
+func (r generalRuntime) NamespaceQuotaUpdate(ctx context.Context,
+	name string, quota types.NamespaceQuota) error {
+	gpuResources := []corev1.ResourceName{}
+	if quota.GPU != "" {
+		var err error
+		if gpuResources, err = r.quotaGPUResources(ctx); err != nil {
+			return errdefs.System(err)
+		}
+	}
+	hard, err := makeQuotaHard(quota, gpuResources)
+	if err != nil {
+		return errdefs.InvalidParameter(err)
+	}
+
+	if len(hard) == 0 {
+		if err := r.kubeClient.CoreV1().ResourceQuotas(name).Delete(
+			ctx, namespaceQuotaName, metav1.DeleteOptions{}); err != nil &&
+			!k8serrors.IsNotFound(err) {
+			return errdefs.System(err)
+		}
+		if err := r.kubeClient.CoreV1().LimitRanges(name).Delete(
+			ctx, namespaceLimitRangeName, metav1.DeleteOptions{}); err != nil &&
+			!k8serrors.IsNotFound(err) {
+			return errdefs.System(err)
+		}
+		return nil
+	}
+
+	// The limit range is created first so that the pods created after the
+	// quota always have the requests.
+	if err := r.applyLimitRange(ctx, makeLimitRange(name)); err != nil {
+		return namespaceQuotaError(err)
+	}
+	resourceQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespaceQuotaName,
+			Namespace: name,
+			Labels: map[string]string{
+				consts.LabelNamespace: "true",
+			},
+			Annotations: map[string]string{},
+		},
+		Spec: corev1.ResourceQuotaSpec{Hard: hard},
+	}
+	if quota.GPU != "" {
+		resourceQuota.Annotations[annotationGPUQuota] = string(quota.GPU)
+	}
+	if err := r.applyResourceQuota(ctx, resourceQuota); err != nil {
+		return namespaceQuotaError(err)
+	}
+	return nil
+}
+
+// quotaGPUResources returns the accelerator resources limited by the GPU
+// quota: the ones of the device plugins, and the MIG devices advertised by
+// the nodes, whose names depend on the partitions.
+func (r generalRuntime) quotaGPUResources(ctx context.Context) ([]corev1.ResourceName, error) {
+	found := map[corev1.ResourceName]bool{}
+	for _, a := range accelerator.Accelerators {
+		for _, name := range a.Resources {
+			found[name] = true
+		}
+	}
+	nodes, err := r.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes.Items {
+		for name := range node.Status.Capacity {
+			if accelerator.IsResource(name) {
+				found[name] = true
+			}
+		}
+	}
+
+	res := make([]corev1.ResourceName, 0, len(found))
+	for name := range found {
+		res = append(res, name)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res, nil
+}
+
+// InferenceQuotaCheck checks that the min replicas of the inference fit in
+// the quota of the namespace, the requests of the existing replicas are
+// released if the inference is updated. The surge replicas of the rolling
+// update must fit besides the existing replicas if the update rolls out
+// and requires more resources. It returns a forbidden error instead of
+// leaving the pods rejected by the quota.
+func (r generalRuntime) InferenceQuotaCheck(ctx context.Context,
+	namespace string, req types.InferenceDeployment) error {
+	quota, err := r.kubeClient.CoreV1().ResourceQuotas(namespace).
+		Get(ctx, namespaceQuotaName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errdefs.System(err)
+	}
+
+	existing, err := r.inferenceInformer.Lister().Inferences(namespace).Get(req.Spec.Name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return errdefs.System(err)
+		}
+		existing = nil
+	}
+
+	var spec *v2alpha1.InferenceSpec
+	if existing == nil {
+		created, err := k8s.MakeInferenceSpec(req)
+		if err != nil {
+			return errdefs.InvalidParameter(err)
+		}
+		spec = &created
+	} else if spec, err = mergeInferenceSpec(existing.Spec, req); err != nil {
+		return err
+	}
+	perReplica, replicas := inferenceRequests(*spec)
+	required := scaleResourceList(perReplica, replicas)
+
+	released := corev1.ResourceList{}
+	running := int64(0)
+	if existing != nil {
+		pods, err := r.podInformer.Lister().Pods(namespace).List(
+			labels.SelectorFromSet(k8s.MakeLabelSelector(req.Spec.Name)))
+		if err != nil {
+			return errdefs.System(err)
+		}
+		for _, pod := range pods {
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			running++
+			for _, c := range pod.Spec.Containers {
+				addResourceList(released, containerRequests(c.Resources))
+			}
+		}
+	}
+
+	if err := checkQuota(quota, existing == nil, "the inference",
+		required, released); err != nil {
+		return err
+	}
+	// The new replicas are created before the old ones are terminated.
+	if existing == nil || !rollsOut(*spec, existing.Spec) ||
+		!growsResourceList(required, released) {
+		return nil
+	}
+	if surge := rollingUpdateSurge(running); surge > 0 {
+		if err := checkQuota(quota, false, "the rolling update of the inference",
+			scaleResourceList(perReplica, surge), corev1.ResourceList{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// limitInferenceGPUResources adds the accelerator resources requested by
+// the inference to the GPU quota if they are not limited yet, e.g. the MIG
+// devices of the nodes added after the quota is set. It is called after the
+// inference is created or updated, the failure is logged since the
+// inference is already applied.
+func (r generalRuntime) limitInferenceGPUResources(ctx context.Context,
+	namespace string, spec v2alpha1.InferenceSpec) {
+	quota, err := r.kubeClient.CoreV1().ResourceQuotas(namespace).
+		Get(ctx, namespaceQuotaName, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logrus.WithError(err).Warnf("failed to get the quota of namespace %s", namespace)
+		}
+		return
+	}
+	total, ok := gpuQuota(quota)
+	if !ok {
+		return
+	}
+	requests, _ := inferenceRequests(spec)
+	expected := quota.DeepCopy()
+	for name := range requests {
+		if _, ok := expected.Spec.Hard[name]; !ok && isGPURequest(name) {
+			expected.Spec.Hard[name] = total
+		}
+	}
+	if len(expected.Spec.Hard) == len(quota.Spec.Hard) {
+		return
+	}
+	if err := r.applyResourceQuota(ctx, expected); err != nil {
+		logrus.WithError(err).Warnf("failed to limit the gpu resources in the quota of namespace %s", namespace)
+	}
+}
+
+// checkQuota returns a forbidden error if the resources required by the
+// subject exceed the available ones in the quota. The accelerator devices
+// are checked against the total of the GPU quota.
+func checkQuota(quota *corev1.ResourceQuota, create bool, subject string,
+	required, released corev1.ResourceList) error {
+	if hard, ok := quota.Spec.Hard[resourceInferences]; ok && create {
+		used := quota.Status.Used[resourceInferences]
+		if used.Cmp(hard) >= 0 {
+			return errdefs.Forbidden(fmt.Errorf(
+				"namespace %s has reached the quota of %s inferences",
+				quota.Namespace, hard.String()))
+		}
+	}
+
+	for _, name := range []corev1.ResourceName{
+		corev1.ResourceRequestsCPU, corev1.ResourceRequestsMemory} {
+		qty, ok := required[name]
+		if !ok || qty.IsZero() {
+			continue
+		}
+		hard, ok := quota.Spec.Hard[name]
+		if !ok {
+			continue
+		}
+		available := hard.DeepCopy()
+		available.Sub(quota.Status.Used[name])
+		available.Add(released[name])
+		if qty.Cmp(available) > 0 {
+			return errdefs.Forbidden(fmt.Errorf(
+				"%s %s requested by %s exceeds the quota of namespace %s, %s of %s is available",
+				strings.TrimPrefix(string(name), "requests."), qty.String(), subject,
+				quota.Namespace, available.String(), hard.String()))
+		}
+	}
+
+	hard, ok := gpuQuota(quota)
+	if !ok {
+		return nil
+	}
+	qty := sumGPURequests(required)
+	if qty.IsZero() {
+		return nil
+	}
+	available := hard.DeepCopy()
+	available.Sub(sumGPURequests(quota.Status.Used))
+	available.Add(sumGPURequests(released))
+	if qty.Cmp(available) > 0 {
+		return errdefs.Forbidden(fmt.Errorf(
+			"gpu %s requested by %s exceeds the quota of namespace %s, %s of %s is available",
+			qty.String(), subject, quota.Namespace, available.String(), hard.String()))
+	}
+	return nil
+}
+
+// inferenceRequests returns the requests of each replica of the inference
+// and the min replicas, at least one replica is required to serve the
+// inference.
+func inferenceRequests(spec v2alpha1.InferenceSpec) (corev1.ResourceList, int64) {
+	resources := corev1.ResourceRequirements{}
+	if spec.Resources != nil {
+		resources = *spec.Resources
+	}
+	replicas := int64(1)
+	if spec.Scaling != nil && spec.Scaling.MinReplicas != nil &&
+		*spec.Scaling.MinReplicas > 1 {
+		replicas = int64(*spec.Scaling.MinReplicas)
+	}
+	return containerRequests(resources), replicas
+}
+
+// rollsOut returns true if the update of the inference rolls out the
+// replicas, i.e. the spec besides the scaling changes.
+func rollsOut(spec, actual v2alpha1.InferenceSpec) bool {
+	expected := spec.DeepCopy()
+	existing := actual.DeepCopy()
+	expected.Scaling, existing.Scaling = nil, nil
+	return !equality.Semantic.DeepEqual(*expected, *existing)
+}
+
+// growsResourceList returns true if any of the required resources exceeds
+// the released one.
+func growsResourceList(required, released corev1.ResourceList) bool {
+	for name, qty := range required {
+		if qty.Cmp(released[name]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// rollingUpdateSurge returns the number of the replicas created besides the
+// existing ones by the rolling update.
+func rollingUpdateSurge(replicas int64) int64 {
+	return (replicas*rollingUpdateMaxSurgePercent + 99) / 100
+}
+
+// containerRequests returns the requests of the container keyed by the
+// quota resources. The request defaults to the limit, and the CPU and the
+// memory default to the ones of the limit range.
+func containerRequests(resources corev1.ResourceRequirements) corev1.ResourceList {
+	res := corev1.ResourceList{}
+	defaults := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(defaultRequestCPU),
+		corev1.ResourceMemory: resource.MustParse(defaultRequestMemory),
+	}
+	names := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+	for _, list := range []corev1.ResourceList{resources.Requests, resources.Limits} {
+		for name := range list {
+			if accelerator.IsResource(name) {
+				names = append(names, name)
+			}
+		}
+	}
+	for _, name := range names {
+		if qty, ok := resources.Requests[name]; ok {
+			res[requestsResource(name)] = qty
+		} else if qty, ok := resources.Limits[name]; ok {
+			res[requestsResource(name)] = qty
+		} else if qty, ok := defaults[name]; ok {
+			res[requestsResource(name)] = qty
+		}
+	}
+	return res
+}
+
+// makeQuotaHard returns the hard of the resource quota, each of the
+// accelerator resources is limited to the GPU quota.
+func makeQuotaHard(quota types.NamespaceQuota,
+	gpuResources []corev1.ResourceName) (corev1.ResourceList, error) {
+	hard := corev1.ResourceList{}
+	if quota.CPU != "" {
+		qty, err := resource.ParseQuantity(string(quota.CPU))
+		if err != nil {
+			return nil, fmt.Errorf("quota.cpu: %v", err)
+		}
+		hard[corev1.ResourceRequestsCPU] = qty
+	}
+	if quota.Memory != "" {
+		qty, err := resource.ParseQuantity(string(quota.Memory))
+		if err != nil {
+			return nil, fmt.Errorf("quota.memory: %v", err)
+		}
+		hard[corev1.ResourceRequestsMemory] = qty
+	}
+	if quota.GPU != "" {
+		qty, err := resource.ParseQuantity(string(quota.GPU))
+		if err != nil {
+			return nil, fmt.Errorf("quota.gpu: %v", err)
+		}
+		for _, name := range gpuResources {
+			hard[requestsResource(name)] = qty
+		}
+	}
+	if quota.Inferences != nil {
+		hard[resourceInferences] = *resource.NewQuantity(
+			int64(*quota.Inferences), resource.DecimalSI)
+	}
+	return hard, nil
+}
+
+func makeLimitRange(namespace string) *corev1.LimitRange {
+	return &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespaceLimitRangeName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				consts.LabelNamespace: "true",
+			},
+		},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{{
+				Type: corev1.LimitTypeContainer,
+				DefaultRequest: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(defaultRequestCPU),
+					corev1.ResourceMemory: resource.MustParse(defaultRequestMemory),
+				},
+			}},
+		},
+	}
+}
+
+// asNamespaceQuotaStatus converts the hard and the used resources of the
+// quota. The used GPUs are the accelerator devices requested in total.
+func asNamespaceQuotaStatus(name string, quota *corev1.ResourceQuota) types.NamespaceQuotaStatus {
+	res := types.NamespaceQuotaStatus{
+		Name: name,
+		Hard: asNamespaceQuota(quota.Spec.Hard),
+		Used: asNamespaceQuota(quota.Status.Used),
+	}
+
+	if qty, ok := gpuQuota(quota); ok {
+		res.Hard.GPU = types.Quantity(qty.String())
+		used := sumGPURequests(quota.Status.Used)
+		res.Used.GPU = types.Quantity(used.String())
+	}
+	return res
+}
+
+// gpuQuota returns the max number of the accelerator devices requested in
+// total. The quotas set by the older agents limit the whole GPUs of each
+// vendor to the same number.
+func gpuQuota(quota *corev1.ResourceQuota) (resource.Quantity, bool) {
+	if value, ok := quota.Annotations[annotationGPUQuota]; ok {
+		if qty, err := resource.ParseQuantity(value); err == nil {
+			return qty, true
+		}
+	}
+	qty, ok := quota.Spec.Hard[requestsResource(consts.ResourceNvidiaGPU)]
+	return qty, ok
+}
+
+// sumGPURequests returns the number of the accelerator devices in the
+// quota resources, each whole GPU, shared GPU or MIG device counts as one.
+func sumGPURequests(list corev1.ResourceList) resource.Quantity {
+	sum := resource.Quantity{}
+	for name, qty := range list {
+		if isGPURequest(name) {
+			sum.Add(qty)
+		}
+	}
+	return sum
+}
+
+func isGPURequest(name corev1.ResourceName) bool {
+	return strings.HasPrefix(string(name), "requests.") &&
+		accelerator.IsResource(corev1.ResourceName(strings.TrimPrefix(string(name), "requests.")))
+}
+
+func asNamespaceQuota(list corev1.ResourceList) types.NamespaceQuota {
+	res := types.NamespaceQuota{}
+	if qty, ok := list[corev1.ResourceRequestsCPU]; ok {
+		res.CPU = types.Quantity(qty.String())
+	}
+	if qty, ok := list[corev1.ResourceRequestsMemory]; ok {
+		res.Memory = types.Quantity(qty.String())
+	}
+	if qty, ok := list[resourceInferences]; ok {
+		count := int32(qty.Value())
+		res.Inferences = &count
+	}
+	return res
+}
+
+func (r generalRuntime) applyResourceQuota(ctx context.Context,
+	quota *corev1.ResourceQuota) error {
+	client := r.kubeClient.CoreV1().ResourceQuotas(quota.Namespace)
+	actual, err := client.Get(ctx, quota.Name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		_, err = client.Create(ctx, quota, metav1.CreateOptions{})
+		return err
+	}
+	expected := actual.DeepCopy()
+	expected.Spec = quota.Spec
+	if expected.Annotations == nil {
+		expected.Annotations = map[string]string{}
+	}
+	if value, ok := quota.Annotations[annotationGPUQuota]; ok {
+		expected.Annotations[annotationGPUQuota] = value
+	} else {
+		delete(expected.Annotations, annotationGPUQuota)
+	}
+	_, err = client.Update(ctx, expected, metav1.UpdateOptions{})
+	return err
+}
+
+func (r generalRuntime) applyLimitRange(ctx context.Context,
+	limitRange *corev1.LimitRange) error {
+	client := r.kubeClient.CoreV1().LimitRanges(limitRange.Namespace)
+	actual, err := client.Get(ctx, limitRange.Name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		_, err = client.Create(ctx, limitRange, metav1.CreateOptions{})
+		return err
+	}
+	expected := actual.DeepCopy()
+	expected.Spec = limitRange.Spec
+	_, err = client.Update(ctx, expected, metav1.UpdateOptions{})
+	return err
+}
+
+func requestsResource(name corev1.ResourceName) corev1.ResourceName {
+	return corev1.ResourceName("requests." + string(name))
+}
+
+func scaleResourceList(list corev1.ResourceList, n int64) corev1.ResourceList {
+	res := corev1.ResourceList{}
+	for name, qty := range list {
+		res[name] = *resource.NewMilliQuantity(qty.MilliValue()*n, qty.Format)
+	}
+	return res
+}
+
+func addResourceList(list, add corev1.ResourceList) {
+	for name, qty := range add {
+		sum := list[name]
+		sum.Add(qty)
+		list[name] = sum
+	}
+}
+
+func namespaceQuotaError(err error) error {
+	switch {
+	case k8serrors.IsNotFound(err):
+		return errdefs.NotFound(err)
+	case k8serrors.IsConflict(err):
+		return errdefs.Conflict(err)
+	case k8serrors.IsInvalid(err), k8serrors.IsBadRequest(err):
+		return errdefs.InvalidParameter(err)
+	default:
+		return errdefs.System(err)
+	}
+}
@@ -0,0 +1,456 @@
+package runtime
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	modelzfake "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/fake"
+	modelzinformers "github.com/tensorchord/openmodelz/modelzetes/pkg/client/informers/externalversions"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+const (
+	requestsNvidiaGPU       corev1.ResourceName = "requests.nvidia.com/gpu"
+	requestsNvidiaGPUShared corev1.ResourceName = "requests.nvidia.com/gpu.shared"
+	requestsNvidiaMIG       corev1.ResourceName = "requests.nvidia.com/mig-1g.5gb"
+)
+
+func makeResourceList(kv map[corev1.ResourceName]string) corev1.ResourceList {
+	res := corev1.ResourceList{}
+	for name, value := range kv {
+		res[name] = resource.MustParse(value)
+	}
+	return res
+}
+
+func makeQuota(gpu string, hard, used map[corev1.ResourceName]string) *corev1.ResourceQuota {
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        namespaceQuotaName,
+			Namespace:   "team-a",
+			Annotations: map[string]string{},
+		},
+		Spec:   corev1.ResourceQuotaSpec{Hard: makeResourceList(hard)},
+		Status: corev1.ResourceQuotaStatus{Used: makeResourceList(used)},
+	}
+	if gpu != "" {
+		quota.Annotations[annotationGPUQuota] = gpu
+	}
+	return quota
+}
+
+var _ = Describe("agent/pkg/runtime/namespace_quota", func() {
+	It("function checkQuota", func() {
+		tcs := []struct {
+			desc      string
+			quota     *corev1.ResourceQuota
+			create    bool
+			required  map[corev1.ResourceName]string
+			released  map[corev1.ResourceName]string
+			forbidden bool
+		}{
+			{
+				desc:     "no limits",
+				quota:    makeQuota("", nil, nil),
+				create:   true,
+				required: map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "100"},
+			},
+			{
+				desc: "inference count reached on create",
+				quota: makeQuota("", map[corev1.ResourceName]string{resourceInferences: "2"},
+					map[corev1.ResourceName]string{resourceInferences: "2"}),
+				create:    true,
+				forbidden: true,
+			},
+			{
+				desc: "inference count reached on update",
+				quota: makeQuota("", map[corev1.ResourceName]string{resourceInferences: "2"},
+					map[corev1.ResourceName]string{resourceInferences: "2"}),
+			},
+			{
+				desc: "cpu exceeds the available",
+				quota: makeQuota("", map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "4"},
+					map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "3"}),
+				required:  map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "2"},
+				forbidden: true,
+			},
+			{
+				desc: "cpu fits with the released",
+				quota: makeQuota("", map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "4"},
+					map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "3"}),
+				required: map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "2"},
+				released: map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "1"},
+			},
+			{
+				desc: "memory exceeds the available with the released",
+				quota: makeQuota("", map[corev1.ResourceName]string{corev1.ResourceRequestsMemory: "8Gi"},
+					map[corev1.ResourceName]string{corev1.ResourceRequestsMemory: "6Gi"}),
+				required:  map[corev1.ResourceName]string{corev1.ResourceRequestsMemory: "4Gi"},
+				released:  map[corev1.ResourceName]string{corev1.ResourceRequestsMemory: "1Gi"},
+				forbidden: true,
+			},
+			{
+				desc: "gpu total fits across nvidia, mig and shared",
+				quota: makeQuota("4", map[corev1.ResourceName]string{
+					requestsNvidiaGPU: "4", requestsNvidiaGPUShared: "4", requestsNvidiaMIG: "4",
+				}, map[corev1.ResourceName]string{requestsNvidiaGPU: "2", requestsNvidiaMIG: "1"}),
+				required: map[corev1.ResourceName]string{requestsNvidiaGPUShared: "1"},
+			},
+			{
+				desc: "gpu total exceeds across nvidia, mig and shared",
+				quota: makeQuota("4", map[corev1.ResourceName]string{
+					requestsNvidiaGPU: "4", requestsNvidiaGPUShared: "4", requestsNvidiaMIG: "4",
+				}, map[corev1.ResourceName]string{requestsNvidiaGPU: "2", requestsNvidiaMIG: "1"}),
+				required:  map[corev1.ResourceName]string{requestsNvidiaGPUShared: "2"},
+				forbidden: true,
+			},
+			{
+				desc: "gpu total fits with the released of the other resource",
+				quota: makeQuota("4", map[corev1.ResourceName]string{
+					requestsNvidiaGPU: "4", requestsNvidiaMIG: "4",
+				}, map[corev1.ResourceName]string{requestsNvidiaGPU: "2", requestsNvidiaMIG: "2"}),
+				required: map[corev1.ResourceName]string{requestsNvidiaMIG: "2"},
+				released: map[corev1.ResourceName]string{requestsNvidiaGPU: "2"},
+			},
+			{
+				desc: "gpu of the older quota without the total",
+				quota: makeQuota("", map[corev1.ResourceName]string{requestsNvidiaGPU: "2"},
+					map[corev1.ResourceName]string{requestsNvidiaGPU: "1"}),
+				required:  map[corev1.ResourceName]string{requestsNvidiaMIG: "2"},
+				forbidden: true,
+			},
+		}
+		for _, tc := range tcs {
+			err := checkQuota(tc.quota, tc.create, "the inference",
+				makeResourceList(tc.required), makeResourceList(tc.released))
+			if tc.forbidden {
+				Expect(errdefs.IsForbidden(err)).To(BeTrue(), tc.desc)
+			} else {
+				Expect(err).NotTo(HaveOccurred(), tc.desc)
+			}
+		}
+	})
+
+	It("function containerRequests", func() {
+		tcs := []struct {
+			desc      string
+			resources corev1.ResourceRequirements
+			expect    map[corev1.ResourceName]string
+		}{
+			{
+				desc: "defaults",
+				expect: map[corev1.ResourceName]string{
+					corev1.ResourceRequestsCPU:    defaultRequestCPU,
+					corev1.ResourceRequestsMemory: defaultRequestMemory,
+				},
+			},
+			{
+				desc: "requests default to the limits",
+				resources: corev1.ResourceRequirements{
+					Limits: makeResourceList(map[corev1.ResourceName]string{
+						corev1.ResourceCPU: "2", "nvidia.com/gpu": "1",
+					}),
+					Requests: makeResourceList(map[corev1.ResourceName]string{
+						corev1.ResourceCPU: "1", corev1.ResourceMemory: "4Gi",
+					}),
+				},
+				expect: map[corev1.ResourceName]string{
+					corev1.ResourceRequestsCPU:    "1",
+					corev1.ResourceRequestsMemory: "4Gi",
+					requestsNvidiaGPU:             "1",
+				},
+			},
+			{
+				desc: "mig and shared gpus without the other resources",
+				resources: corev1.ResourceRequirements{
+					Requests: makeResourceList(map[corev1.ResourceName]string{
+						"nvidia.com/mig-1g.5gb": "1", "nvidia.com/gpu.shared": "1",
+						corev1.ResourceEphemeralStorage: "10Gi",
+					}),
+				},
+				expect: map[corev1.ResourceName]string{
+					corev1.ResourceRequestsCPU:    defaultRequestCPU,
+					corev1.ResourceRequestsMemory: defaultRequestMemory,
+					requestsNvidiaMIG:             "1",
+					requestsNvidiaGPUShared:       "1",
+				},
+			},
+		}
+		for _, tc := range tcs {
+			Expect(containerRequests(tc.resources)).To(
+				Equal(makeResourceList(tc.expect)), tc.desc)
+		}
+	})
+
+	It("function rollsOut", func() {
+		actual := v2alpha1.InferenceSpec{
+			Name:    "bloomz",
+			Image:   "bloomz:v1",
+			Scaling: &v2alpha1.ScalingConfig{MinReplicas: Ptr(int32(1))},
+		}
+		tcs := []struct {
+			desc   string
+			spec   v2alpha1.InferenceSpec
+			expect bool
+		}{
+			{
+				desc:   "same spec",
+				spec:   *actual.DeepCopy(),
+				expect: false,
+			},
+			{
+				desc: "scaling only",
+				spec: v2alpha1.InferenceSpec{
+					Name:    "bloomz",
+					Image:   "bloomz:v1",
+					Scaling: &v2alpha1.ScalingConfig{MinReplicas: Ptr(int32(3))},
+				},
+				expect: false,
+			},
+			{
+				desc: "image",
+				spec: v2alpha1.InferenceSpec{
+					Name:    "bloomz",
+					Image:   "bloomz:v2",
+					Scaling: &v2alpha1.ScalingConfig{MinReplicas: Ptr(int32(1))},
+				},
+				expect: true,
+			},
+		}
+		for _, tc := range tcs {
+			Expect(rollsOut(tc.spec, actual)).To(Equal(tc.expect), tc.desc)
+		}
+	})
+
+	It("function growsResourceList", func() {
+		released := makeResourceList(map[corev1.ResourceName]string{
+			corev1.ResourceRequestsCPU: "2", requestsNvidiaGPU: "1",
+		})
+		tcs := []struct {
+			desc     string
+			required map[corev1.ResourceName]string
+			expect   bool
+		}{
+			{
+				desc:     "same",
+				required: map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "2", requestsNvidiaGPU: "1"},
+				expect:   false,
+			},
+			{
+				desc:     "less",
+				required: map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "1"},
+				expect:   false,
+			},
+			{
+				desc:     "more cpu",
+				required: map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "3", requestsNvidiaGPU: "1"},
+				expect:   true,
+			},
+			{
+				desc:     "new resource",
+				required: map[corev1.ResourceName]string{requestsNvidiaMIG: "1"},
+				expect:   true,
+			},
+		}
+		for _, tc := range tcs {
+			Expect(growsResourceList(makeResourceList(tc.required), released)).
+				To(Equal(tc.expect), tc.desc)
+		}
+	})
+
+	It("function rollingUpdateSurge", func() {
+		tcs := []struct {
+			replicas int64
+			expect   int64
+		}{
+			{replicas: 0, expect: 0},
+			{replicas: 1, expect: 1},
+			{replicas: 10, expect: 1},
+			{replicas: 11, expect: 2},
+		}
+		for _, tc := range tcs {
+			Expect(rollingUpdateSurge(tc.replicas)).To(Equal(tc.expect), "replicas %d", tc.replicas)
+		}
+	})
+
+	It("function sumGPURequests", func() {
+		sum := sumGPURequests(makeResourceList(map[corev1.ResourceName]string{
+			requestsNvidiaGPU:          "2",
+			requestsNvidiaGPUShared:    "1",
+			requestsNvidiaMIG:          "3",
+			"requests.amd.com/gpu":     "1",
+			"nvidia.com/gpu":           "8",
+			corev1.ResourceRequestsCPU: "4",
+		}))
+		Expect(sum.Value()).To(Equal(int64(7)))
+	})
+
+	It("function makeQuotaHard", func() {
+		hard, err := makeQuotaHard(types.NamespaceQuota{
+			CPU:        "8",
+			GPU:        "2",
+			Inferences: Ptr(int32(5)),
+		}, []corev1.ResourceName{"nvidia.com/gpu", "nvidia.com/mig-1g.5gb"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hard).To(Equal(corev1.ResourceList{
+			corev1.ResourceRequestsCPU: resource.MustParse("8"),
+			requestsNvidiaGPU:          resource.MustParse("2"),
+			requestsNvidiaMIG:          resource.MustParse("2"),
+			resourceInferences:         *resource.NewQuantity(5, resource.DecimalSI),
+		}))
+
+		hard, err = makeQuotaHard(types.NamespaceQuota{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hard).To(BeEmpty())
+
+		_, err = makeQuotaHard(types.NamespaceQuota{Memory: "a lot"}, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("function InferenceQuotaCheck", func() {
+		gpuRequests := corev1.ResourceRequirements{
+			Requests: makeResourceList(map[corev1.ResourceName]string{"nvidia.com/gpu": "1"}),
+		}
+		existing := &v2alpha1.Inference{
+			ObjectMeta: metav1.ObjectMeta{Name: "bloomz", Namespace: "team-a"},
+			Spec: v2alpha1.InferenceSpec{
+				Name:      "bloomz",
+				Image:     "bloomz:v1",
+				Resources: &gpuRequests,
+				Scaling:   &v2alpha1.ScalingConfig{MinReplicas: Ptr(int32(2))},
+			},
+		}
+		makePod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "team-a",
+					Labels:    map[string]string{"app": "bloomz"},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "bloomz", Resources: gpuRequests},
+				}},
+				Status: corev1.PodStatus{Phase: phase},
+			}
+		}
+		// The existing replicas use up the gpus of the namespace.
+		quota := makeQuota("2", map[corev1.ResourceName]string{
+			requestsNvidiaGPU: "2", resourceInferences: "1",
+		}, map[corev1.ResourceName]string{
+			requestsNvidiaGPU: "2", resourceInferences: "1",
+		})
+
+		newRuntime := func(objs ...*corev1.ResourceQuota) generalRuntime {
+			kubeClient := kubefake.NewSimpleClientset()
+			for _, obj := range objs {
+				_, err := kubeClient.CoreV1().ResourceQuotas(obj.Namespace).
+					Create(context.TODO(), obj, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			podInformer := kubeinformers.NewSharedInformerFactory(kubeClient, 0).
+				Core().V1().Pods()
+			inferenceInformer := modelzinformers.NewSharedInformerFactory(
+				modelzfake.NewSimpleClientset(), 0).Tensorchord().V2alpha1().Inferences()
+			Expect(inferenceInformer.Informer().GetIndexer().Add(existing)).To(Succeed())
+			for _, pod := range []*corev1.Pod{
+				makePod("bloomz-1", corev1.PodRunning),
+				makePod("bloomz-2", corev1.PodRunning),
+				makePod("bloomz-0", corev1.PodSucceeded),
+			} {
+				Expect(podInformer.Informer().GetIndexer().Add(pod)).To(Succeed())
+			}
+			return generalRuntime{
+				kubeClient:        kubeClient,
+				podInformer:       podInformer,
+				inferenceInformer: inferenceInformer,
+				logger:            logrus.WithField("component", "runtime"),
+			}
+		}
+
+		tcs := []struct {
+			desc      string
+			quota     *corev1.ResourceQuota
+			req       types.InferenceDeploymentSpec
+			forbidden bool
+		}{
+			{
+				desc: "namespace without quota",
+				req: types.InferenceDeploymentSpec{
+					Name: "opt", Image: "opt:v1",
+					Resources: &types.ResourceRequirements{
+						Requests: types.ResourceList{types.ResourceGPU: "8"},
+					},
+				},
+			},
+			{
+				desc:      "create exceeds the inference count",
+				quota:     quota,
+				req:       types.InferenceDeploymentSpec{Name: "opt", Image: "opt:v1"},
+				forbidden: true,
+			},
+			{
+				desc:  "scaling only at the limit",
+				quota: quota,
+				req: types.InferenceDeploymentSpec{
+					Name: "bloomz",
+					Scaling: &types.ScalingConfig{
+						MinReplicas: Ptr(int32(2)), MaxReplicas: Ptr(int32(5)),
+					},
+				},
+			},
+			{
+				desc:  "scaling up exceeds the gpus",
+				quota: quota,
+				req: types.InferenceDeploymentSpec{
+					Name:    "bloomz",
+					Scaling: &types.ScalingConfig{MinReplicas: Ptr(int32(3))},
+				},
+				forbidden: true,
+			},
+			{
+				desc:  "rollout with the same resources",
+				quota: quota,
+				req:   types.InferenceDeploymentSpec{Name: "bloomz", Image: "bloomz:v2"},
+			},
+			{
+				desc:  "rollout with more resources exceeds the surge",
+				quota: quota,
+				req: types.InferenceDeploymentSpec{
+					Name:  "bloomz",
+					Image: "bloomz:v2",
+					Resources: &types.ResourceRequirements{
+						Requests: types.ResourceList{
+							types.ResourceGPU: "1", types.ResourceCPU: "2",
+						},
+					},
+				},
+				forbidden: true,
+			},
+		}
+		for _, tc := range tcs {
+			objs := []*corev1.ResourceQuota{}
+			if tc.quota != nil {
+				objs = append(objs, tc.quota)
+			}
+			r := newRuntime(objs...)
+			err := r.InferenceQuotaCheck(context.TODO(), "team-a",
+				types.InferenceDeployment{Spec: tc.req})
+			if tc.forbidden {
+				Expect(errdefs.IsForbidden(err)).To(BeTrue(), "%s: %v", tc.desc, err)
+			} else {
+				Expect(err).NotTo(HaveOccurred(), tc.desc)
+			}
+		}
+	})
+})
@@ -26,6 +26,11 @@ import (
 )
 
 type Runtime interface {
+	// batch inference
+	BatchInferenceCreate(ctx context.Context, req types.BatchInference) (types.BatchInference, error)
+	BatchInferenceList(ctx context.Context, namespace string) ([]types.BatchInference, error)
+	BatchInferenceGet(ctx context.Context, namespace, name string) (types.BatchInference, error)
+	BatchInferenceCancel(ctx context.Context, namespace, name string) (types.BatchInference, error)
 	// build
 	BuildList(ctx context.Context, namespace string) ([]types.Build, error)
 	BuildCreate(ctx context.Context, req types.Build, inference *v2alpha1.Inference, builderImage,
@@ -36,12 +41,18 @@ type Runtime interface {
 	// inference
 	InferenceCreate(ctx context.Context,
 		req types.InferenceDeployment, cfg config.IngressConfig, event string, serverPort int) error
+	InferenceCrashLoopUpdate(ctx context.Context, namespace, name string, state *types.CrashLoopState) error
 	InferenceDelete(ctx context.Context, namespace, inferenceName, ingressNamespace, event string) error
+	InferenceEventCreate(ctx context.Context, namespace, name string, event types.InferenceEvent) error
 	InferenceExec(ctx *gin.Context, namespace, instance string, commands []string, tty bool) error
 	InferenceGet(namespace, inferenceName string) (*types.InferenceDeployment, error)
 	InferenceGetCRD(namespace, name string) (*apis.Inference, error)
 	InferenceInstanceList(namespace, inferenceName string) ([]types.InferenceDeploymentInstance, error)
 	InferenceList(namespace string) ([]types.InferenceDeployment, error)
+	InferenceQuotaCheck(ctx context.Context, namespace string, req types.InferenceDeployment) error
+	InferencePendingReasonUpdate(ctx context.Context, namespace, name, reason string) error
+	InferenceRevisionList(ctx context.Context, namespace, name string) ([]types.InferenceRevision, error)
+	InferenceRollback(ctx context.Context, namespace, name string, revision int64) (*types.InferenceRevision, error)
 	InferenceScale(ctx context.Context, namespace string, req types.ScaleServiceRequest, inf *types.InferenceDeployment) error
 	InferenceUpdate(ctx context.Context, namespace string, req types.InferenceDeployment, event string) (err error)
 	// namespace
@@ -49,6 +60,13 @@ type Runtime interface {
 	NamespaceCreate(ctx context.Context, name string) error
 	NamespaceGet(ctx context.Context, name string) bool
 	NamespaceDelete(ctx context.Context, name string) error
+	NamespaceQuotaGet(ctx context.Context, name string) (types.NamespaceQuotaStatus, error)
+	NamespaceQuotaUpdate(ctx context.Context, name string, quota types.NamespaceQuota) error
+	// secret
+	SecretList(ctx context.Context, namespace string) ([]types.Secret, error)
+	SecretCreate(ctx context.Context, secret types.Secret) error
+	SecretUpdate(ctx context.Context, secret types.Secret) error
+	SecretDelete(ctx context.Context, namespace, name string) error
 	// server
 	ServerDeleteNode(ctx context.Context, name string) error
 	ServerLabelCreate(ctx context.Context, name string, spec types.ServerSpec) error
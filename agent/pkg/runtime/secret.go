@@ -0,0 +1,68 @@
+package runtime
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	modelzk8s "github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
+)
+
+// SecretList returns the secrets managed by modelz in the namespace. Only
+// the names are returned, the values are never read.
+func (r generalRuntime) SecretList(ctx context.Context,
+	namespace string) ([]types.Secret, error) {
+	names, err := modelzk8s.NewSecretsClient(r.kubeClient).List(namespace)
+	if err != nil {
+		return nil, secretError(err)
+	}
+
+	res := make([]types.Secret, len(names))
+	for i, name := range names {
+		res[i] = types.Secret{
+			Name:      name,
+			Namespace: namespace,
+		}
+	}
+	return res, nil
+}
+
+// SecretCreate creates the secret, which can be mounted to the inferences
+// in the same namespace.
+func (r generalRuntime) SecretCreate(ctx context.Context, secret types.Secret) error {
+	if err := modelzk8s.NewSecretsClient(r.kubeClient).Create(secret); err != nil {
+		return secretError(err)
+	}
+	return nil
+}
+
+// SecretUpdate replaces the values of the secret.
+func (r generalRuntime) SecretUpdate(ctx context.Context, secret types.Secret) error {
+	if err := modelzk8s.NewSecretsClient(r.kubeClient).Replace(secret); err != nil {
+		return secretError(err)
+	}
+	return nil
+}
+
+// SecretDelete deletes the secret.
+func (r generalRuntime) SecretDelete(ctx context.Context, namespace, name string) error {
+	if err := modelzk8s.NewSecretsClient(r.kubeClient).Delete(namespace, name); err != nil {
+		return secretError(err)
+	}
+	return nil
+}
+
+func secretError(err error) error {
+	switch {
+	case k8serrors.IsNotFound(err):
+		return errdefs.NotFound(err)
+	case k8serrors.IsAlreadyExists(err):
+		return errdefs.Conflict(err)
+	case k8serrors.IsInvalid(err), k8serrors.IsBadRequest(err):
+		return errdefs.InvalidParameter(err)
+	default:
+		return errdefs.System(err)
+	}
+}
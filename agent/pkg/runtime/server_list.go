@@ -7,6 +7,7 @@ import (
 	"github.com/tensorchord/openmodelz/agent/api/types"
 	"github.com/tensorchord/openmodelz/agent/errdefs"
 	"github.com/tensorchord/openmodelz/agent/pkg/k8s"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/accelerator"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -27,13 +28,55 @@ func (r generalRuntime) ServerList(ctx context.Context) ([]types.Server, error)
 		return nil, nil
 	}
 
-	return getServers(nodes.Items), nil
+	pods, err := r.kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx,
+		metav1.ListOptions{
+			FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+		})
+	if err != nil {
+		return nil, errdefs.System(err)
+	}
+
+	return getServers(nodes.Items, pods.Items), nil
 }
 
-func getServers(nodes []v1.Node) []types.Server {
+func getServers(nodes []v1.Node, pods []v1.Pod) []types.Server {
+	allocated := allocatedResources(pods)
 	res := []types.Server{}
 	for _, n := range nodes {
-		res = append(res, getServer(n))
+		server := getServer(n)
+		server.Status.Allocated = k8s.AsResourceList(allocated[n.Name])
+		res = append(res, server)
+	}
+	return res
+}
+
+// allocatedResources sums the resource requests of the pods by node.
+func allocatedResources(pods []v1.Pod) map[string]v1.ResourceList {
+	res := make(map[string]v1.ResourceList)
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		if _, ok := res[pod.Spec.NodeName]; !ok {
+			res[pod.Spec.NodeName] = v1.ResourceList{}
+		}
+		allocated := res[pod.Spec.NodeName]
+		for _, c := range pod.Spec.Containers {
+			for name, qty := range c.Resources.Limits {
+				// The requests default to the limits if they are not specified.
+				if _, ok := c.Resources.Requests[name]; ok {
+					continue
+				}
+				sum := allocated[name]
+				sum.Add(qty)
+				allocated[name] = sum
+			}
+			for name, qty := range c.Resources.Requests {
+				sum := allocated[name]
+				sum.Add(qty)
+				allocated[name] = sum
+			}
+		}
 	}
 	return res
 }
@@ -41,8 +84,9 @@ func getServers(nodes []v1.Node) []types.Server {
 func getServer(n v1.Node) types.Server {
 	node := types.Server{
 		Spec: types.ServerSpec{
-			Name:   n.Name,
-			Labels: make(map[string]string),
+			Name:       n.Name,
+			Labels:     make(map[string]string),
+			NodeLabels: n.Labels,
 		},
 		Status: types.ServerStatus{
 			Allocatable: k8s.AsResourceList(n.Status.Allocatable),
@@ -57,6 +101,21 @@ func getServer(n v1.Node) types.Server {
 		},
 	}
 
+	for _, t := range n.Spec.Taints {
+		node.Spec.Taints = append(node.Spec.Taints, types.Taint{
+			Key:    t.Key,
+			Value:  t.Value,
+			Effect: string(t.Effect),
+		})
+	}
+
+	if a, model, ok := accelerator.Detect(n.Labels, n.Status.Capacity); ok {
+		node.Status.Accelerator = &types.ServerAccelerator{
+			Vendor: string(a.Vendor),
+			Model:  model,
+		}
+	}
+
 	for k, v := range n.Labels {
 		if strings.HasPrefix(k, "ai.tensorchord.") {
 			node.Spec.Labels[strings.TrimPrefix(k, "ai.tensorchord.")] = v
@@ -0,0 +1,49 @@
+package runtime
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+)
+
+// updateInferenceAnnotation sets the annotation of the inference object, the
+// annotation is removed if the value is nil. The annotations of the inference
+// object (not the spec) are used, thus the pods are not restarted.
+func (r generalRuntime) updateInferenceAnnotation(ctx context.Context,
+	namespace, name, key string, value *string) error {
+	actual, err := r.inferenceClient.TensorchordV2alpha1().
+		Inferences(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return errdefs.NotFound(err)
+		}
+		return errdefs.System(err)
+	}
+
+	current, ok := actual.Annotations[key]
+	if value == nil && !ok {
+		return nil
+	}
+	if value != nil && ok && current == *value {
+		return nil
+	}
+
+	expected := actual.DeepCopy()
+	if value == nil {
+		delete(expected.Annotations, key)
+	} else {
+		if expected.Annotations == nil {
+			expected.Annotations = make(map[string]string)
+		}
+		expected.Annotations[key] = *value
+	}
+
+	if _, err := r.inferenceClient.TensorchordV2alpha1().
+		Inferences(namespace).Update(ctx, expected, metav1.UpdateOptions{}); err != nil {
+		return errdefs.System(err)
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/tensorchord/openmodelz/agent/api/types"
 	"github.com/tensorchord/openmodelz/agent/pkg/runtime"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
 )
 
 const (
@@ -175,9 +176,10 @@ func (s *InferenceScaler) Scale(ctx context.Context,
 		}
 	}
 
-	switch resp.Framework {
-	// Return early for prototype frameworks.
-	case "gradio", "streamlit":
+	// Return early for the frameworks which render the loading page, e.g.
+	// the prototype web UIs.
+	if f, ok := framework.Get(resp.Framework); ok &&
+		f.ColdStart == framework.ColdStartLoadingPage {
 		return FunctionScaleResult{
 			Error:     nil,
 			Available: false,
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	_ "github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     Cancel the batch inference.
+// @Description Cancel the batch inference, the written responses are kept.
+// @Tags        batch-inference
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string true "Namespace"
+// @Param       name      path     string true "Batch inference name"
+// @Success     200       {object} types.BatchInference
+// @Router      /system/batch-inferences/{name}/cancel [post]
+func (s *Server) handleBatchInferenceCancel(c *gin.Context) error {
+	event := "batch-inference-cancel"
+	namespace, name, err := batchInferenceParams(c, event)
+	if err != nil {
+		return err
+	}
+
+	bi, err := s.runtime.BatchInferenceCancel(c.Request.Context(), namespace, name)
+	if err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusOK, bi)
+	return nil
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     Submit the batch inference.
+// @Description Submit the batch inference, the items are processed by a job.
+// @Tags        batch-inference
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string               true "Namespace"
+// @Param       body      body     types.BatchInference true "Batch inference"
+// @Success     201       {object} types.BatchInference
+// @Router      /system/batch-inferences [post]
+func (s *Server) handleBatchInferenceCreate(c *gin.Context) error {
+	event := "batch-inference-create"
+	var req types.BatchInference
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return NewError(http.StatusBadRequest, err, event)
+	}
+
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("namespace is required"), event)
+	}
+	req.Spec.Namespace = namespace
+
+	if err := s.validator.ValidateBatchInferenceRequest(&req); err != nil {
+		return NewError(http.StatusBadRequest, err, event)
+	}
+
+	bi, err := s.runtime.BatchInferenceCreate(c.Request.Context(), req)
+	if err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusCreated, bi)
+	return nil
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	_ "github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     Get the batch inference by name.
+// @Description Get the batch inference with the progress.
+// @Tags        batch-inference
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string true "Namespace"
+// @Param       name      path     string true "Batch inference name"
+// @Success     200       {object} types.BatchInference
+// @Router      /system/batch-inferences/{name} [get]
+func (s *Server) handleBatchInferenceGet(c *gin.Context) error {
+	event := "batch-inference-get"
+	namespace, name, err := batchInferenceParams(c, event)
+	if err != nil {
+		return err
+	}
+
+	bi, err := s.runtime.BatchInferenceGet(c.Request.Context(), namespace, name)
+	if err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusOK, bi)
+	return nil
+}
+
+// batchInferenceParams returns the namespace in the query and the name in
+// the path.
+func batchInferenceParams(c *gin.Context, event string) (string, string, error) {
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return "", "", NewError(
+			http.StatusBadRequest, errors.New("namespace is required"), event)
+	}
+	name := c.Param("name")
+	if name == "" {
+		return "", "", NewError(
+			http.StatusBadRequest, errors.New("name is required"), event)
+	}
+	return namespace, name, nil
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	_ "github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     List the batch inferences.
+// @Description List the batch inferences in the namespace.
+// @Tags        batch-inference
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string true "Namespace"
+// @Success     200       {object} []types.BatchInference
+// @Router      /system/batch-inferences [get]
+func (s *Server) handleBatchInferenceList(c *gin.Context) error {
+	event := "batch-inference-list"
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("namespace is required"), event)
+	}
+
+	list, err := s.runtime.BatchInferenceList(c.Request.Context(), namespace)
+	if err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusOK, list)
+	return nil
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	"github.com/tensorchord/openmodelz/agent/pkg/server/validator"
+)
+
+var _ = Describe("batch inference", func() {
+	BeforeEach(func() {
+		server = &Server{
+			router:        gin.New(),
+			metricsRouter: gin.New(),
+			runtime:       mockRuntime,
+			validator:     validator.New(),
+		}
+	})
+	request := func() types.BatchInference {
+		return types.BatchInference{
+			Spec: types.BatchInferenceSpec{
+				Name:      "score",
+				Inference: "llm",
+				Input: types.BatchInput{
+					BatchLocation: types.BatchLocation{URI: "s3://data/input"},
+					JSONL:         "prompts.jsonl",
+				},
+				Output: types.BatchLocation{URI: "s3://data/output"},
+			},
+		}
+	}
+	It("create - no namespace", func() {
+		c := mkJsonBodyContext("POST", "/", nil, request())
+		err := server.handleBatchInferenceCreate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("create - no input", func() {
+		req := request()
+		req.Spec.Input.JSONL = ""
+		c := mkJsonBodyContext("POST", "/", nil, req)
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleBatchInferenceCreate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("create - no image or inference", func() {
+		req := request()
+		req.Spec.Inference = ""
+		c := mkJsonBodyContext("POST", "/", nil, req)
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleBatchInferenceCreate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("create - good request", func() {
+		expected := request()
+		expected.Spec.Namespace = "mock-namespace"
+		mockRuntime.EXPECT().BatchInferenceCreate(gomock.Any(), expected).
+			Times(1).Return(expected, nil)
+		c := mkJsonBodyContext("POST", "/", nil, request())
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleBatchInferenceCreate(c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("list - good request", func() {
+		mockRuntime.EXPECT().BatchInferenceList(gomock.Any(), "mock-namespace").
+			Times(1).Return([]types.BatchInference{request()}, nil)
+		c := mkContext("GET", "/", nil, nil)
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleBatchInferenceList(c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("get - not found", func() {
+		mockRuntime.EXPECT().BatchInferenceGet(gomock.Any(), "mock-namespace", "score").
+			Times(1).Return(types.BatchInference{}, errdefs.NotFound(errors.New("mock-error")))
+		c := mkContext("GET", "/", nil, nil)
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		setParam(c, map[string]string{"name": "score"})
+		err := server.handleBatchInferenceGet(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("cancel - no name", func() {
+		c := mkContext("POST", "/", nil, nil)
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleBatchInferenceCancel(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("cancel - good request", func() {
+		mockRuntime.EXPECT().BatchInferenceCancel(gomock.Any(), "mock-namespace", "score").
+			Times(1).Return(request(), nil)
+		c := mkContext("POST", "/", nil, nil)
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		setParam(c, map[string]string{"name": "score"})
+		err := server.handleBatchInferenceCancel(c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
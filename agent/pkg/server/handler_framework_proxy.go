@@ -0,0 +1,131 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/tensorchord/openmodelz/agent/pkg/consts"
+	"github.com/tensorchord/openmodelz/agent/pkg/server/static"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
+)
+
+// @Summary     Reverse proxy to the inference of the framework.
+// @Description Reverse proxy to the inference of the framework. The web UIs, e.g. gradio, are proxied without the API key. The framework must be the one of the inference.
+// @Tags        inference
+// @Accept      */*
+// @Produce     json
+// @Param       framework path string true "Framework"
+// @Param       id        path string true "Deployment ID"
+// @Router      /{framework}/{id} [get]
+// @Router      /{framework}/{id} [post]
+// @Success     201
+// @Failure     404
+func (s *Server) proxyFramework(c *gin.Context) error {
+	f, ok := framework.Get(c.Param("framework"))
+	if !ok {
+		return NewError(http.StatusNotFound,
+			fmt.Errorf("framework %s is not found", c.Param("framework")), "framework-proxy")
+	}
+
+	remote, err := url.Parse(fmt.Sprintf("http://0.0.0.0:%d", s.config.Server.ServerPort))
+	if err != nil {
+		return err
+	}
+	proxy := httputil.NewSingleHostReverseProxy(remote)
+	proxy.Transport = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   s.config.ModelZCloud.UpstreamTimeout,
+			KeepAlive: s.config.ModelZCloud.UpstreamTimeout,
+			DualStack: true,
+		}).DialContext,
+		MaxIdleConns:          s.config.ModelZCloud.MaxIdleConnections,
+		MaxIdleConnsPerHost:   s.config.ModelZCloud.MaxIdleConnectionsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if f.Streaming {
+		// Flush the streamed tokens to the client immediately.
+		proxy.FlushInterval = -1
+	}
+
+	var uid, deployment string
+	if f.NoAuth {
+		uid, deployment, err = s.proxyNoAuth(c)
+	} else {
+		uid, deployment, err = s.proxyAuth(c)
+	}
+	if err != nil {
+		return err
+	}
+
+	ns := consts.DefaultPrefix + uid
+	// The framework decides whether the API key is required, thus the
+	// inference must not be proxied by the route of another framework.
+	inference, err := s.runtime.InferenceGetCRD(ns, deployment)
+	if err != nil {
+		return errFromErrDefs(err, "framework-proxy")
+	}
+	if name := string(inference.Spec.Framework); name != f.Name &&
+		!(name == "" && f.Name == framework.Other) {
+		return NewError(http.StatusNotFound,
+			fmt.Errorf("inference %s is not found in framework %s", deployment, f.Name),
+			"framework-proxy")
+	}
+	proxy.Director = func(req *http.Request) {
+		req.Header = c.Request.Header
+		req.Host = remote.Host
+		req.URL.Scheme = remote.Scheme
+		req.URL.Host = remote.Host
+		req.URL.Path = path.Join(
+			"/", "inference", fmt.Sprintf("%s.%s", deployment, ns), c.Param("proxyPath"))
+
+		logrus.WithFields(logrus.Fields{
+			"framework":  f.Name,
+			"deployment": deployment,
+			"uid":        uid,
+			"ns":         ns,
+			"path":       req.URL.Path,
+			"remote":     remote.String(),
+		}).Debug("proxying to the framework")
+	}
+
+	if f.ColdStart == framework.ColdStartLoadingPage {
+		proxy.ModifyResponse = func(resp *http.Response) error {
+			// http.StatusSeeOther indicates that the server is still loading.
+			if resp.StatusCode == http.StatusSeeOther {
+				resp.StatusCode = http.StatusOK
+
+				instances, err := s.runtime.InferenceInstanceList(ns, deployment)
+				if err != nil {
+					return NewError(http.StatusInternalServerError, err, "instance-list")
+				}
+
+				buf, err := static.RenderDeploymentLoadingPage(f.Name, resp.Header.Get("X-Call-Id"),
+					"We are currently processing your request.", deployment, instances)
+				if err != nil {
+					return NewError(http.StatusInternalServerError, err, "render-loading-page")
+				}
+				resp.Body = io.NopCloser(buf)
+				resp.ContentLength = int64(buf.Len())
+				resp.Header.Set("Content-Length", strconv.Itoa(buf.Len()))
+				resp.Header.Set("Content-Type", "text/html")
+				resp.StatusCode = http.StatusServiceUnavailable
+			}
+			return nil
+		}
+	}
+
+	proxy.ServeHTTP(c.Writer, c.Request)
+	return nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     Update the crash loop state of the inference.
+// @Description Update the crash loop state of the inference, it is used by the autoscaler.
+// @Tags        inference
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string               true "Namespace"
+// @Param       name      path     string               true "Name"
+// @Param       request   body     types.CrashLoopState true "state"
+// @Success     200       {object} types.CrashLoopState
+// @Failure     400
+// @Router      /system/inference/{name}/crash-loop [put]
+func (s *Server) handleInferenceCrashLoopUpdate(c *gin.Context) error {
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return NewError(http.StatusBadRequest,
+			errors.New("namespace is required"), "inference-crash-loop-update")
+	}
+	name := c.Param("name")
+
+	var req types.CrashLoopState
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return NewError(http.StatusBadRequest, err, "inference-crash-loop-update")
+	}
+
+	if err := s.runtime.InferenceCrashLoopUpdate(c.Request.Context(),
+		namespace, name, &req); err != nil {
+		return errFromErrDefs(err, "inference-crash-loop-update")
+	}
+
+	c.JSON(http.StatusOK, req)
+	return nil
+}
+
+// @Summary     Clear the crash loop state of the inference.
+// @Description Clear the crash loop state of the inference, it is used by the autoscaler.
+// @Tags        inference
+// @Accept      json
+// @Produce     json
+// @Param       namespace query string true "Namespace"
+// @Param       name      path  string true "Name"
+// @Success     200
+// @Failure     400
+// @Router      /system/inference/{name}/crash-loop [delete]
+func (s *Server) handleInferenceCrashLoopDelete(c *gin.Context) error {
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return NewError(http.StatusBadRequest,
+			errors.New("namespace is required"), "inference-crash-loop-delete")
+	}
+	name := c.Param("name")
+
+	if err := s.runtime.InferenceCrashLoopUpdate(c.Request.Context(),
+		namespace, name, nil); err != nil {
+		return errFromErrDefs(err, "inference-crash-loop-delete")
+	}
+
+	c.Status(http.StatusOK)
+	return nil
+}
@@ -52,6 +52,12 @@ func (s *Server) handleInferenceCreate(c *gin.Context) error {
 		return NewError(http.StatusBadRequest, err, event)
 	}
 
+	// Reject the inference up front if it does not fit in the quota.
+	if err := s.runtime.InferenceQuotaCheck(c.Request.Context(),
+		req.Spec.Namespace, req); err != nil {
+		return errFromErrDefs(err, event)
+	}
+
 	// Create the inference.
 	if err := s.runtime.InferenceCreate(c.Request.Context(), req,
 		s.config.Ingress, event, s.config.Server.ServerPort); err != nil {
@@ -1,11 +1,14 @@
 package server
 
 import (
+	"errors"
+
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
 	"github.com/tensorchord/openmodelz/agent/pkg/server/validator"
 	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
 )
@@ -30,6 +33,7 @@ var _ = Describe("inference create", func() {
 		Expect(err).To(HaveOccurred())
 	})
 	It("good request", func() {
+		mockRuntime.EXPECT().InferenceQuotaCheck(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 		mockRuntime.EXPECT().InferenceCreate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 		c := mkJsonBodyContext("GET", "/", nil, types.InferenceDeployment{
 			Spec: types.InferenceDeploymentSpec{
@@ -41,4 +45,83 @@ var _ = Describe("inference create", func() {
 		err := server.handleInferenceCreate(c)
 		Expect(err).NotTo(HaveOccurred())
 	})
+	It("exceeds quota", func() {
+		mockRuntime.EXPECT().InferenceQuotaCheck(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+			Return(errdefs.Forbidden(errors.New("mock-error")))
+		c := mkJsonBodyContext("GET", "/", nil, types.InferenceDeployment{
+			Spec: types.InferenceDeploymentSpec{
+				Name:  "abc",
+				Image: "mock-image",
+				Port:  Ptr(int32(123)),
+			},
+		})
+		err := server.handleInferenceCreate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("invalid request - custom metric", func() {
+		c := mkJsonBodyContext("GET", "/", nil, types.InferenceDeployment{
+			Spec: types.InferenceDeploymentSpec{
+				Name:  "abc",
+				Image: "mock-image",
+				Port:  Ptr(int32(123)),
+				Scaling: &types.ScalingConfig{
+					Type: Ptr(types.ScalingTypeCustom),
+					CustomMetric: &types.CustomMetric{
+						Query:       "queue_depth{inference=\"{{.Name\"}",
+						TargetValue: "1",
+					},
+				},
+			},
+		})
+		err := server.handleInferenceCreate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("invalid request - constraint", func() {
+		c := mkJsonBodyContext("GET", "/", nil, types.InferenceDeployment{
+			Spec: types.InferenceDeploymentSpec{
+				Name:        "abc",
+				Image:       "mock-image",
+				Port:        Ptr(int32(123)),
+				Constraints: []string{"tensorchord.ai/gpu"},
+			},
+		})
+		err := server.handleInferenceCreate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("invalid request - model", func() {
+		c := mkJsonBodyContext("GET", "/", nil, types.InferenceDeployment{
+			Spec: types.InferenceDeploymentSpec{
+				Name:  "abc",
+				Image: "mock-image",
+				Port:  Ptr(int32(123)),
+				Model: &types.ModelSource{
+					Type: types.ModelSourceS3,
+					URI:  "models/bert",
+				},
+			},
+		})
+		err := server.handleInferenceCreate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("invalid request - scheduling", func() {
+		c := mkJsonBodyContext("GET", "/", nil, types.InferenceDeployment{
+			Spec: types.InferenceDeploymentSpec{
+				Name:  "abc",
+				Image: "mock-image",
+				Port:  Ptr(int32(123)),
+				Scheduling: &types.SchedulingConfig{
+					NodeAffinity: &types.NodeAffinity{
+						Required: []types.NodeSelectorTerm{{
+							MatchExpressions: []types.NodeSelectorRequirement{{
+								Key:      "tensorchord.ai/gpu",
+								Operator: types.NodeSelectorOpIn,
+							}},
+						}},
+					},
+				},
+			},
+		})
+		err := server.handleInferenceCreate(c)
+		Expect(err).To(HaveOccurred())
+	})
 })
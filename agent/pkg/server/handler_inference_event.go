@@ -0,0 +1,56 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     Create an event of the inference.
+// @Description Create a kubernetes event of the inference.
+// @Tags        inference
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string               true "Namespace"
+// @Param       name      path     string               true "Name"
+// @Param       request   body     types.InferenceEvent true "event"
+// @Success     201       {object} types.InferenceEvent
+// @Failure     400
+// @Router      /system/inference/{name}/events [post]
+func (s *Server) handleInferenceEventCreate(c *gin.Context) error {
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return NewError(http.StatusBadRequest,
+			errors.New("namespace is required"), "inference-event-create")
+	}
+	name := c.Param("name")
+	if name == "" {
+		return NewError(http.StatusBadRequest,
+			errors.New("name is required"), "inference-event-create")
+	}
+
+	var req types.InferenceEvent
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return NewError(http.StatusBadRequest, err, "inference-event-create")
+	}
+	if req.Type != types.InferenceEventTypeNormal &&
+		req.Type != types.InferenceEventTypeWarning {
+		return NewError(http.StatusBadRequest,
+			errors.New("type must be Normal or Warning"), "inference-event-create")
+	}
+	if req.Reason == "" {
+		return NewError(http.StatusBadRequest,
+			errors.New("reason is required"), "inference-event-create")
+	}
+
+	if err := s.runtime.InferenceEventCreate(c.Request.Context(),
+		namespace, name, req); err != nil {
+		return errFromErrDefs(err, "inference-event-create")
+	}
+
+	c.JSON(http.StatusCreated, req)
+	return nil
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     Update the pending reason of the inference.
+// @Description Update the pending reason of the inference, it is used by the autoscaler.
+// @Tags        inference
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string                     true "Namespace"
+// @Param       name      path     string                     true "Name"
+// @Param       request   body     types.PendingReasonRequest true "reason"
+// @Success     200       {object} types.PendingReasonRequest
+// @Failure     400
+// @Router      /system/inference/{name}/pending-reason [put]
+func (s *Server) handleInferencePendingReasonUpdate(c *gin.Context) error {
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return NewError(http.StatusBadRequest,
+			errors.New("namespace is required"), "inference-pending-reason-update")
+	}
+	name := c.Param("name")
+
+	var req types.PendingReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return NewError(http.StatusBadRequest, err, "inference-pending-reason-update")
+	}
+
+	if err := s.runtime.InferencePendingReasonUpdate(c.Request.Context(),
+		namespace, name, req.Reason); err != nil {
+		return errFromErrDefs(err, "inference-pending-reason-update")
+	}
+
+	c.JSON(http.StatusOK, req)
+	return nil
+}
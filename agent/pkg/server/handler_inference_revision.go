@@ -0,0 +1,85 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	_ "github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     List the revisions of the inference.
+// @Description List the revisions of the inference.
+// @Tags        inference
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string true "Namespace"
+// @Param       name      path     string true "inference id"
+// @Success     200       {object} []types.InferenceRevision
+// @Router      /system/inference/{name}/revisions [get]
+func (s *Server) handleInferenceRevisionList(c *gin.Context) error {
+	event := "inference-revision-list"
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("namespace is required"), event)
+	}
+	name := c.Param("name")
+	if name == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("name is required"), event)
+	}
+
+	revisions, err := s.runtime.InferenceRevisionList(
+		c.Request.Context(), namespace, name)
+	if err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusOK, revisions)
+	return nil
+}
+
+// @Summary     Roll back the inference to the revision.
+// @Description Roll back the inference to the revision, the previous revision is used if it is not set.
+// @Tags        inference
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string true  "Namespace"
+// @Param       name      path     string true  "inference id"
+// @Param       revision  query    int    false "Revision"
+// @Success     202       {object} types.InferenceRevision
+// @Router      /system/inference/{name}/rollback [post]
+func (s *Server) handleInferenceRollback(c *gin.Context) error {
+	event := "inference-rollback"
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("namespace is required"), event)
+	}
+	name := c.Param("name")
+	if name == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("name is required"), event)
+	}
+	var revision int64
+	if value := c.Query("revision"); value != "" {
+		var err error
+		revision, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || revision < 0 {
+			return NewError(http.StatusBadRequest,
+				errors.New("revision must be a non-negative integer"), event)
+		}
+	}
+
+	res, err := s.runtime.InferenceRollback(
+		c.Request.Context(), namespace, name, revision)
+	if err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusAccepted, res)
+	return nil
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	"github.com/tensorchord/openmodelz/agent/pkg/server/validator"
+)
+
+var _ = Describe("inference revision", func() {
+	BeforeEach(func() {
+		server = &Server{
+			router:        gin.New(),
+			metricsRouter: gin.New(),
+			runtime:       mockRuntime,
+			validator:     validator.New(),
+		}
+	})
+	It("list - no namespace", func() {
+		c := mkContext("GET", "/", nil, nil)
+		err := server.handleInferenceRevisionList(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("list - good request", func() {
+		mockRuntime.EXPECT().InferenceRevisionList(gomock.Any(), "mock-namespace", "mock-name").
+			Times(1).Return([]types.InferenceRevision{{Revision: 1}}, nil)
+		c := mkJsonBodyContext("GET", "/", nil, nil)
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		setParam(c, map[string]string{"name": "mock-name"})
+		err := server.handleInferenceRevisionList(c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("rollback - invalid revision", func() {
+		c := mkJsonBodyContext("POST", "/", nil, nil)
+		setQuery(c, map[string]string{"namespace": "mock-namespace", "revision": "-1"})
+		setParam(c, map[string]string{"name": "mock-name"})
+		err := server.handleInferenceRollback(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("rollback - revision not found", func() {
+		mockRuntime.EXPECT().InferenceRollback(gomock.Any(), "mock-namespace", "mock-name", int64(3)).
+			Times(1).Return(nil, errdefs.NotFound(errors.New("mock-error")))
+		c := mkJsonBodyContext("POST", "/", nil, nil)
+		setQuery(c, map[string]string{"namespace": "mock-namespace", "revision": "3"})
+		setParam(c, map[string]string{"name": "mock-name"})
+		err := server.handleInferenceRollback(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("rollback - previous revision", func() {
+		mockRuntime.EXPECT().InferenceRollback(gomock.Any(), "mock-namespace", "mock-name", int64(0)).
+			Times(1).Return(&types.InferenceRevision{Revision: 1}, nil)
+		c := mkJsonBodyContext("POST", "/", nil, nil)
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		setParam(c, map[string]string{"name": "mock-name"})
+		err := server.handleInferenceRollback(c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -36,6 +36,11 @@ func (s *Server) handleInferenceUpdate(c *gin.Context) error {
 		return NewError(http.StatusBadRequest, err, event)
 	}
 
+	if err := s.runtime.InferenceQuotaCheck(c.Request.Context(),
+		namespace, req); err != nil {
+		return errFromErrDefs(err, event)
+	}
+
 	if err := s.runtime.InferenceUpdate(c.Request.Context(),
 		namespace, req, event); err != nil {
 		return errFromErrDefs(err, event)
@@ -8,7 +8,7 @@ import (
 )
 
 // @Summary     Create the namespace.
-// @Description Create the namespace.
+// @Description Create the namespace, the resources are limited if the quota is set.
 // @Tags        namespace
 // @Accept      json
 // @Produce     json
@@ -16,13 +16,25 @@ import (
 // @Success     200  {object} types.NamespaceRequest
 // @Router      /system/namespaces [post]
 func (s *Server) handleNamespaceCreate(c *gin.Context) error {
+	event := "namespace-create"
 	var req types.NamespaceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		return NewError(http.StatusBadRequest, err, "namespace-create")
+		return NewError(http.StatusBadRequest, err, event)
+	}
+
+	if err := s.validator.ValidateNamespaceRequest(&req); err != nil {
+		return NewError(http.StatusBadRequest, err, event)
 	}
 
 	if err := s.runtime.NamespaceCreate(c.Request.Context(), req.Name); err != nil {
-		return errFromErrDefs(err, "namespace-create")
+		return errFromErrDefs(err, event)
+	}
+
+	if req.Quota != nil {
+		if err := s.runtime.NamespaceQuotaUpdate(
+			c.Request.Context(), req.Name, *req.Quota); err != nil {
+			return errFromErrDefs(err, event)
+		}
 	}
 
 	c.JSON(http.StatusOK, req)
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     Get the quota of the namespace.
+// @Description Get the quota of the namespace and the usage.
+// @Tags        namespace
+// @Accept      json
+// @Produce     json
+// @Param       name path     string true "Namespace name"
+// @Success     200  {object} types.NamespaceQuotaStatus
+// @Router      /system/namespaces/{name}/quota [get]
+func (s *Server) handleNamespaceQuotaGet(c *gin.Context) error {
+	event := "namespace-quota-get"
+	name := c.Param("name")
+	if name == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("name is required"), event)
+	}
+
+	status, err := s.runtime.NamespaceQuotaGet(c.Request.Context(), name)
+	if err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusOK, status)
+	return nil
+}
+
+// @Summary     Update the quota of the namespace.
+// @Description Replace the quota of the namespace, the namespace is not limited if the quota is empty.
+// @Tags        namespace
+// @Accept      json
+// @Produce     json
+// @Param       name path     string               true "Namespace name"
+// @Param       body body     types.NamespaceQuota true "Quota"
+// @Success     200  {object} types.NamespaceQuota
+// @Router      /system/namespaces/{name}/quota [put]
+func (s *Server) handleNamespaceQuotaUpdate(c *gin.Context) error {
+	event := "namespace-quota-update"
+	name := c.Param("name")
+	if name == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("name is required"), event)
+	}
+
+	var req types.NamespaceQuota
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return NewError(http.StatusBadRequest, err, event)
+	}
+
+	if err := s.validator.ValidateNamespaceQuota(&req); err != nil {
+		return NewError(http.StatusBadRequest, err, event)
+	}
+
+	if err := s.runtime.NamespaceQuotaUpdate(c.Request.Context(), name, req); err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusOK, req)
+	return nil
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	"github.com/tensorchord/openmodelz/agent/pkg/server/validator"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+var _ = Describe("namespace quota", func() {
+	BeforeEach(func() {
+		server = &Server{
+			router:        gin.New(),
+			metricsRouter: gin.New(),
+			runtime:       mockRuntime,
+			validator:     validator.New(),
+		}
+	})
+	It("create - invalid quota", func() {
+		c := mkJsonBodyContext("POST", "/", nil, types.NamespaceRequest{
+			Name:  "team-a",
+			Quota: &types.NamespaceQuota{GPU: "0.5"},
+		})
+		err := server.handleNamespaceCreate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("create - with quota", func() {
+		quota := types.NamespaceQuota{GPU: "4", Memory: "64Gi", Inferences: Ptr(int32(10))}
+		mockRuntime.EXPECT().NamespaceCreate(gomock.Any(), "team-a").Times(1).Return(nil)
+		mockRuntime.EXPECT().NamespaceQuotaUpdate(gomock.Any(), "team-a", quota).
+			Times(1).Return(nil)
+		c := mkJsonBodyContext("POST", "/", nil, types.NamespaceRequest{
+			Name:  "team-a",
+			Quota: &quota,
+		})
+		err := server.handleNamespaceCreate(c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("get - not found", func() {
+		mockRuntime.EXPECT().NamespaceQuotaGet(gomock.Any(), "team-a").
+			Times(1).Return(types.NamespaceQuotaStatus{}, errdefs.NotFound(errors.New("mock-error")))
+		c := mkContext("GET", "/", nil, nil)
+		setParam(c, map[string]string{"name": "team-a"})
+		err := server.handleNamespaceQuotaGet(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("update - negative", func() {
+		c := mkJsonBodyContext("PUT", "/", nil, types.NamespaceQuota{CPU: "-1"})
+		setParam(c, map[string]string{"name": "team-a"})
+		err := server.handleNamespaceQuotaUpdate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("update - good request", func() {
+		mockRuntime.EXPECT().NamespaceQuotaUpdate(gomock.Any(), "team-a",
+			types.NamespaceQuota{CPU: "16"}).Times(1).Return(nil)
+		c := mkJsonBodyContext("PUT", "/", nil, types.NamespaceQuota{CPU: "16"})
+		setParam(c, map[string]string{"name": "team-a"})
+		err := server.handleNamespaceQuotaUpdate(c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
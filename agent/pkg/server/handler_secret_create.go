@@ -0,0 +1,78 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     Create the secret.
+// @Description Create the secret, the values are not returned.
+// @Tags        secret
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string       true "Namespace"
+// @Param       body      body     types.Secret true "Secret"
+// @Success     201       {object} types.Secret
+// @Router      /system/secrets [post]
+func (s *Server) handleSecretCreate(c *gin.Context) error {
+	event := "secret-create"
+	req, err := s.bindSecret(c, event)
+	if err != nil {
+		return err
+	}
+
+	if err := s.runtime.SecretCreate(c.Request.Context(), req); err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusCreated, types.Secret{Name: req.Name, Namespace: req.Namespace})
+	return nil
+}
+
+// @Summary     Update the secret.
+// @Description Replace the values of the secret, the values are not returned.
+// @Tags        secret
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string       true "Namespace"
+// @Param       body      body     types.Secret true "Secret"
+// @Success     200       {object} types.Secret
+// @Router      /system/secrets [put]
+func (s *Server) handleSecretUpdate(c *gin.Context) error {
+	event := "secret-update"
+	req, err := s.bindSecret(c, event)
+	if err != nil {
+		return err
+	}
+
+	if err := s.runtime.SecretUpdate(c.Request.Context(), req); err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusOK, types.Secret{Name: req.Name, Namespace: req.Namespace})
+	return nil
+}
+
+// bindSecret binds and validates the secret in the namespace of the query.
+func (s *Server) bindSecret(c *gin.Context, event string) (types.Secret, error) {
+	var req types.Secret
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return req, NewError(http.StatusBadRequest, err, event)
+	}
+
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return req, NewError(
+			http.StatusBadRequest, errors.New("namespace is required"), event)
+	}
+	req.Namespace = namespace
+
+	if err := s.validator.ValidateSecretRequest(&req); err != nil {
+		return req, NewError(http.StatusBadRequest, err, event)
+	}
+	return req, nil
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     Delete the secret.
+// @Description Delete the secret.
+// @Tags        secret
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string       true "Namespace"
+// @Param       body      body     types.Secret true "Secret name"
+// @Success     200       {object} types.Secret
+// @Router      /system/secrets [delete]
+func (s *Server) handleSecretDelete(c *gin.Context) error {
+	event := "secret-delete"
+	var req types.Secret
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return NewError(http.StatusBadRequest, err, event)
+	}
+
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("namespace is required"), event)
+	}
+	if req.Name == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("name is required"), event)
+	}
+
+	if err := s.runtime.SecretDelete(
+		c.Request.Context(), namespace, req.Name); err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusOK, types.Secret{Name: req.Name, Namespace: namespace})
+	return nil
+}
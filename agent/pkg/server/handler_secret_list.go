@@ -0,0 +1,35 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	_ "github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+// @Summary     List the secrets.
+// @Description List the secrets in the namespace, the values are not returned.
+// @Tags        secret
+// @Accept      json
+// @Produce     json
+// @Param       namespace query    string true "Namespace"
+// @Success     200       {object} []types.Secret
+// @Router      /system/secrets [get]
+func (s *Server) handleSecretList(c *gin.Context) error {
+	event := "secret-list"
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		return NewError(
+			http.StatusBadRequest, errors.New("namespace is required"), event)
+	}
+
+	secrets, err := s.runtime.SecretList(c.Request.Context(), namespace)
+	if err != nil {
+		return errFromErrDefs(err, event)
+	}
+
+	c.JSON(http.StatusOK, secrets)
+	return nil
+}
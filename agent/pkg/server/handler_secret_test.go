@@ -0,0 +1,85 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/errdefs"
+	"github.com/tensorchord/openmodelz/agent/pkg/server/validator"
+)
+
+var _ = Describe("secret", func() {
+	BeforeEach(func() {
+		server = &Server{
+			router:        gin.New(),
+			metricsRouter: gin.New(),
+			runtime:       mockRuntime,
+			validator:     validator.New(),
+		}
+	})
+	It("list - no namespace", func() {
+		c := mkContext("GET", "/", nil, nil)
+		err := server.handleSecretList(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("list - good request", func() {
+		mockRuntime.EXPECT().SecretList(gomock.Any(), "mock-namespace").
+			Times(1).Return([]types.Secret{{Name: "hf-token"}}, nil)
+		c := mkJsonBodyContext("GET", "/", nil, nil)
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleSecretList(c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("create - invalid key", func() {
+		c := mkJsonBodyContext("POST", "/", nil, types.Secret{
+			Name: "hf-token",
+			Data: map[string][]byte{"../token": []byte("mock")},
+		})
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleSecretCreate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("create - good request", func() {
+		mockRuntime.EXPECT().SecretCreate(gomock.Any(), types.Secret{
+			Name:      "hf-token",
+			Namespace: "mock-namespace",
+			Value:     "mock",
+		}).Times(1).Return(nil)
+		c := mkJsonBodyContext("POST", "/", nil, types.Secret{
+			Name:  "hf-token",
+			Value: "mock",
+		})
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleSecretCreate(c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("update - not found", func() {
+		mockRuntime.EXPECT().SecretUpdate(gomock.Any(), gomock.Any()).
+			Times(1).Return(errdefs.NotFound(errors.New("mock-error")))
+		c := mkJsonBodyContext("PUT", "/", nil, types.Secret{
+			Name:  "hf-token",
+			Value: "mock",
+		})
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleSecretUpdate(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("delete - no name", func() {
+		c := mkJsonBodyContext("DELETE", "/", nil, types.Secret{})
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleSecretDelete(c)
+		Expect(err).To(HaveOccurred())
+	})
+	It("delete - good request", func() {
+		mockRuntime.EXPECT().SecretDelete(gomock.Any(), "mock-namespace", "hf-token").
+			Times(1).Return(nil)
+		c := mkJsonBodyContext("DELETE", "/", nil, types.Secret{Name: "hf-token"})
+		setQuery(c, map[string]string{"namespace": "mock-namespace"})
+		err := server.handleSecretDelete(c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -24,7 +24,7 @@ func (s *Server) proxyAuth(c *gin.Context) (string, string, error) {
 	key := c.GetHeader("X-API-Key")
 	// Be compatible with the OpenAI API.
 	rawKeyStr := c.GetHeader("Authorization")
-	logrus.Debug("proxyOther: key: ", key, ", rawKeyStr: ", rawKeyStr)
+	logrus.Debug("proxyAuth: key: ", key, ", rawKeyStr: ", rawKeyStr)
 
 	if s.validateUnifiedKey(key) {
 		// uid 0 means to use unified api key
@@ -117,7 +117,7 @@ func (s *Server) initKubernetesResources() error {
 		logrus.Warn("running in dev mode, using port forwarding to access pods, please do not use dev mode in production")
 		s.endpointResolver = k8s.NewPortForwardingResolver(clientCmdConfig, kubeClient)
 	} else {
-		s.endpointResolver = k8s.NewEndpointResolver(endpoints.Lister())
+		s.endpointResolver = k8s.NewEndpointResolver(endpoints.Lister(), pods.Lister())
 	}
 	s.deploymentLogRequester = log.NewK8sAPIRequestor(kubeClient)
 	s.scaler, err = scaling.NewInferenceScaler(runtime, s.config.Inference.CacheTTL)
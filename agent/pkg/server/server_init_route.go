@@ -18,6 +18,8 @@ const (
 	endpointInfo            = "/info"
 	endpointLogPlural       = "/logs"
 	endpointNamespacePlural = "/namespaces"
+	endpointSecretPlural    = "/secrets"
+	endpointBatchPlural     = "/batch-inferences"
 	endpointHealthz         = "/healthz"
 	endpointBuild           = "/build"
 	endpointImageCache      = "/image-cache"
@@ -37,10 +39,8 @@ func (s *Server) registerRoutes() {
 		WrapHandler(s.middlewareCallID),
 		WrapHandler(s.handleInferenceProxy))
 
-	v1.Any("/mosec/:id/*proxyPath", WrapHandler(s.proxyMosec))
-	v1.Any("/gradio/:id/*proxyPath", WrapHandler(s.proxyGradio))
-	v1.Any("/streamlit/:id/*proxyPath", WrapHandler(s.proxyStreamlit))
-	v1.Any("/other/:id/*proxyPath", WrapHandler(s.proxyOther))
+	// The ingress rewrites the requests to /api/v1/<framework>/<name>.
+	v1.Any("/:framework/:id/*proxyPath", WrapHandler(s.proxyFramework))
 
 	// healthz
 	root.GET(endpointHealthz, WrapHandler(s.handleHealthz))
@@ -63,6 +63,20 @@ func (s *Server) registerRoutes() {
 		WrapHandler(s.handleInferenceScale))
 	controlPlane.GET(endpointInference+"/:name",
 		WrapHandler(s.handleInferenceGet))
+	controlPlane.POST(endpointInference+"/:name/events",
+		WrapHandler(s.handleInferenceEventCreate))
+	controlPlane.PUT(endpointInference+"/:name/crash-loop",
+		WrapHandler(s.handleInferenceCrashLoopUpdate))
+	controlPlane.DELETE(endpointInference+"/:name/crash-loop",
+		WrapHandler(s.handleInferenceCrashLoopDelete))
+	controlPlane.PUT(endpointInference+"/:name/pending-reason",
+		WrapHandler(s.handleInferencePendingReasonUpdate))
+
+	// revisions
+	controlPlane.GET(endpointInference+"/:name/revisions",
+		WrapHandler(s.handleInferenceRevisionList))
+	controlPlane.POST(endpointInference+"/:name/rollback",
+		WrapHandler(s.handleInferenceRollback))
 
 	// instances
 	controlPlane.GET(endpointInference+"/:name/instances",
@@ -90,9 +104,30 @@ func (s *Server) registerRoutes() {
 		WrapHandler(s.handleNamespaceCreate))
 	controlPlane.DELETE(endpointNamespacePlural,
 		WrapHandler(s.handleNamespaceDelete))
-
-	// TODO(gaocegege): Support secrets
-	// controlPlane.GET("/secrets")
+	controlPlane.GET(endpointNamespacePlural+"/:name/quota",
+		WrapHandler(s.handleNamespaceQuotaGet))
+	controlPlane.PUT(endpointNamespacePlural+"/:name/quota",
+		WrapHandler(s.handleNamespaceQuotaUpdate))
+
+	// secrets
+	controlPlane.GET(endpointSecretPlural,
+		WrapHandler(s.handleSecretList))
+	controlPlane.POST(endpointSecretPlural,
+		WrapHandler(s.handleSecretCreate))
+	controlPlane.PUT(endpointSecretPlural,
+		WrapHandler(s.handleSecretUpdate))
+	controlPlane.DELETE(endpointSecretPlural,
+		WrapHandler(s.handleSecretDelete))
+
+	// batch inferences
+	controlPlane.GET(endpointBatchPlural,
+		WrapHandler(s.handleBatchInferenceList))
+	controlPlane.POST(endpointBatchPlural,
+		WrapHandler(s.handleBatchInferenceCreate))
+	controlPlane.GET(endpointBatchPlural+"/:name",
+		WrapHandler(s.handleBatchInferenceGet))
+	controlPlane.POST(endpointBatchPlural+"/:name/cancel",
+		WrapHandler(s.handleBatchInferenceCancel))
 
 	// builds
 	if s.config.Build.BuildEnabled {
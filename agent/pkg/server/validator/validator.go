@@ -2,46 +2,32 @@ package validator
 
 import (
 	"fmt"
-	"regexp"
 
 	"k8s.io/apimachinery/pkg/util/rand"
 
 	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/pkg/k8s"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/validation"
 )
 
 const (
-	defaultMinReplicas     = 0
-	defaultMaxReplicas     = 1
-	maxReplicas            = 5
-	defaultTargetLoad      = 100
-	defaultZeroDuration    = 300
-	defaultStartupDuration = 600
-	defaultBuildDuration   = "40m"
-	defaultHTTPProbePath   = "/"
+	defaultBuildDuration = "40m"
 )
 
-var (
-	dnsValidRegex = `^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
-)
-
-type Validator struct {
-	validDNS *regexp.Regexp
-}
+// Validator sets the defaults and validates the requests. The rules of the
+// inference are shared with the admission webhook of modelzetes.
+type Validator struct{}
 
 func New() *Validator {
-	return &Validator{
-		validDNS: regexp.MustCompile(dnsValidRegex),
-	}
+	return &Validator{}
 }
 
 // Validates that the service name is valid for Kubernetes
 func (v Validator) ValidateService(service string) error {
-	matched := v.validDNS.MatchString(service)
-	if matched {
-		return nil
+	if err := validation.ValidateName(service); err != nil {
+		return fmt.Errorf("service: (%s) is invalid, must be a valid DNS entry", service)
 	}
-
-	return fmt.Errorf("service: (%s) is invalid, must be a valid DNS entry", service)
+	return nil
 }
 
 // DefaultDeployRequest sets default values for the deploy request.
@@ -52,17 +38,20 @@ func (v Validator) DefaultDeployRequest(request *types.InferenceDeployment) {
 
 	if request.Spec.Scaling.MinReplicas == nil {
 		request.Spec.Scaling.MinReplicas = new(int32)
-		*request.Spec.Scaling.MinReplicas = defaultMinReplicas
+		*request.Spec.Scaling.MinReplicas = validation.DefaultMinReplicas
 	}
 
 	if request.Spec.Scaling.MaxReplicas == nil {
 		request.Spec.Scaling.MaxReplicas = new(int32)
-		*request.Spec.Scaling.MaxReplicas = defaultMinReplicas
+		*request.Spec.Scaling.MaxReplicas = validation.DefaultMaxReplicas
+		if *request.Spec.Scaling.MinReplicas > *request.Spec.Scaling.MaxReplicas {
+			*request.Spec.Scaling.MaxReplicas = *request.Spec.Scaling.MinReplicas
+		}
 	}
 
 	if request.Spec.Scaling.TargetLoad == nil {
 		request.Spec.Scaling.TargetLoad = new(int32)
-		*request.Spec.Scaling.TargetLoad = defaultTargetLoad
+		*request.Spec.Scaling.TargetLoad = validation.DefaultTargetLoad
 	}
 
 	if request.Spec.Scaling.Type == nil {
@@ -72,17 +61,24 @@ func (v Validator) DefaultDeployRequest(request *types.InferenceDeployment) {
 
 	if request.Spec.Scaling.ZeroDuration == nil {
 		request.Spec.Scaling.ZeroDuration = new(int32)
-		*request.Spec.Scaling.ZeroDuration = defaultZeroDuration
+		*request.Spec.Scaling.ZeroDuration = validation.DefaultZeroDuration
 	}
 
 	if request.Spec.Scaling.StartupDuration == nil {
 		request.Spec.Scaling.StartupDuration = new(int32)
-		*request.Spec.Scaling.StartupDuration = defaultStartupDuration
+		*request.Spec.Scaling.StartupDuration = validation.DefaultStartupDuration
 	}
 
 	if request.Spec.Framework == "" {
 		request.Spec.Framework = types.FrameworkOther
 	}
+
+	if request.Spec.Port == nil {
+		if port, ok := validation.FrameworkPort(
+			string(request.Spec.Framework), request.Spec.EnvVars); ok {
+			request.Spec.Port = &port
+		}
+	}
 }
 
 // ValidateDeployRequest validates that the service name is valid for Kubernetes
@@ -97,21 +93,17 @@ func (v Validator) ValidateDeployRequest(request *types.InferenceDeployment) err
 		return err
 	}
 
-	if request.Spec.Image == "" {
-		return fmt.Errorf("image: is required")
-	}
-
 	if request.Spec.Scaling == nil {
 		return fmt.Errorf("scaling: is required")
 	}
 
-	if request.Spec.Framework == types.FrameworkOther {
-		if request.Spec.Port == nil {
-			return fmt.Errorf("port: is required for other framework")
-		}
+	spec, err := k8s.MakeInferenceSpec(*request)
+	if err != nil {
+		return err
 	}
-
-	return nil
+	// The startup probe period is only known by modelzetes, it is
+	// validated by the admission webhook.
+	return validation.ValidateInferenceSpec(&spec, request.Spec.Namespace, 0)
 }
 
 func (v Validator) ValidateBuildRequest(request *types.Build) error {
@@ -145,6 +137,110 @@ func (v Validator) ValidateImageCacheRequest(request *types.ImageCache) error {
 	return nil
 }
 
+// ValidateSecretRequest validates the secret. The value is either the data
+// with multiple keys or the single value stored with the name as the key.
+func (v Validator) ValidateSecretRequest(request *types.Secret) error {
+	keys := []string{}
+	for key := range request.Data {
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 && (request.Value != "" || len(request.RawValue) > 0) {
+		return fmt.Errorf("data: cannot be set with value")
+	}
+	return validation.ValidateSecret(request.Name, keys)
+}
+
+// ValidateBatchInferenceRequest validates the batch inference. The fields
+// inherited from the inference are validated by the controller after they
+// are merged.
+func (v Validator) ValidateBatchInferenceRequest(request *types.BatchInference) error {
+	if request.Spec.Name == "" {
+		return fmt.Errorf("name: is required")
+	}
+	if err := validation.ValidateName(request.Spec.Name); err != nil {
+		return fmt.Errorf("name: (%s) is invalid, must be a valid DNS entry", request.Spec.Name)
+	}
+
+	if request.Spec.Inference == "" {
+		if request.Spec.Image == "" {
+			return fmt.Errorf("image: is required if the inference is not set")
+		}
+		if err := validation.ValidateFramework(string(request.Spec.Framework),
+			request.Spec.Port, request.Spec.EnvVars); err != nil {
+			return err
+		}
+	}
+
+	if err := validation.ValidateBatchLocation("input",
+		request.Spec.Input.URI, request.Spec.Input.VolumeClaim); err != nil {
+		return err
+	}
+	if err := validation.ValidateBatchInput(
+		request.Spec.Input.JSONL, request.Spec.Input.Files); err != nil {
+		return err
+	}
+	if err := validation.ValidateBatchLocation("output",
+		request.Spec.Output.URI, request.Spec.Output.VolumeClaim); err != nil {
+		return err
+	}
+	if err := validation.ValidateBatchLimits(request.Spec.Parallelism,
+		request.Spec.MaxRetries, request.Spec.BackoffLimit); err != nil {
+		return err
+	}
+
+	resources, err := k8s.MakeResourceRequirements(request.Spec.Resources)
+	if err != nil {
+		return err
+	}
+	if err := validation.ValidateResourceRequirements(&resources); err != nil {
+		return err
+	}
+	return validation.ValidateSecretNames(request.Spec.Secrets)
+}
+
+// ValidateNamespaceRequest validates the name and the quota of the
+// namespace.
+func (v Validator) ValidateNamespaceRequest(request *types.NamespaceRequest) error {
+	if request.Name == "" {
+		return fmt.Errorf("name: is required")
+	}
+	if err := validation.ValidateName(request.Name); err != nil {
+		return fmt.Errorf("name: (%s) is invalid, must be a valid DNS entry", request.Name)
+	}
+	if request.Quota == nil {
+		return nil
+	}
+	return v.ValidateNamespaceQuota(request.Quota)
+}
+
+// ValidateNamespaceQuota validates that the quantities of the quota are not
+// negative and the GPUs are whole.
+func (v Validator) ValidateNamespaceQuota(quota *types.NamespaceQuota) error {
+	if quota.CPU != "" {
+		if _, err := validation.ParseQuantity("quota.cpu", string(quota.CPU)); err != nil {
+			return err
+		}
+	}
+	if quota.Memory != "" {
+		if _, err := validation.ParseQuantity("quota.memory", string(quota.Memory)); err != nil {
+			return err
+		}
+	}
+	if quota.GPU != "" {
+		qty, err := validation.ParseQuantity("quota.gpu", string(quota.GPU))
+		if err != nil {
+			return err
+		}
+		if qty.MilliValue()%1000 != 0 {
+			return fmt.Errorf("quota.gpu: (%s) must be a whole number", quota.GPU)
+		}
+	}
+	if quota.Inferences != nil && *quota.Inferences < 0 {
+		return fmt.Errorf("quota.inferences: must be greater than or equal to 0")
+	}
+	return nil
+}
+
 func (v Validator) DefaultBuildRequest(request *types.Build) {
 	if request.Spec.BuildTarget.Builder == "" {
 		request.Spec.BuildTarget.Builder = types.BuilderTypeImage
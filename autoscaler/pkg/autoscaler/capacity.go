@@ -0,0 +1,470 @@
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/accelerator"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const eventReasonInsufficientCapacity = "InsufficientCapacity"
+
+// CapacityConfig configures the capacity-aware scaling.
+type CapacityConfig struct {
+	// Enabled caps the expected replicas at what can be scheduled.
+	Enabled bool
+	// Overcommit is the ratio applied to the allocatable CPU and memory of
+	// the servers. GPU cannot be overcommitted.
+	Overcommit float64
+	// NamespaceGPUBudgets is the max number of GPUs used by the inferences
+	// of each namespace. The shared GPUs, the MIG devices and the GPUs of
+	// all the vendors are counted.
+	NamespaceGPUBudgets map[string]int64
+}
+
+// ParseNamespaceGPUBudgets parses the budgets in the format of
+// <namespace>=<gpus>.
+func ParseNamespaceGPUBudgets(values []string) (map[string]int64, error) {
+	res := make(map[string]int64)
+	for _, v := range values {
+		parts := strings.Split(v, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid namespace gpu budget %s, expected <namespace>=<gpus>", v)
+		}
+		var budget int64
+		if _, err := fmt.Sscanf(parts[1], "%d", &budget); err != nil || budget < 0 {
+			return nil, fmt.Errorf("invalid namespace gpu budget %s, expected <namespace>=<gpus>", v)
+		}
+		res[parts[0]] = budget
+	}
+	return res, nil
+}
+
+// replicaRequests returns the resources requested by one replica. The
+// requests default to the limits if they are not specified. The
+// accelerators are keyed by the names of the agent API, e.g. gpu,
+// mig-1g.10gb or amd.com/gpu.
+func replicaRequests(resources *types.ResourceRequirements) map[types.ResourceName]float64 {
+	res := make(map[types.ResourceName]float64)
+	if resources == nil {
+		return res
+	}
+	names := map[types.ResourceName]bool{}
+	for _, list := range []types.ResourceList{resources.Requests, resources.Limits} {
+		for name := range list {
+			if name == types.ResourceCPU || name == types.ResourceMemory ||
+				isAccelerator(name) {
+				names[name] = true
+			}
+		}
+	}
+	for name := range names {
+		value, ok := resources.Requests[name]
+		if !ok || value == "" {
+			value = resources.Limits[name]
+		}
+		if value == "" {
+			continue
+		}
+		qty, err := resource.ParseQuantity(string(value))
+		if err != nil || qty.Sign() <= 0 {
+			continue
+		}
+		res[name] = qty.AsApproximateFloat64()
+	}
+	return res
+}
+
+// isAccelerator returns true if the resource is allocated on the
+// accelerators, which cannot be overcommitted.
+func isAccelerator(name types.ResourceName) bool {
+	_, ok := accelerator.ResourceName(string(name))
+	return ok
+}
+
+// acceleratorRequests returns the number of the accelerator devices
+// requested by one replica.
+func acceleratorRequests(requests map[types.ResourceName]float64) float64 {
+	res := 0.0
+	for name, qty := range requests {
+		if isAccelerator(name) {
+			res += qty
+		}
+	}
+	return res
+}
+
+// placement is where the replicas of the inference could be scheduled, it
+// follows the constraints and the scheduling config of the inference.
+type placement struct {
+	constraints []string
+	scheduling  *types.SchedulingConfig
+	// tolerations include the ones added by the controller for the
+	// requested accelerator.
+	tolerations []types.Toleration
+}
+
+func newPlacement(spec types.InferenceDeploymentSpec,
+	requests map[types.ResourceName]float64) placement {
+	p := placement{
+		constraints: spec.Constraints,
+		scheduling:  spec.Scheduling,
+	}
+	for name := range requests {
+		resourceName, ok := accelerator.ResourceName(string(name))
+		if !ok {
+			continue
+		}
+		a, _ := accelerator.ForResource(resourceName)
+		for _, t := range a.Tolerations {
+			p.tolerations = append(p.tolerations, types.Toleration{
+				Key:      t.Key,
+				Operator: string(t.Operator),
+				Value:    t.Value,
+				Effect:   string(t.Effect),
+			})
+		}
+	}
+	if spec.Scheduling != nil {
+		p.tolerations = append(p.tolerations, spec.Scheduling.Tolerations...)
+	}
+	return p
+}
+
+// matches returns true if the replicas could be scheduled on the server.
+func (p placement) matches(server types.Server) bool {
+	if server.Status.Phase != "Ready" ||
+		!matchConstraints(server.Spec.NodeLabels, p.constraints) {
+		return false
+	}
+	if p.scheduling != nil && p.scheduling.NodeAffinity != nil &&
+		len(p.scheduling.NodeAffinity.Required) > 0 {
+		matched := false
+		for _, term := range p.scheduling.NodeAffinity.Required {
+			if matchNodeSelectorTerm(server.Spec.NodeLabels, term) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, taint := range server.Spec.Taints {
+		if taint.Effect == string(corev1.TaintEffectPreferNoSchedule) {
+			continue
+		}
+		tolerated := false
+		for _, t := range p.tolerations {
+			if tolerates(t, taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// maxReplicas returns the max number of the replicas if the required pod
+// anti affinity keeps them in different topology domains of the servers.
+// It returns false if the replicas are not limited.
+func (p placement) maxReplicas(servers []types.Server) (int, string, bool) {
+	if p.scheduling == nil || p.scheduling.PodAntiAffinity == nil ||
+		!p.scheduling.PodAntiAffinity.Required {
+		return 0, "", false
+	}
+	key := p.scheduling.PodAntiAffinity.TopologyKey
+	domains := map[string]bool{}
+	for _, server := range servers {
+		if !p.matches(server) {
+			continue
+		}
+		domain, ok := server.Spec.NodeLabels[key]
+		if !ok {
+			// The servers without the topology key are not limited.
+			return 0, "", false
+		}
+		domains[domain] = true
+	}
+	return len(domains), key, true
+}
+
+// matchNodeSelectorTerm returns true if the labels match all the
+// expressions of the term.
+func matchNodeSelectorTerm(labels map[string]string,
+	term types.NodeSelectorTerm) bool {
+	for _, expr := range term.MatchExpressions {
+		value, ok := labels[expr.Key]
+		switch expr.Operator {
+		case types.NodeSelectorOpIn:
+			if !ok || !contains(expr.Values, value) {
+				return false
+			}
+		case types.NodeSelectorOpNotIn:
+			if ok && contains(expr.Values, value) {
+				return false
+			}
+		case types.NodeSelectorOpExists:
+			if !ok {
+				return false
+			}
+		case types.NodeSelectorOpDoesNotExist:
+			if ok {
+				return false
+			}
+		case types.NodeSelectorOpGt, types.NodeSelectorOpLt:
+			if !ok || len(expr.Values) != 1 {
+				return false
+			}
+			actual, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return false
+			}
+			expected, err := strconv.ParseInt(expr.Values[0], 10, 64)
+			if err != nil {
+				return false
+			}
+			if (expr.Operator == types.NodeSelectorOpGt && actual <= expected) ||
+				(expr.Operator == types.NodeSelectorOpLt && actual >= expected) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// tolerates returns true if the toleration tolerates the taint, it follows
+// the rules of kubernetes.
+func tolerates(t types.Toleration, taint types.Taint) bool {
+	if t.Effect != "" && t.Effect != taint.Effect {
+		return false
+	}
+	if t.Key != "" && t.Key != taint.Key {
+		return false
+	}
+	switch corev1.TolerationOperator(t.Operator) {
+	case corev1.TolerationOpExists:
+		return true
+	case "", corev1.TolerationOpEqual:
+		return t.Key != "" && t.Value == taint.Value
+	default:
+		return false
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matchConstraints returns true if the labels match all the constraints
+// in the format of <key>=<value>.
+func matchConstraints(labels map[string]string, constraints []string) bool {
+	for _, constraint := range constraints {
+		parts := strings.Split(constraint, "=")
+		if len(parts) != 2 {
+			continue
+		}
+		if labels[parts[0]] != parts[1] {
+			return false
+		}
+	}
+	return true
+}
+
+func quantity(list types.ResourceList, name types.ResourceName) float64 {
+	value, ok := list[name]
+	if !ok || value == "" {
+		return 0
+	}
+	qty, err := resource.ParseQuantity(string(value))
+	if err != nil {
+		return 0
+	}
+	return qty.AsApproximateFloat64()
+}
+
+// schedulableReplicas returns the number of replicas which can be placed on
+// the servers matching the placement, and the resource which limits it.
+func schedulableReplicas(servers []types.Server, p placement,
+	requests map[types.ResourceName]float64, overcommit float64) (int, types.ResourceName) {
+	names := make([]types.ResourceName, 0, len(requests))
+	for name := range requests {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	total := 0
+	// fits is the number of replicas which fit into the servers
+	// by every single resource.
+	fits := make(map[types.ResourceName]int)
+	for _, server := range servers {
+		if !p.matches(server) {
+			continue
+		}
+
+		serverFit := math.MaxInt32
+		for _, name := range names {
+			ratio := overcommit
+			if isAccelerator(name) {
+				ratio = 1
+			}
+			free := quantity(server.Status.Allocatable, name)*ratio -
+				quantity(server.Status.Allocated, name)
+			fit := 0
+			if free > 0 {
+				fit = int(math.Floor(free / requests[name]))
+			}
+			fits[name] += fit
+			if fit < serverFit {
+				serverFit = fit
+			}
+		}
+		total += serverFit
+	}
+
+	var limiting types.ResourceName
+	for _, name := range names {
+		if limiting == "" || fits[name] < fits[limiting] {
+			limiting = name
+		}
+	}
+	return total, limiting
+}
+
+// capReplicas caps the expected replicas of the inference at what can be
+// scheduled in the cluster and allowed by the namespace GPU budget. It
+// returns the capped replicas and the reason if it is capped.
+func (s *Scaler) capReplicas(ctx context.Context, resp types.InferenceDeployment,
+	expectedReplicas int) (int, string, error) {
+	requests := replicaRequests(resp.Spec.Resources)
+	if len(requests) == 0 {
+		return expectedReplicas, "", nil
+	}
+	name, namespace := resp.Spec.Name, resp.Spec.Namespace
+
+	instances, err := s.client.InstanceList(ctx, namespace, name)
+	if err != nil {
+		return expectedReplicas, "", err
+	}
+	// The scheduled replicas are already counted in the allocated resources.
+	scheduled := 0
+	for _, instance := range instances {
+		switch instance.Status.Phase {
+		case types.InstancePhaseScheduling, types.InstancePhaseFailed,
+			types.InstancePhaseSucceeded:
+		default:
+			scheduled++
+		}
+	}
+
+	servers, err := s.listServers(ctx)
+	if err != nil {
+		return expectedReplicas, "", err
+	}
+	p := newPlacement(resp.Spec, requests)
+	schedulable, limiting := schedulableReplicas(servers,
+		p, requests, s.Capacity.Overcommit)
+
+	replicas, reason := expectedReplicas, ""
+	if scheduled+schedulable < replicas {
+		replicas = scheduled + schedulable
+		reason = fmt.Sprintf("insufficient %s on nodes matching constraints, %d/%d replicas can be scheduled",
+			limiting, replicas, expectedReplicas)
+	}
+	if limit, key, ok := p.maxReplicas(servers); ok && limit < replicas {
+		replicas = limit
+		reason = fmt.Sprintf("required pod anti affinity allows one replica per %s, %d/%d replicas can be scheduled",
+			key, replicas, expectedReplicas)
+	}
+
+	gpu := acceleratorRequests(requests)
+	budget, budgeted := s.Capacity.NamespaceGPUBudgets[namespace]
+	if gpu == 0 || !budgeted {
+		return replicas, reason, nil
+	}
+
+	inferences, err := s.client.InferenceList(ctx, namespace)
+	if err != nil {
+		return replicas, reason, err
+	}
+	used := 0.0
+	for _, inf := range inferences {
+		if inf.Spec.Name == name {
+			continue
+		}
+		used += float64(inf.Status.Replicas) *
+			acceleratorRequests(replicaRequests(inf.Spec.Resources))
+	}
+	allowed := int(math.Floor((float64(budget) - used) / gpu))
+	if allowed < 0 {
+		allowed = 0
+	}
+	if allowed < replicas {
+		replicas = allowed
+		reason = fmt.Sprintf("GPU budget of namespace %s is exhausted (%.0f/%d GPUs used by other inferences), %d/%d replicas are allowed",
+			namespace, used, budget, replicas, expectedReplicas)
+	}
+	return replicas, reason, nil
+}
+
+// listServers lists the servers once per autoscaling round.
+func (s *Scaler) listServers(ctx context.Context) ([]types.Server, error) {
+	if s.servers != nil {
+		return s.servers, nil
+	}
+	servers, err := s.client.ServerList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.servers = servers
+	return servers, nil
+}
+
+// updatePendingReason records the pending reason in the inference status,
+// and records an event if it changes.
+func (s *Scaler) updatePendingReason(ctx context.Context, service string,
+	resp types.InferenceDeployment, reason string) {
+	if resp.Status.PendingReason == reason {
+		return
+	}
+
+	name, namespace := resp.Spec.Name, resp.Spec.Namespace
+	if err := s.client.InferencePendingReasonUpdate(ctx,
+		namespace, name, reason); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"service": service,
+			"error":   err,
+		}).Error("failed to update pending reason")
+		return
+	}
+
+	resp.Status.PendingReason = reason
+	s.InferenceCache.Set(service, Inference{
+		Timestamp:  time.Now(),
+		Deployment: resp,
+	})
+
+	if reason != "" {
+		_ = s.recordEvent(ctx, namespace, name, types.InferenceEventTypeWarning,
+			eventReasonInsufficientCapacity, reason)
+	}
+}
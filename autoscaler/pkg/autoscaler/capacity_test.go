@@ -0,0 +1,297 @@
+package autoscaler
+
+import (
+	"testing"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+func TestSchedulableReplicas(t *testing.T) {
+	servers := []types.Server{
+		{
+			Spec: types.ServerSpec{
+				Name:       "gpu-1",
+				NodeLabels: map[string]string{"tensorchord.ai/gpu": "a100"},
+			},
+			Status: types.ServerStatus{
+				Phase: "Ready",
+				Allocatable: types.ResourceList{
+					types.ResourceGPU:    "4",
+					types.ResourceCPU:    "16",
+					types.ResourceMemory: "64Gi",
+				},
+				Allocated: types.ResourceList{
+					types.ResourceGPU: "3",
+					types.ResourceCPU: "2",
+				},
+			},
+		},
+		{
+			Spec: types.ServerSpec{
+				Name:       "gpu-2",
+				NodeLabels: map[string]string{"tensorchord.ai/gpu": "t4"},
+			},
+			Status: types.ServerStatus{
+				Phase: "Ready",
+				Allocatable: types.ResourceList{
+					types.ResourceGPU: "2",
+					types.ResourceCPU: "8",
+				},
+			},
+		},
+		{
+			Spec: types.ServerSpec{
+				Name:       "gpu-3",
+				NodeLabels: map[string]string{"tensorchord.ai/gpu": "a100"},
+			},
+			Status: types.ServerStatus{
+				Phase: "NotReady",
+				Allocatable: types.ResourceList{
+					types.ResourceGPU: "8",
+				},
+			},
+		},
+	}
+
+	tcs := []struct {
+		desc        string
+		constraints []string
+		requests    map[types.ResourceName]float64
+		overcommit  float64
+		expect      int
+		limiting    types.ResourceName
+	}{
+		{
+			desc:        "gpu on matching nodes",
+			constraints: []string{"tensorchord.ai/gpu=a100"},
+			requests:    map[types.ResourceName]float64{types.ResourceGPU: 1},
+			overcommit:  1,
+			expect:      1,
+			limiting:    types.ResourceGPU,
+		},
+		{
+			desc:       "gpu on all nodes",
+			requests:   map[types.ResourceName]float64{types.ResourceGPU: 1},
+			overcommit: 1,
+			expect:     3,
+			limiting:   types.ResourceGPU,
+		},
+		{
+			desc:       "cpu is the bottleneck",
+			requests:   map[types.ResourceName]float64{types.ResourceGPU: 1, types.ResourceCPU: 8},
+			overcommit: 1,
+			expect:     2,
+			limiting:   types.ResourceCPU,
+		},
+		{
+			desc:       "cpu overcommit",
+			requests:   map[types.ResourceName]float64{types.ResourceCPU: 8},
+			overcommit: 2,
+			expect:     5,
+			limiting:   types.ResourceCPU,
+		},
+	}
+	for _, tc := range tcs {
+		got, limiting := schedulableReplicas(servers,
+			placement{constraints: tc.constraints}, tc.requests, tc.overcommit)
+		if got != tc.expect {
+			t.Errorf("%s: expected %d replicas, got %d", tc.desc, tc.expect, got)
+		}
+		if limiting != tc.limiting {
+			t.Errorf("%s: expected limiting resource %s, got %s",
+				tc.desc, tc.limiting, limiting)
+		}
+	}
+}
+
+func TestSchedulableReplicasWithScheduling(t *testing.T) {
+	servers := []types.Server{
+		{
+			Spec: types.ServerSpec{
+				Name: "mig-1",
+				NodeLabels: map[string]string{
+					"topology.kubernetes.io/zone": "a",
+				},
+				Taints: []types.Taint{
+					{Key: "nvidia.com/gpu", Value: "present", Effect: "NoSchedule"},
+				},
+			},
+			Status: types.ServerStatus{
+				Phase: "Ready",
+				Allocatable: types.ResourceList{
+					"mig-1g.10gb":     "7",
+					types.ResourceCPU: "32",
+				},
+				Allocated: types.ResourceList{
+					"mig-1g.10gb": "5",
+				},
+			},
+		},
+		{
+			Spec: types.ServerSpec{
+				Name: "mig-2",
+				NodeLabels: map[string]string{
+					"topology.kubernetes.io/zone": "b",
+				},
+				Taints: []types.Taint{
+					{Key: "dedicated", Value: "training", Effect: "NoSchedule"},
+				},
+			},
+			Status: types.ServerStatus{
+				Phase: "Ready",
+				Allocatable: types.ResourceList{
+					"mig-1g.10gb": "7",
+				},
+			},
+		},
+		{
+			Spec: types.ServerSpec{
+				Name: "amd-1",
+				NodeLabels: map[string]string{
+					"topology.kubernetes.io/zone": "a",
+				},
+			},
+			Status: types.ServerStatus{
+				Phase: "Ready",
+				Allocatable: types.ResourceList{
+					"amd.com/gpu": "2",
+				},
+			},
+		},
+	}
+	mig := map[types.ResourceName]float64{"mig-1g.10gb": 1}
+
+	tcs := []struct {
+		desc       string
+		spec       types.InferenceDeploymentSpec
+		requests   map[types.ResourceName]float64
+		expect     int
+		limiting   types.ResourceName
+		maxReplica int
+		limited    bool
+	}{
+		{
+			desc:     "mig devices on the tolerated nodes",
+			requests: mig,
+			expect:   2,
+			limiting: "mig-1g.10gb",
+		},
+		{
+			desc: "tolerate the dedicated nodes",
+			spec: types.InferenceDeploymentSpec{
+				Scheduling: &types.SchedulingConfig{
+					Tolerations: []types.Toleration{
+						{Key: "dedicated", Operator: "Exists"},
+					},
+				},
+			},
+			requests: mig,
+			expect:   9,
+			limiting: "mig-1g.10gb",
+		},
+		{
+			desc: "required node affinity",
+			spec: types.InferenceDeploymentSpec{
+				Scheduling: &types.SchedulingConfig{
+					NodeAffinity: &types.NodeAffinity{
+						Required: []types.NodeSelectorTerm{{
+							MatchExpressions: []types.NodeSelectorRequirement{{
+								Key:      "topology.kubernetes.io/zone",
+								Operator: types.NodeSelectorOpNotIn,
+								Values:   []string{"a"},
+							}},
+						}},
+					},
+					Tolerations: []types.Toleration{
+						{Key: "dedicated", Operator: "Exists"},
+					},
+				},
+			},
+			requests: mig,
+			expect:   7,
+			limiting: "mig-1g.10gb",
+		},
+		{
+			desc:     "amd gpus",
+			requests: map[types.ResourceName]float64{"amd.com/gpu": 1},
+			expect:   2,
+			limiting: "amd.com/gpu",
+		},
+		{
+			desc: "required pod anti affinity",
+			spec: types.InferenceDeploymentSpec{
+				Scheduling: &types.SchedulingConfig{
+					PodAntiAffinity: &types.PodAntiAffinity{
+						TopologyKey: "topology.kubernetes.io/zone",
+						Required:    true,
+					},
+					// Tolerate all the taints.
+					Tolerations: []types.Toleration{{Operator: "Exists"}},
+				},
+			},
+			requests:   map[types.ResourceName]float64{types.ResourceCPU: 1},
+			expect:     64,
+			limiting:   types.ResourceCPU,
+			maxReplica: 2,
+			limited:    true,
+		},
+	}
+	for _, tc := range tcs {
+		p := newPlacement(tc.spec, tc.requests)
+		got, limiting := schedulableReplicas(servers, p, tc.requests, 2)
+		if got != tc.expect || limiting != tc.limiting {
+			t.Errorf("%s: expected %d replicas limited by %s, got %d by %s",
+				tc.desc, tc.expect, tc.limiting, got, limiting)
+		}
+		limit, _, limited := p.maxReplicas(servers)
+		if limited != tc.limited || limit != tc.maxReplica {
+			t.Errorf("%s: expected max replicas %d (%t), got %d (%t)",
+				tc.desc, tc.maxReplica, tc.limited, limit, limited)
+		}
+	}
+}
+
+func TestReplicaRequests(t *testing.T) {
+	got := replicaRequests(&types.ResourceRequirements{
+		Limits: types.ResourceList{
+			types.ResourceGPU:    "1",
+			types.ResourceMemory: "2Gi",
+		},
+		Requests: types.ResourceList{
+			types.ResourceMemory: "1Gi",
+			types.ResourceCPU:    "500m",
+		},
+	})
+	if got[types.ResourceGPU] != 1 || got[types.ResourceCPU] != 0.5 ||
+		got[types.ResourceMemory] != 1<<30 {
+		t.Errorf("unexpected requests: %v", got)
+	}
+	got = replicaRequests(&types.ResourceRequirements{
+		Limits: types.ResourceList{
+			"mig-1g.10gb": "2",
+			"amd.com/gpu": "1",
+		},
+	})
+	if got["mig-1g.10gb"] != 2 || got["amd.com/gpu"] != 1 || acceleratorRequests(got) != 3 {
+		t.Errorf("unexpected accelerator requests: %v", got)
+	}
+	if len(replicaRequests(nil)) != 0 {
+		t.Errorf("expected no requests")
+	}
+}
+
+func TestParseNamespaceGPUBudgets(t *testing.T) {
+	budgets, err := ParseNamespaceGPUBudgets([]string{"team-a=4", "team-b=0"})
+	if err != nil {
+		t.Fatalf("failed to parse budgets: %v", err)
+	}
+	if budgets["team-a"] != 4 || budgets["team-b"] != 0 {
+		t.Errorf("unexpected budgets: %v", budgets)
+	}
+
+	for _, invalid := range []string{"team-a", "=4", "team-a=-1", "team-a=x"} {
+		if _, err := ParseNamespaceGPUBudgets([]string{invalid}); err == nil {
+			t.Errorf("expected error for %s", invalid)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tensorchord/openmodelz/agent/api/types"
+
+	"github.com/tensorchord/openmodelz/autoscaler/pkg/metrics"
+)
+
+const (
+	// crashLoopBackoffBase is the delay before the first recovery attempt.
+	crashLoopBackoffBase = time.Minute
+	// crashLoopBackoffMax is the max delay between two recovery attempts.
+	crashLoopBackoffMax = 30 * time.Minute
+	// crashLoopStableDuration is the duration the instances should keep
+	// running after a recovery attempt before the suppression is lifted.
+	crashLoopStableDuration = 2 * time.Minute
+
+	eventReasonCrashLoopSuppressed      = "CrashLoopSuppressed"
+	eventReasonCrashLoopRecoveryAttempt = "CrashLoopRecoveryAttempt"
+	eventReasonCrashLoopRecoveryFailed  = "CrashLoopRecoveryFailed"
+	eventReasonCrashLoopRecovered       = "CrashLoopRecovered"
+)
+
+// crashLoopBackoff returns the delay before the next recovery attempt,
+// it doubles after every attempt.
+func crashLoopBackoff(attempts int32) time.Duration {
+	backoff := crashLoopBackoffBase
+	for i := int32(0); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= crashLoopBackoffMax {
+			return crashLoopBackoffMax
+		}
+	}
+	return backoff
+}
+
+// crashLoopCount returns the count of the instances in CrashLoopBackOff
+// per inference.
+func (s *Scaler) crashLoopCount(ctx context.Context) (map[string]int, error) {
+	// Detect if the instance pod always restart,
+	// if pod restart count in 10 minutes before last update time is more than 2, will scale it down.
+	results, err := s.Metrics.RestartedInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inferenceCount := make(map[string]int)
+	for _, restarted := range results {
+		podName, inferenceName, namespace := restarted.Pod,
+			restarted.InferenceName, restarted.Namespace
+
+		strs := strings.Split(inferenceName, ".")
+		if len(strs) != 2 {
+			logrus.Infof("Invalid inference name: %s", inferenceName)
+			continue
+		}
+		name := strs[0]
+		resp, err := s.client.InstanceList(ctx, namespace, name)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"service": inferenceName,
+				"error":   err,
+			}).Error("failed to get instance list")
+			continue
+		}
+
+		for _, instance := range resp {
+			if instance.Spec.Name == podName {
+				if instance.Status.Phase == "CrashLoopBackOff" {
+					inferenceCount[inferenceName] += 1
+				}
+			}
+		}
+	}
+	return inferenceCount, nil
+}
+
+// suppressCrashLoop scales down the crashing instances of the inference. The
+// scaling config is kept as it is, the suppression is recorded in the crash
+// loop state of the inference instead.
+func (s *Scaler) suppressCrashLoop(ctx context.Context, service string, count int) {
+	strs := strings.Split(service, ".")
+	if len(strs) != 2 {
+		logrus.Infof("Invalid inference name: %s", service)
+		return
+	}
+	name := strs[0]
+	namespace := strs[1]
+
+	resp, err := s.getInference(ctx, service, namespace, name)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"service": service,
+			"error":   err,
+		}).Error("failed to get inference")
+		return
+	}
+
+	now := time.Now()
+	totalReplicas := resp.Status.Replicas
+	expectedReplicas := totalReplicas - int32(count)
+	if expectedReplicas < 0 {
+		expectedReplicas = 0
+	}
+
+	decision := types.AutoscalerDecision{
+		Name:      name,
+		Namespace: namespace,
+		Timestamp: now,
+		Inputs: types.AutoscalerDecisionInputs{
+			CrashLoopCount: count,
+		},
+		Replicas:          totalReplicas,
+		AvailableReplicas: resp.Status.AvailableReplicas,
+		ExpectedReplicas:  expectedReplicas,
+		Action:            types.AutoscalerActionSkip,
+		Reason: fmt.Sprintf("%d replicas always CrashLoopBackOff, skip load based scaling",
+			count),
+	}
+	defer func() {
+		s.Decisions.Set(service, decision)
+	}()
+
+	var reason, message string
+	state := resp.Status.CrashLoop
+	switch {
+	case state == nil:
+		var originalMinReplicas int32
+		if resp.Spec.Scaling != nil && resp.Spec.Scaling.MinReplicas != nil {
+			originalMinReplicas = *resp.Spec.Scaling.MinReplicas
+		}
+		state = &types.CrashLoopState{
+			OriginalMinReplicas: originalMinReplicas,
+			MinReplicas:         minInt32(expectedReplicas, originalMinReplicas),
+			Since:               now,
+			NextAttempt:         now.Add(crashLoopBackoff(0)),
+		}
+		reason = eventReasonCrashLoopSuppressed
+		message = fmt.Sprintf("%d replicas always CrashLoopBackOff, min replicas %d is suspended, next recovery attempt at %s",
+			count, originalMinReplicas, state.NextAttempt.Format(time.RFC3339))
+	case state.LastAttempt != nil:
+		// The instances crash again during the recovery attempt.
+		updated := *state
+		updated.LastAttempt = nil
+		updated.MinReplicas = minInt32(expectedReplicas, state.OriginalMinReplicas)
+		updated.NextAttempt = now.Add(crashLoopBackoff(state.Attempts))
+		state = &updated
+		reason = eventReasonCrashLoopRecoveryFailed
+		message = fmt.Sprintf("Recovery attempt %d failed, %d replicas always CrashLoopBackOff, next recovery attempt at %s",
+			state.Attempts, count, state.NextAttempt.Format(time.RFC3339))
+	case expectedReplicas < state.MinReplicas:
+		updated := *state
+		updated.MinReplicas = expectedReplicas
+		state = &updated
+	default:
+		state = nil
+	}
+
+	// The state should be recorded before scaling, since the agent does not
+	// scale the inference below its min replicas otherwise.
+	if state != nil {
+		if !s.updateCrashLoopState(ctx, service, resp, state) {
+			decision.Action = types.AutoscalerActionError
+			decision.Reason = "failed to update crash loop state"
+			return
+		}
+		if reason != "" {
+			_ = s.recordEvent(ctx, namespace, name,
+				types.InferenceEventTypeWarning, reason, message)
+		}
+	}
+
+	if expectedReplicas != totalReplicas {
+		logrus.Infof("Scaling inference %s to %d replicas", service, expectedReplicas)
+		// Add event to record the scale down operation
+		eventMessage := fmt.Sprintf("Deployment %d replicas always CrashLoopBackOff, system scale down the deployment replicas to %d", count, expectedReplicas)
+		decision.Action = types.AutoscalerActionScaleDown
+		decision.Reason = eventMessage
+		s.scale(ctx, service, namespace, name, expectedReplicas, eventMessage, &decision)
+	}
+}
+
+// recoverCrashLoop tries to recover the suppressed inference with exponential
+// backoff. The suppression is lifted once the instances keep running for
+// crashLoopStableDuration after a recovery attempt.
+func (s *Scaler) recoverCrashLoop(ctx context.Context, service string,
+	resp types.InferenceDeployment, decision *types.AutoscalerDecision) {
+	name, namespace := resp.Spec.Name, resp.Spec.Namespace
+	state := resp.Status.CrashLoop
+	now := time.Now()
+
+	if state.LastAttempt == nil {
+		if now.Before(state.NextAttempt) {
+			decision.Action = types.AutoscalerActionSkip
+			decision.Reason = fmt.Sprintf("suppressed because of CrashLoopBackOff since %s, next recovery attempt at %s",
+				state.Since.Format(time.RFC3339), state.NextAttempt.Format(time.RFC3339))
+			return
+		}
+
+		updated := *state
+		updated.Attempts++
+		updated.LastAttempt = &now
+		if !s.updateCrashLoopState(ctx, service, resp, &updated) {
+			decision.Action = types.AutoscalerActionError
+			decision.Reason = "failed to update crash loop state"
+			return
+		}
+
+		replicas := state.OriginalMinReplicas
+		if replicas < 1 {
+			replicas = 1
+		}
+		message := fmt.Sprintf("Recovery attempt %d, scaling the inference to %d replicas",
+			updated.Attempts, replicas)
+		_ = s.recordEvent(ctx, namespace, name, types.InferenceEventTypeNormal,
+			eventReasonCrashLoopRecoveryAttempt, message)
+
+		decision.ExpectedReplicas = replicas
+		decision.Reason = message
+		if replicas == resp.Status.Replicas {
+			decision.Action = types.AutoscalerActionNone
+			return
+		}
+		decision.Action = types.AutoscalerActionScaleUp
+		s.scale(ctx, service, namespace, name, replicas, message, decision)
+		return
+	}
+
+	elapsed := now.Sub(*state.LastAttempt)
+	switch {
+	case resp.Status.AvailableReplicas > 0 && elapsed >= crashLoopStableDuration:
+		if !s.updateCrashLoopState(ctx, service, resp, nil) {
+			decision.Action = types.AutoscalerActionError
+			decision.Reason = "failed to clear crash loop state"
+			return
+		}
+		message := fmt.Sprintf("Instances are healthy after %d recovery attempts, min replicas %d is restored",
+			state.Attempts, state.OriginalMinReplicas)
+		_ = s.recordEvent(ctx, namespace, name, types.InferenceEventTypeNormal,
+			eventReasonCrashLoopRecovered, message)
+		decision.Action = types.AutoscalerActionNone
+		decision.Reason = message
+	case resp.Status.AvailableReplicas == 0 && elapsed > crashLoopBackoff(state.Attempts):
+		// The instances never become available, give up this attempt.
+		updated := *state
+		updated.LastAttempt = nil
+		updated.NextAttempt = now.Add(crashLoopBackoff(state.Attempts))
+		if !s.updateCrashLoopState(ctx, service, resp, &updated) {
+			decision.Action = types.AutoscalerActionError
+			decision.Reason = "failed to update crash loop state"
+			return
+		}
+		message := fmt.Sprintf("Recovery attempt %d failed, no replicas are available after %s, next recovery attempt at %s",
+			state.Attempts, elapsed.Round(time.Second), updated.NextAttempt.Format(time.RFC3339))
+		_ = s.recordEvent(ctx, namespace, name, types.InferenceEventTypeWarning,
+			eventReasonCrashLoopRecoveryFailed, message)
+
+		decision.ExpectedReplicas = state.MinReplicas
+		decision.Reason = message
+		if state.MinReplicas == resp.Status.Replicas {
+			decision.Action = types.AutoscalerActionNone
+			return
+		}
+		decision.Action = types.AutoscalerActionScaleDown
+		s.scale(ctx, service, namespace, name, state.MinReplicas, message, decision)
+	default:
+		decision.Action = types.AutoscalerActionSkip
+		decision.Reason = fmt.Sprintf("recovery attempt %d in progress since %s",
+			state.Attempts, state.LastAttempt.Format(time.RFC3339))
+	}
+}
+
+// updateCrashLoopState records the crash loop state of the inference through
+// the agent, and updates the inference cache. The state is cleared if it is nil.
+func (s *Scaler) updateCrashLoopState(ctx context.Context, service string,
+	resp types.InferenceDeployment, state *types.CrashLoopState) bool {
+	if err := s.client.InferenceCrashLoopUpdate(ctx,
+		resp.Spec.Namespace, resp.Spec.Name, state); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"service": service,
+			"error":   err,
+		}).Error("failed to update crash loop state")
+		return false
+	}
+
+	resp.Status.CrashLoop = state
+	s.InferenceCache.Set(service, Inference{
+		Timestamp:  time.Now(),
+		Deployment: resp,
+	})
+	return true
+}
+
+// scale scales the inference and records the error in the decision.
+func (s *Scaler) scale(ctx context.Context, service, namespace, name string,
+	replicas int32, eventMessage string, decision *types.AutoscalerDecision) {
+	if err := s.client.InferenceScale(ctx,
+		namespace, name, int(replicas), eventMessage); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"service":  service,
+			"expected": replicas,
+			"error":    err,
+		}).Error("failed to scale inference")
+		metrics.ScaleErrors.WithLabelValues(service).Inc()
+		decision.Action = types.AutoscalerActionError
+		decision.Reason = fmt.Sprintf("failed to scale inference: %s", err)
+	}
+}
+
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
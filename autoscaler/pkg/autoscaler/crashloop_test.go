@@ -0,0 +1,237 @@
+package autoscaler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/client"
+)
+
+// fakeAgent records the requests sent to the agent.
+type fakeAgent struct {
+	mu       sync.Mutex
+	requests []string
+	bodies   map[string][]byte
+	// status is the status code of the requests, default is 200.
+	status map[string]int
+}
+
+func (f *fakeAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := r.Method + " " + r.URL.Path
+	f.requests = append(f.requests, key)
+	body, _ := io.ReadAll(r.Body)
+	f.bodies[key] = body
+	if status, ok := f.status[key]; ok {
+		w.WriteHeader(status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func newTestScaler(t *testing.T) (*Scaler, *fakeAgent) {
+	agent := &fakeAgent{
+		bodies: make(map[string][]byte),
+		status: make(map[string]int),
+	}
+	srv := httptest.NewServer(agent)
+	t.Cleanup(srv.Close)
+
+	c, err := client.NewClientWithOpts(client.WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return newScaler(c, nil, nil, newLoadCache(), newZeroCache(nil),
+		newInferenceCache()), agent
+}
+
+func TestCrashLoopBackoff(t *testing.T) {
+	tcs := []struct {
+		attempts int32
+		expect   time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{3, 8 * time.Minute},
+		{10, crashLoopBackoffMax},
+	}
+	for _, tc := range tcs {
+		if got := crashLoopBackoff(tc.attempts); got != tc.expect {
+			t.Errorf("attempts %d: expected %s, got %s", tc.attempts, tc.expect, got)
+		}
+	}
+}
+
+func TestRecoverCrashLoop(t *testing.T) {
+	now := time.Now()
+	lastAttempt := now.Add(-crashLoopStableDuration - time.Second)
+	tcs := []struct {
+		desc      string
+		state     types.CrashLoopState
+		available int32
+		action    types.AutoscalerAction
+		requests  []string
+	}{
+		{
+			desc: "wait for the next attempt",
+			state: types.CrashLoopState{
+				OriginalMinReplicas: 2,
+				NextAttempt:         now.Add(time.Minute),
+			},
+			action: types.AutoscalerActionSkip,
+		},
+		{
+			desc: "start a recovery attempt",
+			state: types.CrashLoopState{
+				OriginalMinReplicas: 2,
+				NextAttempt:         now.Add(-time.Second),
+			},
+			action: types.AutoscalerActionScaleUp,
+			requests: []string{
+				"PUT /system/inference/bert/crash-loop",
+				"POST /system/inference/bert/events",
+				"POST /system/scale-inference",
+			},
+		},
+		{
+			desc: "recovered",
+			state: types.CrashLoopState{
+				OriginalMinReplicas: 2,
+				Attempts:            1,
+				LastAttempt:         &lastAttempt,
+			},
+			available: 1,
+			action:    types.AutoscalerActionNone,
+			requests: []string{
+				"DELETE /system/inference/bert/crash-loop",
+				"POST /system/inference/bert/events",
+			},
+		},
+		{
+			desc: "attempt in progress",
+			state: types.CrashLoopState{
+				OriginalMinReplicas: 2,
+				Attempts:            3,
+				LastAttempt:         &lastAttempt,
+			},
+			action: types.AutoscalerActionSkip,
+		},
+	}
+	for _, tc := range tcs {
+		s, agent := newTestScaler(t)
+		state := tc.state
+		resp := types.InferenceDeployment{
+			Spec: types.InferenceDeploymentSpec{
+				Name:      "bert",
+				Namespace: "default",
+			},
+			Status: types.InferenceDeploymentStatus{
+				AvailableReplicas: tc.available,
+				CrashLoop:         &state,
+			},
+		}
+		decision := types.AutoscalerDecision{}
+		s.recoverCrashLoop(context.Background(), "bert.default", resp, &decision)
+
+		if decision.Action != tc.action {
+			t.Errorf("%s: expected action %s, got %s (%s)",
+				tc.desc, tc.action, decision.Action, decision.Reason)
+		}
+		if len(agent.requests) != len(tc.requests) {
+			t.Fatalf("%s: expected requests %v, got %v", tc.desc, tc.requests, agent.requests)
+		}
+		for i := range tc.requests {
+			if agent.requests[i] != tc.requests[i] {
+				t.Errorf("%s: expected request %s, got %s",
+					tc.desc, tc.requests[i], agent.requests[i])
+			}
+		}
+	}
+}
+
+func TestSuppressCrashLoopKeepsScalingConfig(t *testing.T) {
+	s, agent := newTestScaler(t)
+	minReplicas := int32(2)
+	s.InferenceCache.Set("bert.default", Inference{
+		Timestamp: time.Now(),
+		Deployment: types.InferenceDeployment{
+			Spec: types.InferenceDeploymentSpec{
+				Name:      "bert",
+				Namespace: "default",
+				Scaling: &types.ScalingConfig{
+					MinReplicas: &minReplicas,
+				},
+			},
+			Status: types.InferenceDeploymentStatus{
+				Replicas: 2,
+			},
+		},
+	})
+
+	s.suppressCrashLoop(context.Background(), "bert.default", 2)
+
+	for _, r := range agent.requests {
+		if r == "PUT /system/inferences" {
+			t.Errorf("the inference should not be updated")
+		}
+	}
+	state := types.CrashLoopState{}
+	if err := json.Unmarshal(
+		agent.bodies["PUT /system/inference/bert/crash-loop"], &state); err != nil {
+		t.Fatalf("failed to decode crash loop state: %v", err)
+	}
+	if state.OriginalMinReplicas != 2 || state.MinReplicas != 0 {
+		t.Errorf("unexpected crash loop state: %+v", state)
+	}
+	if minReplicas != 2 {
+		t.Errorf("min replicas should be kept, got %d", minReplicas)
+	}
+
+	decision, ok := s.Decisions.Get("bert.default")
+	if !ok || decision.Action != types.AutoscalerActionScaleDown {
+		t.Errorf("unexpected decision: %+v", decision)
+	}
+}
+
+func TestRecoverSuppressedEvictsStaleEntries(t *testing.T) {
+	s, agent := newTestScaler(t)
+	agent.status["GET /system/inference/bert"] = http.StatusNotFound
+	state := types.CrashLoopState{OriginalMinReplicas: 1}
+	// The cached inference is expired, thus it is fetched from the agent
+	// which returns not found since it is deleted.
+	s.InferenceCache.Set("bert.default", Inference{
+		Timestamp: time.Now().Add(-2 * inferenceCacheTTL),
+		Deployment: types.InferenceDeployment{
+			Status: types.InferenceDeploymentStatus{CrashLoop: &state},
+		},
+	})
+	s.InferenceCache.Set("gpt2.default", Inference{
+		Timestamp: time.Now().Add(-inferenceCacheEviction - time.Second),
+		Deployment: types.InferenceDeployment{
+			Status: types.InferenceDeploymentStatus{CrashLoop: &state},
+		},
+	})
+
+	s.recoverSuppressed(context.Background(), map[string]int{})
+	if len(agent.requests) != 1 || agent.requests[0] != "GET /system/inference/bert" {
+		t.Errorf("expected only the expired inference to be fetched, got %v", agent.requests)
+	}
+	if len(s.InferenceCache.inference) != 0 {
+		t.Errorf("expected the deleted and the stale inferences to be evicted, got %v",
+			s.InferenceCache.inference)
+	}
+
+	// The deleted inference is not fetched again in the next round.
+	s.recoverSuppressed(context.Background(), map[string]int{})
+	if len(agent.requests) != 1 {
+		t.Errorf("expected no more requests, got %v", agent.requests)
+	}
+}
@@ -0,0 +1,85 @@
+package autoscaler
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/validation"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/tensorchord/openmodelz/autoscaler/pkg/prom"
+)
+
+const eventReasonInvalidCustomMetric = "InvalidCustomMetric"
+
+// isCustomScaling returns true if the inference is scaled by a custom metric.
+func isCustomScaling(inf types.InferenceDeployment) bool {
+	return inf.Spec.Scaling != nil && inf.Spec.Scaling.Type != nil &&
+		*inf.Spec.Scaling.Type == types.ScalingTypeCustom
+}
+
+// customMetricValue evaluates the custom metric of the inference. It returns
+// the current value of the metric (the sum of all the returned series) and
+// the target value per replica.
+func (s *Scaler) customMetricValue(metric *types.CustomMetric,
+	name, namespace string) (float64, float64, error) {
+	if metric == nil {
+		return 0, 0, errors.New("custom metric is not specified")
+	}
+	if s.PromQuery == nil {
+		return 0, 0, errors.Newf("custom metric requires the %s metrics source",
+			MetricsSourcePrometheus)
+	}
+
+	qty, err := resource.ParseQuantity(string(metric.TargetValue))
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid target value %s", metric.TargetValue)
+	}
+	target := qty.AsApproximateFloat64()
+	if target <= 0 {
+		return 0, 0, errors.Newf("target value must be greater than 0, got %s",
+			metric.TargetValue)
+	}
+
+	query, err := validation.CustomMetricQuery(metric.Query, name, namespace)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid query template %s", metric.Query)
+	}
+
+	series, err := s.PromQuery.Query(query, time.Now())
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to query %s", query)
+	}
+	return sumLatestSamples(series), target, nil
+}
+
+// sumLatestSamples sums the latest sample of every series.
+func sumLatestSamples(series []*prom.TimeSeries) float64 {
+	res := 0.0
+	for _, ts := range series {
+		if ts == nil || len(ts.Samples) == 0 {
+			continue
+		}
+		res += ts.Samples[len(ts.Samples)-1].Value
+	}
+	return res
+}
+
+// reportCustomMetricError records a warning event on the inference. The
+// event is only recorded when the error changes, to avoid flooding the
+// events every autoscaling round.
+func (s *Scaler) reportCustomMetricError(ctx context.Context,
+	service, name, namespace string, err error) {
+	message := err.Error()
+	if s.customMetricErrors[service] == message {
+		return
+	}
+
+	if err := s.recordEvent(ctx, namespace, name, types.InferenceEventTypeWarning,
+		eventReasonInvalidCustomMetric, message); err != nil {
+		return
+	}
+	s.customMetricErrors[service] = message
+}
@@ -0,0 +1,66 @@
+package autoscaler
+
+import (
+	"testing"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+
+	"github.com/tensorchord/openmodelz/autoscaler/pkg/prom"
+)
+
+func TestSumLatestSamples(t *testing.T) {
+	series := []*prom.TimeSeries{
+		{Samples: []prom.Sample{{Value: 1, Timestamp: 1}, {Value: 3, Timestamp: 2}}},
+		{Samples: []prom.Sample{{Value: 2, Timestamp: 2}}},
+		{Samples: nil},
+		nil,
+	}
+	if got := sumLatestSamples(series); got != 5 {
+		t.Errorf("expected 5, got %v", got)
+	}
+}
+
+func TestCustomMetricValueInvalid(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		promQuery *prom.PrometheusQuery
+		metric    *types.CustomMetric
+	}{
+		{
+			desc:      "no metric",
+			promQuery: &prom.PrometheusQuery{},
+			metric:    nil,
+		},
+		{
+			desc:      "no prometheus",
+			promQuery: nil,
+			metric:    &types.CustomMetric{Query: "up", TargetValue: "1"},
+		},
+		{
+			desc:      "invalid target value",
+			promQuery: &prom.PrometheusQuery{},
+			metric:    &types.CustomMetric{Query: "up", TargetValue: "abc"},
+		},
+		{
+			desc:      "zero target value",
+			promQuery: &prom.PrometheusQuery{},
+			metric:    &types.CustomMetric{Query: "up", TargetValue: "0"},
+		},
+		{
+			desc:      "invalid template",
+			promQuery: &prom.PrometheusQuery{},
+			metric:    &types.CustomMetric{Query: `up{job="{{.Name"}`, TargetValue: "1"},
+		},
+		{
+			desc:      "unknown field",
+			promQuery: &prom.PrometheusQuery{},
+			metric:    &types.CustomMetric{Query: `up{job="{{.Image}}"}`, TargetValue: "1"},
+		},
+	}
+	for _, tc := range tcs {
+		s := &Scaler{PromQuery: tc.promQuery}
+		if _, _, err := s.customMetricValue(tc.metric, "bert", "default"); err == nil {
+			t.Errorf("%s: expected error", tc.desc)
+		}
+	}
+}
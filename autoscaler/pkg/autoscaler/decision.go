@@ -0,0 +1,71 @@
+package autoscaler
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+
+	"github.com/tensorchord/openmodelz/autoscaler/pkg/metrics"
+)
+
+// DecisionCache keeps the latest autoscaling decision of every inference.
+type DecisionCache struct {
+	mu        sync.RWMutex
+	decisions map[string]types.AutoscalerDecision
+}
+
+func newDecisionCache() *DecisionCache {
+	return &DecisionCache{
+		decisions: make(map[string]types.AutoscalerDecision),
+	}
+}
+
+// Set records the decision and updates the replicas metrics.
+func (d *DecisionCache) Set(key string, decision types.AutoscalerDecision) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.decisions[key] = decision
+
+	metrics.DesiredReplicas.WithLabelValues(key).Set(float64(decision.ExpectedReplicas))
+	metrics.ActualReplicas.WithLabelValues(key).Set(float64(decision.Replicas))
+}
+
+func (d *DecisionCache) Get(key string) (types.AutoscalerDecision, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	decision, ok := d.decisions[key]
+	return decision, ok
+}
+
+// List returns the decisions sorted by namespace and name.
+func (d *DecisionCache) List() []types.AutoscalerDecision {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	res := make([]types.AutoscalerDecision, 0, len(d.decisions))
+	for _, decision := range d.decisions {
+		res = append(res, decision)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Namespace != res[j].Namespace {
+			return res[i].Namespace < res[j].Namespace
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// Prune removes the decisions made before the given time, they belong to
+// inferences which are not seen by the autoscaler anymore.
+func (d *DecisionCache) Prune(before time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key, decision := range d.decisions {
+		if decision.Timestamp.Before(before) {
+			delete(d.decisions, key)
+			metrics.DeleteInference(key)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package autoscaler
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+const eventSource = "modelz-autoscaler"
+
+// recordEvent records a kubernetes event on the inference through the agent.
+func (s *Scaler) recordEvent(ctx context.Context, namespace, name,
+	eventType, reason, message string) error {
+	if err := s.client.InferenceEventCreate(ctx, namespace, name, types.InferenceEvent{
+		Type:    eventType,
+		Reason:  reason,
+		Message: message,
+		Source:  eventSource,
+	}); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"inference": name,
+			"namespace": namespace,
+			"reason":    reason,
+			"error":     err,
+		}).Error("failed to create inference event")
+		return err
+	}
+	return nil
+}
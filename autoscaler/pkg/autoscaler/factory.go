@@ -20,6 +20,19 @@ type Opt struct {
 	PrometheusPort   int
 
 	Interval time.Duration
+
+	// MetricsSource is the source of the load metrics, prometheus or agent.
+	MetricsSource string
+	// AgentMetricsURL is the URL of the agent metrics endpoint,
+	// used by the agent metrics source.
+	AgentMetricsURL string
+
+	// Capacity configures the capacity-aware scaling.
+	Capacity CapacityConfig
+
+	// ZeroCacheStore persists the zero cache. It is optional, the zero cache
+	// is kept in memory only if it is nil.
+	ZeroCacheStore ZeroCacheStore
 }
 
 func New(opt Opt) (*Scaler, error) {
@@ -37,8 +50,31 @@ func New(opt Opt) (*Scaler, error) {
 		return nil, errors.Wrap(err, "failed to create client")
 	}
 
-	prometheusQuery := prom.NewPrometheusQuery(opt.PrometheusHost, opt.PrometheusPort, &http.Client{})
+	var promQuery *prom.PrometheusQuery
+	var metricsSource MetricsSource
+	switch opt.MetricsSource {
+	case MetricsSourcePrometheus, "":
+		prometheusQuery := prom.NewPrometheusQuery(opt.PrometheusHost, opt.PrometheusPort, &http.Client{})
+		promQuery = &prometheusQuery
+		metricsSource = NewPrometheusSource(promQuery)
+	case MetricsSourceAgent:
+		if opt.AgentMetricsURL == "" {
+			return nil, errors.New("agent metrics url is required for the agent metrics source")
+		}
+		metricsSource = NewAgentSource(client, &http.Client{
+			Timeout: 10 * time.Second,
+		}, opt.AgentMetricsURL)
+	default:
+		return nil, errors.Newf("unknown metrics source %s", opt.MetricsSource)
+	}
+
+	if opt.Capacity.Enabled && opt.Capacity.Overcommit <= 0 {
+		return nil, errors.Newf("invalid capacity overcommit %f, it should be greater than 0",
+			opt.Capacity.Overcommit)
+	}
 
-	as := newScaler(client, &prometheusQuery, newLoadCache(), newInferenceCache())
+	as := newScaler(client, promQuery, metricsSource, newLoadCache(),
+		newZeroCache(opt.ZeroCacheStore), newInferenceCache())
+	as.Capacity = opt.Capacity
 	return as, nil
 }
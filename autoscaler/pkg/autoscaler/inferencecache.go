@@ -25,6 +25,10 @@ func (i *InferenceCache) Set(key string, inference Inference) {
 	i.inference[key] = inference
 }
 
+func (i *InferenceCache) Delete(key string) {
+	delete(i.inference, key)
+}
+
 func (i *InferenceCache) Get(key string, expireTime time.Duration) (types.InferenceDeployment, bool) {
 	inference, ok := i.inference[key]
 
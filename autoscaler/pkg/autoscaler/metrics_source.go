@@ -0,0 +1,39 @@
+package autoscaler
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// MetricsSourcePrometheus reads the load from the prometheus recording rules.
+	MetricsSourcePrometheus = "prometheus"
+	// MetricsSourceAgent scrapes the load from the agent metrics endpoint directly.
+	MetricsSourceAgent = "agent"
+
+	// restartWindow is the window to count the instance restarts.
+	restartWindow = 10 * time.Minute
+	// restartThreshold is the number of restarts in the window
+	// after which the instance is considered as restarting.
+	restartThreshold = 2
+)
+
+// MetricsSource provides the metrics used by the autoscaler.
+type MetricsSource interface {
+	// Load returns the current load of the inferences,
+	// keyed by the inference name (<name>.<namespace>).
+	Load(ctx context.Context) (map[string]Load, error)
+	// RestartedInstances returns the instances restarted more than
+	// restartThreshold times in the last restartWindow.
+	RestartedInstances(ctx context.Context) ([]RestartedInstance, error)
+}
+
+// RestartedInstance is an instance which keeps restarting.
+type RestartedInstance struct {
+	// Pod is the name of the instance.
+	Pod string
+	// InferenceName is the inference name (<name>.<namespace>).
+	InferenceName string
+	// Namespace is the namespace of the instance.
+	Namespace string
+}
@@ -0,0 +1,220 @@
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/sirupsen/logrus"
+	"github.com/tensorchord/openmodelz/agent/client"
+)
+
+const (
+	metricInflight     = "gateway_inference_invocation_inflight"
+	metricStarted      = "gateway_inference_invocation_started"
+	metricServiceCount = "gateway_service_count"
+	labelInferenceName = "inference_name"
+
+	// startedWindow is the window to count the started requests.
+	startedWindow = time.Minute
+)
+
+type sample struct {
+	timestamp time.Time
+	value     float64
+}
+
+// AgentSource scrapes the metrics endpoint of the agent directly, thus it
+// works without prometheus. The load is the number of inflight requests, and
+// the started requests are computed from the increase of the started counter
+// in startedWindow. The restart counts are read from the agent instance API.
+type AgentSource struct {
+	client     *client.Client
+	httpClient *http.Client
+	metricsURL string
+
+	mu sync.Mutex
+	// started is the history of the started counter, keyed by inference name.
+	started map[string][]sample
+	// restarts is the history of the restart count, keyed by namespace/pod.
+	restarts map[string][]sample
+}
+
+func NewAgentSource(c *client.Client, httpClient *http.Client, metricsURL string) *AgentSource {
+	return &AgentSource{
+		client:     c,
+		httpClient: httpClient,
+		metricsURL: metricsURL,
+		started:    make(map[string][]sample),
+		restarts:   make(map[string][]sample),
+	}
+}
+
+func (a *AgentSource) Load(ctx context.Context) (map[string]Load, error) {
+	families, err := a.scrape(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	loads := make(map[string]Load)
+	for name, value := range sumByInference(families[metricInflight]) {
+		loads[name] = Load{
+			CurrentLoad: value,
+			Timestamp:   now,
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	started := make(map[string][]sample)
+	for name, value := range sumByInference(families[metricStarted]) {
+		history := appendSample(a.started[name], sample{now, value}, startedWindow)
+		started[name] = history
+
+		l := loads[name]
+		l.CurrentStartedRequests = increase(history)
+		l.Timestamp = now
+		loads[name] = l
+	}
+	a.started = started
+
+	return loads, nil
+}
+
+func (a *AgentSource) RestartedInstances(ctx context.Context) ([]RestartedInstance, error) {
+	families, err := a.scrape(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := []RestartedInstance{}
+	restarts := make(map[string][]sample)
+	for inferenceName := range sumByInference(families[metricServiceCount]) {
+		strs := strings.Split(inferenceName, ".")
+		if len(strs) != 2 {
+			logrus.Infof("Invalid inference name: %s", inferenceName)
+			continue
+		}
+		name, namespace := strs[0], strs[1]
+
+		instances, err := a.client.InstanceList(ctx, namespace, name)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"service": inferenceName,
+				"error":   err,
+			}).Error("failed to get instance list")
+			continue
+		}
+
+		a.mu.Lock()
+		for _, instance := range instances {
+			key := fmt.Sprintf("%s/%s", namespace, instance.Spec.Name)
+			history := appendSample(a.restarts[key],
+				sample{now, float64(instance.Status.RestartCount)}, restartWindow)
+			restarts[key] = history
+
+			if increase(history) > restartThreshold {
+				res = append(res, RestartedInstance{
+					Pod:           instance.Spec.Name,
+					InferenceName: inferenceName,
+					Namespace:     namespace,
+				})
+			}
+		}
+		a.mu.Unlock()
+	}
+
+	a.mu.Lock()
+	a.restarts = restarts
+	a.mu.Unlock()
+	return res, nil
+}
+
+// scrape gets the metric families from the agent metrics endpoint.
+func (a *AgentSource) scrape(ctx context.Context) (map[string]*dto.MetricFamily, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.metricsURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to scrape agent metrics")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("unexpected status code from agent metrics want: %d, got: %d",
+			http.StatusOK, resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse agent metrics")
+	}
+	return families, nil
+}
+
+// sumByInference sums the gauge or counter values by the inference name.
+func sumByInference(family *dto.MetricFamily) map[string]float64 {
+	res := make(map[string]float64)
+	if family == nil {
+		return res
+	}
+
+	for _, m := range family.GetMetric() {
+		name := ""
+		for _, label := range m.GetLabel() {
+			if label.GetName() == labelInferenceName {
+				name = label.GetValue()
+			}
+		}
+		if name == "" {
+			continue
+		}
+
+		switch {
+		case m.GetGauge() != nil:
+			res[name] += m.GetGauge().GetValue()
+		case m.GetCounter() != nil:
+			res[name] += m.GetCounter().GetValue()
+		}
+	}
+	return res
+}
+
+// appendSample appends the sample to the history, and drops the samples
+// which are older than the window.
+func appendSample(history []sample, s sample, window time.Duration) []sample {
+	res := []sample{}
+	for _, h := range history {
+		if s.timestamp.Sub(h.timestamp) <= window {
+			res = append(res, h)
+		}
+	}
+	return append(res, s)
+}
+
+// increase returns the increase of the counter in the history,
+// it handles the counter resets like prometheus does.
+func increase(history []sample) float64 {
+	res := 0.0
+	for i := 1; i < len(history); i++ {
+		delta := history[i].value - history[i-1].value
+		if delta < 0 {
+			// The counter is reset.
+			delta = history[i].value
+		}
+		res += delta
+	}
+	return res
+}
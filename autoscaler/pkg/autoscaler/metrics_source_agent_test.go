@@ -0,0 +1,95 @@
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAgentSourceLoad(t *testing.T) {
+	started := 10
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `# TYPE gateway_inference_invocation_inflight gauge
+gateway_inference_invocation_inflight{inference_name="bert.modelz-default"} 3
+gateway_inference_invocation_inflight{inference_name="llm.modelz-default"} 0
+# TYPE gateway_inference_invocation_started counter
+gateway_inference_invocation_started{inference_name="bert.modelz-default"} %d
+gateway_inference_invocation_started{inference_name="llm.modelz-default"} 1
+`, started)
+	}))
+	defer srv.Close()
+
+	a := NewAgentSource(nil, srv.Client(), srv.URL)
+
+	loads, err := a.Load(context.Background())
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if got := loads["bert.modelz-default"].CurrentLoad; got != 3 {
+		t.Errorf("expected load 3, got %v", got)
+	}
+	if got := loads["bert.modelz-default"].CurrentStartedRequests; got != 0 {
+		t.Errorf("expected no started requests on the first scrape, got %v", got)
+	}
+
+	started = 15
+	loads, err = a.Load(context.Background())
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if got := loads["bert.modelz-default"].CurrentStartedRequests; got != 5 {
+		t.Errorf("expected 5 started requests, got %v", got)
+	}
+	if got := loads["llm.modelz-default"].CurrentStartedRequests; got != 0 {
+		t.Errorf("expected 0 started requests, got %v", got)
+	}
+}
+
+func TestIncrease(t *testing.T) {
+	now := time.Now()
+	tcs := []struct {
+		desc    string
+		history []sample
+		expect  float64
+	}{
+		{
+			desc:    "empty",
+			history: nil,
+			expect:  0,
+		},
+		{
+			desc: "monotonic",
+			history: []sample{
+				{now, 1}, {now.Add(time.Second), 3}, {now.Add(2 * time.Second), 4},
+			},
+			expect: 3,
+		},
+		{
+			desc: "counter reset",
+			history: []sample{
+				{now, 5}, {now.Add(time.Second), 2}, {now.Add(2 * time.Second), 4},
+			},
+			expect: 4,
+		},
+	}
+	for _, tc := range tcs {
+		if got := increase(tc.history); got != tc.expect {
+			t.Errorf("%s: expected %v, got %v", tc.desc, tc.expect, got)
+		}
+	}
+}
+
+func TestAppendSampleDropsExpired(t *testing.T) {
+	now := time.Now()
+	history := []sample{
+		{now.Add(-2 * time.Minute), 1},
+		{now.Add(-30 * time.Second), 2},
+	}
+	history = appendSample(history, sample{now, 3}, time.Minute)
+	if len(history) != 2 || history[0].value != 2 {
+		t.Errorf("unexpected history: %v", history)
+	}
+}
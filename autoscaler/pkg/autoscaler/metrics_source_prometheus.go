@@ -0,0 +1,128 @@
+package autoscaler
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/tensorchord/openmodelz/autoscaler/pkg/prom"
+)
+
+// PrometheusSource reads the metrics from the prometheus recording rules
+// job:inference_current_load:sum, job:inference_current_started:max_sum
+// and pod_restart_count_over_2_10m.
+type PrometheusSource struct {
+	PromQuery *prom.PrometheusQuery
+}
+
+func NewPrometheusSource(promQuery *prom.PrometheusQuery) *PrometheusSource {
+	return &PrometheusSource{
+		PromQuery: promQuery,
+	}
+}
+
+func (p *PrometheusSource) Load(ctx context.Context) (map[string]Load, error) {
+	loads := make(map[string]Load)
+
+	results, err := p.PromQuery.Fetch(url.QueryEscape("job:inference_current_load:sum"))
+	if err != nil {
+		// log the error but continue, the mixIn will correctly handle the empty results.
+		logrus.Infof("Error querying Prometheus: %s\n", err.Error())
+		results = &prom.VectorQueryResponse{}
+	}
+
+	currentSumResults, err := p.PromQuery.Fetch(
+		url.QueryEscape("job:inference_current_started:max_sum"))
+	if err != nil {
+		// log the error but continue, the mixIn will correctly handle the empty results.
+		logrus.Infof("Error querying Prometheus: %s\n", err.Error())
+		currentSumResults = &prom.VectorQueryResponse{}
+	}
+
+	for _, result := range results.Data.Result {
+		currentLoad := 0.0
+
+		switch val := result.Value[1].(type) {
+		case string:
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				logrus.Infof("add_metrics: unable to convert value %q for metric: %s", val, err)
+				continue
+			}
+			currentLoad = f
+		}
+
+		timestamp := time.Now()
+		switch val := result.Value[0].(type) {
+		case float64:
+			timestamp = time.Unix(int64(val), 0)
+		}
+
+		l := loads[result.Metric.InferenceName]
+		l.CurrentLoad = currentLoad
+		l.Timestamp = timestamp
+		loads[result.Metric.InferenceName] = l
+	}
+
+	for _, result := range currentSumResults.Data.Result {
+		currentSum := 0.0
+
+		switch val := result.Value[1].(type) {
+		case string:
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				logrus.Infof("add_metrics: unable to convert value %q for metric: %s", val, err)
+				continue
+			}
+			currentSum = f
+		}
+
+		timestamp := time.Now()
+		switch val := result.Value[0].(type) {
+		case float64:
+			timestamp = time.Unix(int64(val), 0)
+		}
+
+		l := loads[result.Metric.InferenceName]
+		l.CurrentStartedRequests = currentSum
+		l.Timestamp = timestamp
+		loads[result.Metric.InferenceName] = l
+	}
+
+	return loads, nil
+}
+
+func (p *PrometheusSource) RestartedInstances(ctx context.Context) ([]RestartedInstance, error) {
+	// record this rule in prometheus
+	// (sum by (pod,namespace) (increase(kube_pod_container_status_restarts_total{namespace=~"modelz-(.*)"}[10m])) > 2) * on (pod) group_left(inference_name) (label_join(label_replace(kube_pod_info{created_by_kind="ReplicaSet",namespace=~"modelz-(.*)"}, "inference", "$1", "created_by_name", "(.+)-.+"), "inference_name",".","inference","namespace"))
+	query := "pod_restart_count_over_2_10m"
+	tsList, err := p.PromQuery.Query(query, time.Now())
+	if err != nil {
+		logrus.Infof("Error querying Prometheus: %s\n", err.Error())
+		return nil, err
+	}
+
+	res := []RestartedInstance{}
+	for _, ts := range tsList {
+		instance := RestartedInstance{}
+		for _, label := range ts.Labels {
+			switch label.Name {
+			case "pod":
+				instance.Pod = label.Value
+			case "inference_name":
+				instance.InferenceName = label.Value
+			case "namespace":
+				instance.Namespace = label.Value
+			}
+		}
+		if len(ts.Samples) < 1 {
+			logrus.Infof("Sample not found for inference %s.", instance.InferenceName)
+			continue
+		}
+		res = append(res, instance)
+	}
+	return res, nil
+}
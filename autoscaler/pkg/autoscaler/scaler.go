@@ -4,423 +4,427 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/tensorchord/openmodelz/agent/api/types"
 	"github.com/tensorchord/openmodelz/agent/client"
 	"github.com/tensorchord/openmodelz/agent/pkg/scaling"
 
+	"github.com/tensorchord/openmodelz/autoscaler/pkg/metrics"
 	"github.com/tensorchord/openmodelz/autoscaler/pkg/prom"
 )
 
+const (
+	inferenceCacheTTL = 1 * time.Minute
+	// inferenceCacheEviction is the age of the cached inferences which are
+	// evicted. It is longer than the TTL, thus the suppressed inferences
+	// which are refreshed in every round are never evicted.
+	inferenceCacheEviction = 10 * time.Minute
+)
+
 type Scaler struct {
+	// PromQuery is nil if prometheus is not used.
 	PromQuery      *prom.PrometheusQuery
+	Metrics        MetricsSource
 	client         *client.Client
 	LoadCache      *LoadCache
-	ZeroCache      map[string]time.Time
+	ZeroCache      *ZeroCache
 	InferenceCache *InferenceCache
+	Decisions      *DecisionCache
+	Capacity       CapacityConfig
+
+	// customMetricErrors is the last reported custom metric error of
+	// every inference.
+	customMetricErrors map[string]string
+	// servers are listed once per autoscaling round.
+	servers []types.Server
 }
 
 func newScaler(c *client.Client,
 	promQuery *prom.PrometheusQuery,
+	metricsSource MetricsSource,
 	loadCache *LoadCache,
+	zeroCache *ZeroCache,
 	inferanceCache *InferenceCache) *Scaler {
 	return &Scaler{
 		client:         c,
 		PromQuery:      promQuery,
+		Metrics:        metricsSource,
 		LoadCache:      loadCache,
-		ZeroCache:      make(map[string]time.Time),
+		ZeroCache:      zeroCache,
 		InferenceCache: inferanceCache,
+		Decisions:      newDecisionCache(),
+
+		customMetricErrors: make(map[string]string),
 	}
 }
 
-func (s *Scaler) AutoScale(interval time.Duration) {
-	ticker := time.NewTicker(interval)
-	quit := make(chan struct{})
+// AutoScale scales the inferences every interval until the context is done.
+func (s *Scaler) AutoScale(ctx context.Context, interval time.Duration) {
+	// Restore the idle timers, they may be recorded by the previous leader.
+	if err := s.ZeroCache.Restore(ctx); err != nil {
+		logrus.WithError(err).Warn("failed to restore zero cache")
+	}
 
-	TTL := 1 * time.Minute
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			// Detect if the instance pod always restart,
-			// if pod restart count in 10 minutes before last update time is more than 2, will scale it down.
-			results, err := s.GetRestartMetrics()
-			if err != nil {
-				logrus.Info("Get Restart Metrics of inference Failed")
-				continue
-			}
-
-			inferenceCount := make(map[string]int)
-			for _, ts := range results {
-				labels := ts.Labels
-				podName, inferenceName, namespace := "", "", ""
-				for _, label := range labels {
-					switch label.Name {
-					case "pod":
-						podName = label.Value
-					case "inference_name":
-						inferenceName = label.Value
-					case "namespace":
-						namespace = label.Value
-					}
-				}
-				if len(ts.Samples) < 1 {
-					logrus.Infof("Sample not found for inference %s.", inferenceName)
-					continue
-				}
+			s.autoScale(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-				strs := strings.Split(inferenceName, ".")
-				if len(strs) != 2 {
-					logrus.Infof("Invalid inference name: %s", inferenceName)
-					continue
-				}
-				name := strs[0]
-				resp, err := s.client.InstanceList(context.TODO(), namespace, name)
-				if err != nil {
-					logrus.WithFields(logrus.Fields{
-						"service": inferenceName,
-						"error":   err,
-					}).Error("failed to get instance list")
-					continue
-				}
+// autoScale runs one round of autoscaling for all inferences.
+func (s *Scaler) autoScale(ctx context.Context) {
+	start := time.Now()
+	defer func() {
+		metrics.DecisionDuration.Observe(time.Since(start).Seconds())
+	}()
 
-				for _, instance := range resp {
-					if instance.Spec.Name == podName {
-						if instance.Status.Phase == "CrashLoopBackOff" {
-							inferenceCount[inferenceName] += 1
-						}
-					}
-				}
-			}
+	s.servers = nil
 
-			if len(inferenceCount) != 0 {
-				for inferenceName, count := range inferenceCount {
-					strs := strings.Split(inferenceName, ".")
-					if len(strs) != 2 {
-						logrus.Infof("Invalid inference name: %s", inferenceName)
-						continue
-					}
-					name := strs[0]
-					namespace := strs[1]
-
-					resp, ok := s.InferenceCache.Get(inferenceName, TTL)
-					if !ok {
-						resp, err = s.client.InferenceGet(context.TODO(), namespace, name)
-						if err != nil {
-							logrus.WithFields(logrus.Fields{
-								"service": inferenceName,
-								"error":   err,
-							}).Error("failed to get inference")
-							continue
-						}
-
-						// update inference cache
-						inference := Inference{
-							Timestamp:  time.Now(),
-							Deployment: resp,
-						}
-						s.InferenceCache.Set(inferenceName, inference)
-					}
-					// check if the instance already exists
-					var expectedReplicas int
-					totalReplicas := resp.Status.Replicas
-					if count > int(totalReplicas) {
-						expectedReplicas = 0
-					} else {
-						expectedReplicas = int(totalReplicas) - count
-					}
-
-					if expectedReplicas != int(totalReplicas) {
-						logrus.Infof("Scaling inference %s to %d replicas", inferenceName, expectedReplicas)
-						// Add event to record the scale down operation
-						eventMessage := fmt.Sprintf("Deployment %d replicas always CrashLoopBackOff, system scale down the deployment replicas to %d", count, expectedReplicas)
-						if err := s.client.InferenceScale(context.TODO(),
-							namespace, name, expectedReplicas, eventMessage); err != nil {
-							logrus.WithFields(logrus.Fields{
-								"service":  inferenceName,
-								"expected": expectedReplicas,
-								"error":    err,
-							}).Error("failed to scale inference")
-							continue
-						}
-
-						// update the inference, set minReplicas to expectedReplicas
-						if resp.Spec.Scaling.MinReplicas != nil &&
-							*resp.Spec.Scaling.MinReplicas > int32(expectedReplicas) {
-							resp.Status.EventMessage = fmt.Sprintf("Deployment %d replicas always CrashLoopBackOff, system scales down the replicas to %d, original min replicas is %d, reset it to %d",
-								count, expectedReplicas, resp.Spec.Scaling.MinReplicas,
-								expectedReplicas)
-							*resp.Spec.Scaling.MinReplicas = int32(expectedReplicas)
-							if _, err := s.client.DeploymentUpdate(context.TODO(), namespace, resp); err != nil {
-								logrus.WithFields(logrus.Fields{
-									"service":  inferenceName,
-									"expected": expectedReplicas,
-									"error":    err,
-								}).Error("failed to update inference")
-								continue
-							}
-						}
-					}
-				}
-			}
+	inferenceCount, err := s.crashLoopCount(ctx)
+	if err != nil {
+		logrus.Info("Get Restart Metrics of inference Failed")
+		return
+	}
+	for service, count := range inferenceCount {
+		s.suppressCrashLoop(ctx, service, count)
+	}
 
-			s.LoadCache = newLoadCache()
-			s.GetLoadMetrics()
+	s.LoadCache = newLoadCache()
+	loads, err := s.Metrics.Load(ctx)
+	if err != nil {
+		logrus.WithError(err).Info("failed to get load metrics")
+	}
+	for service, lc := range loads {
+		s.LoadCache.Set(service, lc)
+	}
 
-			for service, lc := range s.LoadCache.load {
-				// if instances of inference are restarting, do not scale it.
-				if value, ok := inferenceCount[service]; ok && value > 0 {
-					continue
-				}
-				strs := strings.Split(service, ".")
-				if len(strs) != 2 {
-					logrus.Infof("Invalid inference name: %s", service)
-					continue
-				}
-				name := strs[0]
-				namespace := strs[1]
-
-				resp, ok := s.InferenceCache.Get(service, TTL)
-				if !ok {
-					resp, err = s.client.InferenceGet(context.TODO(), namespace, name)
-					if err != nil {
-						logrus.WithFields(logrus.Fields{
-							"service": service,
-							"error":   err,
-						}).Error("failed to get inference")
-						continue
-					}
-
-					// update inference cache
-					inference := Inference{
-						Timestamp:  time.Now(),
-						Deployment: resp,
-					}
-					s.InferenceCache.Set(service, inference)
-				}
+	for service, lc := range s.LoadCache.load {
+		// if instances of inference are restarting, do not scale it.
+		if value, ok := inferenceCount[service]; ok && value > 0 {
+			continue
+		}
+		s.scaleInference(ctx, service, lc)
+	}
 
-				if resp.Spec.Labels == nil {
-					logrus.WithFields(logrus.Fields{
-						"service": service,
-						"error":   err,
-					}).Error("failed to get inference labels")
-					continue
-				}
+	// The suppressed inferences may have no load metrics since they have no
+	// replicas, try to recover them as well.
+	s.recoverSuppressed(ctx, inferenceCount)
 
-				var expectedReplicas int
-				var targetLoad int
-				// If the inference has a target load label, use that instead.
-				if resp.Spec.Scaling != nil && resp.Spec.Scaling.TargetLoad != nil {
-					targetLoad = int(*resp.Spec.Scaling.TargetLoad)
-					expectedReplicas = int(math.Ceil(
-						lc.CurrentLoad / float64(*resp.Spec.Scaling.TargetLoad)))
-				}
+	s.Decisions.Prune(start)
 
-				if expectedReplicas == 0 {
-					// Check the current start requests to see if the inference is being used.
-					if lc.CurrentStartedRequests > 0 {
-						logrus.WithFields(logrus.Fields{
-							"service":                  service,
-							"current_started_requests": lc.CurrentStartedRequests,
-							"target_load":              lc.CurrentLoad,
-						}).Debug("inference is being used")
-						expectedReplicas = 1
-					}
-				}
+	if err := s.ZeroCache.Flush(ctx); err != nil {
+		logrus.WithError(err).Warn("failed to persist zero cache")
+	}
+}
 
-				var maxReplicas, minReplicas int
-				var zeroDuration time.Duration
-				if resp.Spec.Scaling != nil {
-					if resp.Spec.Scaling.MinReplicas != nil {
-						minReplicas = int(*resp.Spec.Scaling.MinReplicas)
-					} else {
-						minReplicas = scaling.DefaultMinReplicas
-					}
-
-					if resp.Spec.Scaling.MaxReplicas != nil {
-						maxReplicas = int(*resp.Spec.Scaling.MaxReplicas)
-					} else {
-						maxReplicas = scaling.DefaultMaxReplicas
-					}
-
-					if resp.Spec.Scaling.ZeroDuration != nil {
-						zeroDuration = time.Duration(*resp.Spec.Scaling.ZeroDuration) * time.Second
-					} else {
-						zeroDuration = scaling.DefaultZeroDuration
-					}
-				}
+// recoverSuppressed tries to recover the suppressed inferences which are not
+// scaled by the load in this round. The stale entries of the inference cache
+// are evicted instead, e.g. the ones of the deleted inferences.
+func (s *Scaler) recoverSuppressed(ctx context.Context, inferenceCount map[string]int) {
+	for service, inf := range s.InferenceCache.inference {
+		if time.Since(inf.Timestamp) > inferenceCacheEviction {
+			s.InferenceCache.Delete(service)
+			continue
+		}
+		if _, ok := s.LoadCache.load[service]; ok {
+			continue
+		}
+		if _, ok := inferenceCount[service]; ok {
+			continue
+		}
+		if inf.Deployment.Status.CrashLoop != nil {
+			s.scaleInference(ctx, service, Load{})
+		}
+	}
+}
 
-				if expectedReplicas > maxReplicas {
-					logrus.Infof("Expected replicas (%d) exceeds max replicas (%d) for inference %s", expectedReplicas, maxReplicas, service)
-					expectedReplicas = maxReplicas
-				}
-				if expectedReplicas < minReplicas {
-					logrus.Infof("Expected replicas (%d) is less than min replicas (%d) for inference %s", expectedReplicas, minReplicas, service)
-					expectedReplicas = minReplicas
-				}
+// scaleInference scales the inference according to its load.
+func (s *Scaler) scaleInference(ctx context.Context, service string, lc Load) {
+	strs := strings.Split(service, ".")
+	if len(strs) != 2 {
+		logrus.Infof("Invalid inference name: %s", service)
+		return
+	}
+	name := strs[0]
+	namespace := strs[1]
+
+	decision := types.AutoscalerDecision{
+		Name:      name,
+		Namespace: namespace,
+		Timestamp: time.Now(),
+		Inputs: types.AutoscalerDecisionInputs{
+			CurrentLoad:            lc.CurrentLoad,
+			CurrentStartedRequests: lc.CurrentStartedRequests,
+		},
+		Action: types.AutoscalerActionNone,
+	}
+	defer func() {
+		s.Decisions.Set(service, decision)
+	}()
 
-				availableReplicas := resp.Status.AvailableReplicas
-				totalReplicas := resp.Status.Replicas
-
-				if expectedReplicas == int(totalReplicas) {
-					// If the expected replicas is the same as the current replicas, remove the entry from the zero cache.
-					delete(s.ZeroCache, service)
-					logrus.WithFields(logrus.Fields{
-						"service":          service,
-						"replicas":         totalReplicas,
-						"expectedReplicas": expectedReplicas,
-					}).Debug("delete zero cache")
-				}
+	resp, err := s.getInference(ctx, service, namespace, name)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"service": service,
+			"error":   err,
+		}).Error("failed to get inference")
+		decision.Action = types.AutoscalerActionError
+		decision.Reason = fmt.Sprintf("failed to get inference: %s", err)
+		return
+	}
 
-				if expectedReplicas == 0 && totalReplicas != 0 {
-					if availableReplicas == 0 {
-						// If the expected replicas is 0 and there are no available replicas,
-						// set the expected replicas to 1 to prevent the inference from being scaled to zero.
-						expectedReplicas = 1
-					} else {
-						// If the expected replicas is 0 and there is no entry in the zero cache, add one.
-						if _, ok := s.ZeroCache[service]; !ok {
-							s.ZeroCache[service] = time.Now()
-						}
-
-						// If the inference has been idle for longer than the zero duration, scale to zero.
-						if time.Since(s.ZeroCache[service]) > zeroDuration {
-							logrus.Infof("Inference %s has been idle for %s, scaling to zero", service, zeroDuration)
-						} else {
-							// If the inference has not been idle for longer than the zero duration, scale to 1.
-							expectedReplicas = 1
-						}
-					}
-				}
+	availableReplicas := resp.Status.AvailableReplicas
+	totalReplicas := resp.Status.Replicas
+	decision.Replicas = totalReplicas
+	decision.AvailableReplicas = availableReplicas
+	decision.ExpectedReplicas = totalReplicas
+
+	// The inference is suppressed since its instances kept crashing,
+	// do not scale it by load until it recovers.
+	if resp.Status.CrashLoop != nil {
+		s.recoverCrashLoop(ctx, service, resp, &decision)
+		return
+	}
 
-				if expectedReplicas == 1 && totalReplicas == 0 {
-					// If the expected replicas is 1 and the current replicas is 0, do nothing since the scaling handler in gateway will take care of this situation.
-					expectedReplicas = 0
-				}
+	if resp.Spec.Labels == nil {
+		logrus.WithFields(logrus.Fields{
+			"service": service,
+			"error":   err,
+		}).Error("failed to get inference labels")
+		decision.Action = types.AutoscalerActionError
+		decision.Reason = "failed to get inference labels"
+		return
+	}
 
-				logrus.WithFields(logrus.Fields{
-					"service":           service,
-					"replicas":          totalReplicas,
-					"expectedReplicas":  expectedReplicas,
-					"availableReplicas": availableReplicas,
-					"currentLoad":       lc.CurrentLoad,
-					"targetLoad":        targetLoad,
-					"zeroDuration":      zeroDuration,
-					"zeroCache":         s.ZeroCache[service],
-				}).Debug("start scaling (replicas)")
-
-				if expectedReplicas != int(totalReplicas) {
-					delete(s.ZeroCache, service)
-					logrus.Infof("Scaling inference %s to %d replicas", service, expectedReplicas)
-					eventMessage := fmt.Sprintf("Scaling inference based load, current %f, target %d",
-						lc.CurrentLoad, targetLoad)
-					if err := s.client.InferenceScale(context.TODO(),
-						namespace, name, expectedReplicas, eventMessage); err != nil {
-						logrus.WithFields(logrus.Fields{
-							"service":  service,
-							"expected": expectedReplicas,
-							"error":    err,
-						}).Error("failed to scale inference")
-						continue
-					}
-				}
-			}
-		case <-quit:
+	var expectedReplicas int
+	var targetLoad int
+	// If the inference has a target load label, use that instead.
+	if resp.Spec.Scaling != nil && resp.Spec.Scaling.TargetLoad != nil {
+		targetLoad = int(*resp.Spec.Scaling.TargetLoad)
+		expectedReplicas = int(math.Ceil(
+			lc.CurrentLoad / float64(*resp.Spec.Scaling.TargetLoad)))
+	}
+	reason := fmt.Sprintf("current load %.2f, target load %d", lc.CurrentLoad, targetLoad)
+	eventMessage := fmt.Sprintf("Scaling inference based load, current %f, target %d",
+		lc.CurrentLoad, targetLoad)
+
+	// If the inference is scaled by a custom metric, use it instead of the load.
+	if isCustomScaling(resp) {
+		value, target, err := s.customMetricValue(
+			resp.Spec.Scaling.CustomMetric, name, namespace)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"service": service,
+				"error":   err,
+			}).Error("failed to evaluate custom metric")
+			s.reportCustomMetricError(ctx, service, name, namespace, err)
+			decision.Action = types.AutoscalerActionError
+			decision.Reason = fmt.Sprintf("failed to evaluate custom metric: %s", err)
 			return
 		}
-	}
-}
-
-func (s *Scaler) GetLoadMetrics() {
-	results, err := s.PromQuery.Fetch(url.QueryEscape("job:inference_current_load:sum"))
-	if err != nil {
-		// log the error but continue, the mixIn will correctly handle the empty results.
-		logrus.Infof("Error querying Prometheus: %s\n", err.Error())
+		delete(s.customMetricErrors, service)
+
+		decision.Inputs.CustomMetricValue = value
+		decision.Inputs.CustomMetricTarget = target
+		expectedReplicas = int(math.Ceil(value / target))
+		reason = fmt.Sprintf("custom metric %.2f, target value %.2f", value, target)
+		eventMessage = fmt.Sprintf("Scaling inference based custom metric, current %f, target %f",
+			value, target)
 	}
 
-	currentSumResults, err := s.PromQuery.Fetch(
-		url.QueryEscape("job:inference_current_started:max_sum"))
-	if err != nil {
-		// log the error but continue, the mixIn will correctly handle the empty results.
-		logrus.Infof("Error querying Prometheus: %s\n", err.Error())
+	if expectedReplicas == 0 {
+		// Check the current start requests to see if the inference is being used.
+		if lc.CurrentStartedRequests > 0 {
+			logrus.WithFields(logrus.Fields{
+				"service":                  service,
+				"current_started_requests": lc.CurrentStartedRequests,
+				"target_load":              lc.CurrentLoad,
+			}).Debug("inference is being used")
+			expectedReplicas = 1
+			reason = fmt.Sprintf("inference is being used, current started requests %.2f",
+				lc.CurrentStartedRequests)
+		}
 	}
 
-	for _, result := range results.Data.Result {
-		currentLoad := 0.0
-
-		switch val := result.Value[1].(type) {
-		case string:
-			f, err := strconv.ParseFloat(val, 64)
-			if err != nil {
-				logrus.Infof("add_metrics: unable to convert value %q for metric: %s", val, err)
-				continue
-			}
-			currentLoad = f
+	var maxReplicas, minReplicas int
+	var zeroDuration time.Duration
+	if resp.Spec.Scaling != nil {
+		if resp.Spec.Scaling.MinReplicas != nil {
+			minReplicas = int(*resp.Spec.Scaling.MinReplicas)
+		} else {
+			minReplicas = scaling.DefaultMinReplicas
 		}
 
-		timestamp := time.Now()
-		switch val := result.Value[0].(type) {
-		case float64:
-			timestamp = time.Unix(int64(val), 0)
+		if resp.Spec.Scaling.MaxReplicas != nil {
+			maxReplicas = int(*resp.Spec.Scaling.MaxReplicas)
+		} else {
+			maxReplicas = scaling.DefaultMaxReplicas
 		}
 
-		if l, ok := s.LoadCache.Get(result.Metric.InferenceName); ok {
-			l.CurrentLoad = currentLoad
-			l.Timestamp = timestamp
-			s.LoadCache.Set(result.Metric.InferenceName, l)
+		if resp.Spec.Scaling.ZeroDuration != nil {
+			zeroDuration = time.Duration(*resp.Spec.Scaling.ZeroDuration) * time.Second
 		} else {
-			s.LoadCache.Set(result.Metric.InferenceName, Load{
-				CurrentLoad: currentLoad,
-				Timestamp:   timestamp,
-			})
+			zeroDuration = scaling.DefaultZeroDuration
 		}
 	}
+	decision.Inputs.TargetLoad = int32(targetLoad)
+	decision.Inputs.MinReplicas = int32(minReplicas)
+	decision.Inputs.MaxReplicas = int32(maxReplicas)
+	decision.Inputs.ZeroDuration = int32(zeroDuration.Seconds())
+
+	if expectedReplicas > maxReplicas {
+		logrus.Infof("Expected replicas (%d) exceeds max replicas (%d) for inference %s", expectedReplicas, maxReplicas, service)
+		reason = fmt.Sprintf("expected replicas %d exceeds max replicas %d", expectedReplicas, maxReplicas)
+		expectedReplicas = maxReplicas
+	}
+	if expectedReplicas < minReplicas {
+		logrus.Infof("Expected replicas (%d) is less than min replicas (%d) for inference %s", expectedReplicas, minReplicas, service)
+		reason = fmt.Sprintf("expected replicas %d is less than min replicas %d", expectedReplicas, minReplicas)
+		expectedReplicas = minReplicas
+	}
 
-	for _, result := range currentSumResults.Data.Result {
-		currentSum := 0.0
-
-		switch val := result.Value[1].(type) {
-		case string:
-			f, err := strconv.ParseFloat(val, 64)
+	if s.Capacity.Enabled {
+		var pendingReason string
+		if expectedReplicas > int(availableReplicas) {
+			capped, capReason, err := s.capReplicas(ctx, resp, expectedReplicas)
 			if err != nil {
-				logrus.Infof("add_metrics: unable to convert value %q for metric: %s", val, err)
-				continue
+				logrus.WithFields(logrus.Fields{
+					"service": service,
+					"error":   err,
+				}).Error("failed to get cluster capacity")
+			} else if capReason != "" {
+				logrus.Infof("Expected replicas (%d) is capped to %d for inference %s: %s",
+					expectedReplicas, capped, service, capReason)
+				pendingReason = capReason
+				reason = capReason
+				expectedReplicas = capped
+				if expectedReplicas < minReplicas {
+					// The agent does not scale the inference below its min replicas.
+					expectedReplicas = minReplicas
+				}
 			}
-			currentSum = f
 		}
+		s.updatePendingReason(ctx, service, resp, pendingReason)
+	}
+
+	if expectedReplicas == int(totalReplicas) {
+		// If the expected replicas is the same as the current replicas, remove the entry from the zero cache.
+		s.ZeroCache.Delete(service)
+		logrus.WithFields(logrus.Fields{
+			"service":          service,
+			"replicas":         totalReplicas,
+			"expectedReplicas": expectedReplicas,
+		}).Debug("delete zero cache")
+	}
+
+	if expectedReplicas == 0 && totalReplicas != 0 {
+		if availableReplicas == 0 {
+			// If the expected replicas is 0 and there are no available replicas,
+			// set the expected replicas to 1 to prevent the inference from being scaled to zero.
+			expectedReplicas = 1
+			reason = "no available replicas, keep one replica"
+		} else {
+			// If the expected replicas is 0 and there is no entry in the zero cache, add one.
+			idleSince, ok := s.ZeroCache.Get(service)
+			if !ok {
+				idleSince = time.Now()
+				s.ZeroCache.Set(service, idleSince)
+			}
 
-		timestamp := time.Now()
-		switch val := result.Value[0].(type) {
-		case float64:
-			timestamp = time.Unix(int64(val), 0)
+			// If the inference has been idle for longer than the zero duration, scale to zero.
+			if time.Since(idleSince) > zeroDuration {
+				logrus.Infof("Inference %s has been idle for %s, scaling to zero", service, zeroDuration)
+				reason = fmt.Sprintf("idle for more than %s, scaling to zero", zeroDuration)
+			} else {
+				// If the inference has not been idle for longer than the zero duration, scale to 1.
+				expectedReplicas = 1
+				reason = fmt.Sprintf("idle since %s, waiting for zero duration %s",
+					idleSince.Format(time.RFC3339), zeroDuration)
+			}
 		}
+	}
+
+	if expectedReplicas == 1 && totalReplicas == 0 {
+		// If the expected replicas is 1 and the current replicas is 0, do nothing since the scaling handler in gateway will take care of this situation.
+		expectedReplicas = 0
+		reason = "scaling from zero is handled by the gateway"
+	}
 
-		if l, ok := s.LoadCache.Get(result.Metric.InferenceName); ok {
-			l.CurrentStartedRequests = currentSum
-			l.Timestamp = timestamp
-			s.LoadCache.Set(result.Metric.InferenceName, l)
+	zeroCache, ok := s.ZeroCache.Get(service)
+	if ok {
+		decision.Inputs.IdleSince = &zeroCache
+	}
+	decision.ExpectedReplicas = int32(expectedReplicas)
+	decision.Reason = reason
+
+	logrus.WithFields(logrus.Fields{
+		"service":           service,
+		"replicas":          totalReplicas,
+		"expectedReplicas":  expectedReplicas,
+		"availableReplicas": availableReplicas,
+		"currentLoad":       lc.CurrentLoad,
+		"targetLoad":        targetLoad,
+		"zeroDuration":      zeroDuration,
+		"zeroCache":         zeroCache,
+	}).Debug("start scaling (replicas)")
+
+	if expectedReplicas != int(totalReplicas) {
+		if expectedReplicas > int(totalReplicas) {
+			decision.Action = types.AutoscalerActionScaleUp
 		} else {
-			s.LoadCache.Set(result.Metric.InferenceName, Load{
-				CurrentStartedRequests: currentSum,
-				Timestamp:              timestamp,
-			})
+			decision.Action = types.AutoscalerActionScaleDown
+		}
+
+		s.ZeroCache.Delete(service)
+		logrus.Infof("Scaling inference %s to %d replicas", service, expectedReplicas)
+		if err := s.client.InferenceScale(ctx,
+			namespace, name, expectedReplicas, eventMessage); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"service":  service,
+				"expected": expectedReplicas,
+				"error":    err,
+			}).Error("failed to scale inference")
+			metrics.ScaleErrors.WithLabelValues(service).Inc()
+			decision.Action = types.AutoscalerActionError
+			decision.Reason = fmt.Sprintf("failed to scale inference: %s", err)
+			return
 		}
 	}
 }
 
-func (s *Scaler) GetRestartMetrics() ([]*prom.TimeSeries, error) {
-	// record this rule in prometheus
-	// (sum by (pod,namespace) (increase(kube_pod_container_status_restarts_total{namespace=~"modelz-(.*)"}[10m])) > 2) * on (pod) group_left(inference_name) (label_join(label_replace(kube_pod_info{created_by_kind="ReplicaSet",namespace=~"modelz-(.*)"}, "inference", "$1", "created_by_name", "(.+)-.+"), "inference_name",".","inference","namespace"))
-	query := "pod_restart_count_over_2_10m"
-	tsList, err := s.PromQuery.Query(query, time.Now())
+// getInference gets the inference from the cache, or from the agent if it
+// is not cached or expired.
+func (s *Scaler) getInference(ctx context.Context,
+	service, namespace, name string) (types.InferenceDeployment, error) {
+	resp, ok := s.InferenceCache.Get(service, inferenceCacheTTL)
+	if ok {
+		return resp, nil
+	}
+
+	resp, err := s.client.InferenceGet(ctx, namespace, name)
 	if err != nil {
-		logrus.Infof("Error querying Prometheus: %s\n", err.Error())
-		return nil, err
+		// The inference is deleted, it should not be recovered anymore.
+		if client.IsErrNotFound(err) {
+			s.InferenceCache.Delete(service)
+		}
+		return types.InferenceDeployment{}, err
 	}
 
-	return tsList, nil
+	// update inference cache
+	inference := Inference{
+		Timestamp:  time.Now(),
+		Deployment: resp,
+	}
+	s.InferenceCache.Set(service, inference)
+	return resp, nil
 }
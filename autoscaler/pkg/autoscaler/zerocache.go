@@ -0,0 +1,104 @@
+package autoscaler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ZeroCacheStore persists the zero cache so that the idle timers survive
+// autoscaler restarts and leader failover.
+type ZeroCacheStore interface {
+	Load(ctx context.Context) (map[string]time.Time, error)
+	Save(ctx context.Context, zero map[string]time.Time) error
+}
+
+// ZeroCache records the time since which an inference has been idle. It is
+// used to decide when an inference can be scaled to zero.
+type ZeroCache struct {
+	mu    sync.RWMutex
+	zero  map[string]time.Time
+	dirty bool
+
+	store ZeroCacheStore
+}
+
+func newZeroCache(store ZeroCacheStore) *ZeroCache {
+	return &ZeroCache{
+		zero:  make(map[string]time.Time),
+		store: store,
+	}
+}
+
+func (z *ZeroCache) Get(key string) (time.Time, bool) {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+	t, ok := z.zero[key]
+	return t, ok
+}
+
+func (z *ZeroCache) Set(key string, t time.Time) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.zero[key] = t
+	z.dirty = true
+}
+
+func (z *ZeroCache) Delete(key string) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if _, ok := z.zero[key]; !ok {
+		return
+	}
+	delete(z.zero, key)
+	z.dirty = true
+}
+
+// Restore replaces the cache content with the persisted one.
+// It is a no-op if there is no store configured.
+func (z *ZeroCache) Restore(ctx context.Context) error {
+	if z.store == nil {
+		return nil
+	}
+
+	zero, err := z.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.zero = make(map[string]time.Time, len(zero))
+	for k, v := range zero {
+		z.zero[k] = v
+	}
+	z.dirty = false
+	return nil
+}
+
+// Flush persists the cache if it changed since the last flush.
+func (z *ZeroCache) Flush(ctx context.Context) error {
+	if z.store == nil {
+		return nil
+	}
+
+	z.mu.Lock()
+	if !z.dirty {
+		z.mu.Unlock()
+		return nil
+	}
+	zero := make(map[string]time.Time, len(z.zero))
+	for k, v := range z.zero {
+		zero[k] = v
+	}
+	z.dirty = false
+	z.mu.Unlock()
+
+	if err := z.store.Save(ctx, zero); err != nil {
+		z.mu.Lock()
+		z.dirty = true
+		z.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package autoscaler
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ConfigMapStore persists the zero cache of the autoscaler in a ConfigMap,
+// so that a new leader continues the scale-to-zero timers of the previous one.
+// The keys are the inference names (<name>.<namespace>) and the values are
+// RFC3339 timestamps.
+type ConfigMapStore struct {
+	client    kubernetes.Interface
+	name      string
+	namespace string
+}
+
+func NewConfigMapStore(client kubernetes.Interface, namespace, name string) *ConfigMapStore {
+	return &ConfigMapStore{
+		client:    client,
+		name:      name,
+		namespace: namespace,
+	}
+}
+
+// Load returns the persisted zero cache, it is empty if the ConfigMap
+// does not exist yet.
+func (s *ConfigMapStore) Load(ctx context.Context) (map[string]time.Time, error) {
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(
+		ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return map[string]time.Time{}, nil
+		}
+		return nil, errors.Wrap(err, "failed to get zero cache configmap")
+	}
+
+	zero := make(map[string]time.Time, len(cm.Data))
+	for k, v := range cm.Data {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"service": k,
+				"value":   v,
+			}).Warn("invalid timestamp in zero cache, ignored")
+			continue
+		}
+		zero[k] = t
+	}
+	return zero, nil
+}
+
+// Save replaces the persisted zero cache.
+func (s *ConfigMapStore) Save(ctx context.Context, zero map[string]time.Time) error {
+	data := make(map[string]string, len(zero))
+	for k, v := range zero {
+		data[k] = v.UTC().Format(time.RFC3339)
+	}
+
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(
+		ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return errors.Wrap(err, "failed to get zero cache configmap")
+		}
+
+		_, err = s.client.CoreV1().ConfigMaps(s.namespace).Create(ctx,
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      s.name,
+					Namespace: s.namespace,
+				},
+				Data: data,
+			}, metav1.CreateOptions{})
+		if err != nil {
+			return errors.Wrap(err, "failed to create zero cache configmap")
+		}
+		return nil
+	}
+
+	cm.Data = data
+	if _, err := s.client.CoreV1().ConfigMaps(s.namespace).Update(
+		ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to update zero cache configmap")
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package autoscaler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMapStore(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	s := NewConfigMapStore(client, "default", "modelz-autoscaler-state")
+	ctx := context.Background()
+
+	zero, err := s.Load(ctx)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if len(zero) != 0 {
+		t.Errorf("expected empty zero cache, got %v", zero)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	for i := 0; i < 2; i++ {
+		// The first save creates the configmap, the second one updates it.
+		if err := s.Save(ctx, map[string]time.Time{
+			"bert.modelz-default": now,
+		}); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+	}
+
+	zero, err = s.Load(ctx)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if got := zero["bert.modelz-default"]; !got.Equal(now) {
+		t.Errorf("expected %v, got %v", now, got)
+	}
+}
@@ -5,15 +5,22 @@
 package autoscalerapp
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/sirupsen/logrus"
 	cli "github.com/urfave/cli/v2"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/tensorchord/openmodelz/autoscaler/pkg/autoscaler"
 	"github.com/tensorchord/openmodelz/autoscaler/pkg/server"
 	"github.com/tensorchord/openmodelz/autoscaler/pkg/version"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/election"
 )
 
 type EnvdServerApp struct {
@@ -67,6 +74,19 @@ func New() EnvdServerApp {
 			EnvVars: []string{"MODELZ_SECRET_PATH"},
 			Aliases: []string{"sp"},
 		},
+		&cli.StringFlag{
+			Name:    "metrics-source",
+			Usage:   "source of the load metrics, prometheus or agent. The agent source scrapes the agent metrics endpoint directly, thus prometheus is not required",
+			Value:   autoscaler.MetricsSourcePrometheus,
+			EnvVars: []string{"MODELZ_METRICS_SOURCE"},
+			Aliases: []string{"ms"},
+		},
+		&cli.StringFlag{
+			Name:    "agent-metrics-url",
+			Usage:   "URL of the agent metrics endpoint, used by the agent metrics source",
+			EnvVars: []string{"MODELZ_AGENT_METRICS_URL"},
+			Aliases: []string{"amu"},
+		},
 		&cli.DurationFlag{
 			Name:    "interval",
 			Usage:   "interval for autoscaling",
@@ -74,7 +94,44 @@ func New() EnvdServerApp {
 			EnvVars: []string{"MODELZ_INTERVAL"},
 			Aliases: []string{"i"},
 		},
+		&cli.StringFlag{
+			Name:    "master-url",
+			Usage:   "URL to master for kubernetes cluster",
+			EnvVars: []string{"MODELZ_MASTER_URL"},
+			Aliases: []string{"mu"},
+		},
+		&cli.StringFlag{
+			Name:    "kube-config",
+			Usage:   "Path to kubeconfig file. If not provided, will use in-cluster config",
+			EnvVars: []string{"MODELZ_KUBE_CONFIG"},
+			Aliases: []string{"kc"},
+		},
+		&cli.BoolFlag{
+			Name:    "capacity-aware",
+			Usage:   "cap the expected replicas at what can be scheduled in the cluster",
+			Value:   false,
+			EnvVars: []string{"MODELZ_CAPACITY_AWARE"},
+		},
+		&cli.Float64Flag{
+			Name:    "capacity-overcommit",
+			Usage:   "ratio applied to the allocatable CPU and memory of the servers when capacity-aware scaling is enabled",
+			Value:   1,
+			EnvVars: []string{"MODELZ_CAPACITY_OVERCOMMIT"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "namespace-gpu-budget",
+			Usage:   "max number of GPUs used by the inferences of a namespace, in the format of <namespace>=<gpus>",
+			EnvVars: []string{"MODELZ_NAMESPACE_GPU_BUDGET"},
+		},
+		&cli.StringFlag{
+			Name:    "zero-cache-configmap",
+			Usage:   "name of the configmap in the namespace of the lease to persist the scale-to-zero timers, used when leader election is enabled",
+			Value:   "modelz-autoscaler-state",
+			EnvVars: []string{"MODELZ_ZERO_CACHE_CONFIGMAP"},
+		},
 	}
+	internalApp.Flags = append(internalApp.Flags,
+		election.Flags("MODELZ", "modelz-autoscaler")...)
 	internalApp.Action = runServer
 
 	// Deal with debug flag.
@@ -106,6 +163,43 @@ func runServer(clicontext *cli.Context) error {
 		SecretPath:       clicontext.Path("secret-path"),
 		PrometheusPort:   clicontext.Int("prometheus-port"),
 		Interval:         clicontext.Duration("interval"),
+		MetricsSource:    clicontext.String("metrics-source"),
+		AgentMetricsURL:  clicontext.String("agent-metrics-url"),
+		Capacity: autoscaler.CapacityConfig{
+			Enabled:    clicontext.Bool("capacity-aware"),
+			Overcommit: clicontext.Float64("capacity-overcommit"),
+		},
+	}
+
+	budgets, err := autoscaler.ParseNamespaceGPUBudgets(
+		clicontext.StringSlice("namespace-gpu-budget"))
+	if err != nil {
+		return err
+	}
+	opt.Capacity.NamespaceGPUBudgets = budgets
+
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var elector *election.Elector
+	if cfg := election.ConfigFromCLI(clicontext); cfg.Enabled {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		kubeClient, err := newKubeClient(clicontext)
+		if err != nil {
+			return err
+		}
+
+		elector, err = election.New(kubeClient, cfg)
+		if err != nil {
+			return errors.Wrap(err, "failed to create leader elector")
+		}
+
+		opt.ZeroCacheStore = autoscaler.NewConfigMapStore(kubeClient,
+			cfg.LeaseNamespace, clicontext.String("zero-cache-configmap"))
 	}
 
 	as, err := autoscaler.New(opt)
@@ -114,9 +208,31 @@ func runServer(clicontext *cli.Context) error {
 	}
 
 	logrus.Info("starting system info server")
-	go server.RunInfoServe()
+	go server.RunInfoServe(elector, as.Decisions)
+
+	if elector == nil {
+		logrus.Info("starting autoscaler")
+		as.AutoScale(ctx, opt.Interval)
+		return nil
+	}
 
-	logrus.Info("starting autoscaler")
-	as.AutoScale(opt.Interval)
-	return nil
+	logrus.Info("starting leader election")
+	return elector.Run(ctx, func(ctx context.Context) {
+		logrus.Info("starting autoscaler")
+		as.AutoScale(ctx, opt.Interval)
+	})
+}
+
+func newKubeClient(clicontext *cli.Context) (kubernetes.Interface, error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags(
+		clicontext.String("master-url"), clicontext.String("kube-config"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build kubeconfig")
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build kubernetes clientset")
+	}
+	return kubeClient, nil
 }
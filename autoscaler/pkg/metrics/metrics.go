@@ -0,0 +1,71 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// DesiredReplicas is the number of replicas computed by the autoscaler.
+	DesiredReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "autoscaler",
+			Name:      "desired_replicas",
+			Help:      "Number of replicas computed by the autoscaler for inference",
+		},
+		[]string{"inference_name"},
+	)
+
+	// ActualReplicas is the number of replicas when the decision is made.
+	ActualReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "autoscaler",
+			Name:      "actual_replicas",
+			Help:      "Current count of replicas for inference seen by the autoscaler",
+		},
+		[]string{"inference_name"},
+	)
+
+	// DecisionDuration is the time taken by one autoscaling round.
+	DecisionDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "autoscaler",
+			Name:      "decision_duration_seconds",
+			Help:      "Time taken to compute and apply the decisions of all inferences",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+	)
+
+	// ScaleErrors counts the failed calls to the scale API.
+	ScaleErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "autoscaler",
+			Name:      "scale_errors_total",
+			Help:      "Count of failed scale API calls for inference",
+		},
+		[]string{"inference_name"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(DesiredReplicas, ActualReplicas,
+		DecisionDuration, ScaleErrors)
+}
+
+// DeleteInference removes the series of the inference.
+func DeleteInference(inferenceName string) {
+	DesiredReplicas.DeleteLabelValues(inferenceName)
+	ActualReplicas.DeleteLabelValues(inferenceName)
+	ScaleErrors.DeleteLabelValues(inferenceName)
+}
+
+// PrometheusHandler returns the handler to expose the metrics.
+func PrometheusHandler() http.Handler {
+	return promhttp.Handler()
+}
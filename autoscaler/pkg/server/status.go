@@ -11,11 +11,33 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/tensorchord/openmodelz/agent/api/types"
+
+	"github.com/tensorchord/openmodelz/autoscaler/pkg/metrics"
 	"github.com/tensorchord/openmodelz/autoscaler/pkg/version"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/election"
 )
 
-func getInfo(w http.ResponseWriter, r *http.Request) {
-	scalerInfo := map[string]string{"version": version.GetEnvdVersion()}
+// DecisionLister lists the latest autoscaling decisions.
+type DecisionLister interface {
+	List() []types.AutoscalerDecision
+}
+
+type info struct {
+	Version        string          `json:"version"`
+	LeaderElection election.Status `json:"leader_election"`
+}
+
+type server struct {
+	elector   *election.Elector
+	decisions DecisionLister
+}
+
+func (s server) getInfo(w http.ResponseWriter, r *http.Request) {
+	scalerInfo := info{
+		Version:        version.GetEnvdVersion(),
+		LeaderElection: s.elector.Status(),
+	}
 	jsonOut, marshalErr := json.Marshal(scalerInfo)
 	if marshalErr != nil {
 		logrus.Infof("Error during unmarshal of autoscaler info request %s\n", marshalErr.Error())
@@ -28,18 +50,37 @@ func getInfo(w http.ResponseWriter, r *http.Request) {
 	w.Write(jsonOut)
 }
 
-func RunInfoServe() {
+func (s server) getDecisions(w http.ResponseWriter, r *http.Request) {
+	jsonOut, marshalErr := json.Marshal(s.decisions.List())
+	if marshalErr != nil {
+		logrus.Infof("Error during marshal of autoscaler decisions %s\n", marshalErr.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonOut)
+}
+
+// RunInfoServe serves the autoscaler info, including the leadership status,
+// the latest decisions and the prometheus metrics.
+// The elector could be nil if the leader election is disabled.
+func RunInfoServe(elector *election.Elector, decisions DecisionLister) {
 	tcpPort := 8080
 
+	s := server{elector: elector, decisions: decisions}
 	serverMux := http.NewServeMux()
-	serverMux.HandleFunc("/system/info", getInfo)
+	serverMux.HandleFunc("/system/info", s.getInfo)
+	serverMux.HandleFunc("/system/decisions", s.getDecisions)
+	serverMux.Handle("/metrics", metrics.PrometheusHandler())
 
-	s := &http.Server{
+	srv := &http.Server{
 		Addr:           fmt.Sprintf(":%d", tcpPort),
 		ReadTimeout:    10 * time.Second,
 		WriteTimeout:   10 * time.Second,
 		MaxHeaderBytes: http.DefaultMaxHeaderBytes, // 1MB - can be overridden by setting Server.MaxHeaderBytes.
 		Handler:        serverMux,
 	}
-	s.ListenAndServe()
+	srv.ListenAndServe()
 }
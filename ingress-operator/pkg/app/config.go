@@ -4,6 +4,7 @@ import (
 	cli "github.com/urfave/cli/v2"
 
 	"github.com/tensorchord/openmodelz/ingress-operator/pkg/config"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/election"
 )
 
 func configFromCLI(c *cli.Context) config.Config {
@@ -20,5 +21,11 @@ func configFromCLI(c *cli.Context) config.Config {
 	cfg.Controller.ThreadCount = c.Int(flagControllerThreads)
 	cfg.Controller.Namespace = c.String(flagNamespace)
 	cfg.Controller.Host = c.String(flagHost)
+
+	// metrics
+	cfg.Metrics.ServerPort = c.Int(flagMetricsServerPort)
+
+	// leader election
+	cfg.LeaderElection = election.ConfigFromCLI(c)
 	return cfg
 }
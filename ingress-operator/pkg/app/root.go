@@ -15,6 +15,8 @@ import (
 	controller "github.com/tensorchord/openmodelz/ingress-operator/pkg/controller/v1"
 	"github.com/tensorchord/openmodelz/ingress-operator/pkg/signals"
 	"github.com/tensorchord/openmodelz/ingress-operator/pkg/version"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/election"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/metrics"
 )
 
 const (
@@ -31,6 +33,9 @@ const (
 	flagControllerThreads = "controller-thread-count"
 	flagNamespace         = "namespace"
 	flagHost              = "host"
+
+	// metrics
+	flagMetricsServerPort = "metrics-server-port"
 )
 
 type App struct {
@@ -104,7 +109,15 @@ func New() App {
 			Usage:   "Host to redirect the request to. (apiserver, agent)",
 			EnvVars: []string{"MODELZ_HOST"},
 		},
+		&cli.IntFlag{
+			Name:    flagMetricsServerPort,
+			Value:   8081,
+			Usage:   "port to serve the metrics, /healthz and /readyz on",
+			EnvVars: []string{"MODELZ_METRICS_SERVER_PORT"},
+		},
 	}
+	internalApp.Flags = append(internalApp.Flags,
+		election.Flags("MODELZ", "ingress-operator")...)
 	internalApp.Action = runServer
 
 	// Deal with debug flag.
@@ -146,10 +159,31 @@ func runServer(clicontext *cli.Context) error {
 	// set up signals so we handle the first shutdown signal gracefully
 	stopCh := signals.SetupSignalHandler()
 
-	s, err := controller.New(c, stopCh)
+	metricsServer := metrics.NewServer(c.Metrics.ServerPort)
+	go func() {
+		if err := metricsServer.Run(stopCh); err != nil {
+			logrus.Fatalf("failed to run metrics server: %v", err)
+		}
+	}()
+
+	s, err := controller.New(c, metricsServer, stopCh)
 	if err != nil {
 		return errors.Wrap(err, "failed to create server")
 	}
 
-	return s.Run(c.Controller.ThreadCount, stopCh)
+	if !c.LeaderElection.Enabled {
+		return s.Run(c.Controller.ThreadCount, stopCh)
+	}
+
+	// The informers are started by all the replicas, the standby takes
+	// over with the warm caches.
+	elector, err := election.NewFromKubeConfig(
+		c.KubeConfig.MasterURL, c.KubeConfig.Kubeconfig, c.LeaderElection)
+	if err != nil {
+		return errors.Wrap(err, "failed to create leader elector")
+	}
+	logrus.Info("starting leader election")
+	return elector.RunOnce(stopCh, func(stopCh <-chan struct{}) error {
+		return s.Run(c.Controller.ThreadCount, stopCh)
+	})
 }
@@ -4,11 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/election"
 )
 
 type Config struct {
-	KubeConfig KubeConfig       `json:"kube_config,omitempty"`
-	Controller ControllerConfig `json:"controller,omitempty"`
+	KubeConfig     KubeConfig       `json:"kube_config,omitempty"`
+	Controller     ControllerConfig `json:"controller,omitempty"`
+	Metrics        MetricsConfig    `json:"metrics,omitempty"`
+	LeaderElection election.Config  `json:"leader_election,omitempty"`
 }
 
 type ControllerConfig struct {
@@ -17,6 +21,10 @@ type ControllerConfig struct {
 	Host        string `json:"host,omitempty"`
 }
 
+type MetricsConfig struct {
+	ServerPort int `json:"server_port,omitempty"`
+}
+
 type KubeConfig struct {
 	Kubeconfig   string        `json:"kubeconfig,omitempty"`
 	MasterURL    string        `json:"master_url,omitempty"`
@@ -46,5 +54,13 @@ func (c Config) Validate() error {
 		return errors.New("invalid controller config")
 	}
 
+	if err := c.LeaderElection.Validate(); err != nil {
+		return err
+	}
+
+	if c.Metrics.ServerPort <= 0 {
+		return errors.New("invalid metrics config")
+	}
+
 	return nil
 }
@@ -27,6 +27,7 @@ import (
 	v1 "github.com/tensorchord/openmodelz/ingress-operator/pkg/client/informers/externalversions/modelzetes/v1"
 	listers "github.com/tensorchord/openmodelz/ingress-operator/pkg/client/listers/modelzetes/v1"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/metrics"
 )
 
 const AgentName = "ingress-operator"
@@ -45,6 +46,12 @@ const (
 	// MessageResourceSynced is the message used for an Event fired when a Function
 	// is synced successfully
 	MessageResourceSynced = "FunctionIngress synced successfully"
+
+	ReasonIngressCreated = "IngressCreated"
+	ReasonIngressUpdated = "IngressUpdated"
+	// ReasonSyncFailed is used as part of the Event 'reason' when the ingress
+	// cannot be created or updated, the fni is requeued with the backoff.
+	ReasonSyncFailed = "SyncFailed"
 )
 
 // BaseController is the controller contains the common function ingress
@@ -61,6 +68,9 @@ type BaseController struct {
 	Workqueue workqueue.RateLimitingInterface
 
 	SyncHandler func(ctx context.Context, key string) error
+
+	// Pending tracks the fni resources which are not synced yet.
+	Pending *metrics.Pending
 }
 
 func (c BaseController) Run(threadiness int, stopCh <-chan struct{}) error {
@@ -77,6 +87,7 @@ func (c BaseController) Run(threadiness int, stopCh <-chan struct{}) error {
 	}
 
 	klog.Info("Starting workers")
+	c.Pending.Start()
 	// Launch two workers to process Function resources
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker(ctx), time.Second, stopCh)
@@ -116,9 +127,15 @@ func (c BaseController) processNextWorkItem(ctx context.Context) bool {
 			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
 			return nil
 		}
-		if err := c.SyncHandler(ctx, key); err != nil {
+		start := time.Now()
+		err := c.SyncHandler(ctx, key)
+		metrics.ObserveReconcile(AgentName, start, err)
+		if err != nil {
+			// Requeue the item to retry with the backoff.
+			c.Workqueue.AddRateLimited(key)
 			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
 		}
+		c.Pending.Done(key)
 		c.Workqueue.Forget(obj)
 		return nil
 	}(obj)
@@ -141,6 +158,7 @@ func (c *BaseController) EnqueueFunction(obj interface{}) {
 		runtime.HandleError(err)
 		return
 	}
+	c.Pending.Add(key)
 	c.Workqueue.AddRateLimited(key)
 }
 
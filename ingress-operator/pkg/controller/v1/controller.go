@@ -15,6 +15,7 @@ import (
 	listers "github.com/tensorchord/openmodelz/ingress-operator/pkg/client/listers/modelzetes/v1"
 	"github.com/tensorchord/openmodelz/ingress-operator/pkg/config"
 	"github.com/tensorchord/openmodelz/ingress-operator/pkg/controller"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/metrics"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -71,6 +72,7 @@ func NewController(
 		FunctionsSynced: functionIngress.Informer().HasSynced,
 		Workqueue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "FunctionIngresses"),
 		SyncHandler:     syncer.handler,
+		Pending:         metrics.NewPending(controller.AgentName),
 	}
 	logrus.Info("Setting up event handlers")
 	ctrl.SetupEventHandlers(functionIngress, kubeInformerFactory)
@@ -144,9 +146,13 @@ func (h SyncHandler) handler(ctx context.Context, key string) error {
 		_, createErr := h.kubeclientset.NetworkingV1().Ingresses(ns).Create(ctx, &newIngress, metav1.CreateOptions{})
 		if createErr != nil {
 			logger.Errorf("cannot create ingress: %v in %v, error: %v", name, namespace, createErr.Error())
+			h.recorder.Eventf(fni, corev1.EventTypeWarning, controller.ReasonSyncFailed,
+				"Failed to create ingress %s: %v", name, createErr)
+			return createErr
 		}
 
-		h.recorder.Event(fni, corev1.EventTypeNormal, controller.SuccessSynced, controller.MessageResourceSynced)
+		h.recorder.Eventf(fni, corev1.EventTypeNormal, controller.ReasonIngressCreated,
+			"Created ingress %s in %s", name, ns)
 		return nil
 	}
 
@@ -183,8 +189,12 @@ func (h SyncHandler) handler(ctx context.Context, key string) error {
 		_, updateErr := h.kubeclientset.NetworkingV1().Ingresses(namespace).Update(ctx, updated, metav1.UpdateOptions{})
 		if updateErr != nil {
 			logrus.Errorf("error updating ingress: %v", updateErr)
+			h.recorder.Eventf(fni, corev1.EventTypeWarning, controller.ReasonSyncFailed,
+				"Failed to update ingress %s: %v", fni.Name, updateErr)
 			return updateErr
 		}
+		h.recorder.Eventf(fni, corev1.EventTypeNormal, controller.ReasonIngressUpdated,
+			"Updated ingress %s in %s", fni.Name, namespace)
 	}
 
 	// If an error occurs during Get/Create, we'll requeue the item so we can
@@ -15,9 +15,12 @@ import (
 	informers "github.com/tensorchord/openmodelz/ingress-operator/pkg/client/informers/externalversions"
 	"github.com/tensorchord/openmodelz/ingress-operator/pkg/config"
 	"github.com/tensorchord/openmodelz/ingress-operator/pkg/controller"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/metrics"
 )
 
-func New(c config.Config, stopCh <-chan struct{}) (*controller.BaseController, error) {
+// New creates the controller from the config, the informers are added to
+// the readiness check of the metrics server.
+func New(c config.Config, server *metrics.Server, stopCh <-chan struct{}) (*controller.BaseController, error) {
 	clientCmdConfig, err := clientcmd.BuildConfigFromFlags(
 		c.KubeConfig.MasterURL, c.KubeConfig.Kubeconfig)
 	if err != nil {
@@ -53,6 +56,7 @@ func New(c config.Config, stopCh <-chan struct{}) (*controller.BaseController, e
 
 	inferenceIngresses := ingressInformerFactory.Tensorchord().V1().InferenceIngresses()
 	go inferenceIngresses.Informer().Run(stopCh)
+	server.AddInformer("inferenceingresses", inferenceIngresses.Informer().HasSynced)
 	if ok := cache.WaitForNamedCacheSync(
 		fmt.Sprintf("%s:inferenceingresses", "tensorchord"),
 		stopCh, inferenceIngresses.Informer().HasSynced); !ok {
@@ -60,6 +64,7 @@ func New(c config.Config, stopCh <-chan struct{}) (*controller.BaseController, e
 	}
 	ingresses := kubeInformerFactory.Networking().V1().Ingresses()
 	go ingresses.Informer().Run(stopCh)
+	server.AddInformer("ingresses", ingresses.Informer().HasSynced)
 	if ok := cache.WaitForNamedCacheSync(
 		fmt.Sprintf("%s:ingresses", "networking"),
 		stopCh, ingresses.Informer().HasSynced); !ok {
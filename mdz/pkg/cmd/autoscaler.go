@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	autoscalerURL string
+)
+
+// autoscalerCmd represents the autoscaler command
+var autoscalerCmd = &cobra.Command{
+	Use:     "autoscaler",
+	Short:   "Inspect the autoscaler",
+	Long:    `Inspect the autoscaler`,
+	Example: `  mdz autoscaler status`,
+	GroupID: "debug",
+	PreRunE: commandInitLog,
+}
+
+func init() {
+	rootCmd.AddCommand(autoscalerCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+	autoscalerCmd.PersistentFlags().StringVarP(&autoscalerURL, "autoscaler-url", "", "", "URL to use for the autoscaler (MDZ_AUTOSCALER_URL) (default http://localhost:8080)")
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/agent/client"
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+var (
+	// Used for flags.
+	autoscalerStatusVerbose bool
+)
+
+// autoscalerStatusCmd represents the autoscaler status command
+var autoscalerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the latest autoscaling decisions",
+	Long:  `Show the latest autoscaling decisions, including the inputs, the computed replicas and the action taken`,
+	Example: `  mdz autoscaler status
+  mdz autoscaler status -v
+  mdz autoscaler status --autoscaler-url http://localhost:8080`,
+	PreRunE: commandInitLog,
+	RunE:    commandAutoscalerStatus,
+}
+
+func init() {
+	autoscalerCmd.AddCommand(autoscalerStatusCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports Persistent Flags which will work for this command
+	// and all subcommands, e.g.:
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	autoscalerStatusCmd.Flags().BoolVarP(&autoscalerStatusVerbose, "verbose", "v", false, "Verbose mode - print out all decision inputs")
+}
+
+func commandAutoscalerStatus(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record("autoscaler status")
+
+	if autoscalerURL == "" {
+		autoscalerURL = os.Getenv("MDZ_AUTOSCALER_URL")
+	}
+	if autoscalerURL == "" {
+		autoscalerURL = "http://localhost:8080"
+	}
+	autoscalerClient, err := client.NewClientWithOpts(client.WithHost(autoscalerURL))
+	if err != nil {
+		cmd.PrintErrf("Failed to connect to autoscaler: %s\n", errors.Cause(err))
+		return err
+	}
+
+	decisions, err := autoscalerClient.AutoscalerDecisionList(cmd.Context())
+	if err != nil {
+		cmd.PrintErrf("Failed to list autoscaler decisions: %s\n", errors.Cause(err))
+		return err
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.Style{
+		Box:     table.StyleBoxDefault,
+		Color:   table.ColorOptionsDefault,
+		Format:  table.FormatOptionsDefault,
+		HTML:    table.DefaultHTMLOptions,
+		Options: table.OptionsNoBordersAndSeparators,
+		Title:   table.TitleOptionsDefault,
+	})
+	if autoscalerStatusVerbose {
+		t.AppendHeader(table.Row{"Name", "Load", "Started", "Target", "Min/Max", "Idle Since", "CrashLoop", "Replicas", "Expected", "Action", "Reason", "Timestamp"})
+		for _, d := range decisions {
+			if d.Namespace != namespace {
+				continue
+			}
+			idleSince := ""
+			if d.Inputs.IdleSince != nil {
+				idleSince = d.Inputs.IdleSince.String()
+			}
+			t.AppendRow(table.Row{
+				d.Name,
+				fmt.Sprintf("%.2f", d.Inputs.CurrentLoad),
+				fmt.Sprintf("%.2f", d.Inputs.CurrentStartedRequests),
+				d.Inputs.TargetLoad,
+				fmt.Sprintf("%d/%d", d.Inputs.MinReplicas, d.Inputs.MaxReplicas),
+				idleSince,
+				d.Inputs.CrashLoopCount,
+				fmt.Sprintf("%d/%d", d.AvailableReplicas, d.Replicas),
+				d.ExpectedReplicas,
+				d.Action,
+				d.Reason,
+				d.Timestamp.String(),
+			})
+		}
+	} else {
+		t.AppendHeader(table.Row{"Name", "Load", "Replicas", "Expected", "Action", "Reason"})
+		for _, d := range decisions {
+			if d.Namespace != namespace {
+				continue
+			}
+			t.AppendRow(table.Row{
+				d.Name,
+				fmt.Sprintf("%.2f", d.Inputs.CurrentLoad),
+				fmt.Sprintf("%d/%d", d.AvailableReplicas, d.Replicas),
+				d.ExpectedReplicas,
+				decisionActionString(d.Action),
+				d.Reason,
+			})
+		}
+	}
+	cmd.Println(t.Render())
+	return nil
+}
+
+func decisionActionString(action types.AutoscalerAction) string {
+	if action == types.AutoscalerActionNone {
+		return "-"
+	}
+	return string(action)
+}
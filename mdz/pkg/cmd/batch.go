@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// batchCmd represents the batch command
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Manage the batch inferences",
+	Long:  `Manage the batch inferences. A batch inference runs the model server offline over the items of a dataset and writes the responses to the output location.`,
+	Example: `  mdz batch submit score --inference llm --input s3://data/prompts --input-jsonl prompts.jsonl --output s3://data/responses --path /v1/completions
+  mdz batch status score
+  mdz batch cancel score`,
+	GroupID: "management",
+	PreRunE: commandInitLog,
+}
+
+func init() {
+	rootCmd.AddCommand(batchCmd)
+}
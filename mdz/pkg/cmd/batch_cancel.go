@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+// batchCancelCmd represents the batch cancel command
+var batchCancelCmd = &cobra.Command{
+	Use:     "cancel",
+	Short:   "Cancel a batch inference",
+	Long:    `Cancel a batch inference, the responses written to the output location are kept`,
+	Example: `  mdz batch cancel score`,
+	PreRunE: commandInit,
+	Args:    cobra.ExactArgs(1),
+	RunE:    commandBatchCancel,
+}
+
+func init() {
+	batchCmd.AddCommand(batchCancelCmd)
+}
+
+func commandBatchCancel(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record("batch cancel")
+	name := args[0]
+	if _, err := agentClient.BatchInferenceCancel(cmd.Context(), namespace, name); err != nil {
+		cmd.PrintErrf("Failed to cancel %s: %s\n", name, errors.Cause(err))
+		return err
+	}
+	cmd.Printf("Batch inference %s is cancelled\n", name)
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+// batchStatusCmd represents the batch status command
+var batchStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the progress of the batch inferences",
+	Long:  `Show the progress of the batch inference, or list all the batch inferences if the name is not specified`,
+	Example: `  mdz batch status
+  mdz batch status score`,
+	PreRunE: commandInit,
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    commandBatchStatus,
+}
+
+func init() {
+	batchCmd.AddCommand(batchStatusCmd)
+}
+
+func commandBatchStatus(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record("batch status")
+	if len(args) == 0 {
+		return batchList(cmd)
+	}
+
+	name := args[0]
+	bi, err := agentClient.BatchInferenceGet(cmd.Context(), namespace, name)
+	if err != nil {
+		cmd.PrintErrf("Failed to get %s: %s\n", name, errors.Cause(err))
+		return err
+	}
+
+	status := bi.Status
+	cmd.Printf("Phase:\t\t%s\n", status.Phase)
+	cmd.Printf("Shards:\t\t%s\n", batchShards(status))
+	cmd.Printf("Items:\t\t%d succeeded, %d failed\n", status.Succeeded, status.Failed)
+	if status.StartTime != nil {
+		cmd.Printf("Started:\t%s\n", status.StartTime.Format(time.RFC3339))
+	}
+	if status.CompletionTime != nil {
+		cmd.Printf("Completed:\t%s\n", status.CompletionTime.Format(time.RFC3339))
+	}
+	cmd.Printf("Output:\t\t%s\n", bi.Spec.Output.URI)
+	if status.Message != "" {
+		cmd.Printf("Message:\t%s\n", status.Message)
+	}
+	return nil
+}
+
+func batchList(cmd *cobra.Command) error {
+	list, err := agentClient.BatchInferenceList(cmd.Context(), namespace)
+	if err != nil {
+		cmd.PrintErrf("Failed to list batch inferences: %s\n", errors.Cause(err))
+		return err
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.Style{
+		Box:     table.StyleBoxDefault,
+		Color:   table.ColorOptionsDefault,
+		Format:  table.FormatOptionsDefault,
+		HTML:    table.DefaultHTMLOptions,
+		Options: table.OptionsNoBordersAndSeparators,
+		Title:   table.TitleOptionsDefault,
+	})
+	t.AppendHeader(table.Row{"Name", "Phase", "Shards", "Succeeded", "Failed"})
+	for _, bi := range list {
+		t.AppendRow(table.Row{bi.Spec.Name, bi.Status.Phase, batchShards(bi.Status),
+			bi.Status.Succeeded, bi.Status.Failed})
+	}
+	cmd.Println(t.Render())
+	return nil
+}
+
+// batchShards formats the completed shards, e.g. 2/4 (1 active).
+func batchShards(status types.BatchInferenceStatus) string {
+	res := fmt.Sprintf("%d/%d", status.CompletedShards, status.Shards)
+	if status.ActiveShards > 0 {
+		res += fmt.Sprintf(" (%d active)", status.ActiveShards)
+	}
+	return res
+}
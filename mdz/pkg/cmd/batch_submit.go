@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+var (
+	batchSubmitInference         string
+	batchSubmitImage             string
+	batchSubmitFramework         string
+	batchSubmitPort              int32
+	batchSubmitCommand           string
+	batchSubmitProbePath         string
+	batchSubmitEnvs              []string
+	batchSubmitSecrets           []string
+	batchSubmitGPU               string
+	batchSubmitInput             string
+	batchSubmitInputEndpoint     string
+	batchSubmitInputVolumeClaim  string
+	batchSubmitInputJSONL        string
+	batchSubmitInputFiles        []string
+	batchSubmitOutput            string
+	batchSubmitOutputEndpoint    string
+	batchSubmitOutputVolumeClaim string
+	batchSubmitPath              string
+	batchSubmitParallelism       int32
+	batchSubmitMaxRetries        int32
+)
+
+// batchSubmitCmd represents the batch submit command
+var batchSubmitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "Submit a batch inference",
+	Long:  `Submit a batch inference. The model server of the inference or the image is started in each shard, the items are posted to it and the responses are written to the output location. The processed items are skipped when the shards are retried.`,
+	Example: `  mdz batch submit score --inference llm --input s3://data/prompts --input-jsonl prompts.jsonl --output s3://data/responses --path /v1/completions --parallelism 4
+  mdz batch submit caption --image modelzai/blip:latest --command "python main.py" --input images --input-volume-claim dataset --input-file cat.png --input-file dog.png --output captions --output-volume-claim dataset`,
+	PreRunE: commandInit,
+	Args:    cobra.ExactArgs(1),
+	RunE:    commandBatchSubmit,
+}
+
+func init() {
+	batchCmd.AddCommand(batchSubmitCmd)
+
+	batchSubmitCmd.Flags().StringVar(&batchSubmitInference, "inference", "",
+		"Inference whose image, framework, port, command, env vars, secrets and resources are used")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitImage, "image", "",
+		"Image of the model server, required if --inference is not set")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitFramework, "framework", "",
+		"Framework of the model server, the port, command and probe path default to the ones of the framework")
+	batchSubmitCmd.Flags().Int32Var(&batchSubmitPort, "port", 8080, "Port of the model server")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitCommand, "command", "", "Command to start the model server")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitProbePath, "probe-path", "",
+		"HTTP path polled until the model server is ready")
+	batchSubmitCmd.Flags().StringArrayVarP(&batchSubmitEnvs, "env", "e", []string{},
+		"Env var of the model server in the format of <key>=<value>, can be specified multiple times")
+	batchSubmitCmd.Flags().StringArrayVar(&batchSubmitSecrets, "secret", []string{},
+		"Secret created by mdz secret create, can be specified multiple times")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitGPU, "gpu", "",
+		"Number of GPUs of each shard, or the GPU profile in the format of <profile>[:<count>]")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitInput, "input", "",
+		"Location of the input, s3://<bucket>/<prefix> or the path in --input-volume-claim")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitInputEndpoint, "input-endpoint", "",
+		"Endpoint of the S3 compatible storage of the input")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitInputVolumeClaim, "input-volume-claim", "",
+		"Persistent volume claim of the input")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitInputJSONL, "input-jsonl", "",
+		"JSONL file in the input, each line is an item")
+	batchSubmitCmd.Flags().StringArrayVar(&batchSubmitInputFiles, "input-file", []string{},
+		"File in the input, each file is an item, can be specified multiple times")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitOutput, "output", "",
+		"Location of the output, s3://<bucket>/<prefix> or the path in --output-volume-claim")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitOutputEndpoint, "output-endpoint", "",
+		"Endpoint of the S3 compatible storage of the output")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitOutputVolumeClaim, "output-volume-claim", "",
+		"Persistent volume claim of the output")
+	batchSubmitCmd.Flags().StringVar(&batchSubmitPath, "path", "/",
+		"HTTP path of the model endpoint which the items are posted to")
+	batchSubmitCmd.Flags().Int32Var(&batchSubmitParallelism, "parallelism", 1,
+		"Number of the shards processed in parallel")
+	batchSubmitCmd.Flags().Int32Var(&batchSubmitMaxRetries, "max-retries", 3,
+		"Retries of each item before it is failed")
+}
+
+func commandBatchSubmit(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record(
+		"batch submit",
+		telemetry.AddField("GPU", batchSubmitGPU),
+	)
+	bi := types.BatchInference{
+		Spec: types.BatchInferenceSpec{
+			Name:      args[0],
+			Namespace: namespace,
+			Inference: batchSubmitInference,
+			Image:     batchSubmitImage,
+			Framework: types.Framework(batchSubmitFramework),
+			Secrets:   batchSubmitSecrets,
+			Input: types.BatchInput{
+				BatchLocation: types.BatchLocation{
+					URI:         batchSubmitInput,
+					Endpoint:    batchSubmitInputEndpoint,
+					VolumeClaim: batchSubmitInputVolumeClaim,
+				},
+				JSONL: batchSubmitInputJSONL,
+				Files: batchSubmitInputFiles,
+			},
+			Output: types.BatchLocation{
+				URI:         batchSubmitOutput,
+				Endpoint:    batchSubmitOutputEndpoint,
+				VolumeClaim: batchSubmitOutputVolumeClaim,
+			},
+			Path:        batchSubmitPath,
+			Parallelism: int32Ptr(batchSubmitParallelism),
+			MaxRetries:  int32Ptr(batchSubmitMaxRetries),
+		},
+	}
+
+	// The port of the inference or the framework is used if it is not set
+	// explicitly.
+	if cmd.Flags().Changed("port") ||
+		(batchSubmitInference == "" && batchSubmitFramework == "") {
+		bi.Spec.Port = int32Ptr(batchSubmitPort)
+	}
+	if batchSubmitCommand != "" {
+		bi.Spec.Command = &batchSubmitCommand
+	}
+	if batchSubmitProbePath != "" {
+		bi.Spec.HTTPProbePath = &batchSubmitProbePath
+	}
+
+	if len(batchSubmitEnvs) > 0 {
+		bi.Spec.EnvVars = map[string]string{}
+		for _, env := range batchSubmitEnvs {
+			key, value, ok := strings.Cut(env, "=")
+			if !ok || key == "" {
+				err := errors.Newf("invalid env %q, must be <key>=<value>", env)
+				cmd.PrintErrf("Failed to submit the batch inference: %s\n", err)
+				return err
+			}
+			bi.Spec.EnvVars[key] = value
+		}
+	}
+
+	gpu, err := makeGPUResources(batchSubmitGPU)
+	if err != nil {
+		cmd.PrintErrf("Failed to submit the batch inference: %s\n", err)
+		return err
+	}
+	if gpu != nil {
+		bi.Spec.Resources = &types.ResourceRequirements{
+			Limits: gpu,
+		}
+	}
+
+	if _, err := agentClient.BatchInferenceCreate(
+		cmd.Context(), namespace, bi); err != nil {
+		cmd.PrintErrf("Failed to submit the batch inference: %s\n", errors.Cause(err))
+		return err
+	}
+	cmd.Printf("Batch inference %s is submitted\n", bi.Spec.Name)
+	return nil
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"math/rand"
-	"strconv"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -19,10 +18,12 @@ var (
 	deployMinReplicas int32
 	deployMaxReplicas int32
 	deployName        string
-	deployGPU         int
+	deployGPU         string
 	deployNodeLabel   []string
 	deployCommand     string
 	deployProbePath   string
+	deploySecrets     []string
+	deployFramework   string
 )
 
 // deployCmd represents the deploy command
@@ -31,7 +32,11 @@ var deployCmd = &cobra.Command{
 	Short: "Deploy a new deployment",
 	Long:  `Deploys a new deployment directly via flags.`,
 	Example: `  mdz deploy --image=modelzai/llm-blomdz-560m:23.06.13
-  mdz deploy --image=modelzai/llm-blomdz-560m:23.06.13 --name blomdz-560m --node-labels gpu=true,name=node-name`,
+  mdz deploy --image=modelzai/llm-blomdz-560m:23.06.13 --name blomdz-560m --node-labels gpu=true,name=node-name
+  mdz deploy --image=modelzai/llm-blomdz-560m:23.06.13 --gpu mig-1g.10gb
+  mdz deploy --image=modelzai/llm-blomdz-560m:23.06.13 --secret hf-token
+  mdz deploy --image=jupyter/minimal-notebook:lab-4.0.3 --framework jupyter
+  mdz deploy --image=modelzai/llm-blomdz-560m:23.06.13 --node-affinity 'tensorchord.ai/gpu in (a100,h100)' --anti-affinity kubernetes.io/hostname --spread topology.kubernetes.io/zone`,
 	GroupID: "basic",
 	PreRunE: commandInit,
 	RunE:    commandDeploy,
@@ -51,13 +56,19 @@ func init() {
 	// deployCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 	deployCmd.Flags().StringVar(&deployImage, "image", "", "Image to deploy")
 	deployCmd.Flags().Int32Var(&deployPort, "port", 8080, "Port to deploy on")
+	deployCmd.Flags().StringVar(&deployFramework, "framework", string(types.FrameworkOther),
+		"Framework of the inference, e.g. gradio, streamlit, mosec, vllm, tgi, triton or jupyter. "+
+			"The port, command and probe path default to the ones of the framework")
 	deployCmd.Flags().Int32Var(&deployMinReplicas, "min-replicas", 1, "Minimum number of replicas (can be 0)")
 	deployCmd.Flags().Int32Var(&deployMaxReplicas, "max-replicas", 1, "Maximum number of replicas")
-	deployCmd.Flags().IntVar(&deployGPU, "gpu", 0, "Number of GPUs")
+	deployCmd.Flags().StringVar(&deployGPU, "gpu", "",
+		"Number of GPUs, or the GPU profile in the format of <profile>[:<count>], e.g. 2, shared, mig-1g.10gb, amd or intel")
 	deployCmd.Flags().StringVar(&deployName, "name", "", "Name of inference")
 	deployCmd.Flags().StringSliceVarP(&deployNodeLabel, "node-labels", "l", []string{}, "Node labels")
 	deployCmd.Flags().StringVar(&deployCommand, "command", "", "Command to run")
 	deployCmd.Flags().StringVar(&deployProbePath, "probe-path", "", "HTTP Health probe path")
+	deployCmd.Flags().StringArrayVar(&deploySecrets, "secret", []string{},
+		"Secret created by mdz secret create to mount under /var/modelz/secrets, can be specified multiple times")
 }
 
 func commandDeploy(cmd *cobra.Command, args []string) error {
@@ -79,7 +90,7 @@ func commandDeploy(cmd *cobra.Command, args []string) error {
 			Labels: map[string]string{
 				"ai.tensorchord.name": name,
 			},
-			Framework: types.FrameworkOther,
+			Framework: types.Framework(deployFramework),
 			Scaling: &types.ScalingConfig{
 				MinReplicas:     int32Ptr(deployMinReplicas),
 				MaxReplicas:     int32Ptr(deployMaxReplicas),
@@ -88,10 +99,14 @@ func commandDeploy(cmd *cobra.Command, args []string) error {
 				StartupDuration: int32Ptr(600),
 				ZeroDuration:    int32Ptr(600),
 			},
-			Port: int32Ptr(deployPort),
 		},
 	}
 
+	// The port of the framework is used if it is not set explicitly.
+	if inf.Spec.Framework == types.FrameworkOther || cmd.Flags().Changed("port") {
+		inf.Spec.Port = int32Ptr(deployPort)
+	}
+
 	if deployCommand != "" {
 		inf.Spec.Command = &deployCommand
 	}
@@ -99,6 +114,10 @@ func commandDeploy(cmd *cobra.Command, args []string) error {
 		inf.Spec.HTTPProbePath = &deployProbePath
 	}
 
+	if len(deploySecrets) > 0 {
+		inf.Spec.Secrets = deploySecrets
+	}
+
 	if len(deployNodeLabel) > 0 {
 		inf.Spec.Constraints = []string{}
 		for _, label := range deployNodeLabel {
@@ -106,13 +125,23 @@ func commandDeploy(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if deployGPU > 0 {
-		GPUNum := types.Quantity(strconv.Itoa(deployGPU))
+	scheduling, err := makeScheduling()
+	if err != nil {
+		return err
+	}
+	inf.Spec.Scheduling = scheduling
+	inf.Spec.Rollout = makeRollout(cmd)
+	inf.Spec.Termination = makeTermination(cmd)
+	inf.Spec.DisruptionBudget = makeDisruptionBudget(cmd)
+
+	gpu, err := makeGPUResources(deployGPU)
+	if err != nil {
+		return err
+	}
+	if gpu != nil {
 		inf.Spec.Resources = &types.ResourceRequirements{
 			// no need to set Requests for GPU
-			Limits: types.ResourceList{
-				types.ResourceGPU: GPUNum,
-			},
+			Limits: gpu,
 		}
 	}
 
@@ -133,3 +162,5 @@ func commandDeploy(cmd *cobra.Command, args []string) error {
 }
 
 func int32Ptr(i int32) *int32 { return &i }
+
+func int64Ptr(i int64) *int64 { return &i }
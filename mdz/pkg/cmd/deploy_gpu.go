@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+const (
+	// gpuProfileShared is the profile of the time-sliced GPUs.
+	gpuProfileShared = "shared"
+	gpuProfileAMD    = "amd"
+	gpuProfileIntel  = "intel"
+)
+
+// makeGPUResources builds the GPU limits from the --gpu flag. The flag is
+// either the number of the whole NVIDIA GPUs, or the profile with an
+// optional count, e.g. shared, shared:2, mig-1g.10gb or amd:2. It returns
+// nil if no GPU is requested.
+func makeGPUResources(gpu string) (types.ResourceList, error) {
+	if gpu == "" {
+		return nil, nil
+	}
+	if n, err := strconv.Atoi(gpu); err == nil {
+		if n < 0 {
+			return nil, errors.Newf("invalid number of GPUs %q", gpu)
+		}
+		if n == 0 {
+			return nil, nil
+		}
+		return types.ResourceList{types.ResourceGPU: types.Quantity(gpu)}, nil
+	}
+
+	profile, count, ok := strings.Cut(gpu, ":")
+	if !ok {
+		count = "1"
+	}
+	if n, err := strconv.Atoi(count); err != nil || n <= 0 {
+		return nil, errors.Newf("invalid number of GPUs %q", gpu)
+	}
+
+	var name types.ResourceName
+	switch {
+	case profile == gpuProfileShared:
+		name = types.ResourceGPUShared
+	case profile == gpuProfileAMD:
+		name = types.ResourceAMDGPU
+	case profile == gpuProfileIntel:
+		name = types.ResourceIntelGPU
+	case strings.HasPrefix(profile, types.ResourceMIGPrefix):
+		name = types.ResourceName(profile)
+	default:
+		return nil, errors.Newf(
+			"invalid GPU profile %q, must be %s, %s, %s or a MIG profile, e.g. mig-1g.10gb",
+			profile, gpuProfileShared, gpuProfileAMD, gpuProfileIntel)
+	}
+	return types.ResourceList{name: types.Quantity(count)}, nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+const flagRolloutMaxErrorPercent = "rollout-max-error-percent"
+
+var (
+	deployAutoRollback           bool
+	deployRolloutDeadline        time.Duration
+	deployRolloutMinReadyPercent int32
+	deployRolloutMaxErrorPercent int32
+)
+
+func init() {
+	deployCmd.Flags().BoolVar(&deployAutoRollback, "auto-rollback", false,
+		"Roll back to the previous revision automatically if the rollout fails")
+	deployCmd.Flags().DurationVar(&deployRolloutDeadline, "rollout-deadline", 0,
+		"Maximum duration of the rollout, e.g. 10m (default 10m). It implies --auto-rollback")
+	deployCmd.Flags().Int32Var(&deployRolloutMinReadyPercent, "rollout-min-ready", 0,
+		"Percentage of the replicas which must be ready before the deadline (default 100). It implies --auto-rollback")
+	deployCmd.Flags().Int32Var(&deployRolloutMaxErrorPercent, flagRolloutMaxErrorPercent, 0,
+		"Fail the rollout if the percentage of the requests responded with 5xx exceeds it. It implies --auto-rollback")
+}
+
+// makeRollout builds the rollout policy from the deploy flags. It returns
+// nil if none of the flags is set.
+func makeRollout(cmd *cobra.Command) *types.RolloutPolicy {
+	maxErrorPercent := cmd.Flags().Changed(flagRolloutMaxErrorPercent)
+	if !deployAutoRollback && deployRolloutDeadline == 0 &&
+		deployRolloutMinReadyPercent == 0 && !maxErrorPercent {
+		return nil
+	}
+
+	rollout := &types.RolloutPolicy{
+		ProgressDeadlineSeconds: int32(deployRolloutDeadline.Seconds()),
+		MinReadyPercent:         deployRolloutMinReadyPercent,
+	}
+	if maxErrorPercent {
+		rollout.ErrorRate = &types.ErrorRateCheck{
+			MaxErrorPercent: deployRolloutMaxErrorPercent,
+		}
+	}
+	return rollout
+}
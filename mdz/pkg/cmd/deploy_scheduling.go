@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+var (
+	deployNodeAffinity         []string
+	deployPreferNodeAffinity   []string
+	deployTolerations          []string
+	deployAntiAffinity         string
+	deployAntiAffinityRequired bool
+	deploySpread               []string
+	deploySpreadRequired       bool
+)
+
+func init() {
+	deployCmd.Flags().StringArrayVar(&deployNodeAffinity, "node-affinity", []string{},
+		"Required node affinity in the label selector syntax, e.g. 'tensorchord.ai/gpu in (a100,h100)'. The flags are ORed")
+	deployCmd.Flags().StringArrayVar(&deployPreferNodeAffinity, "prefer-node-affinity", []string{},
+		"Preferred node affinity in the format of [<weight>:]<selector>, e.g. '50:tensorchord.ai/gpu=a100'")
+	deployCmd.Flags().StringArrayVar(&deployTolerations, "toleration", []string{},
+		"Toleration in the format of <key>[=<value>][:<effect>], e.g. 'dedicated=inference:NoSchedule'")
+	deployCmd.Flags().StringVar(&deployAntiAffinity, "anti-affinity", "",
+		"Topology key to keep the replicas apart, e.g. kubernetes.io/hostname")
+	deployCmd.Flags().BoolVar(&deployAntiAffinityRequired, "anti-affinity-required", false,
+		"Do not schedule the replicas if the anti affinity cannot be satisfied")
+	deployCmd.Flags().StringArrayVar(&deploySpread, "spread", []string{},
+		"Topology key to spread the replicas across in the format of <key>[:<max-skew>], e.g. topology.kubernetes.io/zone:1")
+	deployCmd.Flags().BoolVar(&deploySpreadRequired, "spread-required", false,
+		"Do not schedule the replicas if the spread constraints cannot be satisfied")
+}
+
+// makeScheduling builds the scheduling config from the deploy flags. It
+// returns nil if none of the flags is set.
+func makeScheduling() (*types.SchedulingConfig, error) {
+	if len(deployNodeAffinity) == 0 && len(deployPreferNodeAffinity) == 0 &&
+		len(deployTolerations) == 0 && deployAntiAffinity == "" && len(deploySpread) == 0 {
+		return nil, nil
+	}
+
+	scheduling := &types.SchedulingConfig{}
+	if len(deployNodeAffinity) > 0 || len(deployPreferNodeAffinity) > 0 {
+		scheduling.NodeAffinity = &types.NodeAffinity{}
+	}
+	for _, expr := range deployNodeAffinity {
+		term, err := parseNodeSelectorTerm(expr)
+		if err != nil {
+			return nil, err
+		}
+		scheduling.NodeAffinity.Required = append(scheduling.NodeAffinity.Required, term)
+	}
+	for _, expr := range deployPreferNodeAffinity {
+		weight := int32(1)
+		if prefix, rest, ok := strings.Cut(expr, ":"); ok {
+			w, err := strconv.ParseInt(prefix, 10, 32)
+			if err != nil {
+				return nil, errors.Newf("invalid weight of node affinity %q", expr)
+			}
+			weight, expr = int32(w), rest
+		}
+		term, err := parseNodeSelectorTerm(expr)
+		if err != nil {
+			return nil, err
+		}
+		scheduling.NodeAffinity.Preferred = append(scheduling.NodeAffinity.Preferred,
+			types.PreferredSchedulingTerm{Weight: weight, Preference: term})
+	}
+
+	for _, t := range deployTolerations {
+		toleration, err := parseToleration(t)
+		if err != nil {
+			return nil, err
+		}
+		scheduling.Tolerations = append(scheduling.Tolerations, toleration)
+	}
+
+	if deployAntiAffinity != "" {
+		scheduling.PodAntiAffinity = &types.PodAntiAffinity{
+			TopologyKey: deployAntiAffinity,
+			Required:    deployAntiAffinityRequired,
+		}
+	}
+
+	whenUnsatisfiable := "ScheduleAnyway"
+	if deploySpreadRequired {
+		whenUnsatisfiable = "DoNotSchedule"
+	}
+	for _, s := range deploySpread {
+		key, skew, _ := strings.Cut(s, ":")
+		constraint := types.TopologySpreadConstraint{
+			TopologyKey:       key,
+			WhenUnsatisfiable: whenUnsatisfiable,
+		}
+		if skew != "" {
+			v, err := strconv.ParseInt(skew, 10, 32)
+			if err != nil {
+				return nil, errors.Newf("invalid max skew of spread %q", s)
+			}
+			constraint.MaxSkew = int32(v)
+		}
+		scheduling.TopologySpreadConstraints = append(
+			scheduling.TopologySpreadConstraints, constraint)
+	}
+	return scheduling, nil
+}
+
+// parseNodeSelectorTerm parses the label selector into the node selector
+// term. The requirements of the selector are ANDed.
+func parseNodeSelectorTerm(expr string) (types.NodeSelectorTerm, error) {
+	term := types.NodeSelectorTerm{}
+	selector, err := labels.Parse(expr)
+	if err != nil {
+		return term, errors.Wrapf(err, "invalid node affinity %q", expr)
+	}
+	requirements, _ := selector.Requirements()
+	for _, r := range requirements {
+		var op types.NodeSelectorOperator
+		switch r.Operator() {
+		case selection.In, selection.Equals, selection.DoubleEquals:
+			op = types.NodeSelectorOpIn
+		case selection.NotIn, selection.NotEquals:
+			op = types.NodeSelectorOpNotIn
+		case selection.Exists:
+			op = types.NodeSelectorOpExists
+		case selection.DoesNotExist:
+			op = types.NodeSelectorOpDoesNotExist
+		case selection.GreaterThan:
+			op = types.NodeSelectorOpGt
+		case selection.LessThan:
+			op = types.NodeSelectorOpLt
+		default:
+			return term, fmt.Errorf("unsupported operator %s in node affinity %q",
+				r.Operator(), expr)
+		}
+		term.MatchExpressions = append(term.MatchExpressions, types.NodeSelectorRequirement{
+			Key:      r.Key(),
+			Operator: op,
+			Values:   r.Values().List(),
+		})
+	}
+	return term, nil
+}
+
+// parseToleration parses the toleration in the same format as the taints of
+// kubectl. The operator is Exists if the value is not set.
+func parseToleration(t string) (types.Toleration, error) {
+	toleration := types.Toleration{}
+	spec, effect, _ := strings.Cut(t, ":")
+	key, value, hasValue := strings.Cut(spec, "=")
+	if key == "" {
+		return toleration, errors.Newf("invalid toleration %q, key is required", t)
+	}
+	toleration.Key = key
+	toleration.Effect = effect
+	if hasValue {
+		toleration.Operator = "Equal"
+		toleration.Value = value
+	} else {
+		toleration.Operator = "Exists"
+	}
+	return toleration, nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+const (
+	flagTerminationGracePeriod = "termination-grace-period"
+	flagPreStopSleep           = "pre-stop-sleep"
+	flagMaxUnavailable         = "max-unavailable"
+)
+
+var (
+	deployTerminationGracePeriod time.Duration
+	deployPreStopSleep           time.Duration
+	deployMaxUnavailable         int32
+	deployNoDisruptionBudget     bool
+)
+
+func init() {
+	deployCmd.Flags().DurationVar(&deployTerminationGracePeriod, flagTerminationGracePeriod, 0,
+		"Duration for the in-flight requests to finish before the replica is killed, e.g. 2m")
+	deployCmd.Flags().DurationVar(&deployPreStopSleep, flagPreStopSleep, 0,
+		"Delay of SIGTERM until the replica stops receiving requests, e.g. 5s. The image must contain sh. 0 disables it")
+	deployCmd.Flags().Int32Var(&deployMaxUnavailable, flagMaxUnavailable, 0,
+		"Maximum number of the replicas evicted at the same time by node drains (default 1)")
+	deployCmd.Flags().BoolVar(&deployNoDisruptionBudget, "no-disruption-budget", false,
+		"Do not limit the replicas evicted by node drains")
+}
+
+// makeTermination builds the termination config from the deploy flags. It
+// returns nil if none of the flags is set.
+func makeTermination(cmd *cobra.Command) *types.TerminationConfig {
+	gracePeriod := cmd.Flags().Changed(flagTerminationGracePeriod)
+	preStopSleep := cmd.Flags().Changed(flagPreStopSleep)
+	if !gracePeriod && !preStopSleep {
+		return nil
+	}
+
+	termination := &types.TerminationConfig{}
+	if gracePeriod {
+		termination.GracePeriodSeconds = int64Ptr(int64(deployTerminationGracePeriod.Seconds()))
+	}
+	if preStopSleep {
+		termination.PreStopSleepSeconds = int32Ptr(int32(deployPreStopSleep.Seconds()))
+	}
+	return termination
+}
+
+// makeDisruptionBudget builds the disruption budget from the deploy flags.
+// It returns nil if none of the flags is set.
+func makeDisruptionBudget(cmd *cobra.Command) *types.DisruptionBudgetConfig {
+	maxUnavailable := cmd.Flags().Changed(flagMaxUnavailable)
+	if !deployNoDisruptionBudget && !maxUnavailable {
+		return nil
+	}
+
+	budget := &types.DisruptionBudgetConfig{
+		Disabled: deployNoDisruptionBudget,
+	}
+	if maxUnavailable {
+		budget.MaxUnavailable = int32Ptr(deployMaxUnavailable)
+	}
+	return budget
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
@@ -72,7 +73,7 @@ func commandList(cmd *cobra.Command, args []string) error {
 			Options: table.OptionsNoBordersAndSeparators,
 			Title:   table.TitleOptionsDefault,
 		})
-		t.AppendHeader(table.Row{"Name", "Endpoint", "Image", "Status", "Invocations", "Replicas", "CreatedAt"})
+		t.AppendHeader(table.Row{"Name", "Endpoint", "Image", "Status", "Invocations", "Replicas", "CreatedAt", "Message"})
 
 		for _, inf := range infs {
 			functionImage := inf.Spec.Image
@@ -88,6 +89,7 @@ func commandList(cmd *cobra.Command, args []string) error {
 				int64(inf.Status.InvocationCount),
 				fmt.Sprintf("%d/%d", inf.Status.AvailableReplicas, inf.Status.Replicas),
 				createdAt,
+				getStatusMessage(inf),
 			})
 		}
 
@@ -132,3 +134,12 @@ func getEndpoint(inf types.InferenceDeployment) string {
 	}
 	return endpoint
 }
+
+// getStatusMessage returns why the inference is not fully available.
+func getStatusMessage(inf types.InferenceDeployment) string {
+	if inf.Status.CrashLoop != nil {
+		return fmt.Sprintf("suppressed because of CrashLoopBackOff, next recovery attempt at %s",
+			inf.Status.CrashLoop.NextAttempt.Format(time.RFC3339))
+	}
+	return inf.Status.PendingReason
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// namespaceCmd represents the namespace command
+var namespaceCmd = &cobra.Command{
+	Use:     "namespace",
+	Short:   "Inspect the namespaces",
+	Long:    `Inspect the namespaces`,
+	Example: `  mdz namespace quota team-a`,
+	GroupID: "management",
+	PreRunE: commandInitLog,
+}
+
+func init() {
+	rootCmd.AddCommand(namespaceCmd)
+}
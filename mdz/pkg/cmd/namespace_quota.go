@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+// namespaceQuotaCmd represents the namespace quota command
+var namespaceQuotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: "Show the usage of the namespace versus the quota",
+	Long: `Show the resources requested in the namespace versus the quota. The namespace of --namespace is used if it is not specified.
+
+The GPU limit is the total of the accelerator devices checked when the inferences are deployed through the agent. The cluster only limits each accelerator resource to it separately, thus the replicas scaled up by the autoscaler and the inferences applied to the cluster directly may exceed it in total`,
+	Example: `  mdz namespace quota
+  mdz namespace quota team-a`,
+	PreRunE: commandInit,
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    commandNamespaceQuota,
+}
+
+func init() {
+	namespaceCmd.AddCommand(namespaceQuotaCmd)
+}
+
+func commandNamespaceQuota(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record("namespace quota")
+	name := namespace
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	status, err := agentClient.NamespaceQuotaGet(cmd.Context(), name)
+	if err != nil {
+		cmd.PrintErrf("Failed to get the quota of %s: %s\n", name, errors.Cause(err))
+		return err
+	}
+
+	if status.Hard == (types.NamespaceQuota{}) {
+		cmd.Printf("Namespace %s is not limited\n", name)
+		return nil
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.Style{
+		Box:     table.StyleBoxDefault,
+		Color:   table.ColorOptionsDefault,
+		Format:  table.FormatOptionsDefault,
+		HTML:    table.DefaultHTMLOptions,
+		Options: table.OptionsNoBordersAndSeparators,
+		Title:   table.TitleOptionsDefault,
+	})
+	t.AppendHeader(table.Row{"Resource", "Used", "Limit"})
+	t.AppendRow(table.Row{"GPU", quotaValue(status.Used.GPU), quotaValue(status.Hard.GPU)})
+	t.AppendRow(table.Row{"CPU", quotaValue(status.Used.CPU), quotaValue(status.Hard.CPU)})
+	t.AppendRow(table.Row{"Memory", quotaValue(status.Used.Memory), quotaValue(status.Hard.Memory)})
+	t.AppendRow(table.Row{"Inferences", quotaCount(status.Used.Inferences), quotaCount(status.Hard.Inferences)})
+	cmd.Println(t.Render())
+	if status.Hard.GPU != "" {
+		cmd.Println("The GPU limit is checked on deploy, the cluster limits each accelerator resource to it separately")
+	}
+	return nil
+}
+
+// quotaValue formats the quantity, the unset limit is shown as -.
+func quotaValue(qty types.Quantity) string {
+	if qty == "" {
+		return "-"
+	}
+	return string(qty)
+}
+
+func quotaCount(count *int32) string {
+	if count == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *count)
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// rolloutCmd represents the rollout command
+var rolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Manage the rollout of the deployments",
+	Long:  `Manage the rollout of the deployments. Every change of the deployment is recorded as a revision.`,
+	Example: `  mdz rollout status bloomz-560m
+  mdz rollout history bloomz-560m
+  mdz rollout undo bloomz-560m`,
+	GroupID: "management",
+	PreRunE: commandInitLog,
+}
+
+func init() {
+	rootCmd.AddCommand(rolloutCmd)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+var (
+	rolloutHistoryRevision int64
+)
+
+// rolloutHistoryCmd represents the rollout history command
+var rolloutHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the revisions of the deployment",
+	Long:  `Show the revisions of the deployment. The changes and the spec of a revision are shown if the revision is specified.`,
+	Example: `  mdz rollout history bloomz-560m
+  mdz rollout history bloomz-560m --revision 2`,
+	PreRunE: commandInit,
+	Args:    cobra.ExactArgs(1),
+	RunE:    commandRolloutHistory,
+}
+
+func init() {
+	rolloutCmd.AddCommand(rolloutHistoryCmd)
+
+	rolloutHistoryCmd.Flags().Int64Var(&rolloutHistoryRevision, "revision", 0, "Show the details of the revision")
+}
+
+func commandRolloutHistory(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record("rollout history")
+	name := args[0]
+	revisions, err := agentClient.InferenceRevisionList(cmd.Context(), namespace, name)
+	if err != nil {
+		cmd.PrintErrf("Failed to list revisions: %s\n", errors.Cause(err))
+		return err
+	}
+
+	if rolloutHistoryRevision != 0 {
+		for _, r := range revisions {
+			if r.Revision != rolloutHistoryRevision {
+				continue
+			}
+			cmd.Printf("Revision:\t%d\n", r.Revision)
+			cmd.Printf("Created:\t%s\n", r.CreatedAt.Format(time.RFC3339))
+			cmd.Printf("Author:\t\t%s\n", r.Author)
+			cmd.Printf("Change Cause:\t%s\n", r.ChangeCause)
+			cmd.Println("Changes:")
+			for _, d := range r.Diff {
+				cmd.Printf("  %s\n", d)
+			}
+			spec, err := json.MarshalIndent(r.Spec, "", "  ")
+			if err != nil {
+				return err
+			}
+			cmd.Printf("Spec:\n%s\n", spec)
+			return nil
+		}
+		cmd.PrintErrf("Revision %d of %s not found\n", rolloutHistoryRevision, name)
+		return errors.Newf("revision %d of %s not found", rolloutHistoryRevision, name)
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.Style{
+		Box:     table.StyleBoxDefault,
+		Color:   table.ColorOptionsDefault,
+		Format:  table.FormatOptionsDefault,
+		HTML:    table.DefaultHTMLOptions,
+		Options: table.OptionsNoBordersAndSeparators,
+		Title:   table.TitleOptionsDefault,
+	})
+	t.AppendHeader(table.Row{"Revision", "Created", "Author", "Change Cause"})
+	for _, r := range revisions {
+		t.AppendRow(table.Row{r.Revision, r.CreatedAt.Format(time.RFC3339),
+			r.Author, r.ChangeCause})
+	}
+	cmd.Println(t.Render())
+	return nil
+}
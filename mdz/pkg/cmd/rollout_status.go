@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+// rolloutStatusCmd represents the rollout status command
+var rolloutStatusCmd = &cobra.Command{
+	Use:     "status",
+	Short:   "Show the status of the latest rollout",
+	Long:    `Show the status of the latest rollout. It is only available if the deployment is created with the rollout policy, e.g. --auto-rollback.`,
+	Example: `  mdz rollout status bloomz-560m`,
+	PreRunE: commandInit,
+	Args:    cobra.ExactArgs(1),
+	RunE:    commandRolloutStatus,
+}
+
+func init() {
+	rolloutCmd.AddCommand(rolloutStatusCmd)
+}
+
+func commandRolloutStatus(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record("rollout status")
+	name := args[0]
+	inf, err := agentClient.InferenceGet(cmd.Context(), namespace, name)
+	if err != nil {
+		cmd.PrintErrf("Failed to get %s: %s\n", name, errors.Cause(err))
+		return err
+	}
+
+	rollout := inf.Status.Rollout
+	if rollout == nil {
+		cmd.Printf("No rollout status of %s, the rollout policy is not specified\n", name)
+		return nil
+	}
+	cmd.Printf("Phase:\t\t%s\n", rollout.Phase)
+	cmd.Printf("Started:\t%s\n", rollout.StartedAt.Format(time.RFC3339))
+	cmd.Printf("Ready:\t\t%d/%d\n", rollout.ReadyReplicas, inf.Status.Replicas)
+	if rollout.Message != "" {
+		cmd.Printf("Message:\t%s\n", rollout.Message)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+var (
+	rolloutUndoToRevision int64
+)
+
+// rolloutUndoCmd represents the rollout undo command
+var rolloutUndoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Roll back the deployment to a previous revision",
+	Long:  `Roll back the deployment to a previous revision. The last revision before the current one is used if the revision is not specified.`,
+	Example: `  mdz rollout undo bloomz-560m
+  mdz rollout undo bloomz-560m --to-revision 2`,
+	PreRunE: commandInit,
+	Args:    cobra.ExactArgs(1),
+	RunE:    commandRolloutUndo,
+}
+
+func init() {
+	rolloutCmd.AddCommand(rolloutUndoCmd)
+
+	rolloutUndoCmd.Flags().Int64Var(&rolloutUndoToRevision, "to-revision", 0, "The revision to roll back to, 0 means the previous revision")
+}
+
+func commandRolloutUndo(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record("rollout undo")
+	name := args[0]
+	revision, err := agentClient.InferenceRollback(cmd.Context(), namespace, name, rolloutUndoToRevision)
+	if err != nil {
+		cmd.PrintErrf("Failed to roll back %s: %s\n", name, errors.Cause(err))
+		return err
+	}
+	cmd.Printf("Inference %s is rolled back to revision %d\n", name, revision.Revision)
+	return nil
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// secretCmd represents the secret command
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage the secrets",
+	Long:  `Manage the secrets. The secrets are mounted under /var/modelz/secrets of the deployments created with --secret.`,
+	Example: `  mdz secret create hf-token --from-literal HF_TOKEN=hf_xxx
+  mdz secret list
+  mdz secret delete hf-token`,
+	GroupID: "management",
+	PreRunE: commandInitLog,
+}
+
+func init() {
+	rootCmd.AddCommand(secretCmd)
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/agent/api/types"
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+var (
+	secretCreateLiterals []string
+	secretCreateFiles    []string
+	secretCreateEnvFiles []string
+	secretCreateReplace  bool
+)
+
+// secretCreateCmd represents the secret create command
+var secretCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a secret",
+	Long:  `Create a secret from the literal values, the files or the env files. Every key is mounted as a file.`,
+	Example: `  mdz secret create hf-token --from-literal HF_TOKEN=hf_xxx
+  mdz secret create s3 --from-file credentials=$HOME/.aws/credentials
+  mdz secret create envs --from-env-file .env`,
+	PreRunE: commandInit,
+	Args:    cobra.ExactArgs(1),
+	RunE:    commandSecretCreate,
+}
+
+func init() {
+	secretCmd.AddCommand(secretCreateCmd)
+
+	secretCreateCmd.Flags().StringArrayVar(&secretCreateLiterals, "from-literal", []string{},
+		"Key and literal value in the format of <key>=<value>")
+	secretCreateCmd.Flags().StringArrayVar(&secretCreateFiles, "from-file", []string{},
+		"File in the format of [<key>=]<path>, the key is the file name by default")
+	secretCreateCmd.Flags().StringArrayVar(&secretCreateEnvFiles, "from-env-file", []string{},
+		"File of the <key>=<value> lines, the empty lines and the lines starting with # are ignored")
+	secretCreateCmd.Flags().BoolVar(&secretCreateReplace, "replace", false,
+		"Replace the values of the existing secret")
+}
+
+func commandSecretCreate(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record("secret create")
+	name := args[0]
+	data, err := makeSecretData()
+	if err != nil {
+		cmd.PrintErrf("Failed to read the secret: %s\n", errors.Cause(err))
+		return err
+	}
+
+	secret := types.Secret{
+		Name: name,
+		Data: data,
+	}
+	if secretCreateReplace {
+		if err := agentClient.SecretUpdate(cmd.Context(), namespace, secret); err != nil {
+			cmd.PrintErrf("Failed to replace the secret: %s\n", errors.Cause(err))
+			return err
+		}
+		cmd.Printf("Secret %s is replaced\n", name)
+		return nil
+	}
+
+	if err := agentClient.SecretCreate(cmd.Context(), namespace, secret); err != nil {
+		cmd.PrintErrf("Failed to create the secret: %s\n", errors.Cause(err))
+		return err
+	}
+	cmd.Printf("Secret %s is created\n", name)
+	return nil
+}
+
+// makeSecretData reads the values of the secret from the flags. A key must
+// not be set twice.
+func makeSecretData() (map[string][]byte, error) {
+	data := map[string][]byte{}
+	add := func(key string, value []byte) error {
+		if key == "" {
+			return errors.New("key of the secret cannot be empty")
+		}
+		if _, ok := data[key]; ok {
+			return errors.Newf("key %q of the secret is duplicated", key)
+		}
+		data[key] = value
+		return nil
+	}
+
+	for _, literal := range secretCreateLiterals {
+		key, value, ok := strings.Cut(literal, "=")
+		if !ok {
+			return nil, errors.Newf("invalid literal %q, must be <key>=<value>", literal)
+		}
+		if err := add(key, []byte(value)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range secretCreateFiles {
+		key, path, ok := strings.Cut(file, "=")
+		if !ok {
+			key, path = filepath.Base(file), file
+		}
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", path)
+		}
+		if err := add(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range secretCreateEnvFiles {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", file)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			key, value, ok := strings.Cut(text, "=")
+			if !ok {
+				return nil, errors.Newf("invalid line %d of %s, must be <key>=<value>", line, file)
+			}
+			if err := add(strings.TrimSpace(key), []byte(value)); err != nil {
+				return nil, err
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", file)
+		}
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("one of --from-literal, --from-file or --from-env-file is required")
+	}
+	return data, nil
+}
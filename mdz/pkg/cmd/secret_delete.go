@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+// secretDeleteCmd represents the secret delete command
+var secretDeleteCmd = &cobra.Command{
+	Use:     "delete",
+	Short:   "Delete a secret",
+	Long:    `Delete a secret`,
+	Example: `  mdz secret delete hf-token`,
+	PreRunE: commandInit,
+	Args:    cobra.ExactArgs(1),
+	RunE:    commandSecretDelete,
+}
+
+func init() {
+	secretCmd.AddCommand(secretDeleteCmd)
+}
+
+func commandSecretDelete(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record("secret delete")
+	name := args[0]
+	if err := agentClient.SecretDelete(cmd.Context(), namespace, name); err != nil {
+		cmd.PrintErrf("Failed to delete the secret: %s\n", errors.Cause(err))
+		return err
+	}
+
+	cmd.Printf("Secret %s is deleted\n", name)
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/tensorchord/openmodelz/mdz/pkg/telemetry"
+)
+
+var (
+	secretListQuiet bool
+)
+
+// secretListCmd represents the secret list command
+var secretListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List the secrets",
+	Long:    `List the secrets, the values are never shown`,
+	Example: `  mdz secret list`,
+	PreRunE: commandInit,
+	RunE:    commandSecretList,
+}
+
+func init() {
+	secretCmd.AddCommand(secretListCmd)
+
+	secretListCmd.Flags().BoolVarP(&secretListQuiet, "quiet", "q", false, "Quiet mode - print out only the secret names")
+}
+
+func commandSecretList(cmd *cobra.Command, args []string) error {
+	telemetry.GetTelemetry().Record("secret list")
+	secrets, err := agentClient.SecretList(cmd.Context(), namespace)
+	if err != nil {
+		cmd.PrintErrf("Failed to list secrets: %s\n", errors.Cause(err))
+		return err
+	}
+
+	if secretListQuiet {
+		for _, secret := range secrets {
+			cmd.Printf("%s\n", secret.Name)
+		}
+		return nil
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.Style{
+		Box:     table.StyleBoxDefault,
+		Color:   table.ColorOptionsDefault,
+		Format:  table.FormatOptionsDefault,
+		HTML:    table.DefaultHTMLOptions,
+		Options: table.OptionsNoBordersAndSeparators,
+		Title:   table.TitleOptionsDefault,
+	})
+	t.AppendHeader(table.Row{"Name"})
+	for _, secret := range secrets {
+		t.AppendRow(table.Row{secret.Name})
+	}
+	cmd.Println(t.Render())
+	return nil
+}
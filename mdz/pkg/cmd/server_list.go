@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/cockroachdb/errors"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -66,12 +67,13 @@ func commandServerList(cmd *cobra.Command, args []string) error {
 			Options: table.OptionsNoBordersAndSeparators,
 			Title:   table.TitleOptionsDefault,
 		})
-		t.AppendHeader(table.Row{"Name", "Phase", "Allocatable", "Capacity", "Distribution", "OS", "Kernel", "Labels"})
+		t.AppendHeader(table.Row{"Name", "Phase", "Allocatable", "Capacity", "Accelerator", "Distribution", "OS", "Kernel", "Labels"})
 
 		for _, server := range servers {
 			t.AppendRow(table.Row{server.Spec.Name, server.Status.Phase,
 				resourceListString(server.Status.Allocatable),
 				resourceListString(server.Status.Capacity),
+				acceleratorString(server.Status.Accelerator),
 				server.Status.System.OSImage,
 				server.Status.System.OperatingSystem,
 				server.Status.System.KernelVersion,
@@ -101,6 +103,16 @@ func commandServerList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func acceleratorString(a *types.ServerAccelerator) string {
+	if a == nil {
+		return ""
+	}
+	if a.Model == "" {
+		return a.Vendor
+	}
+	return fmt.Sprintf("%s (%s)", a.Vendor, a.Model)
+}
+
 func labelsString(labels map[string]string) string {
 	res := ""
 	for k, v := range labels {
@@ -142,5 +154,18 @@ func resourceListString(l types.ResourceList) string {
 	if l[types.ResourceGPU] != "" {
 		res += fmt.Sprintf("\ngpu: %s", l[types.ResourceGPU])
 	}
+	// The shared GPUs, the MIG devices and the accelerators of the other
+	// vendors, sorted by the name.
+	names := []string{}
+	for name := range l {
+		if name != types.ResourceCPU && name != types.ResourceMemory &&
+			name != types.ResourceGPU {
+			names = append(names, string(name))
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		res += fmt.Sprintf("\n%s: %s", name, l[types.ResourceName(name)])
+	}
 	return res
 }
@@ -18,6 +18,7 @@ var (
 	serverStartDomain     string = consts.Domain
 	serverStartVersion    string
 	serverStartWithGPU    bool
+	serverStartGPUVendor  string
 	enableModelZCloud     bool
 	modelzCloudUrl        string
 	modelzCloudAgentToken string
@@ -53,6 +54,8 @@ func init() {
 	serverStartCmd.Flags().MarkHidden("version")
 	serverStartCmd.Flags().BoolVarP(&serverStartWithGPU, "force-gpu", "g",
 		false, "Start the server with GPU support (ignore the GPU detection)")
+	serverStartCmd.Flags().StringVarP(&serverStartGPUVendor, "gpu-vendor", "",
+		"", "Vendor of the GPUs (nvidia, amd or intel), nvidia and amd are detected from the PCI devices if not set")
 	serverStartCmd.Flags().StringVarP(&serverRegistryMirrorName, "mirror-name", "",
 		"docker.io", "Mirror domain name of the registry")
 	serverStartCmd.Flags().StringArrayVarP(&serverRegistryMirrorEndpoints, "mirror-endpoints", "",
@@ -102,6 +105,7 @@ func commandServerStart(cmd *cobra.Command, args []string) error {
 		Domain:        domain,
 		Version:       serverStartVersion,
 		ForceGPU:      serverStartWithGPU,
+		GPUVendor:     serverStartGPUVendor,
 		Mirror: server.Mirror{
 			Name:      serverRegistryMirrorName,
 			Endpoints: serverRegistryMirrorEndpoints,
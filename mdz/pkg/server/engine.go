@@ -20,7 +20,10 @@ type Options struct {
 	Domain        *string
 	Version       string
 	ForceGPU      bool
-	ModelZCloud   ModelZCloud
+	// GPUVendor is the vendor of the GPUs, it is detected from the PCI
+	// devices if it is empty.
+	GPUVendor   string
+	ModelZCloud ModelZCloud
 }
 
 type ModelZCloud struct {
@@ -11,10 +11,36 @@ import (
 	"syscall"
 )
 
-//go:embed gpu-resource.yaml
-var gpuYamlContent string
+const (
+	GPUVendorNvidia = "nvidia"
+	GPUVendorAMD    = "amd"
+	GPUVendorIntel  = "intel"
+)
+
+//go:embed nvidia-gpu-resource.yaml
+var nvidiaGPUYamlContent string
+
+//go:embed amd-gpu-resource.yaml
+var amdGPUYamlContent string
+
+//go:embed intel-gpu-resource.yaml
+var intelGPUYamlContent string
+
+// gpuDevicePlugins are the device plugins of the GPU vendors.
+var gpuDevicePlugins = map[string]string{
+	GPUVendorNvidia: nvidiaGPUYamlContent,
+	GPUVendorAMD:    amdGPUYamlContent,
+	GPUVendorIntel:  intelGPUYamlContent,
+}
 
-// gpuInstallStep installs the GPU related resources.
+var (
+	// regexDisplayDevice matches the GPUs in the output of lspci, e.g.
+	// "03:00.0 Display controller: Advanced Micro Devices, Inc. [AMD/ATI] ...".
+	regexDisplayDevice = regexp.MustCompile(`(?im)^.*(VGA compatible|3D|Display) controller: (.*)$`)
+	regexAMD           = regexp.MustCompile(`(?i)advanced micro devices|amd/ati`)
+)
+
+// gpuInstallStep installs the device plugin of the GPU vendor.
 type gpuInstallStep struct {
 	options Options
 }
@@ -40,24 +66,60 @@ func (s *gpuInstallStep) hasNvidiaToolkit() bool {
 	return false
 }
 
-func (s *gpuInstallStep) hasNvidiaDevice() bool {
-	output, err := exec.Command("/bin/sh", "-c", "lspci").Output()
-	if err != nil {
+func (s *gpuInstallStep) hasNvidiaDevice(lspci []byte) bool {
+	regexNvidia := regexp.MustCompile("(?i)nvidia")
+	return regexNvidia.Match(lspci)
+}
+
+// hasDisplayDevice checks if the GPU of the vendor is in the output of
+// lspci and the driver exposes the device on the host.
+func (s *gpuInstallStep) hasDisplayDevice(lspci []byte, vendor *regexp.Regexp, device string) bool {
+	if _, err := os.Stat(device); err != nil {
 		return false
 	}
-	regexNvidia := regexp.MustCompile("(?i)nvidia")
-	return regexNvidia.Match(output)
+	for _, match := range regexDisplayDevice.FindAllSubmatch(lspci, -1) {
+		if vendor.Match(match[2]) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectVendor returns the vendor of the GPUs on the host, or empty if no
+// GPU is found. NVIDIA takes precedence since the hosts usually have an
+// integrated GPU as well. Intel GPUs are not detected, the integrated
+// graphics of most hosts could not be told from the discrete ones by lspci,
+// --gpu-vendor intel should be set instead.
+func (s *gpuInstallStep) detectVendor() string {
+	lspci, _ := exec.Command("/bin/sh", "-c", "lspci").Output()
+	switch {
+	case s.hasNvidiaDevice(lspci) || s.hasNvidiaToolkit():
+		return GPUVendorNvidia
+	// The ROCm driver exposes the compute devices by /dev/kfd.
+	case s.hasDisplayDevice(lspci, regexAMD, "/dev/kfd"):
+		return GPUVendorAMD
+	}
+	return ""
 }
 
 func (s *gpuInstallStep) Run() error {
-	if !s.options.ForceGPU {
-		// detect GPU
-		if !(s.hasNvidiaDevice() || s.hasNvidiaToolkit()) {
-			fmt.Fprintf(s.options.OutputStream, "🚧 Nvidia Toolkit is missing, skip the GPU initialization.\n")
+	vendor := s.options.GPUVendor
+	if vendor == "" {
+		vendor = s.detectVendor()
+	}
+	if vendor == "" {
+		if !s.options.ForceGPU {
+			fmt.Fprintf(s.options.OutputStream, "🚧 No GPU is detected, skip the GPU initialization.\n")
 			return nil
 		}
+		vendor = GPUVendorNvidia
+	}
+	content, ok := gpuDevicePlugins[vendor]
+	if !ok {
+		return fmt.Errorf("unsupported GPU vendor %s, must be one of %s, %s or %s",
+			vendor, GPUVendorNvidia, GPUVendorAMD, GPUVendorIntel)
 	}
-	fmt.Fprintf(s.options.OutputStream, "🚧 Initializing the GPU resource...\n")
+	fmt.Fprintf(s.options.OutputStream, "🚧 Initializing the %s GPU resource...\n", vendor)
 
 	cmd := exec.Command("/bin/sh", "-c", "sudo k3s kubectl apply -f -")
 	cmd.SysProcAttr = &syscall.SysProcAttr{
@@ -78,7 +140,7 @@ func (s *gpuInstallStep) Run() error {
 	if err := cmd.Start(); err != nil {
 		return err
 	}
-	if _, err := io.WriteString(stdin, gpuYamlContent); err != nil {
+	if _, err := io.WriteString(stdin, content); err != nil {
 		return err
 	}
 	// Close the input stream to finish the pipe. Then the command will use the
@@ -0,0 +1,11 @@
+package main
+
+import "testing"
+
+// Test_run runs the command with --help, the flags of the logging
+// libraries linked into the binary must not conflict.
+func Test_run(t *testing.T) {
+	if err := run([]string{"modelzetes", "--help"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -3,6 +3,9 @@
 export controllergen="$GOPATH/bin/controller-gen"
 export PKG=sigs.k8s.io/controller-tools/cmd/controller-gen@v0.7.0
 
+SCRIPT_ROOT=$(dirname "${BASH_SOURCE[0]}")
+INFERENCE_CRD=./artifacts/crds/tensorchord.ai_inferences.yaml
+
 if [ ! -e "$controllergen" ]; then
   echo "Getting $PKG"
   go install $PKG
@@ -11,5 +14,11 @@ fi
 "$controllergen" \
   crd \
   schemapatch:manifests=./artifacts/crds \
-  paths=./pkg/apis/modelzetes/v2alpha1 \
+  paths=./pkg/apis/modelzetes/... \
   output:dir=./artifacts/crds
+
+# controller-gen does not generate the conversion of the served versions,
+# patch in the webhook which converts v1beta1 to the storage version.
+if ! grep -q "^  conversion:" "$INFERENCE_CRD"; then
+  sed -i "/^spec:$/r ${SCRIPT_ROOT}/crd-conversion.yaml" "$INFERENCE_CRD"
+fi
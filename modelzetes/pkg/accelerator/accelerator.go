@@ -0,0 +1,216 @@
+// Package accelerator describes the accelerators of the vendors and how the
+// inferences use them. An inference requests the accelerator by the resource
+// advertised by the device plugin of the vendor, e.g. nvidia.com/gpu,
+// amd.com/gpu or gpu.intel.com/i915. Besides the whole GPUs, an NVIDIA GPU
+// could be time-sliced (nvidia.com/gpu.shared) or partitioned into the MIG
+// devices, e.g. nvidia.com/mig-1g.10gb.
+//
+// The agent API uses the NVIDIA resources without the vendor prefix for
+// backward compatibility, e.g. gpu, gpu.shared and mig-1g.10gb, and the
+// resources of the other vendors as they are.
+package accelerator
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+// Vendor is the vendor of the accelerators.
+type Vendor string
+
+const (
+	VendorNvidia Vendor = "nvidia"
+	VendorAMD    Vendor = "amd"
+	VendorIntel  Vendor = "intel"
+)
+
+const nvidiaPrefix = "nvidia.com/"
+
+// Accelerator is how the pods use the accelerators of a vendor.
+type Accelerator struct {
+	Vendor Vendor
+	// Resources are the resources advertised by the device plugin.
+	Resources []corev1.ResourceName
+	// ResourcePrefixes matches the resources with dynamic names, e.g. the
+	// MIG devices.
+	ResourcePrefixes []string
+	// Tolerations tolerate the taints of the nodes with the accelerators.
+	Tolerations []corev1.Toleration
+	// RuntimeClassName is the runtime class which exposes the devices to
+	// the containers. It is empty if the default runtime works.
+	RuntimeClassName string
+	// HideDevicesEnv are the env vars set to empty to hide the devices from
+	// the containers which request none of them.
+	HideDevicesEnv []string
+	// ModelLabels are the node labels of the product name, which are set by
+	// the feature discovery of the vendor.
+	ModelLabels []string
+	// ModelLabelPrefix matches the node labels in the format of
+	// <prefix><model>.present if the product is not a label value.
+	ModelLabelPrefix string
+}
+
+// Accelerators are the supported accelerators.
+var Accelerators = []Accelerator{
+	{
+		Vendor: VendorNvidia,
+		Resources: []corev1.ResourceName{
+			consts.ResourceNvidiaGPU, consts.ResourceNvidiaGPUShared},
+		ResourcePrefixes: []string{consts.ResourceNvidiaMIGPrefix},
+		Tolerations: []corev1.Toleration{
+			{
+				Key:      consts.TolerationGPU,
+				Operator: corev1.TolerationOpEqual,
+				Value:    "true",
+			},
+			{
+				Key:      consts.TolerationNvidiaGPUPresent,
+				Operator: corev1.TolerationOpEqual,
+				Value:    "present",
+			},
+		},
+		RuntimeClassName: "nvidia",
+		HideDevicesEnv:   []string{"CUDA_VISIBLE_DEVICES"},
+		ModelLabels:      []string{"nvidia.com/gpu.product"},
+	},
+	{
+		Vendor:    VendorAMD,
+		Resources: []corev1.ResourceName{consts.ResourceAMDGPU},
+		Tolerations: []corev1.Toleration{
+			{
+				Key:      consts.TolerationGPU,
+				Operator: corev1.TolerationOpEqual,
+				Value:    "true",
+			},
+			{
+				Key:      consts.ResourceAMDGPU,
+				Operator: corev1.TolerationOpExists,
+			},
+		},
+		HideDevicesEnv: []string{"HIP_VISIBLE_DEVICES", "ROCR_VISIBLE_DEVICES"},
+		ModelLabels:    []string{"amd.com/gpu.product-name", "amd.com/gpu.family"},
+	},
+	{
+		Vendor:    VendorIntel,
+		Resources: []corev1.ResourceName{consts.ResourceIntelGPU},
+		Tolerations: []corev1.Toleration{
+			{
+				Key:      consts.TolerationGPU,
+				Operator: corev1.TolerationOpEqual,
+				Value:    "true",
+			},
+			{
+				Key:      consts.ResourceIntelGPU,
+				Operator: corev1.TolerationOpExists,
+			},
+		},
+		ModelLabelPrefix: "gpu.intel.com/platform_",
+	},
+}
+
+// Owns returns true if the resource is advertised by the device plugin of
+// the accelerator.
+func (a Accelerator) Owns(name corev1.ResourceName) bool {
+	for _, r := range a.Resources {
+		if r == name {
+			return true
+		}
+	}
+	for _, prefix := range a.ResourcePrefixes {
+		if strings.HasPrefix(string(name), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForResource returns the accelerator of the resource.
+func ForResource(name corev1.ResourceName) (Accelerator, bool) {
+	for _, a := range Accelerators {
+		if a.Owns(name) {
+			return a, true
+		}
+	}
+	return Accelerator{}, false
+}
+
+// IsResource returns true if the resource is allocated on the accelerators.
+func IsResource(name corev1.ResourceName) bool {
+	_, ok := ForResource(name)
+	return ok
+}
+
+// IsMIG returns true if the resource is an NVIDIA MIG device.
+func IsMIG(name corev1.ResourceName) bool {
+	return strings.HasPrefix(string(name), consts.ResourceNvidiaMIGPrefix)
+}
+
+// ResourceName returns the resource of the device plugin from the name
+// used by the agent API, e.g. mig-1g.10gb is nvidia.com/mig-1g.10gb. It
+// returns false if the name is not an accelerator resource.
+func ResourceName(name string) (corev1.ResourceName, bool) {
+	if res := corev1.ResourceName(nvidiaPrefix + name); IsResource(res) {
+		return res, true
+	}
+	if res := corev1.ResourceName(name); IsResource(res) {
+		return res, true
+	}
+	return "", false
+}
+
+// ShortName returns the name used by the agent API of the accelerator
+// resource.
+func ShortName(name corev1.ResourceName) string {
+	return strings.TrimPrefix(string(name), nvidiaPrefix)
+}
+
+// Requested returns the accelerator resource in the list and its quantity.
+// It returns false if no accelerator resource is in the list.
+func Requested(list corev1.ResourceList) (Accelerator, corev1.ResourceName, resource.Quantity, bool) {
+	for name, qty := range list {
+		if a, ok := ForResource(name); ok {
+			return a, name, qty, true
+		}
+	}
+	return Accelerator{}, "", resource.Quantity{}, false
+}
+
+// Detect returns the accelerator of the node and the product name from the
+// node labels. The model is empty if the feature discovery is not deployed.
+func Detect(labels map[string]string, capacity corev1.ResourceList) (Accelerator, string, bool) {
+	for _, a := range Accelerators {
+		found := false
+		for name, qty := range capacity {
+			if a.Owns(name) && !qty.IsZero() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		return a, a.model(labels), true
+	}
+	return Accelerator{}, "", false
+}
+
+func (a Accelerator) model(labels map[string]string) string {
+	for _, l := range a.ModelLabels {
+		if v := labels[l]; v != "" {
+			return v
+		}
+	}
+	if a.ModelLabelPrefix == "" {
+		return ""
+	}
+	for k := range labels {
+		if strings.HasPrefix(k, a.ModelLabelPrefix) && strings.HasSuffix(k, ".present") {
+			return strings.TrimSuffix(strings.TrimPrefix(k, a.ModelLabelPrefix), ".present")
+		}
+	}
+	return ""
+}
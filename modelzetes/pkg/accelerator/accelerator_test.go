@@ -0,0 +1,86 @@
+package accelerator
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestResourceName(t *testing.T) {
+	tcs := []struct {
+		name   string
+		expect corev1.ResourceName
+		ok     bool
+	}{
+		{"gpu", "nvidia.com/gpu", true},
+		{"gpu.shared", "nvidia.com/gpu.shared", true},
+		{"mig-1g.10gb", "nvidia.com/mig-1g.10gb", true},
+		{"amd.com/gpu", "amd.com/gpu", true},
+		{"gpu.intel.com/i915", "gpu.intel.com/i915", true},
+		{"cpu", "", false},
+	}
+	for _, tc := range tcs {
+		res, ok := ResourceName(tc.name)
+		if res != tc.expect || ok != tc.ok {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expect, res)
+		}
+		if ok && ShortName(res) != tc.name {
+			t.Errorf("%s: unexpected short name %s", tc.name, ShortName(res))
+		}
+	}
+}
+
+func TestRequested(t *testing.T) {
+	a, name, qty, ok := Requested(corev1.ResourceList{
+		corev1.ResourceCPU:       resource.MustParse("1"),
+		"nvidia.com/mig-3g.40gb": resource.MustParse("2"),
+	})
+	if !ok || a.Vendor != VendorNvidia || name != "nvidia.com/mig-3g.40gb" || qty.Value() != 2 {
+		t.Errorf("unexpected accelerator resource %s: %s", name, qty.String())
+	}
+	if _, _, _, ok := Requested(corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("1")}); ok {
+		t.Errorf("expected no accelerator resource")
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		labels   map[string]string
+		capacity corev1.ResourceList
+		vendor   Vendor
+		model    string
+	}{
+		{
+			desc:     "nvidia",
+			labels:   map[string]string{"nvidia.com/gpu.product": "NVIDIA-A100-SXM4-40GB"},
+			capacity: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+			vendor:   VendorNvidia,
+			model:    "NVIDIA-A100-SXM4-40GB",
+		},
+		{
+			desc:     "amd without labeller",
+			capacity: corev1.ResourceList{"amd.com/gpu": resource.MustParse("1")},
+			vendor:   VendorAMD,
+		},
+		{
+			desc:     "intel",
+			labels:   map[string]string{"gpu.intel.com/platform_gpu_flex.present": "true"},
+			capacity: corev1.ResourceList{"gpu.intel.com/i915": resource.MustParse("1")},
+			vendor:   VendorIntel,
+			model:    "gpu_flex",
+		},
+	}
+	for _, tc := range tcs {
+		a, model, ok := Detect(tc.labels, tc.capacity)
+		if !ok || a.Vendor != tc.vendor || model != tc.model {
+			t.Errorf("%s: unexpected accelerator %s %s", tc.desc, a.Vendor, model)
+		}
+	}
+	if _, _, ok := Detect(nil, corev1.ResourceList{
+		"nvidia.com/gpu": resource.MustParse("0")}); ok {
+		t.Errorf("expected no accelerator on the node without the devices")
+	}
+}
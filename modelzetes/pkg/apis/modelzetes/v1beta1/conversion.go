@@ -0,0 +1,512 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+)
+
+// ConversionDataAnnotation keeps the fields which cannot be represented in
+// the other version, so that the objects round-trip without loss.
+const ConversionDataAnnotation = "ai.tensorchord.conversion-data"
+
+// conversionData is the content of ConversionDataAnnotation. The fields are
+// only set if they cannot be restored from the other version.
+type conversionData struct {
+	// Command, Args and NodeSelector are kept when converting v1beta1 to
+	// v2alpha1.
+	Command      []string          `json:"command,omitempty"`
+	Args         []string          `json:"args,omitempty"`
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Probes is only read from the objects converted before v2alpha1
+	// supported the probe settings.
+	Probes *Probes `json:"probes,omitempty"`
+	// Constraints is kept when converting v2alpha1 to v1beta1.
+	Constraints []string `json:"constraints,omitempty"`
+	// EmptyProbes is kept when converting v2alpha1 to v1beta1 if the
+	// probes are empty while the http probe path is set.
+	EmptyProbes bool `json:"emptyProbes,omitempty"`
+}
+
+func (d conversionData) empty() bool {
+	return d.Command == nil && d.Args == nil && d.NodeSelector == nil &&
+		d.Probes == nil && d.Constraints == nil && !d.EmptyProbes
+}
+
+// ConvertTo converts the inference to v2alpha1, which is the storage version.
+func (src *Inference) ConvertTo(dst *v2alpha1.Inference) error {
+	data, err := getConversionData(src.ObjectMeta.Annotations)
+	if err != nil {
+		return err
+	}
+
+	dst.TypeMeta = src.TypeMeta
+	dst.APIVersion = v2alpha1.SchemeGroupVersion.String()
+	dst.Kind = v2alpha1.Kind
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	in := src.Spec.DeepCopy()
+	dst.Spec = v2alpha1.InferenceSpec{
+		Name:        in.Name,
+		Image:       in.Image,
+		Framework:   v2alpha1.Framework(in.Framework),
+		Port:        in.Port,
+		EnvVars:     in.EnvVars,
+		Secrets:     in.Secrets,
+		Labels:      in.Labels,
+		Annotations: in.Annotations,
+		Resources:   in.Resources,
+	}
+	if in.Model != nil {
+		dst.Spec.Model = &v2alpha1.ModelSource{
+			Type:      v2alpha1.ModelSourceType(in.Model.Type),
+			URI:       in.Model.URI,
+			Revision:  in.Model.Revision,
+			Endpoint:  in.Model.Endpoint,
+			MountPath: in.Model.MountPath,
+		}
+	}
+	if in.Scheduling != nil {
+		dst.Spec.Scheduling = convertSchedulingTo(in.Scheduling)
+	}
+	if in.Rollout != nil {
+		dst.Spec.Rollout = &v2alpha1.RolloutPolicy{
+			ProgressDeadlineSeconds: in.Rollout.ProgressDeadlineSeconds,
+			MinReadyPercent:         in.Rollout.MinReadyPercent,
+		}
+		if in.Rollout.ErrorRate != nil {
+			dst.Spec.Rollout.ErrorRate = &v2alpha1.ErrorRateCheck{
+				MaxErrorPercent: in.Rollout.ErrorRate.MaxErrorPercent,
+				WindowSeconds:   in.Rollout.ErrorRate.WindowSeconds,
+				MinRequests:     in.Rollout.ErrorRate.MinRequests,
+			}
+		}
+	}
+	if in.Termination != nil {
+		dst.Spec.Termination = &v2alpha1.TerminationConfig{
+			GracePeriodSeconds:  in.Termination.GracePeriodSeconds,
+			PreStopSleepSeconds: in.Termination.PreStopSleepSeconds,
+		}
+	}
+	if in.DisruptionBudget != nil {
+		dst.Spec.DisruptionBudget = &v2alpha1.DisruptionBudgetConfig{
+			Disabled:       in.DisruptionBudget.Disabled,
+			MaxUnavailable: in.DisruptionBudget.MaxUnavailable,
+		}
+	}
+	if in.Scaling != nil {
+		dst.Spec.Scaling = &v2alpha1.ScalingConfig{
+			MinReplicas:     in.Scaling.MinReplicas,
+			MaxReplicas:     in.Scaling.MaxReplicas,
+			TargetLoad:      in.Scaling.TargetLoad,
+			ZeroDuration:    in.Scaling.ZeroDuration,
+			StartupDuration: in.Scaling.StartupDuration,
+		}
+		if in.Scaling.Type != nil {
+			typ := v2alpha1.ScalingType(*in.Scaling.Type)
+			dst.Spec.Scaling.Type = &typ
+		}
+		if in.Scaling.CustomMetric != nil {
+			dst.Spec.Scaling.CustomMetric = &v2alpha1.CustomMetric{
+				Query:       in.Scaling.CustomMetric.Query,
+				TargetValue: in.Scaling.CustomMetric.TargetValue,
+			}
+		}
+	}
+
+	next := conversionData{}
+
+	// The command of v2alpha1 is split on spaces, keep the arrays if they
+	// cannot be restored from the joined command.
+	all := append(append([]string{}, in.Command...), in.Args...)
+	if len(all) > 0 {
+		command := strings.Join(all, " ")
+		dst.Spec.Command = &command
+		if len(in.Args) > 0 ||
+			!equality.Semantic.DeepEqual(strings.Split(command, " "), in.Command) {
+			next.Command, next.Args = in.Command, in.Args
+		}
+	}
+
+	// Restore the constraints of v2alpha1 if the node selector is unchanged,
+	// since the order and malformed constraints cannot be kept in the map.
+	if data.Constraints != nil && equality.Semantic.DeepEqual(
+		nodeSelectorFromConstraints(data.Constraints), in.NodeSelector) {
+		dst.Spec.Constraints = data.Constraints
+	} else {
+		dst.Spec.Constraints = constraintsFromNodeSelector(in.NodeSelector)
+		if !equality.Semantic.DeepEqual(
+			nodeSelectorFromConstraints(dst.Spec.Constraints), in.NodeSelector) {
+			next.NodeSelector = in.NodeSelector
+		}
+	}
+
+	if in.Probes != nil {
+		dst.Spec.HTTPProbePath = in.Probes.HTTPPath
+		probes := &v2alpha1.Probes{
+			Startup:   convertProbeTo(in.Probes.Startup),
+			Readiness: convertProbeTo(in.Probes.Readiness),
+			Liveness:  convertProbeTo(in.Probes.Liveness),
+		}
+		// The probes with the path only are represented by the http probe
+		// path in v2alpha1.
+		if *probes != (v2alpha1.Probes{}) || in.Probes.HTTPPath == nil ||
+			data.EmptyProbes {
+			dst.Spec.Probes = probes
+		}
+	}
+
+	convertStatusTo(&src.Status, &dst.Status)
+	return setConversionData(&dst.ObjectMeta.Annotations, next)
+}
+
+// ConvertFrom converts the inference from v2alpha1.
+func (dst *Inference) ConvertFrom(src *v2alpha1.Inference) error {
+	data, err := getConversionData(src.ObjectMeta.Annotations)
+	if err != nil {
+		return err
+	}
+
+	dst.TypeMeta = src.TypeMeta
+	dst.APIVersion = SchemeGroupVersion.String()
+	dst.Kind = v2alpha1.Kind
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	in := src.Spec.DeepCopy()
+	dst.Spec = InferenceSpec{
+		Name:        in.Name,
+		Image:       in.Image,
+		Framework:   Framework(in.Framework),
+		Port:        in.Port,
+		EnvVars:     in.EnvVars,
+		Secrets:     in.Secrets,
+		Labels:      in.Labels,
+		Annotations: in.Annotations,
+		Resources:   in.Resources,
+	}
+	if in.Model != nil {
+		dst.Spec.Model = &ModelSource{
+			Type:      ModelSourceType(in.Model.Type),
+			URI:       in.Model.URI,
+			Revision:  in.Model.Revision,
+			Endpoint:  in.Model.Endpoint,
+			MountPath: in.Model.MountPath,
+		}
+	}
+	if in.Scheduling != nil {
+		dst.Spec.Scheduling = convertSchedulingFrom(in.Scheduling)
+	}
+	if in.Rollout != nil {
+		dst.Spec.Rollout = &RolloutPolicy{
+			ProgressDeadlineSeconds: in.Rollout.ProgressDeadlineSeconds,
+			MinReadyPercent:         in.Rollout.MinReadyPercent,
+		}
+		if in.Rollout.ErrorRate != nil {
+			dst.Spec.Rollout.ErrorRate = &ErrorRateCheck{
+				MaxErrorPercent: in.Rollout.ErrorRate.MaxErrorPercent,
+				WindowSeconds:   in.Rollout.ErrorRate.WindowSeconds,
+				MinRequests:     in.Rollout.ErrorRate.MinRequests,
+			}
+		}
+	}
+	if in.Termination != nil {
+		dst.Spec.Termination = &TerminationConfig{
+			GracePeriodSeconds:  in.Termination.GracePeriodSeconds,
+			PreStopSleepSeconds: in.Termination.PreStopSleepSeconds,
+		}
+	}
+	if in.DisruptionBudget != nil {
+		dst.Spec.DisruptionBudget = &DisruptionBudgetConfig{
+			Disabled:       in.DisruptionBudget.Disabled,
+			MaxUnavailable: in.DisruptionBudget.MaxUnavailable,
+		}
+	}
+	if in.Scaling != nil {
+		dst.Spec.Scaling = &ScalingConfig{
+			MinReplicas:     in.Scaling.MinReplicas,
+			MaxReplicas:     in.Scaling.MaxReplicas,
+			TargetLoad:      in.Scaling.TargetLoad,
+			ZeroDuration:    in.Scaling.ZeroDuration,
+			StartupDuration: in.Scaling.StartupDuration,
+		}
+		if in.Scaling.Type != nil {
+			typ := ScalingType(*in.Scaling.Type)
+			dst.Spec.Scaling.Type = &typ
+		}
+		if in.Scaling.CustomMetric != nil {
+			dst.Spec.Scaling.CustomMetric = &CustomMetric{
+				Query:       in.Scaling.CustomMetric.Query,
+				TargetValue: in.Scaling.CustomMetric.TargetValue,
+			}
+		}
+	}
+
+	next := conversionData{}
+
+	if in.Command != nil {
+		all := append(append([]string{}, data.Command...), data.Args...)
+		if (data.Command != nil || data.Args != nil) &&
+			strings.Join(all, " ") == *in.Command {
+			dst.Spec.Command, dst.Spec.Args = data.Command, data.Args
+		} else {
+			// The same as how the controller splits the command.
+			dst.Spec.Command = strings.Split(*in.Command, " ")
+		}
+	}
+
+	if data.NodeSelector != nil && equality.Semantic.DeepEqual(
+		constraintsFromNodeSelector(data.NodeSelector), in.Constraints) {
+		dst.Spec.NodeSelector = data.NodeSelector
+	} else {
+		dst.Spec.NodeSelector = nodeSelectorFromConstraints(in.Constraints)
+		if !equality.Semantic.DeepEqual(
+			constraintsFromNodeSelector(dst.Spec.NodeSelector), in.Constraints) {
+			next.Constraints = in.Constraints
+		}
+	}
+
+	if in.Probes != nil {
+		dst.Spec.Probes = &Probes{
+			HTTPPath:  in.HTTPProbePath,
+			Startup:   convertProbeFrom(in.Probes.Startup),
+			Readiness: convertProbeFrom(in.Probes.Readiness),
+			Liveness:  convertProbeFrom(in.Probes.Liveness),
+		}
+		if *in.Probes == (v2alpha1.Probes{}) && in.HTTPProbePath != nil {
+			next.EmptyProbes = true
+		}
+	} else if data.Probes != nil {
+		dst.Spec.Probes = data.Probes
+		dst.Spec.Probes.HTTPPath = in.HTTPProbePath
+	} else if in.HTTPProbePath != nil {
+		dst.Spec.Probes = &Probes{HTTPPath: in.HTTPProbePath}
+	}
+
+	convertStatusFrom(&src.Status, &dst.Status)
+	return setConversionData(&dst.ObjectMeta.Annotations, next)
+}
+
+func convertProbeTo(in *ProbeSettings) *v2alpha1.Probe {
+	if in == nil {
+		return nil
+	}
+	out := &v2alpha1.Probe{
+		InitialDelaySeconds: in.InitialDelaySeconds,
+		PeriodSeconds:       in.PeriodSeconds,
+		TimeoutSeconds:      in.TimeoutSeconds,
+		SuccessThreshold:    in.SuccessThreshold,
+		FailureThreshold:    in.FailureThreshold,
+	}
+	if in.HTTPGet != nil {
+		out.HTTPGet = &v2alpha1.HTTPGetAction{Path: in.HTTPGet.Path, Port: in.HTTPGet.Port}
+	}
+	if in.TCPSocket != nil {
+		out.TCPSocket = &v2alpha1.TCPSocketAction{Port: in.TCPSocket.Port}
+	}
+	if in.GRPC != nil {
+		out.GRPC = &v2alpha1.GRPCAction{Port: in.GRPC.Port, Service: in.GRPC.Service}
+	}
+	if in.Exec != nil {
+		out.Exec = &v2alpha1.ExecAction{Command: in.Exec.Command}
+	}
+	return out
+}
+
+func convertProbeFrom(in *v2alpha1.Probe) *ProbeSettings {
+	if in == nil {
+		return nil
+	}
+	out := &ProbeSettings{
+		InitialDelaySeconds: in.InitialDelaySeconds,
+		PeriodSeconds:       in.PeriodSeconds,
+		TimeoutSeconds:      in.TimeoutSeconds,
+		SuccessThreshold:    in.SuccessThreshold,
+		FailureThreshold:    in.FailureThreshold,
+	}
+	if in.HTTPGet != nil {
+		out.HTTPGet = &HTTPGetAction{Path: in.HTTPGet.Path, Port: in.HTTPGet.Port}
+	}
+	if in.TCPSocket != nil {
+		out.TCPSocket = &TCPSocketAction{Port: in.TCPSocket.Port}
+	}
+	if in.GRPC != nil {
+		out.GRPC = &GRPCAction{Port: in.GRPC.Port, Service: in.GRPC.Service}
+	}
+	if in.Exec != nil {
+		out.Exec = &ExecAction{Command: in.Exec.Command}
+	}
+	return out
+}
+
+func convertSchedulingTo(in *SchedulingConfig) *v2alpha1.SchedulingConfig {
+	out := &v2alpha1.SchedulingConfig{
+		Tolerations: in.Tolerations,
+	}
+	if in.NodeAffinity != nil {
+		out.NodeAffinity = &v2alpha1.NodeAffinity{
+			Required:  in.NodeAffinity.Required,
+			Preferred: in.NodeAffinity.Preferred,
+		}
+	}
+	if in.PodAntiAffinity != nil {
+		out.PodAntiAffinity = &v2alpha1.PodAntiAffinity{
+			TopologyKey: in.PodAntiAffinity.TopologyKey,
+			Required:    in.PodAntiAffinity.Required,
+			Weight:      in.PodAntiAffinity.Weight,
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		out.TopologySpreadConstraints = make([]v2alpha1.TopologySpreadConstraint,
+			len(in.TopologySpreadConstraints))
+		for i, c := range in.TopologySpreadConstraints {
+			out.TopologySpreadConstraints[i] = v2alpha1.TopologySpreadConstraint{
+				TopologyKey:       c.TopologyKey,
+				MaxSkew:           c.MaxSkew,
+				WhenUnsatisfiable: c.WhenUnsatisfiable,
+			}
+		}
+	}
+	return out
+}
+
+func convertSchedulingFrom(in *v2alpha1.SchedulingConfig) *SchedulingConfig {
+	out := &SchedulingConfig{
+		Tolerations: in.Tolerations,
+	}
+	if in.NodeAffinity != nil {
+		out.NodeAffinity = &NodeAffinity{
+			Required:  in.NodeAffinity.Required,
+			Preferred: in.NodeAffinity.Preferred,
+		}
+	}
+	if in.PodAntiAffinity != nil {
+		out.PodAntiAffinity = &PodAntiAffinity{
+			TopologyKey: in.PodAntiAffinity.TopologyKey,
+			Required:    in.PodAntiAffinity.Required,
+			Weight:      in.PodAntiAffinity.Weight,
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		out.TopologySpreadConstraints = make([]TopologySpreadConstraint,
+			len(in.TopologySpreadConstraints))
+		for i, c := range in.TopologySpreadConstraints {
+			out.TopologySpreadConstraints[i] = TopologySpreadConstraint{
+				TopologyKey:       c.TopologyKey,
+				MaxSkew:           c.MaxSkew,
+				WhenUnsatisfiable: c.WhenUnsatisfiable,
+			}
+		}
+	}
+	return out
+}
+
+func convertStatusTo(in *InferenceStatus, out *v2alpha1.InferenceStatus) {
+	*out = v2alpha1.InferenceStatus{
+		ObservedGeneration: in.ObservedGeneration,
+		Replicas:           in.Replicas,
+		AvailableReplicas:  in.AvailableReplicas,
+		Phase:              v2alpha1.InferencePhase(in.Phase),
+	}
+	for _, c := range in.Conditions {
+		out.Conditions = append(out.Conditions, *c.DeepCopy())
+	}
+	if in.Rollout != nil {
+		out.Rollout = &v2alpha1.RolloutStatus{
+			ReplicaSet:    in.Rollout.ReplicaSet,
+			Phase:         v2alpha1.RolloutPhase(in.Rollout.Phase),
+			StartedAt:     in.Rollout.StartedAt,
+			ReadyReplicas: in.Rollout.ReadyReplicas,
+			Message:       in.Rollout.Message,
+		}
+	}
+}
+
+func convertStatusFrom(in *v2alpha1.InferenceStatus, out *InferenceStatus) {
+	*out = InferenceStatus{
+		ObservedGeneration: in.ObservedGeneration,
+		Replicas:           in.Replicas,
+		AvailableReplicas:  in.AvailableReplicas,
+		Phase:              InferencePhase(in.Phase),
+	}
+	for _, c := range in.Conditions {
+		out.Conditions = append(out.Conditions, *c.DeepCopy())
+	}
+	if in.Rollout != nil {
+		out.Rollout = &RolloutStatus{
+			ReplicaSet:    in.Rollout.ReplicaSet,
+			Phase:         RolloutPhase(in.Rollout.Phase),
+			StartedAt:     in.Rollout.StartedAt,
+			ReadyReplicas: in.Rollout.ReadyReplicas,
+			Message:       in.Rollout.Message,
+		}
+	}
+}
+
+// nodeSelectorFromConstraints parses the constraints in the format of
+// <label>=<value>, the malformed ones are ignored like the controller does.
+func nodeSelectorFromConstraints(constraints []string) map[string]string {
+	if len(constraints) == 0 {
+		return nil
+	}
+	selector := map[string]string{}
+	for _, constraint := range constraints {
+		parts := strings.Split(constraint, "=")
+		if len(parts) == 2 {
+			selector[parts[0]] = parts[1]
+		}
+	}
+	return selector
+}
+
+// constraintsFromNodeSelector returns the sorted constraints of the node
+// selector.
+func constraintsFromNodeSelector(selector map[string]string) []string {
+	if len(selector) == 0 {
+		return nil
+	}
+	constraints := make([]string, 0, len(selector))
+	for k, v := range selector {
+		constraints = append(constraints, k+"="+v)
+	}
+	sort.Strings(constraints)
+	return constraints
+}
+
+// getConversionData decodes the conversion data from the annotations of the
+// source object.
+func getConversionData(annotations map[string]string) (conversionData, error) {
+	data := conversionData{}
+	value, ok := annotations[ConversionDataAnnotation]
+	if !ok {
+		return data, nil
+	}
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return data, fmt.Errorf("failed to decode %s: %w", ConversionDataAnnotation, err)
+	}
+	return data, nil
+}
+
+// setConversionData replaces the conversion data in the annotations of the
+// converted object.
+func setConversionData(annotations *map[string]string, data conversionData) error {
+	delete(*annotations, ConversionDataAnnotation)
+	if !data.empty() {
+		value, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if *annotations == nil {
+			*annotations = map[string]string{}
+		}
+		(*annotations)[ConversionDataAnnotation] = string(value)
+	}
+	if len(*annotations) == 0 {
+		*annotations = nil
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package v1beta1
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+const fuzzIterations = 1000
+
+func newFuzzer(seed int64) *fuzz.Fuzzer {
+	return fuzz.New().NilChance(0.3).NumElements(0, 3).
+		RandSource(rand.NewSource(seed)).
+		Funcs(
+			func(q *resource.Quantity, c fuzz.Continue) {
+				*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+			},
+			func(m *metav1.ObjectMeta, c fuzz.Continue) {
+				m.Name = c.RandString()
+				m.Namespace = c.RandString()
+				m.Generation = c.Int63()
+				c.Fuzz(&m.Labels)
+				c.Fuzz(&m.Annotations)
+			},
+			// Use a small alphabet so that spaces and separators are common.
+			func(s *string, c fuzz.Continue) {
+				const alphabet = "ab =/"
+				b := make([]byte, c.Intn(6))
+				for i := range b {
+					b[i] = alphabet[c.Intn(len(alphabet))]
+				}
+				*s = string(b)
+			},
+		)
+}
+
+func typeMeta(version string) metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: "tensorchord.ai/" + version,
+		Kind:       v2alpha1.Kind,
+	}
+}
+
+func Test_RoundTripFromV1beta1(t *testing.T) {
+	f := newFuzzer(1)
+	for i := 0; i < fuzzIterations; i++ {
+		original := &Inference{}
+		f.Fuzz(original)
+		original.TypeMeta = typeMeta("v1beta1")
+
+		hub := &v2alpha1.Inference{}
+		if err := original.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatalf("failed to convert to v2alpha1: %v", err)
+		}
+		res := &Inference{}
+		if err := res.ConvertFrom(hub); err != nil {
+			t.Fatalf("failed to convert from v2alpha1: %v", err)
+		}
+		if !equality.Semantic.DeepEqual(original, res) {
+			t.Fatalf("round trip failed: %s", cmp.Diff(original, res))
+		}
+	}
+}
+
+func Test_RoundTripFromV2alpha1(t *testing.T) {
+	f := newFuzzer(2)
+	for i := 0; i < fuzzIterations; i++ {
+		original := &v2alpha1.Inference{}
+		f.Fuzz(original)
+		original.TypeMeta = typeMeta("v2alpha1")
+
+		spoke := &Inference{}
+		if err := spoke.ConvertFrom(original.DeepCopy()); err != nil {
+			t.Fatalf("failed to convert from v2alpha1: %v", err)
+		}
+		res := &v2alpha1.Inference{}
+		if err := spoke.ConvertTo(res); err != nil {
+			t.Fatalf("failed to convert to v2alpha1: %v", err)
+		}
+		if !equality.Semantic.DeepEqual(original, res) {
+			t.Fatalf("round trip failed: %s", cmp.Diff(original, res))
+		}
+	}
+}
+
+func Test_ConvertStructuredFields(t *testing.T) {
+	src := &Inference{
+		Spec: InferenceSpec{
+			Command:      []string{"python", "-m", "vllm"},
+			Args:         []string{"--model", "facebook/opt-125m"},
+			NodeSelector: map[string]string{"tensorchord.ai/gpu": "a100", "zone": "a"},
+			Probes: &Probes{
+				HTTPPath: Ptr("/healthz"),
+			},
+		},
+	}
+	dst := &v2alpha1.Inference{}
+	if err := src.ConvertTo(dst); err != nil {
+		t.Fatal(err)
+	}
+	if *dst.Spec.Command != "python -m vllm --model facebook/opt-125m" {
+		t.Errorf("unexpected command %q", *dst.Spec.Command)
+	}
+	if diff := cmp.Diff([]string{"tensorchord.ai/gpu=a100", "zone=a"},
+		dst.Spec.Constraints); diff != "" {
+		t.Errorf("unexpected constraints: %s", diff)
+	}
+	if *dst.Spec.HTTPProbePath != "/healthz" {
+		t.Errorf("unexpected probe path %q", *dst.Spec.HTTPProbePath)
+	}
+	if _, ok := dst.Annotations[ConversionDataAnnotation]; !ok {
+		t.Errorf("the args should be kept in the conversion data")
+	}
+
+	// The arrays are split again if the command is changed in v2alpha1.
+	dst.Spec.Command = Ptr("python app.py")
+	res := &Inference{}
+	if err := res.ConvertFrom(dst); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"python", "app.py"}, res.Spec.Command); diff != "" ||
+		res.Spec.Args != nil {
+		t.Errorf("unexpected command %v, args %v", res.Spec.Command, res.Spec.Args)
+	}
+	if res.Annotations != nil {
+		t.Errorf("unexpected annotations %v", res.Annotations)
+	}
+}
+
+func Test_ConvertProbes(t *testing.T) {
+	src := &Inference{
+		Spec: InferenceSpec{
+			Probes: &Probes{
+				HTTPPath: Ptr("/healthz"),
+				Startup: &ProbeSettings{
+					TCPSocket:        &TCPSocketAction{Port: Ptr(int32(8080))},
+					FailureThreshold: Ptr(int32(60)),
+				},
+			},
+		},
+	}
+	dst := &v2alpha1.Inference{}
+	if err := src.ConvertTo(dst); err != nil {
+		t.Fatal(err)
+	}
+	expect := &v2alpha1.Probes{
+		Startup: &v2alpha1.Probe{
+			TCPSocket:        &v2alpha1.TCPSocketAction{Port: Ptr(int32(8080))},
+			FailureThreshold: Ptr(int32(60)),
+		},
+	}
+	if diff := cmp.Diff(expect, dst.Spec.Probes); diff != "" {
+		t.Errorf("unexpected probes: %s", diff)
+	}
+	if *dst.Spec.HTTPProbePath != "/healthz" {
+		t.Errorf("unexpected probe path %q", *dst.Spec.HTTPProbePath)
+	}
+
+	res := &Inference{}
+	if err := res.ConvertFrom(dst); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(src.Spec.Probes, res.Spec.Probes); diff != "" {
+		t.Errorf("unexpected probes after the round trip: %s", diff)
+	}
+}
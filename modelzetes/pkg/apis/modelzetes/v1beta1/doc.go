@@ -0,0 +1,22 @@
+// +k8s:deepcopy-gen=package,register
+
+// Package v1beta1 is the modelzetes API.
+//
+// v1beta1 replaces the stringly typed fields of v2alpha1 with structured
+// ones: nodeSelector instead of key=value constraints, command and args
+// arrays instead of a command split on spaces, typed probe settings and a
+// camelCase scaling config. Both versions are served, v2alpha1 stays the
+// storage version and the conversion webhook of modelzetes translates
+// between them. The fields which cannot be represented in v2alpha1 are kept
+// in the conversion data annotation, so objects round-trip without loss.
+//
+// To migrate the storage version to v1beta1 once all clients use it:
+//  1. Set storage to true for v1beta1 and false for v2alpha1 in the CRD.
+//  2. Rewrite all inferences to store them in v1beta1, e.g. with
+//     kube-storage-version-migrator or `kubectl get inferences -A -o json |
+//     kubectl replace -f -`.
+//  3. Remove v2alpha1 from status.storedVersions of the CRD, then stop
+//     serving v2alpha1.
+//
+// +groupName=tensorchord.ai
+package v1beta1
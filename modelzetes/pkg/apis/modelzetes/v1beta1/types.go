@@ -0,0 +1,394 @@
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.replicas`
+// +kubebuilder:printcolumn:name="Available",type=integer,JSONPath=`.status.availableReplicas`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Inference describes an Inference
+type Inference struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InferenceSpec   `json:"spec"`
+	Status InferenceStatus `json:"status,omitempty"`
+}
+
+// InferenceSpec defines the desired state of Inference
+type InferenceSpec struct {
+	Name string `json:"name"`
+
+	Image string `json:"image"`
+
+	// Scaling is the scaling configuration for the inference.
+	Scaling *ScalingConfig `json:"scaling,omitempty"`
+
+	// Framework is the inference framework.
+	Framework Framework `json:"framework,omitempty"`
+
+	// Port is the port exposed by the inference.
+	Port *int32 `json:"port,omitempty"`
+
+	// Command is the entrypoint array of the inference. It is not executed
+	// within a shell.
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments to the entrypoint.
+	Args []string `json:"args,omitempty"`
+
+	// EnvVars can be provided to set environment variables for the inference runtime.
+	EnvVars map[string]string `json:"envVars,omitempty"`
+
+	// NodeSelector is the labels of the nodes which the inference can be
+	// scheduled to.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Secrets list of secrets to be made available to inference
+	Secrets []string `json:"secrets,omitempty"`
+
+	// Labels are metadata for inferences which may be used by the
+	// faas-provider or the gateway
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are metadata for inferences which may be used by the
+	// faas-provider or the gateway
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Limits for inference
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Probes configures the health checks of the inference.
+	Probes *Probes `json:"probes,omitempty"`
+
+	// Model is the source of the model artifacts. They are downloaded into
+	// the cache of the node before the inference starts.
+	Model *ModelSource `json:"model,omitempty"`
+
+	// Scheduling is the placement of the inference pods across the nodes.
+	Scheduling *SchedulingConfig `json:"scheduling,omitempty"`
+
+	// Rollout checks the health of the rolling update, the inference is
+	// rolled back to the previous revision if the check fails.
+	Rollout *RolloutPolicy `json:"rollout,omitempty"`
+
+	// Termination configures the graceful shutdown of the inference pods.
+	Termination *TerminationConfig `json:"termination,omitempty"`
+
+	// DisruptionBudget limits the voluntary disruptions of the replicas,
+	// e.g. node drains. It only applies if the min replicas is greater
+	// than 1.
+	DisruptionBudget *DisruptionBudgetConfig `json:"disruptionBudget,omitempty"`
+}
+
+// Framework is the inference framework. It is only used to set the default port
+// and command. For example, if the framework is "gradio", the default port is
+// 7860 and the default command is "python app.py". You could override these
+// defaults by setting the port and command fields and framework to `other`.
+type Framework string
+
+const (
+	FrameworkGradio    Framework = "gradio"
+	FrameworkStreamlit Framework = "streamlit"
+	FrameworkMosec     Framework = "mosec"
+	FrameworkOther     Framework = "other"
+)
+
+type ScalingConfig struct {
+	// MinReplicas is the lower limit for the number of replicas to which the
+	// autoscaler can scale down. It defaults to 0.
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit for the number of replicas to which the
+	// autoscaler can scale up. It cannot be less that minReplicas. It defaults
+	// to 1.
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// TargetLoad is the target load. In capacity mode, it is the expected number of the inflight requests per replica.
+	TargetLoad *int32 `json:"targetLoad,omitempty"`
+	// Type is the scaling type. It can be "capacity", "rps" or "custom". Default is "capacity".
+	Type *ScalingType `json:"type,omitempty"`
+	// ZeroDuration is the duration of zero load before scaling down to zero. Default is 5 minutes.
+	ZeroDuration *int32 `json:"zeroDuration,omitempty"`
+	// StartupDuration is the duration of startup time.
+	StartupDuration *int32 `json:"startupDuration,omitempty"`
+	// CustomMetric is the metric used to scale the inference in custom mode.
+	CustomMetric *CustomMetric `json:"customMetric,omitempty"`
+}
+
+type ScalingType string
+
+const (
+	ScalingTypeCapacity ScalingType = "capacity"
+	ScalingTypeRPS      ScalingType = "rps"
+	ScalingTypeCustom   ScalingType = "custom"
+)
+
+// CustomMetric is a prometheus metric used to scale the inference.
+type CustomMetric struct {
+	// Query is the PromQL expression template. {{.Name}} and {{.Namespace}}
+	// are replaced with the name and namespace of the inference.
+	Query string `json:"query"`
+	// TargetValue is the expected value of the metric per replica.
+	TargetValue resource.Quantity `json:"targetValue"`
+}
+
+// Probes configures the health checks of the inference. The unset settings
+// default to the ones of modelzetes.
+type Probes struct {
+	// HTTPPath is the path of the http probes.
+	HTTPPath *string `json:"httpPath,omitempty"`
+	// Startup is the settings of the startup probe.
+	Startup *ProbeSettings `json:"startup,omitempty"`
+	// Readiness is the settings of the readiness probe.
+	Readiness *ProbeSettings `json:"readiness,omitempty"`
+	// Liveness is the settings of the liveness probe.
+	Liveness *ProbeSettings `json:"liveness,omitempty"`
+}
+
+// ProbeSettings is a health check of the inference. At most one handler
+// can be set, the http GET of the http path is used if none is set.
+type ProbeSettings struct {
+	HTTPGet   *HTTPGetAction   `json:"httpGet,omitempty"`
+	TCPSocket *TCPSocketAction `json:"tcpSocket,omitempty"`
+	GRPC      *GRPCAction      `json:"grpc,omitempty"`
+	Exec      *ExecAction      `json:"exec,omitempty"`
+
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       *int32 `json:"periodSeconds,omitempty"`
+	TimeoutSeconds      *int32 `json:"timeoutSeconds,omitempty"`
+	SuccessThreshold    *int32 `json:"successThreshold,omitempty"`
+	FailureThreshold    *int32 `json:"failureThreshold,omitempty"`
+}
+
+// HTTPGetAction probes the inference with a http GET request. The path
+// defaults to the http path of the probes and the port defaults to the port
+// of the inference.
+type HTTPGetAction struct {
+	Path string `json:"path,omitempty"`
+	Port *int32 `json:"port,omitempty"`
+}
+
+// TCPSocketAction probes the inference by opening a TCP connection. The port
+// defaults to the port of the inference.
+type TCPSocketAction struct {
+	Port *int32 `json:"port,omitempty"`
+}
+
+// GRPCAction probes the inference with the gRPC health checking protocol.
+// The port defaults to the port of the inference.
+type GRPCAction struct {
+	Port    *int32  `json:"port,omitempty"`
+	Service *string `json:"service,omitempty"`
+}
+
+// ExecAction probes the inference by running the command in the container,
+// it is not executed within a shell.
+type ExecAction struct {
+	Command []string `json:"command"`
+}
+
+type ModelSourceType string
+
+const (
+	ModelSourceHuggingFace ModelSourceType = "huggingface"
+	ModelSourceS3          ModelSourceType = "s3"
+	ModelSourceHTTP        ModelSourceType = "http"
+)
+
+// ModelSource is the source of the model artifacts. The artifacts are
+// downloaded by an init container into a per-node cache keyed by the model
+// ID and revision, and mounted read-only into the inference container.
+type ModelSource struct {
+	// Type is the type of the source. It can be "huggingface", "s3" or "http".
+	Type ModelSourceType `json:"type"`
+	// URI is the repository ID in huggingface, e.g. bigscience/bloomz-560m,
+	// the URL of the prefix in s3, e.g. s3://bucket/path, or the URL of the
+	// tarball in http.
+	URI string `json:"uri"`
+	// Revision is the branch, tag or commit of the huggingface repository.
+	// Default is "main".
+	Revision string `json:"revision,omitempty"`
+	// Endpoint is the endpoint of the S3 compatible storage. Default is
+	// https://s3.amazonaws.com.
+	Endpoint string `json:"endpoint,omitempty"`
+	// MountPath is the path of the model in the inference container.
+	// Default is /models.
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// SchedulingConfig is the placement of the inference pods. It is applied in
+// addition to the node selector.
+type SchedulingConfig struct {
+	// NodeAffinity constrains the nodes which the pods could be scheduled on.
+	NodeAffinity *NodeAffinity `json:"nodeAffinity,omitempty"`
+	// Tolerations are added to the pods, in addition to the GPU tolerations
+	// if GPU is requested.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// PodAntiAffinity keeps the replicas of the inference apart.
+	PodAntiAffinity *PodAntiAffinity `json:"podAntiAffinity,omitempty"`
+	// TopologySpreadConstraints spread the replicas of the inference
+	// across the topology domains, e.g. nodes or zones.
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// NodeAffinity is the node affinity of the inference pods.
+type NodeAffinity struct {
+	// Required terms must be satisfied to schedule the pods. The terms are
+	// ORed, and the expressions in one term are ANDed.
+	Required []v1.NodeSelectorTerm `json:"required,omitempty"`
+	// Preferred terms are satisfied by the scheduler if possible, the node
+	// with the largest sum of weights is preferred.
+	Preferred []v1.PreferredSchedulingTerm `json:"preferred,omitempty"`
+}
+
+// PodAntiAffinity keeps the replicas of the inference in different
+// topology domains.
+type PodAntiAffinity struct {
+	// TopologyKey is the node label of the topology domain, e.g.
+	// kubernetes.io/hostname or topology.kubernetes.io/zone.
+	TopologyKey string `json:"topologyKey"`
+	// Required makes the pods unschedulable if the anti affinity cannot be
+	// satisfied. Otherwise it is preferred.
+	Required bool `json:"required,omitempty"`
+	// Weight is the weight of the preferred anti affinity in the range
+	// 1-100. Default is 100.
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// TopologySpreadConstraint spreads the replicas of the inference across the
+// topology domains.
+type TopologySpreadConstraint struct {
+	// TopologyKey is the node label of the topology domain.
+	TopologyKey string `json:"topologyKey"`
+	// MaxSkew is the maximum difference of the number of replicas between
+	// two domains. Default is 1.
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+	// WhenUnsatisfiable is DoNotSchedule or ScheduleAnyway. Default is
+	// ScheduleAnyway, so that scaling is not blocked by the constraint.
+	WhenUnsatisfiable v1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+}
+
+// RolloutPolicy is the health check of the rolling update. The rollout
+// fails if the new replica set is not ready before the deadline, or the
+// error rate of the requests exceeds the threshold.
+type RolloutPolicy struct {
+	// ProgressDeadlineSeconds is the maximum duration of the rollout.
+	// Default is 600.
+	ProgressDeadlineSeconds int32 `json:"progressDeadlineSeconds,omitempty"`
+	// MinReadyPercent is the percentage of the expected replicas which must
+	// be ready in the new replica set before the deadline. Default is 100.
+	MinReadyPercent int32 `json:"minReadyPercent,omitempty"`
+	// ErrorRate is the optional check of the requests read from the
+	// gateway metrics during the rollout.
+	ErrorRate *ErrorRateCheck `json:"errorRate,omitempty"`
+}
+
+// ErrorRateCheck fails the rollout if the percentage of the requests
+// responded with 5xx exceeds the threshold.
+type ErrorRateCheck struct {
+	// MaxErrorPercent is the maximum percentage of the failed requests.
+	MaxErrorPercent int32 `json:"maxErrorPercent"`
+	// WindowSeconds is the duration of the requests to check. Default is 60.
+	WindowSeconds int32 `json:"windowSeconds,omitempty"`
+	// MinRequests is the minimum number of the requests in the window to
+	// make the check meaningful. Default is 10.
+	MinRequests int32 `json:"minRequests,omitempty"`
+}
+
+// TerminationConfig is the graceful shutdown of the inference pods. The
+// defaults are configured in modelzetes.
+type TerminationConfig struct {
+	// GracePeriodSeconds is the duration from the start of the termination
+	// to SIGKILL, including the preStop hook. The in-flight requests
+	// should be finished in the duration.
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+	// PreStopSleepSeconds delays SIGTERM in the preStop hook, so that the
+	// pod is removed from the endpoints before the process stops accepting
+	// requests. The hook runs sleep with sh, the image must contain a shell.
+	// 0 disables the hook.
+	PreStopSleepSeconds *int32 `json:"preStopSleepSeconds,omitempty"`
+}
+
+// DisruptionBudgetConfig is the PodDisruptionBudget of the inference. The
+// default max unavailable is configured in modelzetes.
+type DisruptionBudgetConfig struct {
+	// Disabled skips creating the PodDisruptionBudget.
+	Disabled bool `json:"disabled,omitempty"`
+	// MaxUnavailable is the maximum number of the replicas which could be
+	// evicted at the same time.
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+}
+
+// InferenceStatus defines the observed state of Inference. It is maintained
+// by the controller from the owned deployment and pods.
+type InferenceStatus struct {
+	// ObservedGeneration is the generation of the inference observed by
+	// the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Replicas is the number of replicas of the deployment.
+	Replicas int32 `json:"replicas,omitempty"`
+	// AvailableReplicas is the number of available replicas.
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// Phase is the summarized phase of the inference.
+	Phase InferencePhase `json:"phase,omitempty"`
+	// Conditions are the latest observations of the inference.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Rollout is the status of the latest rollout, it is only set if the
+	// rollout policy is specified.
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// RolloutStatus is the status of the rollout of a replica set.
+type RolloutStatus struct {
+	// ReplicaSet is the name of the new replica set.
+	ReplicaSet string `json:"replicaSet"`
+	// Phase is the phase of the rollout.
+	Phase RolloutPhase `json:"phase"`
+	// StartedAt is the time when the controller observes the new replica
+	// set.
+	StartedAt metav1.Time `json:"startedAt,omitempty"`
+	// ReadyReplicas is the number of ready replicas of the new replica set.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Message is the human readable details of the phase.
+	Message string `json:"message,omitempty"`
+}
+
+type RolloutPhase string
+
+const (
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	RolloutPhaseSucceeded   RolloutPhase = "Succeeded"
+	RolloutPhaseFailed      RolloutPhase = "Failed"
+	RolloutPhaseRolledBack  RolloutPhase = "RolledBack"
+)
+
+type InferencePhase string
+
+const (
+	InferencePhaseReady        InferencePhase = "Ready"
+	InferencePhaseScaling      InferencePhase = "Scaling"
+	InferencePhaseNotReady     InferencePhase = "NotReady"
+	InferencePhaseScaledToZero InferencePhase = "ScaledToZero"
+	InferencePhaseFailed       InferencePhase = "Failed"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InferenceList is a list of inference resources
+type InferenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Inference `json:"items"`
+}
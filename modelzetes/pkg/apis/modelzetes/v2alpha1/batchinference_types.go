@@ -0,0 +1,171 @@
+package v2alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Shards",type=integer,JSONPath=`.status.shards`
+// +kubebuilder:printcolumn:name="Completed",type=integer,JSONPath=`.status.completedShards`
+// +kubebuilder:printcolumn:name="Succeeded",type=integer,JSONPath=`.status.succeeded`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failed`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BatchInference runs the model server of an inference offline over the
+// items of a dataset. The items are split into shards, each shard is
+// processed by a pod of an indexed job which posts the items to the model
+// server in the pod and writes the responses to the output location.
+type BatchInference struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BatchInferenceSpec   `json:"spec"`
+	Status BatchInferenceStatus `json:"status,omitempty"`
+}
+
+// BatchInferenceSpec defines the desired state of BatchInference. The
+// changes of the spec besides cancel are not applied to the running job,
+// they are applied once the job is recreated, e.g. the cancelled batch
+// inference is resumed. The finished job is recreated if the spec changes.
+type BatchInferenceSpec struct {
+	// Inference is the name of the inference in the same namespace, its
+	// image, framework, port, command, env vars, secrets and resources are
+	// used. The fields set below override them.
+	Inference string `json:"inference,omitempty"`
+
+	// Image is the image of the model server. It is required if the
+	// inference is not set.
+	Image string `json:"image,omitempty"`
+
+	// Framework provides the default port, command and probe path.
+	Framework Framework `json:"framework,omitempty"`
+
+	// Port is the port of the model server.
+	Port *int32 `json:"port,omitempty"`
+
+	// Command starts the model server. It is required if the framework has
+	// no default command, since the batch runner starts the model server
+	// by itself.
+	Command *string `json:"command,omitempty"`
+
+	// HTTPProbePath is polled until the model server is ready. Any response
+	// is accepted if it is not set.
+	HTTPProbePath *string `json:"http_probe_path,omitempty"`
+
+	// EnvVars are set in the container of the model server and the runner.
+	EnvVars map[string]string `json:"envVars,omitempty"`
+
+	// Secrets are exposed as the env vars, e.g. the AWS credentials of the
+	// object store.
+	Secrets []string `json:"secrets,omitempty"`
+
+	// Resources are the compute resource requirements of each pod.
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Input is the dataset.
+	Input BatchInput `json:"input"`
+
+	// Output is where the responses are written, one object per item.
+	// The items with the output are skipped, so that the job is resumable.
+	Output BatchLocation `json:"output"`
+
+	// Path is the HTTP path of the model endpoint, each item is posted to
+	// it. Default is /.
+	Path string `json:"path,omitempty"`
+
+	// Parallelism is the number of the shards processed in parallel.
+	// Default is 1.
+	Parallelism *int32 `json:"parallelism,omitempty"`
+
+	// MaxRetries is the retries of each item before it is failed.
+	// Default is 3.
+	MaxRetries *int32 `json:"max_retries,omitempty"`
+
+	// BackoffLimit is the retries of the failed pods, the retried pods
+	// skip the processed items. Default is 3.
+	BackoffLimit *int32 `json:"backoff_limit,omitempty"`
+
+	// Cancel stops the batch inference, the written responses are kept.
+	Cancel bool `json:"cancel,omitempty"`
+}
+
+// BatchLocation is a location on the object store or a volume.
+type BatchLocation struct {
+	// URI is s3://<bucket>/<prefix> on the object store, or the path in
+	// the volume claim.
+	URI string `json:"uri"`
+
+	// Endpoint is the endpoint of the S3 compatible storage. Default is
+	// AWS S3.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// VolumeClaim is the persistent volume claim which contains the path.
+	// It is required if the uri is not on the object store.
+	VolumeClaim string `json:"volume_claim,omitempty"`
+}
+
+// BatchInput is the manifest of the dataset, either a JSONL file or a list
+// of files under the location.
+type BatchInput struct {
+	BatchLocation `json:",inline"`
+
+	// JSONL is the name of the JSONL file, each line is an item.
+	JSONL string `json:"jsonl,omitempty"`
+
+	// Files are the names of the files, each file is an item.
+	Files []string `json:"files,omitempty"`
+}
+
+type BatchInferenceStatus struct {
+	// ObservedGeneration is the generation observed by the controller, i.e.
+	// the one which the job is created from, or the one which cancels it.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Phase is the summarized phase of the batch inference.
+	Phase BatchInferencePhase `json:"phase,omitempty"`
+	// JobName is the name of the job which processes the items.
+	JobName string `json:"jobName,omitempty"`
+	// Shards is the number of the shards.
+	Shards int32 `json:"shards,omitempty"`
+	// ActiveShards is the number of the shards being processed.
+	ActiveShards int32 `json:"activeShards,omitempty"`
+	// CompletedShards is the number of the shards processed successfully.
+	CompletedShards int32 `json:"completedShards,omitempty"`
+	// Total is the number of the items in the started shards.
+	Total int32 `json:"total,omitempty"`
+	// Succeeded is the number of the items processed successfully in the
+	// started shards, including the ones processed by the previous runs.
+	Succeeded int32 `json:"succeeded,omitempty"`
+	// Failed is the number of the items failed after the retries in the
+	// started shards.
+	Failed int32 `json:"failed,omitempty"`
+	// StartTime is the time when the job is created.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time when the batch inference is finished.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Message is the human readable reason of the phase.
+	Message string `json:"message,omitempty"`
+}
+
+type BatchInferencePhase string
+
+const (
+	BatchInferencePhasePending   BatchInferencePhase = "Pending"
+	BatchInferencePhaseRunning   BatchInferencePhase = "Running"
+	BatchInferencePhaseSucceeded BatchInferencePhase = "Succeeded"
+	BatchInferencePhaseFailed    BatchInferencePhase = "Failed"
+	BatchInferencePhaseCancelled BatchInferencePhase = "Cancelled"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BatchInferenceList is a list of batch inference resources
+type BatchInferenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []BatchInference `json:"items"`
+}
@@ -22,6 +22,7 @@ var (
 	localSchemeBuilder = &SchemeBuilder
 	AddToScheme        = localSchemeBuilder.AddToScheme
 	Kind               = "Inference"
+	BatchKind          = "BatchInference"
 )
 
 func init() {
@@ -36,6 +37,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Inference{},
 		&InferenceList{},
+		&BatchInference{},
+		&BatchInferenceList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -2,20 +2,30 @@ package v2alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
 )
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.replicas`
+// +kubebuilder:printcolumn:name="Available",type=integer,JSONPath=`.status.availableReplicas`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Inference describes an Inference
 type Inference struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec InferenceSpec `json:"spec"`
+	Spec   InferenceSpec   `json:"spec"`
+	Status InferenceStatus `json:"status,omitempty"`
 }
 
 // InferenceSpec defines the desired state of Inference
@@ -36,6 +46,10 @@ type InferenceSpec struct {
 	// HTTPProbePath is the path of the http probe.
 	HTTPProbePath *string `json:"http_probe_path,omitempty"`
 
+	// Probes configures the startup, readiness and liveness probes
+	// independently. The unset settings default to the ones of modelzetes.
+	Probes *Probes `json:"probes,omitempty"`
+
 	// Command to run when starting the
 	Command *string `json:"command,omitempty"`
 
@@ -58,19 +72,45 @@ type InferenceSpec struct {
 
 	// Limits for inference
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Model is the source of the model artifacts. They are downloaded into
+	// the cache of the node before the inference starts.
+	Model *ModelSource `json:"model,omitempty"`
+
+	// Scheduling is the placement of the inference pods across the nodes.
+	Scheduling *SchedulingConfig `json:"scheduling,omitempty"`
+
+	// Rollout checks the health of the rolling update, the inference is
+	// rolled back to the previous revision if the check fails.
+	Rollout *RolloutPolicy `json:"rollout,omitempty"`
+
+	// Termination configures the graceful shutdown of the inference pods.
+	Termination *TerminationConfig `json:"termination,omitempty"`
+
+	// DisruptionBudget limits the voluntary disruptions of the replicas,
+	// e.g. node drains. It only applies if the min replicas is greater
+	// than 1.
+	DisruptionBudget *DisruptionBudgetConfig `json:"disruption_budget,omitempty"`
 }
 
-// Framework is the inference framework. It is only used to set the default port
-// and command. For example, if the framework is "gradio", the default port is
-// 7860 and the default command is "python app.py". You could override these
-// defaults by setting the port and command fields and framework to `other`.
+// Framework is the inference framework. It is only used to set the defaults,
+// e.g. the port, command, env vars and probe path, and the proxy behavior,
+// which are declared in the framework registry of modelzetes. For example, if
+// the framework is "gradio", the default port is 7860. You could override
+// these defaults by setting the port and command fields and framework to
+// `other`. The operators could register the custom frameworks besides the
+// builtin ones below, whose names are declared by the registry.
 type Framework string
 
 const (
-	FrameworkGradio    Framework = "gradio"
-	FrameworkStreamlit Framework = "streamlit"
-	FrameworkMosec     Framework = "mosec"
-	FrameworkOther     Framework = "other"
+	FrameworkGradio    Framework = framework.Gradio
+	FrameworkStreamlit Framework = framework.Streamlit
+	FrameworkMosec     Framework = framework.Mosec
+	FrameworkVLLM      Framework = framework.VLLM
+	FrameworkTGI       Framework = framework.TGI
+	FrameworkTriton    Framework = framework.Triton
+	FrameworkJupyter   Framework = framework.Jupyter
+	FrameworkOther     Framework = framework.Other
 )
 
 type ScalingConfig struct {
@@ -83,12 +123,14 @@ type ScalingConfig struct {
 	MaxReplicas *int32 `json:"max_replicas,omitempty"`
 	// TargetLoad is the target load. In capacity mode, it is the expected number of the inflight requests per replica.
 	TargetLoad *int32 `json:"target_load,omitempty"`
-	// Type is the scaling type. It can be either "capacity" or "rps". Default is "capacity".
+	// Type is the scaling type. It can be "capacity", "rps" or "custom". Default is "capacity".
 	Type *ScalingType `json:"type,omitempty"`
 	// ZeroDuration is the duration of zero load before scaling down to zero. Default is 5 minutes.
 	ZeroDuration *int32 `json:"zero_duration,omitempty"`
 	// StartupDuration is the duration of startup time.
 	StartupDuration *int32 `json:"startup_duration,omitempty"`
+	// CustomMetric is the metric used to scale the inference in custom mode.
+	CustomMetric *CustomMetric `json:"custom_metric,omitempty"`
 }
 
 type ScalingType string
@@ -96,6 +138,269 @@ type ScalingType string
 const (
 	ScalingTypeCapacity ScalingType = "capacity"
 	ScalingTypeRPS      ScalingType = "rps"
+	ScalingTypeCustom   ScalingType = "custom"
+)
+
+// CustomMetric is a prometheus metric used to scale the inference.
+type CustomMetric struct {
+	// Query is the PromQL expression template. {{.Name}} and {{.Namespace}}
+	// are replaced with the name and namespace of the inference.
+	Query string `json:"query"`
+	// TargetValue is the expected value of the metric per replica.
+	TargetValue resource.Quantity `json:"target_value"`
+}
+
+// Probes configures the health checks of the inference.
+type Probes struct {
+	// Startup is the startup probe.
+	Startup *Probe `json:"startup,omitempty"`
+	// Readiness is the readiness probe.
+	Readiness *Probe `json:"readiness,omitempty"`
+	// Liveness is the liveness probe.
+	Liveness *Probe `json:"liveness,omitempty"`
+}
+
+// Probe is a health check of the inference. At most one handler can be set,
+// the http GET of the http probe path is used if none is set.
+type Probe struct {
+	HTTPGet   *HTTPGetAction   `json:"http_get,omitempty"`
+	TCPSocket *TCPSocketAction `json:"tcp_socket,omitempty"`
+	GRPC      *GRPCAction      `json:"grpc,omitempty"`
+	Exec      *ExecAction      `json:"exec,omitempty"`
+
+	InitialDelaySeconds *int32 `json:"initial_delay_seconds,omitempty"`
+	PeriodSeconds       *int32 `json:"period_seconds,omitempty"`
+	TimeoutSeconds      *int32 `json:"timeout_seconds,omitempty"`
+	SuccessThreshold    *int32 `json:"success_threshold,omitempty"`
+	FailureThreshold    *int32 `json:"failure_threshold,omitempty"`
+}
+
+// HTTPGetAction probes the inference with a http GET request. The path
+// defaults to the http probe path and the port defaults to the port of the
+// inference.
+type HTTPGetAction struct {
+	Path string `json:"path,omitempty"`
+	Port *int32 `json:"port,omitempty"`
+}
+
+// TCPSocketAction probes the inference by opening a TCP connection. The port
+// defaults to the port of the inference.
+type TCPSocketAction struct {
+	Port *int32 `json:"port,omitempty"`
+}
+
+// GRPCAction probes the inference with the gRPC health checking protocol.
+// The port defaults to the port of the inference.
+type GRPCAction struct {
+	Port    *int32  `json:"port,omitempty"`
+	Service *string `json:"service,omitempty"`
+}
+
+// ExecAction probes the inference by running the command in the container,
+// it is not executed within a shell.
+type ExecAction struct {
+	Command []string `json:"command"`
+}
+
+type ModelSourceType string
+
+const (
+	ModelSourceHuggingFace ModelSourceType = "huggingface"
+	ModelSourceS3          ModelSourceType = "s3"
+	ModelSourceHTTP        ModelSourceType = "http"
+)
+
+// ModelSource is the source of the model artifacts. The artifacts are
+// downloaded by an init container into a per-node cache keyed by the model
+// ID and revision, and mounted read-only into the inference container.
+type ModelSource struct {
+	// Type is the type of the source. It can be "huggingface", "s3" or "http".
+	Type ModelSourceType `json:"type"`
+	// URI is the repository ID in huggingface, e.g. bigscience/bloomz-560m,
+	// the URL of the prefix in s3, e.g. s3://bucket/path, or the URL of the
+	// tarball in http.
+	URI string `json:"uri"`
+	// Revision is the branch, tag or commit of the huggingface repository.
+	// Default is "main".
+	Revision string `json:"revision,omitempty"`
+	// Endpoint is the endpoint of the S3 compatible storage. Default is
+	// https://s3.amazonaws.com.
+	Endpoint string `json:"endpoint,omitempty"`
+	// MountPath is the path of the model in the inference container.
+	// Default is /models.
+	MountPath string `json:"mount_path,omitempty"`
+}
+
+// SchedulingConfig is the placement of the inference pods. It is applied in
+// addition to the node selector built from the constraints.
+type SchedulingConfig struct {
+	// NodeAffinity constrains the nodes which the pods could be scheduled on.
+	NodeAffinity *NodeAffinity `json:"node_affinity,omitempty"`
+	// Tolerations are added to the pods, in addition to the GPU tolerations
+	// if GPU is requested.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// PodAntiAffinity keeps the replicas of the inference apart.
+	PodAntiAffinity *PodAntiAffinity `json:"pod_anti_affinity,omitempty"`
+	// TopologySpreadConstraints spread the replicas of the inference
+	// across the topology domains, e.g. nodes or zones.
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topology_spread_constraints,omitempty"`
+}
+
+// NodeAffinity is the node affinity of the inference pods.
+type NodeAffinity struct {
+	// Required terms must be satisfied to schedule the pods. The terms are
+	// ORed, and the expressions in one term are ANDed.
+	Required []v1.NodeSelectorTerm `json:"required,omitempty"`
+	// Preferred terms are satisfied by the scheduler if possible, the node
+	// with the largest sum of weights is preferred.
+	Preferred []v1.PreferredSchedulingTerm `json:"preferred,omitempty"`
+}
+
+// PodAntiAffinity keeps the replicas of the inference in different
+// topology domains.
+type PodAntiAffinity struct {
+	// TopologyKey is the node label of the topology domain, e.g.
+	// kubernetes.io/hostname or topology.kubernetes.io/zone.
+	TopologyKey string `json:"topology_key"`
+	// Required makes the pods unschedulable if the anti affinity cannot be
+	// satisfied. Otherwise it is preferred.
+	Required bool `json:"required,omitempty"`
+	// Weight is the weight of the preferred anti affinity in the range
+	// 1-100. Default is 100.
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// TopologySpreadConstraint spreads the replicas of the inference across the
+// topology domains.
+type TopologySpreadConstraint struct {
+	// TopologyKey is the node label of the topology domain.
+	TopologyKey string `json:"topology_key"`
+	// MaxSkew is the maximum difference of the number of replicas between
+	// two domains. Default is 1.
+	MaxSkew int32 `json:"max_skew,omitempty"`
+	// WhenUnsatisfiable is DoNotSchedule or ScheduleAnyway. Default is
+	// ScheduleAnyway, so that scaling is not blocked by the constraint.
+	WhenUnsatisfiable v1.UnsatisfiableConstraintAction `json:"when_unsatisfiable,omitempty"`
+}
+
+// RolloutPolicy is the health check of the rolling update. The rollout
+// fails if the new replica set is not ready before the deadline, or the
+// error rate of the requests exceeds the threshold.
+type RolloutPolicy struct {
+	// ProgressDeadlineSeconds is the maximum duration of the rollout.
+	// Default is 600.
+	ProgressDeadlineSeconds int32 `json:"progress_deadline_seconds,omitempty"`
+	// MinReadyPercent is the percentage of the expected replicas which must
+	// be ready in the new replica set before the deadline. Default is 100.
+	MinReadyPercent int32 `json:"min_ready_percent,omitempty"`
+	// ErrorRate is the optional check of the requests read from the
+	// gateway metrics during the rollout.
+	ErrorRate *ErrorRateCheck `json:"error_rate,omitempty"`
+}
+
+// ErrorRateCheck fails the rollout if the percentage of the requests
+// responded with 5xx exceeds the threshold.
+type ErrorRateCheck struct {
+	// MaxErrorPercent is the maximum percentage of the failed requests.
+	MaxErrorPercent int32 `json:"max_error_percent"`
+	// WindowSeconds is the duration of the requests to check. Default is 60.
+	WindowSeconds int32 `json:"window_seconds,omitempty"`
+	// MinRequests is the minimum number of the requests in the window to
+	// make the check meaningful. Default is 10.
+	MinRequests int32 `json:"min_requests,omitempty"`
+}
+
+// TerminationConfig is the graceful shutdown of the inference pods. The
+// defaults are configured in modelzetes.
+type TerminationConfig struct {
+	// GracePeriodSeconds is the duration from the start of the termination
+	// to SIGKILL, including the preStop hook. The in-flight requests
+	// should be finished in the duration.
+	GracePeriodSeconds *int64 `json:"grace_period_seconds,omitempty"`
+	// PreStopSleepSeconds delays SIGTERM in the preStop hook, so that the
+	// pod is removed from the endpoints before the process stops accepting
+	// requests. The hook runs sleep with sh, the image must contain a shell.
+	// 0 disables the hook.
+	PreStopSleepSeconds *int32 `json:"pre_stop_sleep_seconds,omitempty"`
+}
+
+// DisruptionBudgetConfig is the PodDisruptionBudget of the inference. The
+// default max unavailable is configured in modelzetes.
+type DisruptionBudgetConfig struct {
+	// Disabled skips creating the PodDisruptionBudget.
+	Disabled bool `json:"disabled,omitempty"`
+	// MaxUnavailable is the maximum number of the replicas which could be
+	// evicted at the same time.
+	MaxUnavailable *int32 `json:"max_unavailable,omitempty"`
+}
+
+// InferenceStatus defines the observed state of Inference. It is maintained
+// by the controller from the owned deployment and pods.
+type InferenceStatus struct {
+	// ObservedGeneration is the generation of the inference observed by
+	// the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Replicas is the number of replicas of the deployment.
+	Replicas int32 `json:"replicas,omitempty"`
+	// AvailableReplicas is the number of available replicas.
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// Phase is the summarized phase of the inference.
+	Phase InferencePhase `json:"phase,omitempty"`
+	// Conditions are the latest observations of the inference.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Rollout is the status of the latest rollout, it is only set if the
+	// rollout policy is specified.
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// RolloutStatus is the status of the rollout of a replica set.
+type RolloutStatus struct {
+	// ReplicaSet is the name of the new replica set.
+	ReplicaSet string `json:"replicaSet"`
+	// Phase is the phase of the rollout.
+	Phase RolloutPhase `json:"phase"`
+	// StartedAt is the time when the controller observes the new replica
+	// set.
+	StartedAt metav1.Time `json:"startedAt,omitempty"`
+	// ReadyReplicas is the number of ready replicas of the new replica set.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Message is the human readable details of the phase.
+	Message string `json:"message,omitempty"`
+}
+
+type RolloutPhase string
+
+const (
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	RolloutPhaseSucceeded   RolloutPhase = "Succeeded"
+	RolloutPhaseFailed      RolloutPhase = "Failed"
+	RolloutPhaseRolledBack  RolloutPhase = "RolledBack"
+)
+
+type InferencePhase string
+
+const (
+	InferencePhaseReady        InferencePhase = "Ready"
+	InferencePhaseScaling      InferencePhase = "Scaling"
+	InferencePhaseNotReady     InferencePhase = "NotReady"
+	InferencePhaseScaledToZero InferencePhase = "ScaledToZero"
+	InferencePhaseFailed       InferencePhase = "Failed"
+)
+
+const (
+	// InferenceConditionReady means all the expected replicas are available.
+	InferenceConditionReady = "Ready"
+	// InferenceConditionProgressing means the deployment is rolling out.
+	InferenceConditionProgressing = "Progressing"
+	// InferenceConditionDegraded means some expected replicas are unavailable.
+	InferenceConditionDegraded = "Degraded"
+	// InferenceConditionScaledToZero means the inference has no replicas.
+	InferenceConditionScaledToZero = "ScaledToZero"
+	// InferenceConditionImagePullFailing means the image of the inference
+	// cannot be pulled.
+	InferenceConditionImagePullFailing = "ImagePullFailing"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,265 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	cli "github.com/urfave/cli/v2"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/batch"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
+)
+
+const (
+	flagBatchInputURI       = "input-uri"
+	flagBatchInputEndpoint  = "input-endpoint"
+	flagBatchInputJSONL     = "input-jsonl"
+	flagBatchInputFile      = "input-file"
+	flagBatchOutputURI      = "output-uri"
+	flagBatchOutputEndpoint = "output-endpoint"
+	flagBatchPort           = "port"
+	flagBatchPath           = "path"
+	flagBatchHTTPProbePath  = "http-probe-path"
+	flagBatchShard          = "shard"
+	flagBatchShards         = "shards"
+	flagBatchMaxRetries     = "max-retries"
+	flagBatchStartupTimeout = "startup-timeout"
+	flagBatchTerminationLog = "termination-log"
+
+	// batchStopTimeout is the time to wait for the model server to exit
+	// before it is killed.
+	batchStopTimeout = 10 * time.Second
+	// batchReportInterval is the interval of patching the progress in the
+	// pod annotation.
+	batchReportInterval = 5 * time.Second
+)
+
+// batchCommand runs the batch inferences, the commands are run in the pods
+// of the batch inference jobs.
+func batchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "batch",
+		Usage: "run the shards of the batch inferences",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "install",
+				Usage:     "copy the modelzetes binary into the directory, it is run by the init container so that the model image could run the shard",
+				ArgsUsage: "<dir>",
+				Action:    runBatchInstall,
+			},
+			{
+				Name:      "run",
+				Usage:     "start the model server and process the items of the shard",
+				ArgsUsage: "-- <model server command>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     flagBatchInputURI,
+						Usage:    "Location of the input, s3://<bucket>/<prefix> or a local directory",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  flagBatchInputEndpoint,
+						Usage: "Endpoint of the S3 compatible storage of the input",
+					},
+					&cli.StringFlag{
+						Name:  flagBatchInputJSONL,
+						Usage: "Name of the JSONL file in the input, each line is an item",
+					},
+					&cli.StringSliceFlag{
+						Name:  flagBatchInputFile,
+						Usage: "Name of a file in the input, each file is an item",
+					},
+					&cli.StringFlag{
+						Name:     flagBatchOutputURI,
+						Usage:    "Location of the output, s3://<bucket>/<prefix> or a local directory",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  flagBatchOutputEndpoint,
+						Usage: "Endpoint of the S3 compatible storage of the output",
+					},
+					&cli.IntFlag{
+						Name:  flagBatchPort,
+						Usage: "Port of the model server",
+						Value: 8080,
+					},
+					&cli.StringFlag{
+						Name:  flagBatchPath,
+						Usage: "HTTP path of the model endpoint",
+						Value: "/",
+					},
+					&cli.StringFlag{
+						Name:  flagBatchHTTPProbePath,
+						Usage: "HTTP path polled until the model server is ready, any response is accepted if it is not set",
+					},
+					&cli.IntFlag{
+						Name:    flagBatchShard,
+						Usage:   "Index of the shard",
+						EnvVars: []string{"JOB_COMPLETION_INDEX"},
+					},
+					&cli.IntFlag{
+						Name:  flagBatchShards,
+						Usage: "Number of the shards",
+						Value: 1,
+					},
+					&cli.IntFlag{
+						Name:  flagBatchMaxRetries,
+						Usage: "Retries of each item",
+						Value: 3,
+					},
+					&cli.DurationFlag{
+						Name:  flagBatchStartupTimeout,
+						Usage: "Time to wait for the model server to be ready",
+						Value: 30 * time.Minute,
+					},
+					&cli.StringFlag{
+						Name:  flagBatchTerminationLog,
+						Usage: "File which the summary of the shard is written to",
+						Value: "/dev/termination-log",
+					},
+				},
+				Action: runBatch,
+			},
+		},
+	}
+}
+
+func runBatchInstall(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("the directory is required")
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "failed to find the binary")
+	}
+	src, err := os.Open(self)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the binary")
+	}
+	defer src.Close()
+
+	dir := c.Args().First()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create the directory")
+	}
+	dst, err := os.OpenFile(filepath.Join(dir, "modelzetes"),
+		os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the binary")
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return errors.Wrap(err, "failed to copy the binary")
+	}
+	return dst.Close()
+}
+
+func runBatch(c *cli.Context) error {
+	args := c.Args().Slice()
+	if len(args) == 0 {
+		return errors.New("the command of the model server is required")
+	}
+
+	cred := s3CredentialsFromEnv()
+	input, err := batch.NewStorage(c.String(flagBatchInputURI),
+		c.String(flagBatchInputEndpoint), cred, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the input")
+	}
+	output, err := batch.NewStorage(c.String(flagBatchOutputURI),
+		c.String(flagBatchOutputEndpoint), cred, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the output")
+	}
+
+	// Start the model server in the background, it is stopped after the
+	// shard is processed.
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start the model server")
+	}
+	exited := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		exited <- cmd.Wait()
+		close(done)
+	}()
+	defer stopModelServer(cmd, done)
+
+	base := fmt.Sprintf("http://127.0.0.1:%d", c.Int(flagBatchPort))
+	ctx, cancel := context.WithTimeout(c.Context, c.Duration(flagBatchStartupTimeout))
+	defer cancel()
+	probePath := c.String(flagBatchHTTPProbePath)
+	if err := batch.WaitReady(ctx, nil, base+probePath,
+		probePath == "", exited); err != nil {
+		return errors.Wrap(err, "failed to wait for the model server")
+	}
+
+	// The progress is reported in the pod annotation, so that the
+	// controller tracks it before the shard finishes.
+	reporter := k8s.NewAnnotationReporterFromEnv(consts.AnnotationBatchProgress)
+	reportCtx, stopReport := context.WithCancel(c.Context)
+	defer stopReport()
+	go reporter.Run(reportCtx, batchReportInterval)
+
+	r := batch.Runner{
+		Input:      input,
+		Output:     output,
+		JSONL:      c.String(flagBatchInputJSONL),
+		Files:      c.StringSlice(flagBatchInputFile),
+		Endpoint:   base + c.String(flagBatchPath),
+		Shard:      c.Int(flagBatchShard),
+		Shards:     c.Int(flagBatchShards),
+		MaxRetries: c.Int(flagBatchMaxRetries),
+		Backoff:    time.Second,
+		Log:        os.Stdout,
+		Progress: func(summary batch.Summary) {
+			if data, err := json.Marshal(summary); err == nil {
+				reporter.Report(string(data))
+			}
+		},
+	}
+	summary, err := r.Run(c.Context)
+	stopReport()
+	reporter.Flush(context.Background())
+	if data, merr := json.Marshal(summary); merr == nil {
+		if werr := os.WriteFile(c.String(flagBatchTerminationLog), data, 0644); werr != nil {
+			fmt.Fprintf(os.Stderr, "failed to write the summary: %v\n", werr)
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to process the shard")
+	}
+	if summary.Failed > 0 {
+		// The pod is retried by the job, the processed items are skipped.
+		return errors.Newf("%d items failed", summary.Failed)
+	}
+	return nil
+}
+
+// stopModelServer terminates the model server and kills it if it does not
+// exit in time.
+func stopModelServer(cmd *exec.Cmd, done <-chan struct{}) {
+	select {
+	case <-done:
+		return
+	default:
+	}
+	_ = cmd.Process.Signal(syscall.SIGTERM)
+	select {
+	case <-done:
+	case <-time.After(batchStopTimeout):
+		_ = cmd.Process.Kill()
+	}
+}
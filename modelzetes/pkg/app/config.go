@@ -4,6 +4,7 @@ import (
 	cli "github.com/urfave/cli/v2"
 
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/config"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/election"
 )
 
 func configFromCLI(c *cli.Context) config.Config {
@@ -41,5 +42,24 @@ func configFromCLI(c *cli.Context) config.Config {
 	// inference
 	cfg.Inference.ImagePullPolicy = c.String(flagInferenceImagePullPolicy)
 	cfg.Inference.SetUpRuntimeClassNvidia = c.Bool(flagInferenceSetUpRuntimeClassNvidia)
+	cfg.Inference.ModelDownloaderImage = c.String(flagInferenceModelDownloaderImage)
+	cfg.Inference.ModelCacheDir = c.String(flagInferenceModelCacheDir)
+	cfg.Inference.ModelCacheMaxSize = c.String(flagInferenceModelCacheMaxSize)
+	cfg.Inference.TerminationGracePeriodSeconds = c.Int64(flagInferenceTerminationGracePeriod)
+	cfg.Inference.PreStopSleepSeconds = int32(c.Int(flagInferencePreStopSleep))
+	cfg.Inference.DisruptionBudgetMaxUnavailable = int32(c.Int(flagInferenceDisruptionBudget))
+	cfg.Inference.FrameworkConfig = c.String(flagInferenceFrameworkConfig)
+
+	// webhook
+	cfg.Webhook.Enabled = c.Bool(flagWebhookEnabled)
+	cfg.Webhook.Port = c.Int(flagWebhookPort)
+	cfg.Webhook.CertFile = c.String(flagWebhookCertFile)
+	cfg.Webhook.KeyFile = c.String(flagWebhookKeyFile)
+
+	// rollout
+	cfg.Rollout.PrometheusURL = c.String(flagRolloutPrometheusURL)
+
+	// leader election
+	cfg.LeaderElection = election.ConfigFromCLI(c)
 	return cfg
 }
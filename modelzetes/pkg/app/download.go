@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	cli "github.com/urfave/cli/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/model"
+)
+
+const (
+	flagDownloadType         = "type"
+	flagDownloadURI          = "uri"
+	flagDownloadRevision     = "revision"
+	flagDownloadEndpoint     = "endpoint"
+	flagDownloadNamespace    = "namespace"
+	flagDownloadCacheDir     = "cache-dir"
+	flagDownloadMaxCacheSize = "max-cache-size"
+
+	// downloadReportInterval is the interval of patching the progress in
+	// the pod annotation.
+	downloadReportInterval = 5 * time.Second
+)
+
+// downloadCommand downloads the model artifacts into the cache, it is run by
+// the init container of the inferences with model.
+func downloadCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "download",
+		Usage: "download the model artifacts into the cache",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagDownloadType,
+				Usage:    "Type of the model source, huggingface, s3 or http",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     flagDownloadURI,
+				Usage:    "URI of the model",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  flagDownloadRevision,
+				Usage: "Revision of the model",
+			},
+			&cli.StringFlag{
+				Name:  flagDownloadEndpoint,
+				Usage: "Endpoint of the S3 compatible storage",
+			},
+			&cli.StringFlag{
+				Name:     flagDownloadNamespace,
+				Usage:    "Namespace of the inference, the models are cached per namespace",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  flagDownloadCacheDir,
+				Usage: "Root directory of the model cache",
+				Value: "/cache",
+			},
+			&cli.StringFlag{
+				Name:  flagDownloadMaxCacheSize,
+				Usage: "Max size of the model cache, e.g. 200Gi, the least recently used models are evicted",
+			},
+		},
+		Action: runDownload,
+	}
+}
+
+func runDownload(c *cli.Context) error {
+	token := os.Getenv("HF_TOKEN")
+	if token == "" {
+		token = os.Getenv("HUGGING_FACE_HUB_TOKEN")
+	}
+
+	var maxCacheBytes int64
+	if size := c.String(flagDownloadMaxCacheSize); size != "" {
+		qty, err := resource.ParseQuantity(size)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse the max cache size")
+		}
+		maxCacheBytes = qty.Value()
+	}
+
+	// The progress is reported in the pod annotation, so that it is shown
+	// in the status of the instance.
+	reporter := k8s.NewAnnotationReporterFromEnv(consts.AnnotationDownloadProgress)
+	ctx, cancel := context.WithCancel(c.Context)
+	defer cancel()
+	go reporter.Run(ctx, downloadReportInterval)
+
+	d := model.Downloader{
+		CacheDir:            c.String(flagDownloadCacheDir),
+		HuggingFaceEndpoint: os.Getenv("HF_ENDPOINT"),
+		HuggingFaceToken:    token,
+		S3Credentials:       s3CredentialsFromEnv(),
+		MaxCacheBytes:       maxCacheBytes,
+		Progress:            os.Stdout,
+		Report:              reporter.Report,
+	}
+	_, err := d.Download(ctx, model.Source{
+		Type:      c.String(flagDownloadType),
+		URI:       c.String(flagDownloadURI),
+		Revision:  c.String(flagDownloadRevision),
+		Endpoint:  c.String(flagDownloadEndpoint),
+		Namespace: c.String(flagDownloadNamespace),
+	})
+	return errors.Wrap(err, "failed to download the model")
+}
+
+// s3CredentialsFromEnv returns the credentials of the S3 compatible storage
+// from the env vars of AWS.
+func s3CredentialsFromEnv() model.S3Credentials {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	return model.S3Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		Region:          region,
+	}
+}
@@ -13,8 +13,12 @@ import (
 	"k8s.io/klog"
 
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/controller"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/election"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/metrics"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/signals"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/version"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/webhook"
 )
 
 const (
@@ -52,6 +56,22 @@ const (
 	// inference
 	flagInferenceImagePullPolicy         = "inference-image-pull-policy"
 	flagInferenceSetUpRuntimeClassNvidia = "inference-set-up-runtime-class-nvidia"
+	flagInferenceModelDownloaderImage    = "inference-model-downloader-image"
+	flagInferenceModelCacheDir           = "inference-model-cache-dir"
+	flagInferenceModelCacheMaxSize       = "inference-model-cache-max-size"
+	flagInferenceTerminationGracePeriod  = "inference-termination-grace-period-seconds"
+	flagInferencePreStopSleep            = "inference-pre-stop-sleep-seconds"
+	flagInferenceDisruptionBudget        = "inference-disruption-budget-max-unavailable"
+	flagInferenceFrameworkConfig         = "inference-framework-config"
+
+	// webhook
+	flagWebhookEnabled  = "webhook-enabled"
+	flagWebhookPort     = "webhook-port"
+	flagWebhookCertFile = "webhook-cert-file"
+	flagWebhookKeyFile  = "webhook-key-file"
+
+	// rollout
+	flagRolloutPrometheusURL = "rollout-prometheus-url"
 )
 
 type App struct {
@@ -75,7 +95,7 @@ func New() App {
 		&cli.IntFlag{
 			Name:    flagMetricsServerPort,
 			Value:   8081,
-			Usage:   "port to listen on",
+			Usage:   "port to serve the metrics, /healthz and /readyz on",
 			EnvVars: []string{"MODELZETES_SERVER_PORT"},
 			Aliases: []string{"p"},
 		},
@@ -201,8 +221,85 @@ func New() App {
 			Usage:   "If true, will set up the Nvidia RuntimeClassName to the inference deployment.",
 			EnvVars: []string{"MODELZETES_INFERENCE_SET_UP_RUNTIME_CLASS_NVIDIA"},
 		},
+		&cli.StringFlag{
+			Name:    flagInferenceModelDownloaderImage,
+			Usage:   "Image of the init container which downloads the model of the inference, it should contain the modelzetes binary.",
+			Value:   "modelzai/modelzetes:latest",
+			EnvVars: []string{"MODELZETES_INFERENCE_MODEL_DOWNLOADER_IMAGE"},
+		},
+		&cli.StringFlag{
+			Name:    flagInferenceModelCacheDir,
+			Usage:   "Directory on the nodes to cache the models of the inferences.",
+			Value:   "/var/lib/modelz/models",
+			EnvVars: []string{"MODELZETES_INFERENCE_MODEL_CACHE_DIR"},
+		},
+		&cli.StringFlag{
+			Name:    flagInferenceModelCacheMaxSize,
+			Usage:   "Max size of the model cache on each node, e.g. 200Gi. The least recently used models are evicted when it is exceeded, the cache is not limited if it is empty.",
+			EnvVars: []string{"MODELZETES_INFERENCE_MODEL_CACHE_MAX_SIZE"},
+		},
+		&cli.Int64Flag{
+			Name:    flagInferenceTerminationGracePeriod,
+			Usage:   "Default termination grace period of the inference pods, in-flight requests should finish in it.",
+			Value:   120,
+			EnvVars: []string{"MODELZETES_INFERENCE_TERMINATION_GRACE_PERIOD_SECONDS"},
+		},
+		&cli.IntFlag{
+			Name: flagInferencePreStopSleep,
+			Usage: "Default seconds to sleep in the preStop hook of the inference pods, " +
+				"so that they are removed from the endpoints before SIGTERM. The hook runs sleep " +
+				"with sh, thus the images must contain a shell. 0 disables the hook.",
+			Value:   0,
+			EnvVars: []string{"MODELZETES_INFERENCE_PRE_STOP_SLEEP_SECONDS"},
+		},
+		&cli.IntFlag{
+			Name: flagInferenceDisruptionBudget,
+			Usage: "Default max unavailable replicas of the PodDisruptionBudget, " +
+				"which is created for the inferences with more than 1 min replicas.",
+			Value:   1,
+			EnvVars: []string{"MODELZETES_INFERENCE_DISRUPTION_BUDGET_MAX_UNAVAILABLE"},
+		},
+		&cli.StringFlag{
+			Name: flagInferenceFrameworkConfig,
+			Usage: "Path to the YAML file of the custom inference frameworks, " +
+				"which are added to the builtin ones or override them",
+			EnvVars: []string{"MODELZETES_INFERENCE_FRAMEWORK_CONFIG"},
+		},
+		&cli.BoolFlag{
+			Name:    flagWebhookEnabled,
+			Usage:   "If true, will serve the defaulting and validating admission webhooks of the inferences.",
+			EnvVars: []string{"MODELZETES_WEBHOOK_ENABLED"},
+		},
+		&cli.IntFlag{
+			Name:    flagWebhookPort,
+			Value:   9443,
+			Usage:   "Port of the admission webhook server",
+			EnvVars: []string{"MODELZETES_WEBHOOK_PORT"},
+		},
+		&cli.StringFlag{
+			Name:    flagWebhookCertFile,
+			Usage:   "Path to the TLS certificate of the admission webhook server",
+			EnvVars: []string{"MODELZETES_WEBHOOK_CERT_FILE"},
+		},
+		&cli.StringFlag{
+			Name:    flagWebhookKeyFile,
+			Usage:   "Path to the TLS key of the admission webhook server",
+			EnvVars: []string{"MODELZETES_WEBHOOK_KEY_FILE"},
+		},
+		&cli.StringFlag{
+			Name: flagRolloutPrometheusURL,
+			Usage: "URL of the prometheus which scrapes the gateway metrics. " +
+				"The error rate check of the rollout is skipped if it is not provided",
+			EnvVars: []string{"MODELZETES_ROLLOUT_PROMETHEUS_URL"},
+		},
 	}
+	internalApp.Flags = append(internalApp.Flags,
+		election.Flags("MODELZETES", "modelzetes")...)
 	internalApp.Action = runServer
+	internalApp.Commands = []*cli.Command{
+		downloadCommand(),
+		batchCommand(),
+	}
 
 	// Deal with debug flag.
 	var debugEnabled bool
@@ -240,13 +337,50 @@ func runServer(clicontext *cli.Context) error {
 		}
 	}
 
+	if c.Inference.FrameworkConfig != "" {
+		if err := framework.LoadFile(c.Inference.FrameworkConfig); err != nil {
+			return errors.Wrap(err, "failed to load the inference frameworks")
+		}
+	}
+
 	// set up signals so we handle the first shutdown signal gracefully
 	stopCh := signals.SetupSignalHandler()
 
-	s, err := controller.New(c, stopCh)
+	if c.Webhook.Enabled {
+		wh := webhook.New(c.Webhook.Port, c.Webhook.CertFile,
+			c.Webhook.KeyFile, c.Probes.Startup.PeriodSeconds)
+		go func() {
+			if err := wh.Run(stopCh); err != nil {
+				klog.Fatalf("failed to run admission webhook server: %v", err)
+			}
+		}()
+	}
+
+	metricsServer := metrics.NewServer(c.Metrics.ServerPort)
+	go func() {
+		if err := metricsServer.Run(stopCh); err != nil {
+			klog.Fatalf("failed to run metrics server: %v", err)
+		}
+	}()
+
+	s, err := controller.New(c, metricsServer, stopCh)
 	if err != nil {
 		return errors.Wrap(err, "failed to create server")
 	}
 
-	return s.Run(c.Controller.ThreadCount, stopCh)
+	if !c.LeaderElection.Enabled {
+		return s.Run(c.Controller.ThreadCount, stopCh)
+	}
+
+	// The informers are started by all the replicas, the standby takes
+	// over with the warm caches.
+	elector, err := election.NewFromKubeConfig(
+		c.KubeConfig.MasterURL, c.KubeConfig.Kubeconfig, c.LeaderElection)
+	if err != nil {
+		return errors.Wrap(err, "failed to create leader elector")
+	}
+	klog.Info("starting leader election")
+	return elector.RunOnce(stopCh, func(stopCh <-chan struct{}) error {
+		return s.Run(c.Controller.ThreadCount, stopCh)
+	})
 }
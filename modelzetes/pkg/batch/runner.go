@@ -0,0 +1,299 @@
+// Package batch runs the shard of a batch inference in the pod of the
+// indexed job. The items of the shard are posted to the model server in
+// the pod one by one, and the responses are written to the output
+// location. The items with the output are skipped, so that the retried
+// pods resume from where the previous ones stopped.
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"time"
+)
+
+const (
+	// OutputSuffix is appended to the item IDs to get the output names.
+	OutputSuffix = ".out"
+
+	// maxFailedItems limits the failed items in the summary, so that it
+	// fits in the termination message.
+	maxFailedItems = 10
+)
+
+// Item is an item of the dataset.
+type Item struct {
+	// ID identifies the item, it is the name of the file or the zero
+	// padded index of the JSONL line.
+	ID string
+	// Line is the content of the JSONL line, the files are read when they
+	// are processed.
+	Line []byte
+}
+
+// Output returns the name of the output of the item.
+func (i Item) Output() string {
+	return i.ID + OutputSuffix
+}
+
+// Summary is the result of a shard, it is written to the termination
+// message of the pod and aggregated into the status by the controller.
+type Summary struct {
+	Shard int `json:"shard"`
+	// Total is the number of the items in the shard.
+	Total int `json:"total"`
+	// Succeeded is the number of the items with the output, including
+	// the skipped ones.
+	Succeeded int `json:"succeeded"`
+	// Skipped is the number of the items processed by the previous runs.
+	Skipped int `json:"skipped"`
+	// Failed is the number of the items failed after the retries.
+	Failed int `json:"failed"`
+	// FailedItems are the first failed items.
+	FailedItems []string `json:"failed_items,omitempty"`
+}
+
+// Runner processes a shard of the batch inference.
+type Runner struct {
+	Input  Storage
+	Output Storage
+	// JSONL is the name of the JSONL file in the input, each line is an
+	// item.
+	JSONL string
+	// Files are the names of the files in the input, each file is an item.
+	Files []string
+	// Endpoint is the URL of the model endpoint which the items are posted
+	// to.
+	Endpoint string
+	// Shard is the index of the shard, the items whose index modulo the
+	// shards equals to it are processed.
+	Shard  int
+	Shards int
+	// MaxRetries is the retries of each item.
+	MaxRetries int
+	// Backoff is the delay before the first retry, it is doubled on each
+	// retry.
+	Backoff time.Duration
+	Client  *http.Client
+	// Log receives the progress line by line.
+	Log io.Writer
+	// Progress receives the summary of the items processed so far after
+	// each item.
+	Progress func(Summary)
+}
+
+// Items returns the items of the shard in order.
+func (r Runner) Items(ctx context.Context) ([]Item, error) {
+	shards := r.Shards
+	if shards < 1 {
+		shards = 1
+	}
+	items := []Item{}
+	if r.JSONL != "" {
+		data, err := r.Input.Read(ctx, r.JSONL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", r.JSONL, err)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+		index := 0
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			if index%shards == r.Shard {
+				items = append(items, Item{
+					ID:   fmt.Sprintf("%08d", index),
+					Line: append([]byte{}, line...),
+				})
+			}
+			index++
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", r.JSONL, err)
+		}
+		return items, nil
+	}
+	for i, name := range r.Files {
+		if i%shards == r.Shard {
+			items = append(items, Item{ID: name})
+		}
+	}
+	return items, nil
+}
+
+// Run processes the items of the shard. The error is only returned if the
+// shard cannot be processed, the failed items are counted in the summary.
+func (r Runner) Run(ctx context.Context) (Summary, error) {
+	summary := Summary{Shard: r.Shard}
+	items, err := r.Items(ctx)
+	if err != nil {
+		return summary, err
+	}
+	summary.Total = len(items)
+	r.printf("Processing %d items of shard %d/%d\n", len(items), r.Shard, r.Shards)
+	r.progress(summary)
+
+	for _, item := range items {
+		exists, err := r.Output.Exists(ctx, item.Output())
+		if err != nil {
+			return summary, fmt.Errorf("failed to check the output of %s: %w", item.ID, err)
+		}
+		if exists {
+			summary.Skipped++
+			summary.Succeeded++
+			r.progress(summary)
+			continue
+		}
+
+		if err := r.process(ctx, item); err != nil {
+			if ctx.Err() != nil {
+				return summary, ctx.Err()
+			}
+			r.printf("Item %s failed: %v\n", item.ID, err)
+			summary.Failed++
+			if len(summary.FailedItems) < maxFailedItems {
+				summary.FailedItems = append(summary.FailedItems, item.ID)
+			}
+			r.progress(summary)
+			continue
+		}
+		summary.Succeeded++
+		r.progress(summary)
+	}
+	r.printf("Processed shard %d: %d succeeded (%d skipped), %d failed\n",
+		r.Shard, summary.Succeeded, summary.Skipped, summary.Failed)
+	return summary, nil
+}
+
+// process posts the item with the retries and writes the response.
+func (r Runner) process(ctx context.Context, item Item) error {
+	body, contentType := item.Line, "application/json"
+	if body == nil {
+		data, err := r.Input.Read(ctx, item.ID)
+		if err != nil {
+			return fmt.Errorf("failed to read the input: %w", err)
+		}
+		body = data
+		contentType = mime.TypeByExtension(path.Ext(item.ID))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+	}
+
+	backoff := r.Backoff
+	var err error
+	for attempt := 0; attempt <= r.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var resp []byte
+		var retry bool
+		resp, retry, err = r.post(ctx, body, contentType)
+		if err == nil {
+			return r.Output.Write(ctx, item.Output(), resp)
+		}
+		if !retry {
+			return err
+		}
+	}
+	return err
+}
+
+// post returns the response of the model endpoint. The client errors are
+// not retried except the rate limits.
+func (r Runner) post(ctx context.Context, body []byte,
+	contentType string) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.Endpoint,
+		bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return data, false, nil
+	}
+	err = fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(truncate(data, 256)))
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return nil, retry, err
+}
+
+func (r Runner) client() *http.Client {
+	if r.Client != nil {
+		return r.Client
+	}
+	return http.DefaultClient
+}
+
+func (r Runner) progress(summary Summary) {
+	if r.Progress != nil {
+		summary.FailedItems = append([]string(nil), summary.FailedItems...)
+		r.Progress(summary)
+	}
+}
+
+func (r Runner) printf(format string, args ...interface{}) {
+	if r.Log != nil {
+		fmt.Fprintf(r.Log, format, args...)
+	}
+}
+
+// WaitReady polls the URL until the model server responds. Any response is
+// accepted if anyStatus is true, otherwise the status must be 2xx or 3xx.
+// It returns the error of the model server if it exits before it is ready.
+func WaitReady(ctx context.Context, client *http.Client, url string,
+	anyStatus bool, exited <-chan error) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := client.Do(req); err == nil {
+			resp.Body.Close()
+			if anyStatus || (resp.StatusCode >= 200 && resp.StatusCode < 400) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-exited:
+			return fmt.Errorf("model server exited before it is ready: %v", err)
+		case <-ticker.C:
+		}
+	}
+}
+
+func truncate(data []byte, n int) []byte {
+	if len(data) > n {
+		return data[:n]
+	}
+	return data
+}
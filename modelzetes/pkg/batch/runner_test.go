@@ -0,0 +1,217 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/model"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_Items(t *testing.T) {
+	input := t.TempDir()
+	writeFile(t, input, "data.jsonl", "{\"a\":0}\n\n{\"a\":1}\n{\"a\":2}\n{\"a\":3}\n{\"a\":4}\n")
+	r := Runner{Input: LocalStorage{Dir: input}, JSONL: "data.jsonl", Shard: 1, Shards: 2}
+	items, err := r.Items(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].ID != "00000001" || string(items[0].Line) != `{"a":1}` ||
+		items[1].ID != "00000003" {
+		t.Errorf("unexpected items of the JSONL file: %v", items)
+	}
+
+	r = Runner{Files: []string{"a.png", "b.png", "c.png"}, Shard: 0, Shards: 2}
+	items, err = r.Items(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].ID != "a.png" || items[1].ID != "c.png" {
+		t.Errorf("unexpected items of the files: %v", items)
+	}
+}
+
+func Test_Run(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		calls[string(body)]++
+		n := calls[string(body)]
+		mu.Unlock()
+		switch {
+		case r.URL.Path != "/predict":
+			w.WriteHeader(http.StatusNotFound)
+		case strings.Contains(string(body), "flaky") && n < 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case strings.Contains(string(body), "bad"):
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			fmt.Fprintf(w, "%s:%s", r.Header.Get("Content-Type"), body)
+		}
+	}))
+	defer server.Close()
+
+	input, output := t.TempDir(), t.TempDir()
+	writeFile(t, input, "data.jsonl", "\"ok\"\n\"flaky\"\n\"bad\"\n\"done\"\n")
+	writeFile(t, output, "00000003.out", "previous")
+
+	r := Runner{
+		Input:      LocalStorage{Dir: input},
+		Output:     LocalStorage{Dir: output},
+		JSONL:      "data.jsonl",
+		Endpoint:   server.URL + "/predict",
+		Shards:     1,
+		MaxRetries: 3,
+		Backoff:    time.Millisecond,
+	}
+	progress := []Summary{}
+	r.Progress = func(s Summary) { progress = append(progress, s) }
+	summary, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The progress is reported before the first item and after each item.
+	if len(progress) != 5 || progress[0].Total != 4 || progress[0].Succeeded != 0 ||
+		progress[2].Succeeded+progress[2].Failed != 2 {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+	if summary.Total != 4 || summary.Succeeded != 3 || summary.Skipped != 1 ||
+		summary.Failed != 1 || len(summary.FailedItems) != 1 || summary.FailedItems[0] != "00000002" {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if calls[`"flaky"`] != 3 {
+		t.Errorf("expected the flaky item to be retried, got %d calls", calls[`"flaky"`])
+	}
+	if calls[`"bad"`] != 1 {
+		t.Errorf("expected the client error not to be retried, got %d calls", calls[`"bad"`])
+	}
+	if calls[`"done"`] != 0 {
+		t.Errorf("expected the processed item to be skipped")
+	}
+	data, _ := os.ReadFile(filepath.Join(output, "00000000.out"))
+	if string(data) != `application/json:"ok"` {
+		t.Errorf("unexpected output %s", data)
+	}
+
+	// The failed item is retried by the next run.
+	r.Progress = nil
+	summary, err = r.Run(context.Background())
+	if err != nil || summary.Skipped != 3 || summary.Failed != 1 {
+		t.Errorf("unexpected summary of the rerun %+v, %v", summary, err)
+	}
+}
+
+func Test_RunFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s:%s", r.Header.Get("Content-Type"), body)
+	}))
+	defer server.Close()
+
+	input, output := t.TempDir(), t.TempDir()
+	writeFile(t, input, "images/cat.png", "cat")
+	r := Runner{
+		Input:    LocalStorage{Dir: input},
+		Output:   LocalStorage{Dir: output},
+		Files:    []string{"images/cat.png"},
+		Endpoint: server.URL,
+	}
+	summary, err := r.Run(context.Background())
+	if err != nil || summary.Succeeded != 1 {
+		t.Fatalf("unexpected summary %+v, %v", summary, err)
+	}
+	data, _ := os.ReadFile(filepath.Join(output, "images", "cat.png.out"))
+	if string(data) != "image/png:cat" {
+		t.Errorf("unexpected output %s", data)
+	}
+}
+
+func Test_S3Storage(t *testing.T) {
+	objects := map[string]string{"/bucket/input/data.jsonl": "{}"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = string(body)
+		case http.MethodGet, http.MethodHead:
+			content, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, content)
+		}
+	}))
+	defer server.Close()
+
+	cred := model.S3Credentials{AccessKeyID: "id", SecretAccessKey: "secret"}
+	s, err := NewStorage("s3://bucket/input/", server.URL, cred, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if data, err := s.Read(ctx, "data.jsonl"); err != nil || string(data) != "{}" {
+		t.Errorf("unexpected object %s, %v", data, err)
+	}
+	if exists, err := s.Exists(ctx, "a.out"); err != nil || exists {
+		t.Errorf("expected the object not to exist, got %v, %v", exists, err)
+	}
+	if err := s.Write(ctx, "a.out", []byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := s.Exists(ctx, "a.out"); err != nil || !exists {
+		t.Errorf("expected the object to exist, got %v, %v", exists, err)
+	}
+	if objects["/bucket/input/a.out"] != "a" {
+		t.Errorf("unexpected objects %v", objects)
+	}
+}
+
+func Test_WaitReady(t *testing.T) {
+	ready := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !ready {
+			ready = true
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := WaitReady(ctx, nil, server.URL, false, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	exited := make(chan error, 1)
+	exited <- fmt.Errorf("exit status 1")
+	if err := WaitReady(ctx, nil, "http://127.0.0.1:1", true, exited); err == nil {
+		t.Fatal("expected the error of the exited model server")
+	}
+}
@@ -0,0 +1,188 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/model"
+)
+
+// Storage reads the inputs and writes the outputs of the batch inference.
+type Storage interface {
+	// Read returns the content of the object.
+	Read(ctx context.Context, name string) ([]byte, error)
+	// Write creates or replaces the object.
+	Write(ctx context.Context, name string, data []byte) error
+	// Exists returns true if the object exists.
+	Exists(ctx context.Context, name string) (bool, error)
+}
+
+// NewStorage returns the storage of the location, s3://<bucket>/<prefix> is
+// on the S3 compatible storage and the others are the local directories,
+// e.g. the mounted volume claims.
+func NewStorage(uri, endpoint string, cred model.S3Credentials,
+	client *http.Client) (Storage, error) {
+	if !strings.HasPrefix(uri, "s3://") {
+		return LocalStorage{Dir: uri}, nil
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("bucket is required in %s", uri)
+	}
+	if endpoint == "" {
+		endpoint = model.DefaultS3Endpoint
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return S3Storage{
+		Endpoint:    strings.TrimSuffix(endpoint, "/"),
+		Bucket:      u.Host,
+		Prefix:      strings.Trim(u.Path, "/"),
+		Credentials: cred,
+		Client:      client,
+	}, nil
+}
+
+// LocalStorage stores the objects as the files under the directory.
+type LocalStorage struct {
+	Dir string
+}
+
+func (s LocalStorage) path(name string) (string, error) {
+	p := filepath.Join(s.Dir, filepath.FromSlash(name))
+	rel, err := filepath.Rel(s.Dir, p)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("invalid name %s", name)
+	}
+	return p, nil
+}
+
+func (s LocalStorage) Read(ctx context.Context, name string) ([]byte, error) {
+	p, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(p)
+}
+
+// Write writes into a temporary file and renames it, so that the partially
+// written outputs are not taken as processed.
+func (s LocalStorage) Write(ctx context.Context, name string, data []byte) error {
+	p, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(p), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+func (s LocalStorage) Exists(ctx context.Context, name string) (bool, error) {
+	p, err := s.path(name)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// S3Storage stores the objects under the prefix of the bucket with
+// path-style requests, so that it works with the S3 compatible storages.
+type S3Storage struct {
+	Endpoint    string
+	Bucket      string
+	Prefix      string
+	Credentials model.S3Credentials
+	Client      *http.Client
+}
+
+func (s S3Storage) do(ctx context.Context, method, name string,
+	body []byte) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method,
+		model.S3ObjectURL(s.Endpoint, s.Bucket, path.Join(s.Prefix, name)), r)
+	if err != nil {
+		return nil, err
+	}
+	model.SignS3(req, s.Credentials)
+	return s.Client.Do(req)
+}
+
+func (s S3Storage) Read(ctx context.Context, name string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, s3Error(resp)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (s S3Storage) Write(ctx context.Context, name string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, name, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp)
+	}
+	return nil
+}
+
+func (s S3Storage) Exists(ctx context.Context, name string) (bool, error) {
+	resp, err := s.do(ctx, http.MethodHead, name, nil)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, s3Error(resp)
+	}
+}
+
+func s3Error(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("%s %s: %s: %s", resp.Request.Method, resp.Request.URL,
+		resp.Status, strings.TrimSpace(string(body)))
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"net/http"
 
+	tensorchordv1beta1 "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/typed/modelzetes/v1beta1"
 	tensorchordv2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/typed/modelzetes/v2alpha1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
@@ -21,12 +22,14 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	TensorchordV2alpha1() tensorchordv2alpha1.TensorchordV2alpha1Interface
+	TensorchordV1beta1() tensorchordv1beta1.TensorchordV1beta1Interface
 }
 
 // Clientset contains the clients for groups.
 type Clientset struct {
 	*discovery.DiscoveryClient
 	tensorchordV2alpha1 *tensorchordv2alpha1.TensorchordV2alpha1Client
+	tensorchordV1beta1  *tensorchordv1beta1.TensorchordV1beta1Client
 }
 
 // TensorchordV2alpha1 retrieves the TensorchordV2alpha1Client
@@ -34,6 +37,11 @@ func (c *Clientset) TensorchordV2alpha1() tensorchordv2alpha1.TensorchordV2alpha
 	return c.tensorchordV2alpha1
 }
 
+// TensorchordV1beta1 retrieves the TensorchordV1beta1Client
+func (c *Clientset) TensorchordV1beta1() tensorchordv1beta1.TensorchordV1beta1Interface {
+	return c.tensorchordV1beta1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -82,6 +90,10 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
+	cs.tensorchordV1beta1, err = tensorchordv1beta1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
@@ -104,6 +116,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.tensorchordV2alpha1 = tensorchordv2alpha1.New(c)
+	cs.tensorchordV1beta1 = tensorchordv1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
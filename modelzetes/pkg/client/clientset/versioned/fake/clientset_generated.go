@@ -10,6 +10,8 @@ package fake
 
 import (
 	clientset "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned"
+	tensorchordv1beta1 "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/typed/modelzetes/v1beta1"
+	faketensorchordv1beta1 "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/typed/modelzetes/v1beta1/fake"
 	tensorchordv2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/typed/modelzetes/v2alpha1"
 	faketensorchordv2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/typed/modelzetes/v2alpha1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -73,3 +75,8 @@ var (
 func (c *Clientset) TensorchordV2alpha1() tensorchordv2alpha1.TensorchordV2alpha1Interface {
 	return &faketensorchordv2alpha1.FakeTensorchordV2alpha1{Fake: &c.Fake}
 }
+
+// TensorchordV1beta1 retrieves the TensorchordV1beta1Client
+func (c *Clientset) TensorchordV1beta1() tensorchordv1beta1.TensorchordV1beta1Interface {
+	return &faketensorchordv1beta1.FakeTensorchordV1beta1{Fake: &c.Fake}
+}
@@ -92,6 +92,18 @@ func (c *FakeInferences) Update(ctx context.Context, inference *v2alpha1.Inferen
 	return obj.(*v2alpha1.Inference), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeInferences) UpdateStatus(ctx context.Context, inference *v2alpha1.Inference, opts v1.UpdateOptions) (*v2alpha1.Inference, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(inferencesResource, "status", c.ns, inference), &v2alpha1.Inference{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.Inference), err
+}
+
 // Delete takes name of the inference and deletes it. Returns an error if one occurs.
 func (c *FakeInferences) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
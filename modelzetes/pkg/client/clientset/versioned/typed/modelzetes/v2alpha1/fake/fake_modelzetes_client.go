@@ -18,6 +18,10 @@ type FakeTensorchordV2alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTensorchordV2alpha1) BatchInferences(namespace string) v2alpha1.BatchInferenceInterface {
+	return &FakeBatchInferences{c, namespace}
+}
+
 func (c *FakeTensorchordV2alpha1) Inferences(namespace string) v2alpha1.InferenceInterface {
 	return &FakeInferences{c, namespace}
 }
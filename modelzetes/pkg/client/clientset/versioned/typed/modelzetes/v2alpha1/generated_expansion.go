@@ -8,4 +8,6 @@ Licensed under the MIT license. See LICENSE file in the project root for full li
 
 package v2alpha1
 
+type BatchInferenceExpansion interface{}
+
 type InferenceExpansion interface{}
@@ -30,6 +30,7 @@ type InferencesGetter interface {
 type InferenceInterface interface {
 	Create(ctx context.Context, inference *v2alpha1.Inference, opts v1.CreateOptions) (*v2alpha1.Inference, error)
 	Update(ctx context.Context, inference *v2alpha1.Inference, opts v1.UpdateOptions) (*v2alpha1.Inference, error)
+	UpdateStatus(ctx context.Context, inference *v2alpha1.Inference, opts v1.UpdateOptions) (*v2alpha1.Inference, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2alpha1.Inference, error)
@@ -125,6 +126,22 @@ func (c *inferences) Update(ctx context.Context, inference *v2alpha1.Inference,
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *inferences) UpdateStatus(ctx context.Context, inference *v2alpha1.Inference, opts v1.UpdateOptions) (result *v2alpha1.Inference, err error) {
+	result = &v2alpha1.Inference{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("inferences").
+		Name(inference.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(inference).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the inference and deletes it. Returns an error if one occurs.
 func (c *inferences) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -18,6 +18,7 @@ import (
 
 type TensorchordV2alpha1Interface interface {
 	RESTClient() rest.Interface
+	BatchInferencesGetter
 	InferencesGetter
 }
 
@@ -26,6 +27,10 @@ type TensorchordV2alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TensorchordV2alpha1Client) BatchInferences(namespace string) BatchInferenceInterface {
+	return newBatchInferences(c, namespace)
+}
+
 func (c *TensorchordV2alpha1Client) Inferences(namespace string) InferenceInterface {
 	return newInferences(c, namespace)
 }
@@ -11,6 +11,7 @@ package externalversions
 import (
 	"fmt"
 
+	v1beta1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v1beta1"
 	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
@@ -42,7 +43,13 @@ func (f *genericInformer) Lister() cache.GenericLister {
 // TODO extend this to unknown resources with a client pool
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
-	// Group=tensorchord.ai, Version=v2alpha1
+	// Group=tensorchord.ai, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("inferences"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tensorchord().V1beta1().Inferences().Informer()}, nil
+
+		// Group=tensorchord.ai, Version=v2alpha1
+	case v2alpha1.SchemeGroupVersion.WithResource("batchinferences"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tensorchord().V2alpha1().BatchInferences().Informer()}, nil
 	case v2alpha1.SchemeGroupVersion.WithResource("inferences"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tensorchord().V2alpha1().Inferences().Informer()}, nil
 
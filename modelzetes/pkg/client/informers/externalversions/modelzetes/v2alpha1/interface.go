@@ -14,6 +14,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// BatchInferences returns a BatchInferenceInformer.
+	BatchInferences() BatchInferenceInformer
 	// Inferences returns a InferenceInformer.
 	Inferences() InferenceInformer
 }
@@ -29,6 +31,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// BatchInferences returns a BatchInferenceInformer.
+func (v *version) BatchInferences() BatchInferenceInformer {
+	return &batchInferenceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Inferences returns a InferenceInformer.
 func (v *version) Inferences() InferenceInformer {
 	return &inferenceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
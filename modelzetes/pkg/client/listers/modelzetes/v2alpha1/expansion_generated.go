@@ -8,6 +8,14 @@ Licensed under the MIT license. See LICENSE file in the project root for full li
 
 package v2alpha1
 
+// BatchInferenceListerExpansion allows custom methods to be added to
+// BatchInferenceLister.
+type BatchInferenceListerExpansion interface{}
+
+// BatchInferenceNamespaceListerExpansion allows custom methods to be added to
+// BatchInferenceNamespaceLister.
+type BatchInferenceNamespaceListerExpansion interface{}
+
 // InferenceListerExpansion allows custom methods to be added to
 // InferenceLister.
 type InferenceListerExpansion interface{}
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/election"
 )
 
 type Config struct {
@@ -13,11 +17,47 @@ type Config struct {
 	HuggingfaceProxy HuggingfaceProxyConfig `json:"huggingface_proxy,omitempty"`
 	Probes           ProbesConfig           `json:"probes,omitempty"`
 	Inference        InferenceConfig        `json:"inference,omitempty"`
+	Webhook          WebhookConfig          `json:"webhook,omitempty"`
+	Rollout          RolloutConfig          `json:"rollout,omitempty"`
+	LeaderElection   election.Config        `json:"leader_election,omitempty"`
+}
+
+type RolloutConfig struct {
+	// PrometheusURL is the address of the prometheus which scrapes the
+	// gateway, it is used by the error rate check of the rollout.
+	PrometheusURL string `json:"prometheus_url,omitempty"`
+}
+
+type WebhookConfig struct {
+	Enabled  bool   `json:"enabled,omitempty"`
+	Port     int    `json:"port,omitempty"`
+	CertFile string `json:"cert_file,omitempty"`
+	KeyFile  string `json:"key_file,omitempty"`
 }
 
 type InferenceConfig struct {
 	ImagePullPolicy         string `json:"image_pull_policy,omitempty"`
 	SetUpRuntimeClassNvidia bool   `json:"set_up_runtime_class_nvidia,omitempty"`
+	// ModelDownloaderImage is the image of the init container which
+	// downloads the model artifacts, it runs `modelzetes download`.
+	ModelDownloaderImage string `json:"model_downloader_image,omitempty"`
+	// ModelCacheDir is the directory on the nodes to cache the models.
+	ModelCacheDir string `json:"model_cache_dir,omitempty"`
+	// ModelCacheMaxSize is the max size of the model cache on each node,
+	// e.g. 200Gi. The cache is not limited if it is empty.
+	ModelCacheMaxSize string `json:"model_cache_max_size,omitempty"`
+	// TerminationGracePeriodSeconds is the default grace period of the
+	// inference pods, the in-flight requests should finish in it.
+	TerminationGracePeriodSeconds int64 `json:"termination_grace_period_seconds,omitempty"`
+	// PreStopSleepSeconds is the default delay of SIGTERM in the preStop
+	// hook, 0 disables the hook. It is off by default since the hook needs
+	// a shell in the image.
+	PreStopSleepSeconds int32 `json:"pre_stop_sleep_seconds,omitempty"`
+	// DisruptionBudgetMaxUnavailable is the default max unavailable replicas
+	// of the PodDisruptionBudget.
+	DisruptionBudgetMaxUnavailable int32 `json:"disruption_budget_max_unavailable,omitempty"`
+	// FrameworkConfig is the path to the file of the custom frameworks.
+	FrameworkConfig string `json:"framework_config,omitempty"`
 }
 
 type ProbesConfig struct {
@@ -43,6 +83,7 @@ type ControllerConfig struct {
 type MetricsConfig struct {
 	ServerPort int `json:"server_port,omitempty"`
 }
+
 type KubeConfig struct {
 	Kubeconfig   string        `json:"kubeconfig,omitempty"`
 	MasterURL    string        `json:"master_url,omitempty"`
@@ -75,8 +116,32 @@ func (c Config) Validate() error {
 		return errors.New("invalid controller config")
 	}
 
-	if c.Inference.ImagePullPolicy == "" {
+	if c.Inference.ImagePullPolicy == "" ||
+		c.Inference.ModelDownloaderImage == "" || c.Inference.ModelCacheDir == "" {
 		return errors.New("invalid inference config")
 	}
+	if c.Inference.ModelCacheMaxSize != "" {
+		if _, err := resource.ParseQuantity(c.Inference.ModelCacheMaxSize); err != nil {
+			return errors.New("invalid model cache max size")
+		}
+	}
+
+	if c.Inference.TerminationGracePeriodSeconds < 0 ||
+		c.Inference.PreStopSleepSeconds < 0 ||
+		int64(c.Inference.PreStopSleepSeconds) >= c.Inference.TerminationGracePeriodSeconds ||
+		c.Inference.DisruptionBudgetMaxUnavailable < 1 {
+		return errors.New("invalid inference termination config")
+	}
+
+	if err := c.LeaderElection.Validate(); err != nil {
+		return err
+	}
+
+	if c.Webhook.Enabled {
+		if c.Webhook.Port <= 0 ||
+			c.Webhook.CertFile == "" || c.Webhook.KeyFile == "" {
+			return errors.New("invalid webhook config")
+		}
+	}
 	return nil
 }
@@ -2,6 +2,16 @@ package consts
 
 const (
 	ResourceNvidiaGPU = "nvidia.com/gpu"
+	// ResourceNvidiaGPUShared is the time-sliced GPU advertised by the
+	// device plugin with renameByDefault enabled.
+	ResourceNvidiaGPUShared = "nvidia.com/gpu.shared"
+	// ResourceNvidiaMIGPrefix is the prefix of the MIG devices, e.g.
+	// nvidia.com/mig-1g.10gb.
+	ResourceNvidiaMIGPrefix = "nvidia.com/mig-"
+	// ResourceAMDGPU is the GPU advertised by the ROCm device plugin.
+	ResourceAMDGPU = "amd.com/gpu"
+	// ResourceIntelGPU is the GPU advertised by the Intel GPU device plugin.
+	ResourceIntelGPU = "gpu.intel.com/i915"
 
 	LabelInferenceName      = "inference"
 	LabelInferenceNamespace = "inference-namespace"
@@ -9,10 +19,46 @@ const (
 	LabelName               = "ai.tensorchord.name"
 	LabelNamespace          = "modelz.tensorchord.ai/namespace"
 	LabelServerResource     = "ai.tensorchord.server-resource"
+	// LabelBatchInferenceName is set on the pods of the batch inference
+	// jobs to the name of the batch inference.
+	LabelBatchInferenceName = "batch-inference"
 
 	AnnotationBuilding        = "ai.tensorchord.building"
 	AnnotationDockerImage     = "ai.tensorchord.docker.image"
 	AnnotationControlPlaneKey = "ai.tensorchord.control-plane"
+	// AnnotationCrashLoop is set on the inference by the autoscaler when the
+	// instances keep crashing, the value is the JSON encoded suppression state.
+	AnnotationCrashLoop = "ai.tensorchord.crash-loop"
+	// AnnotationPendingReason is set on the inference by the autoscaler when
+	// the replicas cannot be scheduled, the value is a human readable reason.
+	AnnotationPendingReason = "ai.tensorchord.pending-reason"
+	// AnnotationChangeAuthor is set on the inference by the agent or the
+	// admission webhook to the user who changed the spec, it is recorded in
+	// the revision.
+	AnnotationChangeAuthor = "ai.tensorchord.change-author"
+	// AnnotationChangeCause is the human readable cause of the spec change,
+	// it is recorded in the revision.
+	AnnotationChangeCause = "ai.tensorchord.change-cause"
+	// AnnotationSecretChecksums is set on the pod template by modelzetes to
+	// the checksums of the mounted secrets, so that a change of the secrets
+	// rolls out the replicas.
+	AnnotationSecretChecksums = "ai.tensorchord.secret-checksums"
+	// AnnotationBatchGeneration is set on the job of the batch inference by
+	// modelzetes to the generation of the batch inference which the job is
+	// created from.
+	AnnotationBatchGeneration = "ai.tensorchord.batch-generation"
+	// AnnotationDownloadProgress is patched on the pod by the model
+	// downloader to the progress of the download.
+	AnnotationDownloadProgress = "ai.tensorchord.download-progress"
+	// AnnotationBatchProgress is patched on the pod of the batch inference
+	// job by the runner to the JSON encoded summary of the items processed
+	// so far.
+	AnnotationBatchProgress = "ai.tensorchord.batch-progress"
+
+	// FinalizerCleanup is added to the inferences by modelzetes, it is
+	// removed after the resources outside of the owner references, e.g. the
+	// ingress and the image caches, are cleaned up.
+	FinalizerCleanup = "tensorchord.ai/cleanup"
 
 	ModelzAnnotationValue = "modelz"
 
@@ -25,9 +71,16 @@ const (
 	ProviderName = "modelzetes"
 
 	DefaultServicePrefix = "mdz-"
+	// DefaultBatchJobPrefix is the prefix of the jobs of the batch
+	// inferences.
+	DefaultBatchJobPrefix = "mdz-batch-"
 
 	DefaultHTTPProbePath = "/"
 
+	// ModelDownloaderContainerName is the name of the init container which
+	// downloads the model of the inference.
+	ModelDownloaderContainerName = "model-downloader"
+
 	// MaxReplicas is the maximum number of replicas that can be set for a inference.
 	MaxReplicas = 5
 )
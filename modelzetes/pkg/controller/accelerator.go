@@ -0,0 +1,38 @@
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/accelerator"
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+)
+
+// configureAccelerator sets the tolerations and the runtime class of the
+// vendor if the inference requests an accelerator. If the quantity is zero,
+// the devices are hidden from the container by the env vars of the vendor.
+func configureAccelerator(inference *v2alpha1.Inference,
+	deployment *appsv1.Deployment, factory FunctionFactory) {
+	a, _, q, ok := accelerator.Requested(inference.Spec.Resources.Limits)
+	if !ok {
+		return
+	}
+
+	spec := &deployment.Spec.Template.Spec
+	if q.Value() > 0 {
+		spec.Tolerations = append([]corev1.Toleration{}, a.Tolerations...)
+		// The runtime class is only available if it is set up in the
+		// cluster, only NVIDIA needs it for now.
+		if a.RuntimeClassName != "" && factory.Factory.Config.RuntimeClassNvidia {
+			spec.RuntimeClassName = &a.RuntimeClassName
+		}
+		return
+	}
+
+	for _, name := range a.HideDevicesEnv {
+		spec.Containers[0].Env = append(spec.Containers[0].Env, corev1.EnvVar{
+			Name:  name,
+			Value: "",
+		})
+	}
+}
@@ -0,0 +1,754 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	glog "k8s.io/klog"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/accelerator"
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/batch"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/validation"
+)
+
+const (
+	// batchControllerName is the controller label of the metrics of the
+	// batch inferences.
+	batchControllerName = "modelz-batch-operator"
+
+	batchContainerName    = "batch"
+	batchInstallerName    = "batch-installer"
+	batchBinVolumeName    = "batch-bin"
+	batchBinMountPath     = "/modelz/bin"
+	batchInputVolumeName  = "batch-input"
+	batchInputMountPath   = "/batch/input"
+	batchOutputVolumeName = "batch-output"
+	batchOutputMountPath  = "/batch/output"
+
+	// annotationJobCompletionIndex is set on the pods of the indexed jobs.
+	annotationJobCompletionIndex = "batch.kubernetes.io/job-completion-index"
+
+	reasonBatchJobCreated  = "JobCreated"
+	reasonBatchInvalid     = "InvalidSpec"
+	reasonBatchSucceeded   = "Succeeded"
+	reasonBatchFailed      = "Failed"
+	reasonBatchCancelled   = "Cancelled"
+	reasonBatchRerun       = "Rerun"
+	messageBatchNotStarted = "Waiting for the pods of the job"
+
+	// maxBatchFailedItems limits the failed items in the message.
+	maxBatchFailedItems = 10
+)
+
+// batchSpec is the spec of the batch inference merged with the referenced
+// inference and the defaults of the framework.
+type batchSpec struct {
+	image         string
+	command       []string
+	port          int32
+	httpProbePath string
+	envVars       map[string]string
+	secrets       []string
+	resources     *corev1.ResourceRequirements
+}
+
+// resolveBatchSpec merges the batch inference with the inference, the
+// fields of the batch inference take precedence.
+func resolveBatchSpec(bi *v2alpha1.BatchInference,
+	inference *v2alpha1.Inference) (batchSpec, error) {
+	spec := bi.Spec
+	res := batchSpec{envVars: map[string]string{}}
+	var fw v2alpha1.Framework
+	var port *int32
+	var command, httpProbePath *string
+	if inference != nil {
+		res.image = inference.Spec.Image
+		fw = inference.Spec.Framework
+		port = inference.Spec.Port
+		command = inference.Spec.Command
+		httpProbePath = inference.Spec.HTTPProbePath
+		for k, v := range inference.Spec.EnvVars {
+			res.envVars[k] = v
+		}
+		res.secrets = append(res.secrets, inference.Spec.Secrets...)
+		res.resources = inference.Spec.Resources
+	}
+
+	if spec.Image != "" {
+		res.image = spec.Image
+	}
+	if spec.Framework != "" {
+		fw = spec.Framework
+	}
+	if spec.Port != nil {
+		port = spec.Port
+	}
+	if spec.Command != nil {
+		command = spec.Command
+	}
+	if spec.HTTPProbePath != nil {
+		httpProbePath = spec.HTTPProbePath
+	}
+	for k, v := range spec.EnvVars {
+		res.envVars[k] = v
+	}
+	for _, s := range spec.Secrets {
+		if !contains(res.secrets, s) {
+			res.secrets = append(res.secrets, s)
+		}
+	}
+	if spec.Resources != nil {
+		res.resources = spec.Resources
+	}
+
+	if res.image == "" {
+		return res, fmt.Errorf("image: is required if the inference is not set")
+	}
+	if err := validation.ValidateFramework(string(fw), port, res.envVars); err != nil {
+		return res, err
+	}
+	if err := validation.ValidateBatchLocation("input",
+		spec.Input.URI, spec.Input.VolumeClaim); err != nil {
+		return res, err
+	}
+	if err := validation.ValidateBatchInput(spec.Input.JSONL, spec.Input.Files); err != nil {
+		return res, err
+	}
+	if err := validation.ValidateBatchLocation("output",
+		spec.Output.URI, spec.Output.VolumeClaim); err != nil {
+		return res, err
+	}
+	if err := validation.ValidateBatchLimits(spec.Parallelism,
+		spec.MaxRetries, spec.BackoffLimit); err != nil {
+		return res, err
+	}
+	if err := validation.ValidateResourceRequirements(res.resources); err != nil {
+		return res, err
+	}
+
+	f, _ := framework.Get(string(fw))
+	for k, v := range f.EnvWithPort() {
+		if _, ok := res.envVars[k]; !ok {
+			res.envVars[k] = v
+		}
+	}
+	switch {
+	case port != nil:
+		res.port = *port
+	case f.Port != 0:
+		res.port, _ = validation.FrameworkPort(f.Name, res.envVars)
+	default:
+		res.port = validation.DefaultPort
+	}
+	switch {
+	case command != nil && *command != "":
+		res.command = strings.Split(*command, " ")
+	case f.Command != "":
+		res.command = strings.Split(f.Command, " ")
+	default:
+		// The runner starts the model server by itself, the entrypoint of
+		// the image is unknown.
+		return res, fmt.Errorf("command: is required since the framework (%s) has no default command", fw)
+	}
+	if httpProbePath != nil && *httpProbePath != "" {
+		res.httpProbePath = *httpProbePath
+	} else {
+		res.httpProbePath = f.HTTPProbePath
+	}
+	return res, nil
+}
+
+// newBatchJob creates the indexed job of the batch inference, each
+// completion index is a shard. The init container copies the modelzetes
+// binary into the pod, so that the runner could start the model server in
+// the model image and post the items to it.
+func newBatchJob(bi *v2alpha1.BatchInference, spec batchSpec,
+	factory FunctionFactory) *batchv1.Job {
+	shards := batchShards(bi)
+	labels := map[string]string{
+		consts.LabelBatchInferenceName: bi.Name,
+	}
+
+	args := []string{"batch", "run"}
+	args = append(args, batchLocationArgs("input",
+		bi.Spec.Input.BatchLocation, batchInputMountPath)...)
+	if bi.Spec.Input.JSONL != "" {
+		args = append(args, "--input-jsonl", bi.Spec.Input.JSONL)
+	}
+	for _, f := range bi.Spec.Input.Files {
+		args = append(args, "--input-file", f)
+	}
+	args = append(args, batchLocationArgs("output",
+		bi.Spec.Output, batchOutputMountPath)...)
+	httpPath := bi.Spec.Path
+	if httpPath == "" {
+		httpPath = "/"
+	}
+	args = append(args,
+		"--port", fmt.Sprint(spec.port),
+		"--path", httpPath,
+		"--shards", fmt.Sprint(shards),
+		"--max-retries", fmt.Sprint(batchMaxRetries(bi)),
+	)
+	if spec.httpProbePath != "" {
+		args = append(args, "--http-probe-path", spec.httpProbePath)
+	}
+	args = append(args, "--")
+	args = append(args, spec.command...)
+
+	names := make([]string, 0, len(spec.envVars))
+	for name := range spec.envVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	envVars := []corev1.EnvVar{}
+	for _, name := range names {
+		envVars = append(envVars, corev1.EnvVar{Name: name, Value: spec.envVars[name]})
+	}
+	if hfEnvs := factory.MakeHuggingfacePullThroughCacheEnvVar(); hfEnvs != nil {
+		envVars = addEnvVarIfNotExists(envVars, hfEnvs.Name, hfEnvs.Value)
+	}
+	envVars = addPodEnvVars(envVars)
+	envFrom := []corev1.EnvFromSource{}
+	for _, s := range spec.secrets {
+		envFrom = append(envFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: s},
+			},
+		})
+	}
+
+	allowPrivilegeEscalation := false
+	pullPolicy := corev1.PullPolicy(factory.Factory.Config.ImagePullPolicy)
+	container := corev1.Container{
+		Name:            batchContainerName,
+		Image:           spec.image,
+		Command:         []string{path.Join(batchBinMountPath, "modelzetes")},
+		Args:            args,
+		Env:             envVars,
+		EnvFrom:         envFrom,
+		ImagePullPolicy: pullPolicy,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: batchBinVolumeName, MountPath: batchBinMountPath, ReadOnly: true},
+			{Name: "dshm", MountPath: "/dev/shm"},
+		},
+	}
+	volumes := []corev1.Volume{
+		{
+			Name:         batchBinVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		{
+			Name: "dshm",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+			},
+		},
+	}
+	for _, v := range []struct {
+		name, mountPath, claim string
+		readOnly               bool
+	}{
+		{batchInputVolumeName, batchInputMountPath, bi.Spec.Input.VolumeClaim, true},
+		{batchOutputVolumeName, batchOutputMountPath, bi.Spec.Output.VolumeClaim, false},
+	} {
+		if v.claim == "" {
+			continue
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: v.name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: v.claim,
+					ReadOnly:  v.readOnly,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      v.name,
+			MountPath: v.mountPath,
+			ReadOnly:  v.readOnly,
+		})
+	}
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		InitContainers: []corev1.Container{
+			{
+				Name:                     batchInstallerName,
+				Image:                    factory.Factory.Config.ModelDownloaderImage,
+				Command:                  []string{"modelzetes", "batch", "install", batchBinMountPath},
+				ImagePullPolicy:          pullPolicy,
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: batchBinVolumeName, MountPath: batchBinMountPath},
+				},
+			},
+		},
+		Containers: []corev1.Container{container},
+		Volumes:    volumes,
+	}
+	if spec.resources != nil {
+		podSpec.Containers[0].Resources = *spec.resources
+		if a, _, q, ok := accelerator.Requested(spec.resources.Limits); ok && q.Value() > 0 {
+			podSpec.Tolerations = append([]corev1.Toleration{}, a.Tolerations...)
+			if a.RuntimeClassName != "" && factory.Factory.Config.RuntimeClassNvidia {
+				podSpec.RuntimeClassName = &a.RuntimeClassName
+			}
+		}
+	}
+
+	completionMode := batchv1.IndexedCompletion
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      consts.DefaultBatchJobPrefix + bi.Name,
+			Namespace: bi.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				consts.AnnotationBatchGeneration: strconv.FormatInt(bi.Generation, 10),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(bi, schema.GroupVersionKind{
+					Group:   v2alpha1.SchemeGroupVersion.Group,
+					Version: v2alpha1.SchemeGroupVersion.Version,
+					Kind:    v2alpha1.BatchKind,
+				}),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			CompletionMode: &completionMode,
+			Completions:    Ptr(shards),
+			Parallelism:    Ptr(shards),
+			BackoffLimit:   Ptr(batchBackoffLimit(bi)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+		},
+	}
+}
+
+// batchLocationArgs returns the flags of the runner for the location, the
+// paths in the volume claims are mounted under the mount path.
+func batchLocationArgs(name string, l v2alpha1.BatchLocation, mountPath string) []string {
+	uri := l.URI
+	if l.VolumeClaim != "" {
+		uri = path.Join(mountPath, uri)
+	}
+	args := []string{"--" + name + "-uri", uri}
+	if l.Endpoint != "" {
+		args = append(args, "--"+name+"-endpoint", l.Endpoint)
+	}
+	return args
+}
+
+func batchShards(bi *v2alpha1.BatchInference) int32 {
+	if bi.Spec.Parallelism != nil {
+		return *bi.Spec.Parallelism
+	}
+	return validation.DefaultBatchParallelism
+}
+
+func batchMaxRetries(bi *v2alpha1.BatchInference) int32 {
+	if bi.Spec.MaxRetries != nil {
+		return *bi.Spec.MaxRetries
+	}
+	return validation.DefaultBatchMaxRetries
+}
+
+func batchBackoffLimit(bi *v2alpha1.BatchInference) int32 {
+	if bi.Spec.BackoffLimit != nil {
+		return *bi.Spec.BackoffLimit
+	}
+	return validation.DefaultBatchBackoffLimit
+}
+
+// syncBatchHandler creates the job of the batch inference and keeps the
+// status up to date with the job and the summaries of the shards. The job
+// is not updated after it is created, since the pod template of the jobs
+// is immutable.
+func (c *Controller) syncBatchHandler(key string) (err error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	bi, err := c.batchInferencesLister.BatchInferences(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// Record the failure on the batch inference, it is retried later.
+	defer func() {
+		if err != nil {
+			c.recorder.Eventf(bi, corev1.EventTypeWarning, reasonSyncFailed,
+				"Failed to sync: %v", err)
+		}
+	}()
+
+	job, err := c.jobsLister.Jobs(namespace).Get(consts.DefaultBatchJobPrefix + name)
+	if errors.IsNotFound(err) {
+		job, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+	if job != nil && !metav1.IsControlledBy(job, bi) {
+		msg := fmt.Sprintf(MessageResourceExists, job.Name)
+		c.recorder.Event(bi, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf(msg)
+	}
+
+	if bi.Spec.Cancel {
+		return c.cancelBatch(bi, job)
+	}
+
+	if generation, ok := batchJobGeneration(job); ok && isJobFinished(job) &&
+		generation < bi.Generation {
+		// The job is not updated with the spec, the finished job is deleted
+		// and recreated from the new generation once the deletion is
+		// observed. The pods are deleted first so that they are not
+		// counted in the new job.
+		foreground := metav1.DeletePropagationForeground
+		if err := c.kubeclientset.BatchV1().Jobs(job.Namespace).Delete(context.TODO(),
+			job.Name, metav1.DeleteOptions{
+				PropagationPolicy: &foreground,
+			}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		c.recorder.Eventf(bi, corev1.EventTypeNormal, reasonBatchRerun,
+			"Deleted the finished job %s to rerun generation %d", job.Name, bi.Generation)
+		return nil
+	}
+
+	if job == nil {
+		// The job of the finished batch inference may be deleted by the
+		// users, it is not recreated unless the spec changes, e.g. the
+		// cancelled batch inference is resumed.
+		if isBatchFinished(bi.Status.Phase) &&
+			bi.Status.ObservedGeneration == bi.Generation {
+			return nil
+		}
+		job, err = c.createBatchJob(bi)
+		if err != nil || job == nil {
+			return err
+		}
+	}
+
+	pods, err := c.podsLister.Pods(namespace).List(labels.SelectorFromSet(
+		map[string]string{consts.LabelBatchInferenceName: name}))
+	if err != nil {
+		return err
+	}
+	status := computeBatchStatus(bi, job, pods)
+	if status.Phase != bi.Status.Phase {
+		switch status.Phase {
+		case v2alpha1.BatchInferencePhaseSucceeded:
+			c.recorder.Event(bi, corev1.EventTypeNormal, reasonBatchSucceeded, status.Message)
+		case v2alpha1.BatchInferencePhaseFailed:
+			c.recorder.Event(bi, corev1.EventTypeWarning, reasonBatchFailed, status.Message)
+		}
+	}
+	return c.updateBatchStatus(bi, status)
+}
+
+// createBatchJob creates the job of the batch inference. The invalid batch
+// inference is failed without the job, nil is returned then.
+func (c *Controller) createBatchJob(bi *v2alpha1.BatchInference) (*batchv1.Job, error) {
+	var inference *v2alpha1.Inference
+	if bi.Spec.Inference != "" {
+		var err error
+		inference, err = c.inferenceLister.Inferences(bi.Namespace).Get(bi.Spec.Inference)
+		if err != nil {
+			// The inference may be created later, the sync is retried.
+			return nil, fmt.Errorf("failed to get the inference %s: %w", bi.Spec.Inference, err)
+		}
+	}
+
+	spec, err := resolveBatchSpec(bi, inference)
+	if err != nil {
+		c.recorder.Eventf(bi, corev1.EventTypeWarning, reasonBatchInvalid,
+			"Invalid spec: %v", err)
+		status := *bi.Status.DeepCopy()
+		status.ObservedGeneration = bi.Generation
+		status.Phase = v2alpha1.BatchInferencePhaseFailed
+		status.Message = err.Error()
+		return nil, c.updateBatchStatus(bi, status)
+	}
+
+	glog.Infof("Creating job for batch inference '%s/%s'", bi.Namespace, bi.Name)
+	job, err := c.kubeclientset.BatchV1().Jobs(bi.Namespace).Create(context.TODO(),
+		newBatchJob(bi, spec, c.factory), metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	c.recorder.Eventf(bi, corev1.EventTypeNormal, reasonBatchJobCreated,
+		"Created job %s with %d shards", job.Name, batchShards(bi))
+	return job, nil
+}
+
+// cancelBatch deletes the running job of the batch inference. The outputs
+// are kept, the finished batch inferences are not changed.
+func (c *Controller) cancelBatch(bi *v2alpha1.BatchInference, job *batchv1.Job) error {
+	if job != nil && !isJobFinished(job) {
+		background := metav1.DeletePropagationBackground
+		if err := c.kubeclientset.BatchV1().Jobs(job.Namespace).Delete(context.TODO(),
+			job.Name, metav1.DeleteOptions{
+				PropagationPolicy: &background,
+			}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	if isBatchFinished(bi.Status.Phase) {
+		return nil
+	}
+
+	status := *bi.Status.DeepCopy()
+	status.ObservedGeneration = bi.Generation
+	status.Phase = v2alpha1.BatchInferencePhaseCancelled
+	status.ActiveShards = 0
+	status.Message = "Cancelled by the user"
+	status.CompletionTime = Ptr(metav1.Now())
+	c.recorder.Event(bi, corev1.EventTypeNormal, reasonBatchCancelled, status.Message)
+	return c.updateBatchStatus(bi, status)
+}
+
+// computeBatchStatus computes the status of the batch inference from the
+// job and the summaries of the shards in the termination messages.
+func computeBatchStatus(bi *v2alpha1.BatchInference, job *batchv1.Job,
+	pods []*corev1.Pod) v2alpha1.BatchInferenceStatus {
+	status := *bi.Status.DeepCopy()
+	// The job is not updated with the spec, thus only the generation which
+	// the job is created from is observed.
+	if generation, ok := batchJobGeneration(job); ok {
+		status.ObservedGeneration = generation
+	}
+	status.JobName = job.Name
+	if job.Spec.Completions != nil {
+		status.Shards = *job.Spec.Completions
+	}
+	status.ActiveShards = job.Status.Active
+	status.CompletedShards = job.Status.Succeeded
+	status.StartTime = job.CreationTimestamp.DeepCopy()
+	status.CompletionTime = nil
+
+	status.Total, status.Succeeded, status.Failed = 0, 0, 0
+	failedItems := []string{}
+	for _, s := range batchSummaries(pods) {
+		status.Total += int32(s.Total)
+		status.Succeeded += int32(s.Succeeded)
+		status.Failed += int32(s.Failed)
+		failedItems = append(failedItems, s.FailedItems...)
+	}
+	if len(failedItems) > maxBatchFailedItems {
+		failedItems = failedItems[:maxBatchFailedItems]
+	}
+
+	status.Phase = v2alpha1.BatchInferencePhasePending
+	status.Message = messageBatchNotStarted
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			status.Phase = v2alpha1.BatchInferencePhaseSucceeded
+			status.Message = fmt.Sprintf("Processed %d items", status.Succeeded)
+			status.CompletionTime = job.Status.CompletionTime
+			return status
+		case batchv1.JobFailed:
+			status.Phase = v2alpha1.BatchInferencePhaseFailed
+			status.Message = cond.Message
+			if status.Failed > 0 {
+				status.Message = fmt.Sprintf("%d items failed after the retries, e.g. %s",
+					status.Failed, strings.Join(failedItems, ", "))
+			}
+			status.ActiveShards = 0
+			status.CompletionTime = cond.LastTransitionTime.DeepCopy()
+			return status
+		}
+	}
+	if (job.Status.Ready != nil && *job.Status.Ready > 0) ||
+		job.Status.Succeeded > 0 || job.Status.Failed > 0 {
+		status.Phase = v2alpha1.BatchInferencePhaseRunning
+		status.Message = ""
+	}
+	return status
+}
+
+// batchSummaries returns the summaries of the shards, the summary of the
+// latest pod of each shard is used since the failed pods are retried. The
+// summary of the finished pods is in the termination message, the running
+// pods report the progress in the annotation.
+func batchSummaries(pods []*corev1.Pod) []batch.Summary {
+	latest := map[string]*corev1.Pod{}
+	summaries := map[string]batch.Summary{}
+	for _, pod := range pods {
+		index, ok := pod.Annotations[annotationJobCompletionIndex]
+		if !ok {
+			continue
+		}
+		summary, ok := batchSummary(pod)
+		if !ok {
+			continue
+		}
+		if p, ok := latest[index]; ok && p.CreationTimestamp.After(pod.CreationTimestamp.Time) {
+			continue
+		}
+		latest[index] = pod
+		summaries[index] = summary
+	}
+
+	indexes := make([]string, 0, len(summaries))
+	for index := range summaries {
+		indexes = append(indexes, index)
+	}
+	sort.Strings(indexes)
+	res := []batch.Summary{}
+	for _, index := range indexes {
+		res = append(res, summaries[index])
+	}
+	return res
+}
+
+// batchSummary parses the summary in the termination message of the runner,
+// or the progress reported in the annotation if it is not terminated.
+func batchSummary(pod *corev1.Pod) (batch.Summary, bool) {
+	summary := batch.Summary{}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != batchContainerName || status.State.Terminated == nil {
+			continue
+		}
+		if err := json.Unmarshal([]byte(status.State.Terminated.Message), &summary); err == nil {
+			return summary, true
+		}
+	}
+	value, ok := pod.Annotations[consts.AnnotationBatchProgress]
+	if !ok {
+		return summary, false
+	}
+	if err := json.Unmarshal([]byte(value), &summary); err != nil {
+		return summary, false
+	}
+	return summary, true
+}
+
+// updateBatchStatus updates the status subresource of the batch inference
+// if it changes.
+func (c *Controller) updateBatchStatus(bi *v2alpha1.BatchInference,
+	status v2alpha1.BatchInferenceStatus) error {
+	if equality.Semantic.DeepEqual(status, bi.Status) {
+		return nil
+	}
+	updated := bi.DeepCopy()
+	updated.Status = status
+	glog.V(4).Infof("Updating status of batch inference '%s': %s", bi.Name, status.Phase)
+	_, err := c.faasclientset.TensorchordV2alpha1().BatchInferences(bi.Namespace).
+		UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// enqueueBatch puts the key of the batch inference onto the batch work
+// queue.
+func (c *Controller) enqueueBatch(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.batchPending.Add(key)
+	c.batchqueue.AddRateLimited(key)
+}
+
+// handleBatchJob enqueues the batch inference which owns the job.
+func (c *Controller) handleBatchJob(obj interface{}) {
+	object, ok := obj.(metav1.Object)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+	}
+	ownerRef := metav1.GetControllerOf(object)
+	if ownerRef == nil || ownerRef.Kind != v2alpha1.BatchKind {
+		return
+	}
+	bi, err := c.batchInferencesLister.BatchInferences(object.GetNamespace()).Get(ownerRef.Name)
+	if err != nil {
+		return
+	}
+	c.enqueueBatch(bi)
+}
+
+// batchJobGeneration returns the generation of the batch inference which
+// the job is created from.
+func batchJobGeneration(job *batchv1.Job) (int64, bool) {
+	if job == nil {
+		return 0, false
+	}
+	generation, err := strconv.ParseInt(
+		job.Annotations[consts.AnnotationBatchGeneration], 10, 64)
+	return generation, err == nil
+}
+
+func isJobFinished(job *batchv1.Job) bool {
+	for _, cond := range job.Status.Conditions {
+		if (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) &&
+			cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func isBatchFinished(phase v2alpha1.BatchInferencePhase) bool {
+	switch phase {
+	case v2alpha1.BatchInferencePhaseSucceeded, v2alpha1.BatchInferencePhaseFailed,
+		v2alpha1.BatchInferencePhaseCancelled:
+		return true
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,354 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	inferencefake "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/fake"
+	listers "github.com/tensorchord/openmodelz/modelzetes/pkg/client/listers/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+func newBatchInference() *v2alpha1.BatchInference {
+	return &v2alpha1.BatchInference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "embeddings",
+			Namespace:  "modelz-uid",
+			UID:        "uid",
+			Generation: 1,
+		},
+		Spec: v2alpha1.BatchInferenceSpec{
+			Inference: "llm",
+			EnvVars:   map[string]string{"MODEL": "batch"},
+			Secrets:   []string{"aws"},
+			Input: v2alpha1.BatchInput{
+				BatchLocation: v2alpha1.BatchLocation{URI: "datasets", VolumeClaim: "data"},
+				JSONL:         "prompts.jsonl",
+			},
+			Output: v2alpha1.BatchLocation{
+				URI:      "s3://bucket/outputs",
+				Endpoint: "http://minio:9000",
+			},
+			Path:        "/v1/completions",
+			Parallelism: Ptr(int32(4)),
+		},
+	}
+}
+
+func newBatchReferencedInference() *v2alpha1.Inference {
+	return &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "modelz-uid"},
+		Spec: v2alpha1.InferenceSpec{
+			Name:      "llm",
+			Image:     "vllm/vllm-openai",
+			Framework: v2alpha1.FrameworkVLLM,
+			EnvVars:   map[string]string{"MODEL": "inference", "DTYPE": "half"},
+			Secrets:   []string{"hf-token"},
+		},
+	}
+}
+
+func Test_newBatchJob(t *testing.T) {
+	bi := newBatchInference()
+	spec, err := resolveBatchSpec(bi, newBatchReferencedInference())
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := newBatchJob(bi, spec, FunctionFactory{})
+
+	if job.Name != "mdz-batch-embeddings" || *job.Spec.Completions != 4 ||
+		*job.Spec.Parallelism != 4 || *job.Spec.CompletionMode != batchv1.IndexedCompletion {
+		t.Errorf("unexpected job %s: %v", job.Name, job.Spec)
+	}
+	if !metav1.IsControlledBy(job, bi) {
+		t.Errorf("expected the job to be controlled by the batch inference")
+	}
+	if job.Spec.Template.Labels[consts.LabelBatchInferenceName] != bi.Name {
+		t.Errorf("unexpected pod labels %v", job.Spec.Template.Labels)
+	}
+
+	container := job.Spec.Template.Spec.Containers[0]
+	if container.Image != "vllm/vllm-openai" {
+		t.Errorf("expected the image of the inference, got %s", container.Image)
+	}
+	args := strings.Join(container.Args, " ")
+	for _, expected := range []string{
+		"--input-uri /batch/input/datasets",
+		"--input-jsonl prompts.jsonl",
+		"--output-uri s3://bucket/outputs",
+		"--output-endpoint http://minio:9000",
+		"--port 8000",
+		"--path /v1/completions",
+		"--shards 4",
+		"--max-retries 3",
+		"--http-probe-path /health",
+		"-- python3 -m vllm.entrypoints.openai.api_server",
+	} {
+		if !strings.Contains(args, expected) {
+			t.Errorf("expected %q in the args %s", expected, args)
+		}
+	}
+
+	env := map[string]string{}
+	for _, e := range container.Env {
+		env[e.Name] = e.Value
+	}
+	if env["MODEL"] != "batch" || env["DTYPE"] != "half" {
+		t.Errorf("expected the env vars of the batch inference to take precedence, got %v", env)
+	}
+	if len(container.EnvFrom) != 2 || container.EnvFrom[0].SecretRef.Name != "hf-token" ||
+		container.EnvFrom[1].SecretRef.Name != "aws" {
+		t.Errorf("unexpected secrets %v", container.EnvFrom)
+	}
+
+	claims := []string{}
+	for _, v := range job.Spec.Template.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil {
+			claims = append(claims, v.Name+"="+v.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	if len(claims) != 1 || claims[0] != batchInputVolumeName+"=data" {
+		t.Errorf("expected only the input volume claim, got %v", claims)
+	}
+	if init := job.Spec.Template.Spec.InitContainers; len(init) != 1 ||
+		strings.Join(init[0].Command, " ") != "modelzetes batch install /modelz/bin" {
+		t.Errorf("unexpected init containers %v", init)
+	}
+}
+
+func Test_resolveBatchSpec(t *testing.T) {
+	bi := newBatchInference()
+	bi.Spec.Inference = ""
+	bi.Spec.Image = "modelzai/gradio"
+	bi.Spec.Framework = v2alpha1.FrameworkGradio
+	if _, err := resolveBatchSpec(bi, nil); err == nil ||
+		!strings.Contains(err.Error(), "command") {
+		t.Errorf("expected the command to be required, got %v", err)
+	}
+
+	bi.Spec.Command = Ptr("python app.py")
+	spec, err := resolveBatchSpec(bi, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.port != 7860 || strings.Join(spec.command, " ") != "python app.py" ||
+		spec.envVars["GRADIO_SERVER_NAME"] != "0.0.0.0" {
+		t.Errorf("expected the defaults of the framework, got %+v", spec)
+	}
+
+	bi.Spec.Image = ""
+	if _, err := resolveBatchSpec(bi, nil); err == nil {
+		t.Errorf("expected the image to be required")
+	}
+
+	bi = newBatchInference()
+	bi.Spec.Output.VolumeClaim = "data"
+	if _, err := resolveBatchSpec(bi, newBatchReferencedInference()); err == nil {
+		t.Errorf("expected the volume claim to be invalid with the object store")
+	}
+}
+
+func Test_computeBatchStatus(t *testing.T) {
+	bi := newBatchInference()
+	job := newBatchJob(bi, batchSpec{}, FunctionFactory{})
+	job.Status.Active = 1
+	job.Status.Succeeded = 1
+	job.Status.Ready = Ptr(int32(1))
+
+	now := time.Now()
+	newPod := func(index string, created time.Time, phase corev1.PodPhase,
+		message string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "pod-" + index + "-" + string(phase),
+				CreationTimestamp: metav1.NewTime(created),
+				Annotations:       map[string]string{annotationJobCompletionIndex: index},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: batchContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: message},
+					},
+				}},
+			},
+		}
+	}
+	pods := []*corev1.Pod{
+		newPod("0", now.Add(-time.Minute), corev1.PodFailed,
+			`{"shard":0,"total":10,"succeeded":8,"failed":2,"failed_items":["00000000","00000004"]}`),
+		newPod("0", now, corev1.PodSucceeded,
+			`{"shard":0,"total":10,"succeeded":10,"skipped":8}`),
+		newPod("1", now, corev1.PodFailed,
+			`{"shard":1,"total":10,"succeeded":9,"failed":1,"failed_items":["00000001"]}`),
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationJobCompletionIndex: "2"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		// The running pod reports the progress in the annotation.
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotationJobCompletionIndex:   "3",
+					consts.AnnotationBatchProgress: `{"shard":3,"total":10,"succeeded":4,"failed":1}`,
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	}
+
+	// The spec changed after the job is created is not observed.
+	bi.Generation = 2
+	status := computeBatchStatus(bi, job, pods)
+	if status.Phase != v2alpha1.BatchInferencePhaseRunning || status.Shards != 4 ||
+		status.CompletedShards != 1 || status.ActiveShards != 1 {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if status.ObservedGeneration != 1 {
+		t.Errorf("expected the generation of the job to be observed, got %d",
+			status.ObservedGeneration)
+	}
+	if status.Total != 30 || status.Succeeded != 23 || status.Failed != 2 {
+		t.Errorf("expected the latest summaries of the shards, got %+v", status)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type:    batchv1.JobFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  "BackoffLimitExceeded",
+		Message: "Job has reached the specified backoff limit",
+	}}
+	status = computeBatchStatus(bi, job, pods)
+	if status.Phase != v2alpha1.BatchInferencePhaseFailed ||
+		!strings.Contains(status.Message, "00000001") || status.CompletionTime == nil {
+		t.Errorf("expected the failed items in the message, got %+v", status)
+	}
+}
+
+func Test_syncBatchHandler(t *testing.T) {
+	newController := func(bi *v2alpha1.BatchInference, jobs ...*batchv1.Job) *Controller {
+		batchIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		_ = batchIndexer.Add(bi)
+		inferenceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		_ = inferenceIndexer.Add(newBatchReferencedInference())
+		jobIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		kubeClient := fake.NewSimpleClientset()
+		for _, job := range jobs {
+			_ = jobIndexer.Add(job)
+			_, _ = kubeClient.BatchV1().Jobs(job.Namespace).
+				Create(context.TODO(), job, metav1.CreateOptions{})
+		}
+		return &Controller{
+			kubeclientset:         kubeClient,
+			faasclientset:         inferencefake.NewSimpleClientset(bi),
+			inferenceLister:       listers.NewInferenceLister(inferenceIndexer),
+			batchInferencesLister: listers.NewBatchInferenceLister(batchIndexer),
+			jobsLister:            batchlisters.NewJobLister(jobIndexer),
+			podsLister: corelisters.NewPodLister(
+				cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+			recorder: record.NewFakeRecorder(10),
+		}
+	}
+	getStatus := func(c *Controller, bi *v2alpha1.BatchInference) v2alpha1.BatchInferenceStatus {
+		updated, err := c.faasclientset.TensorchordV2alpha1().BatchInferences(bi.Namespace).
+			Get(context.TODO(), bi.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return updated.Status
+	}
+
+	t.Run("create", func(t *testing.T) {
+		bi := newBatchInference()
+		c := newController(bi)
+		if err := c.syncBatchHandler("modelz-uid/embeddings"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.kubeclientset.BatchV1().Jobs(bi.Namespace).
+			Get(context.TODO(), "mdz-batch-embeddings", metav1.GetOptions{}); err != nil {
+			t.Fatalf("expected the job to be created, got %v", err)
+		}
+		status := getStatus(c, bi)
+		if status.Phase != v2alpha1.BatchInferencePhasePending ||
+			status.JobName != "mdz-batch-embeddings" || status.Shards != 4 {
+			t.Errorf("unexpected status %+v", status)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		bi := newBatchInference()
+		bi.Spec.Input.JSONL = ""
+		c := newController(bi)
+		if err := c.syncBatchHandler("modelz-uid/embeddings"); err != nil {
+			t.Fatal(err)
+		}
+		jobs, _ := c.kubeclientset.BatchV1().Jobs(bi.Namespace).
+			List(context.TODO(), metav1.ListOptions{})
+		if len(jobs.Items) != 0 {
+			t.Errorf("expected no job for the invalid batch inference")
+		}
+		if status := getStatus(c, bi); status.Phase != v2alpha1.BatchInferencePhaseFailed ||
+			!strings.Contains(status.Message, "input") {
+			t.Errorf("unexpected status %+v", status)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		bi := newBatchInference()
+		spec, err := resolveBatchSpec(bi, newBatchReferencedInference())
+		if err != nil {
+			t.Fatal(err)
+		}
+		job := newBatchJob(bi, spec, FunctionFactory{})
+		bi.Spec.Cancel = true
+		c := newController(bi, job)
+		if err := c.syncBatchHandler("modelz-uid/embeddings"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.kubeclientset.BatchV1().Jobs(bi.Namespace).
+			Get(context.TODO(), job.Name, metav1.GetOptions{}); err == nil {
+			t.Errorf("expected the job to be deleted")
+		}
+		if status := getStatus(c, bi); status.Phase != v2alpha1.BatchInferencePhaseCancelled ||
+			status.CompletionTime == nil {
+			t.Errorf("unexpected status %+v", status)
+		}
+	})
+	t.Run("rerun", func(t *testing.T) {
+		bi := newBatchInference()
+		spec, err := resolveBatchSpec(bi, newBatchReferencedInference())
+		if err != nil {
+			t.Fatal(err)
+		}
+		job := newBatchJob(bi, spec, FunctionFactory{})
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:   batchv1.JobComplete,
+			Status: corev1.ConditionTrue,
+		}}
+		bi.Generation++
+		c := newController(bi, job)
+		if err := c.syncBatchHandler("modelz-uid/embeddings"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.kubeclientset.BatchV1().Jobs(bi.Namespace).
+			Get(context.TODO(), job.Name, metav1.GetOptions{}); err == nil {
+			t.Errorf("expected the finished job of the old generation to be deleted")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -17,17 +18,23 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	glog "k8s.io/klog"
 
+	kubefledgedclientset "github.com/senthilrch/kube-fledged/pkg/client/clientset/versioned"
+	ingressclientset "github.com/tensorchord/openmodelz/ingress-operator/pkg/client/clientset/versioned"
 	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
 	clientset "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned"
 	faasscheme "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/scheme"
 	informers "github.com/tensorchord/openmodelz/modelzetes/pkg/client/informers/externalversions"
 	listers "github.com/tensorchord/openmodelz/modelzetes/pkg/client/listers/modelzetes/v2alpha1"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/metrics"
 )
 
 const (
@@ -45,6 +52,13 @@ const (
 	// MessageResourceSynced is the message used for an Event fired when a Function
 	// is synced successfully
 	MessageResourceSynced = "Function synced successfully"
+
+	reasonDeploymentCreated = "DeploymentCreated"
+	reasonDeploymentUpdated = "DeploymentUpdated"
+	reasonServiceCreated    = "ServiceCreated"
+	// reasonSyncFailed is the reason of the event when the sync fails, the
+	// inference is requeued with the backoff.
+	reasonSyncFailed = "SyncFailed"
 )
 
 // Controller is the controller implementation for Function resources
@@ -54,11 +68,31 @@ type Controller struct {
 	kubeclientset kubernetes.Interface
 	// faasclientset is a clientset for our own API group
 	faasclientset clientset.Interface
+	// ingressclientset and kubefledgedclientset are used to clean up the
+	// ingresses and the image caches of the deleted inferences, the cleanup
+	// is skipped if they are nil.
+	ingressclientset     ingressclientset.Interface
+	kubefledgedclientset kubefledgedclientset.Interface
 
 	deploymentsLister appslisters.DeploymentLister
 	deploymentsSynced cache.InformerSynced
 	inferenceLister   listers.InferenceLister
 	inferencesSynced  cache.InformerSynced
+	podsLister        corelisters.PodLister
+	podsSynced        cache.InformerSynced
+	revisionsLister   appslisters.ControllerRevisionLister
+	revisionsSynced   cache.InformerSynced
+	replicaSetsLister appslisters.ReplicaSetLister
+	replicaSetsSynced cache.InformerSynced
+	pdbsLister        policylisters.PodDisruptionBudgetLister
+	pdbsSynced        cache.InformerSynced
+	secretsLister     corelisters.SecretLister
+	secretsSynced     cache.InformerSynced
+
+	batchInferencesLister listers.BatchInferenceLister
+	batchInferencesSynced cache.InformerSynced
+	jobsLister            batchlisters.JobLister
+	jobsSynced            cache.InformerSynced
 
 	// workqueue is a rate limited work queue. This is used to queue work to be
 	// processed instead of performing it as soon as a change happens. This
@@ -66,12 +100,23 @@ type Controller struct {
 	// time, and makes it easy to ensure we are never processing the same item
 	// simultaneously in two different workers.
 	workqueue workqueue.RateLimitingInterface
+	// batchqueue is the work queue of the batch inferences.
+	batchqueue workqueue.RateLimitingInterface
 	// recorder is an event recorder for recording Event resources to the
 	// Kubernetes API.
 	recorder record.EventRecorder
 
 	// OpenFaaS function factory
 	factory FunctionFactory
+
+	// errorRates is used by the error rate check of the rollout, the check
+	// is skipped if it is nil.
+	errorRates errorRateFetcher
+
+	// pending tracks the inferences which are not synced yet.
+	pending *metrics.Pending
+	// batchPending tracks the batch inferences which are not synced yet.
+	batchPending *metrics.Pending
 }
 
 // NewController returns a new OpenFaaS controller
@@ -79,12 +124,20 @@ func NewController(
 	kubeclientset kubernetes.Interface,
 	inferenceclientset clientset.Interface,
 	kubeInformerFactory kubeinformers.SharedInformerFactory,
+	secretInformerFactory kubeinformers.SharedInformerFactory,
 	inferenceInformerFactory informers.SharedInformerFactory,
 	factory FunctionFactory) *Controller {
 
 	// obtain references to shared index informers for the Deployment and Function types
 	deploymentInformer := kubeInformerFactory.Apps().V1().Deployments()
 	inferenceInformer := inferenceInformerFactory.Tensorchord().V2alpha1().Inferences()
+	podInformer := kubeInformerFactory.Core().V1().Pods()
+	revisionInformer := kubeInformerFactory.Apps().V1().ControllerRevisions()
+	replicaSetInformer := kubeInformerFactory.Apps().V1().ReplicaSets()
+	pdbInformer := kubeInformerFactory.Policy().V1().PodDisruptionBudgets()
+	secretInformer := secretInformerFactory.Core().V1().Secrets()
+	batchInferenceInformer := inferenceInformerFactory.Tensorchord().V2alpha1().BatchInferences()
+	jobInformer := kubeInformerFactory.Batch().V1().Jobs()
 
 	// Create event broadcaster
 	// Add o6s types to the default Kubernetes Scheme so Events can be
@@ -103,9 +156,28 @@ func NewController(
 		deploymentsSynced: deploymentInformer.Informer().HasSynced,
 		inferenceLister:   inferenceInformer.Lister(),
 		inferencesSynced:  inferenceInformer.Informer().HasSynced,
-		workqueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Functions"),
-		recorder:          recorder,
-		factory:           factory,
+		podsLister:        podInformer.Lister(),
+		podsSynced:        podInformer.Informer().HasSynced,
+		revisionsLister:   revisionInformer.Lister(),
+		revisionsSynced:   revisionInformer.Informer().HasSynced,
+		replicaSetsLister: replicaSetInformer.Lister(),
+		replicaSetsSynced: replicaSetInformer.Informer().HasSynced,
+		pdbsLister:        pdbInformer.Lister(),
+		pdbsSynced:        pdbInformer.Informer().HasSynced,
+		secretsLister:     secretInformer.Lister(),
+		secretsSynced:     secretInformer.Informer().HasSynced,
+
+		batchInferencesLister: batchInferenceInformer.Lister(),
+		batchInferencesSynced: batchInferenceInformer.Informer().HasSynced,
+		jobsLister:            jobInformer.Lister(),
+		jobsSynced:            jobInformer.Informer().HasSynced,
+
+		workqueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Functions"),
+		batchqueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "BatchInferences"),
+		recorder:     recorder,
+		factory:      factory,
+		pending:      metrics.NewPending(controllerAgentName),
+		batchPending: metrics.NewPending(batchControllerName),
 	}
 
 	glog.Info("Setting up event handlers")
@@ -121,6 +193,63 @@ func NewController(
 			},
 		})
 
+	// Set up event handlers for the owned deployments and their pods, so that
+	// the status of the inference is kept up to date.
+	deploymentInformer.Informer().
+		AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: controller.handleObject,
+			UpdateFunc: func(old, new interface{}) {
+				oldDeployment := old.(*appsv1.Deployment)
+				newDeployment := new.(*appsv1.Deployment)
+				if oldDeployment.ResourceVersion == newDeployment.ResourceVersion {
+					return
+				}
+				controller.handleObject(new)
+			},
+			DeleteFunc: controller.handleObject,
+		})
+	podInformer.Informer().
+		AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: controller.handlePod,
+			UpdateFunc: func(old, new interface{}) {
+				controller.handlePod(new)
+			},
+			DeleteFunc: controller.handlePod,
+		})
+
+	// Set up an event handler for the secrets mounted by the inferences, so
+	// that the replicas are restarted with the new values.
+	secretInformer.Informer().
+		AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: controller.handleSecret,
+			UpdateFunc: func(old, new interface{}) {
+				oldSecret := old.(*corev1.Secret)
+				newSecret := new.(*corev1.Secret)
+				if oldSecret.ResourceVersion == newSecret.ResourceVersion {
+					return
+				}
+				controller.handleSecret(new)
+			},
+			DeleteFunc: controller.handleSecret,
+		})
+
+	// Set up the event handlers for the batch inferences and their jobs.
+	batchInferenceInformer.Informer().
+		AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: controller.enqueueBatch,
+			UpdateFunc: func(old, new interface{}) {
+				controller.enqueueBatch(new)
+			},
+		})
+	jobInformer.Informer().
+		AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: controller.handleBatchJob,
+			UpdateFunc: func(old, new interface{}) {
+				controller.handleBatchJob(new)
+			},
+			DeleteFunc: controller.handleBatchJob,
+		})
+
 	// Set up an event handler for when functions related resources like pods, deployments, replica sets
 	// can't be materialized. This logs abnormal events like ImagePullBackOff, back-off restarting failed container,
 	// failed to start container, oci runtime errors, etc
@@ -149,19 +278,25 @@ func NewController(
 func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
 	defer runtime.HandleCrash()
 	defer c.workqueue.ShutDown()
+	defer c.batchqueue.ShutDown()
 
 	// Start the informer factories to begin populating the informer caches
 	// Wait for the caches to be synced before starting workers
 	glog.Info("Waiting for informer caches to sync")
 	if ok := cache.WaitForCacheSync(stopCh,
-		c.deploymentsSynced, c.inferencesSynced); !ok {
+		c.deploymentsSynced, c.inferencesSynced, c.podsSynced,
+		c.revisionsSynced, c.replicaSetsSynced, c.pdbsSynced,
+		c.secretsSynced, c.batchInferencesSynced, c.jobsSynced); !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 
 	glog.Info("Starting workers")
+	c.pending.Start()
+	c.batchPending.Start()
 	// Launch two workers to process Function resources
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
+		go wait.Until(c.runBatchWorker, time.Second, stopCh)
 	}
 
 	glog.Info("Started workers")
@@ -178,28 +313,49 @@ func (c *Controller) runWorker() {
 	}
 }
 
+// runBatchWorker processes the batch inferences on the batch queue.
+func (c *Controller) runBatchWorker() {
+	for c.processNextItem(c.batchqueue, c.batchPending,
+		batchControllerName, c.syncBatchHandler) {
+	}
+}
+
 // processNextWorkItem will read a single work item off the workqueue and
 // attempt to process it, by calling the syncHandler.
 func (c *Controller) processNextWorkItem() bool {
-	obj, shutdown := c.workqueue.Get()
+	return c.processNextItem(c.workqueue, c.pending,
+		controllerAgentName, c.syncHandler)
+}
+
+// processNextItem reads a single key off the queue and syncs it, the key is
+// requeued with the backoff if the sync fails.
+func (c *Controller) processNextItem(queue workqueue.RateLimitingInterface,
+	pending *metrics.Pending, name string, sync func(key string) error) bool {
+	obj, shutdown := queue.Get()
 
 	if shutdown {
 		return false
 	}
 
 	err := func(obj interface{}) error {
-		defer c.workqueue.Done(obj)
+		defer queue.Done(obj)
 		var key string
 		var ok bool
 		if key, ok = obj.(string); !ok {
-			c.workqueue.Forget(obj)
+			queue.Forget(obj)
 			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
 			return nil
 		}
-		if err := c.syncHandler(key); err != nil {
+		start := time.Now()
+		err := sync(key)
+		metrics.ObserveReconcile(name, start, err)
+		if err != nil {
+			// Requeue the item to retry with the backoff.
+			queue.AddRateLimited(key)
 			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
 		}
-		c.workqueue.Forget(obj)
+		pending.Done(key)
+		queue.Forget(obj)
 		return nil
 	}(obj)
 
@@ -213,7 +369,7 @@ func (c *Controller) processNextWorkItem() bool {
 
 // syncHandler compares the actual state with the desired, and attempts to
 // converge the two.
-func (c *Controller) syncHandler(key string) error {
+func (c *Controller) syncHandler(key string) (err error) {
 	// Convert the namespace/name string into a distinct namespace and name
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
@@ -242,6 +398,20 @@ func (c *Controller) syncHandler(key string) error {
 		return nil
 	}
 
+	// The cleanup of the deleted inference runs even if it is still
+	// building.
+	if done, err := c.syncFinalizer(function); done || err != nil {
+		return err
+	}
+
+	// Record the failure on the inference, it is retried later.
+	defer func() {
+		if err != nil {
+			c.recorder.Eventf(function, corev1.EventTypeWarning, reasonSyncFailed,
+				"Failed to sync: %v", err)
+		}
+	}()
+
 	if function.Spec.Annotations != nil {
 		if _, ok := function.Spec.Annotations[consts.AnnotationBuilding]; ok {
 			glog.Infof("Function '%s' is still building", function.Spec.Name)
@@ -249,16 +419,25 @@ func (c *Controller) syncHandler(key string) error {
 		}
 	}
 
+	existingSecrets, missingSecrets, err := c.getSecrets(function.Namespace, function.Spec.Secrets)
+	if err != nil {
+		return err
+	}
+	for _, name := range missingSecrets {
+		c.recorder.Eventf(function, corev1.EventTypeWarning, reasonSecretNotFound,
+			"Secret %s is not found, the replicas are started without it", name)
+	}
+
+	// synced is true if the deployment is created or updated.
+	synced := false
+
 	// Get the deployment with the name specified in Function.spec
 	deployment, err := c.deploymentsLister.
 		Deployments(function.Namespace).Get(deploymentName)
 	// If the resource doesn't exist, we'll create it
 	if errors.IsNotFound(err) {
+		synced = true
 		err = nil
-		existingSecrets, err := c.getSecrets(function.Namespace, function.Spec.Secrets)
-		if err != nil {
-			return err
-		}
 
 		glog.Infof("Creating deployment for '%s'", function.Spec.Name)
 		deployment, err = c.kubeclientset.AppsV1().Deployments(function.Namespace).Create(
@@ -269,22 +448,13 @@ func (c *Controller) syncHandler(key string) error {
 		if err != nil {
 			return err
 		}
+		c.recorder.Eventf(function, corev1.EventTypeNormal, reasonDeploymentCreated,
+			"Created deployment %s", deployment.Name)
 	}
 
-	svcGetOptions := metav1.GetOptions{}
 	svcName := consts.DefaultServicePrefix + deploymentName
-	_, getSvcErr := c.kubeclientset.CoreV1().Services(function.Namespace).Get(context.TODO(), deploymentName, svcGetOptions)
-	if errors.IsNotFound(getSvcErr) {
-		glog.Infof("Creating ClusterIP service for '%s'", function.Spec.Name)
-		if _, err := c.kubeclientset.CoreV1().Services(function.Namespace).Create(context.TODO(), newService(function), metav1.CreateOptions{}); err != nil {
-			// If an error occurs during Service Create, we'll requeue the item
-			if errors.IsAlreadyExists(err) {
-				err = nil
-				glog.V(2).Infof("ClusterIP service '%s' already exists. Skipping creation.", function.Spec.Name)
-			} else {
-				return err
-			}
-		}
+	if err == nil {
+		err = c.syncService(function)
 	}
 
 	// If an error occurs during Get/Create, we'll requeue the item so we can
@@ -302,14 +472,12 @@ func (c *Controller) syncHandler(key string) error {
 		return fmt.Errorf(msg)
 	}
 
-	// Update the Deployment resource if the Function definition differs
-	if deploymentNeedsUpdate(function, deployment) {
+	// Update the Deployment resource if the Function definition or the
+	// mounted secrets differ
+	secretsUpdated, changedSecrets := secretsChanged(deployment, existingSecrets)
+	if deploymentNeedsUpdate(function, deployment) || secretsUpdated {
 		glog.Infof("Updating deployment for '%s'", function.Spec.Name)
-
-		existingSecrets, err := c.getSecrets(function.Namespace, function.Spec.Secrets)
-		if err != nil {
-			return err
-		}
+		synced = true
 
 		deployment, err = c.kubeclientset.AppsV1().Deployments(function.Namespace).Update(
 			context.TODO(),
@@ -318,6 +486,13 @@ func (c *Controller) syncHandler(key string) error {
 		)
 		if err != nil {
 			glog.Errorf("Updating deployment for '%s' failed: %v", function.Spec.Name, err)
+			return err
+		}
+		c.recorder.Eventf(function, corev1.EventTypeNormal, reasonDeploymentUpdated,
+			"Updated deployment %s", deployment.Name)
+		for _, name := range changedSecrets {
+			c.recorder.Eventf(function, corev1.EventTypeNormal, reasonSecretChanged,
+				"Restarting the replicas since secret %s is changed", name)
 		}
 
 		existingService, err := c.kubeclientset.CoreV1().Services(function.Namespace).Get(context.TODO(), svcName, metav1.GetOptions{})
@@ -339,7 +514,33 @@ func (c *Controller) syncHandler(key string) error {
 		return err
 	}
 
-	c.recorder.Event(function, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	if deployment, err = c.backfillSecretChecksums(
+		function, deployment, existingSecrets); err != nil {
+		return fmt.Errorf("failed to record secret checksums: %v", err)
+	}
+
+	if err := c.syncRevision(function); err != nil {
+		return fmt.Errorf("failed to record revision: %v", err)
+	}
+
+	if err := c.syncPodDisruptionBudget(function); err != nil {
+		return fmt.Errorf("failed to sync pod disruption budget: %v", err)
+	}
+
+	function, rollout, err := c.syncRollout(function, deployment)
+	if err != nil {
+		return fmt.Errorf("failed to check rollout: %v", err)
+	}
+
+	if err := c.updateStatus(function, deployment, rollout); err != nil {
+		return fmt.Errorf("failed to update status: %v", err)
+	}
+
+	// The inference is also synced on changes of the deployment and pods,
+	// only record the event if the deployment is created or updated.
+	if synced {
+		c.recorder.Event(function, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	}
 	return nil
 }
 
@@ -353,6 +554,7 @@ func (c *Controller) enqueueFunction(obj interface{}) {
 		runtime.HandleError(err)
 		return
 	}
+	c.pending.Add(key)
 	c.workqueue.AddRateLimited(key)
 }
 
@@ -397,31 +599,47 @@ func (c *Controller) handleObject(obj interface{}) {
 	}
 }
 
-// getSecrets queries Kubernetes for a list of secrets by name in the given k8s namespace.
+// getSecrets returns the secrets by name in the given k8s namespace, with
+// the names of the missing ones. The secrets managed by modelz are read from
+// the cache, the other ones, e.g. created by kubectl, are not watched and
+// read from the API server.
 func (c *Controller) getSecrets(namespace string,
-	secretNames []string) (map[string]*corev1.Secret, error) {
+	secretNames []string) (map[string]*corev1.Secret, []string, error) {
 	secrets := map[string]*corev1.Secret{}
+	missing := []string{}
 
 	for _, secretName := range secretNames {
-		secret, err := c.kubeclientset.CoreV1().
-			Secrets(namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
-		if err != nil {
-			return secrets, err
+		secret, err := c.secretsLister.Secrets(namespace).Get(secretName)
+		if errors.IsNotFound(err) {
+			secret, err = c.kubeclientset.CoreV1().Secrets(namespace).Get(
+				context.TODO(), secretName, metav1.GetOptions{})
+		}
+		if errors.IsNotFound(err) {
+			missing = append(missing, secretName)
+			continue
+		} else if err != nil {
+			return nil, nil, err
 		}
 		secrets[secretName] = secret
 	}
 
-	return secrets, nil
+	return secrets, missing, nil
 }
 
 // getReplicas returns the desired number of replicas for a function taking into account
-// the min replicas label, HPA, the autoscaler and scaled to zero deployments
+// the min replicas label, HPA, the autoscaler, the crash loop suppression
+// and scaled to zero deployments
 func getReplicas(inference *v2alpha1.Inference, deployment *appsv1.Deployment) *int32 {
 	var minReplicas, maxReplicas *int32
 	if inference.Spec.Scaling != nil {
 		minReplicas = inference.Spec.Scaling.MinReplicas
 		maxReplicas = inference.Spec.Scaling.MaxReplicas
 	}
+	if suppressed, ok := crashLoopMinReplicas(inference); ok &&
+		minReplicas != nil && suppressed < *minReplicas {
+		// The autoscaler suppresses the inference since its instances keep crashing.
+		minReplicas = &suppressed
+	}
 
 	// extract current deployment replicas if specified
 	var deploymentReplicas *int32
@@ -464,3 +682,19 @@ func getReplicas(inference *v2alpha1.Inference, deployment *appsv1.Deployment) *
 
 	return minReplicas
 }
+
+// crashLoopMinReplicas returns the min replicas applied by the autoscaler
+// while the instances of the inference keep crashing.
+func crashLoopMinReplicas(inference *v2alpha1.Inference) (int32, bool) {
+	value, ok := inference.Annotations[consts.AnnotationCrashLoop]
+	if !ok {
+		return 0, false
+	}
+	state := struct {
+		MinReplicas int32 `json:"minReplicas"`
+	}{}
+	if err := json.Unmarshal([]byte(value), &state); err != nil {
+		return 0, false
+	}
+	return state.MinReplicas, true
+}
@@ -2,7 +2,7 @@ package controller
 
 import (
 	"encoding/json"
-	"strconv"
+	"sort"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -15,6 +15,7 @@ import (
 
 	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
 	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
 )
@@ -24,8 +25,6 @@ const (
 	defaultPort             = 8080
 )
 
-var runtimeClassNvidia = "nvidia"
-
 // newDeployment creates a new Deployment for a Function resource. It also sets
 // the appropriate OwnerReferences on the resource so handleObject can discover
 // the Function resource that 'owns' it.
@@ -148,7 +147,9 @@ func newDeployment(
 		if probes.Startup != nil {
 			deploymentSpec.Spec.Template.Spec.Containers[0].StartupProbe = probes.Startup
 			if inference.Spec.Scaling != nil &&
-				inference.Spec.Scaling.StartupDuration != nil {
+				inference.Spec.Scaling.StartupDuration != nil &&
+				probes.Startup.PeriodSeconds > 0 &&
+				!hasStartupFailureThreshold(inference) {
 				// Set the failure threshold to the number of seconds in the duration.
 				deploymentSpec.Spec.Template.Spec.Containers[0].
 					StartupProbe.FailureThreshold = int32(
@@ -159,53 +160,33 @@ func newDeployment(
 
 	if inference.Spec.Resources != nil {
 		deploymentSpec.Spec.Template.Spec.Containers[0].Resources = *inference.Spec.Resources
-		if q, ok := inference.Spec.Resources.Limits[consts.ResourceNvidiaGPU]; ok {
-			if q.Value() > 0 {
-				// If GPU is requested, add the GPU toleration.
-				deploymentSpec.Spec.Template.Spec.Tolerations = makeTolerationGPU()
-				if factory.Factory.Config.RuntimeClassNvidia {
-					deploymentSpec.Spec.Template.Spec.RuntimeClassName = &runtimeClassNvidia
-				}
-			} else {
-				// If GPU is not requested, set CUDA_VISIBLE_DEVICES to empty string.
-				deploymentSpec.Spec.Template.Spec.Containers[0].Env = append(
-					deploymentSpec.Spec.Template.Spec.Containers[0].Env,
-					corev1.EnvVar{
-						Name:  "CUDA_VISIBLE_DEVICES",
-						Value: "",
-					},
-				)
-			}
-		}
+		configureAccelerator(inference, deploymentSpec, factory)
 	}
 
+	if inference.Spec.Rollout != nil {
+		deploymentSpec.Spec.ProgressDeadlineSeconds = Ptr(
+			progressDeadlineSeconds(inference.Spec.Rollout))
+	}
+
+	configureScheduling(inference, deploymentSpec)
+	configureModel(inference, deploymentSpec, envVars, factory)
+	configureTermination(inference, deploymentSpec, factory)
+	configureSecrets(inference, deploymentSpec, existingSecrets, factory)
+
 	factory.ConfigureReadOnlyRootFilesystem(inference, deploymentSpec)
 	factory.ConfigureContainerUserID(deploymentSpec)
 
 	return deploymentSpec
 }
 
-func makeTolerationGPU() []corev1.Toleration {
-	res := []corev1.Toleration{
-		{
-			Key:      consts.TolerationGPU,
-			Operator: corev1.TolerationOpEqual,
-			Value:    "true",
-		},
-		{
-			Key:      consts.TolerationNvidiaGPUPresent,
-			Operator: corev1.TolerationOpEqual,
-			Value:    "present",
-		},
-	}
-	return res
-}
-
 func makeCommand(inference *v2alpha1.Inference) []string {
 	if inference.Spec.Command != nil {
 		res := strings.Split(*inference.Spec.Command, " ")
 		return res
 	}
+	if f, ok := framework.Get(string(inference.Spec.Framework)); ok && f.Command != "" {
+		return strings.Split(f.Command, " ")
+	}
 	return nil
 }
 
@@ -221,20 +202,17 @@ func makeEnvVars(inference *v2alpha1.Inference) []corev1.EnvVar {
 		}
 	}
 
-	// Set environment variables for different frameworks.
-	switch inference.Spec.Framework {
-	case v2alpha1.FrameworkGradio:
-		envVars = addEnvVarIfNotExists(envVars,
-			"GRADIO_SERVER_NAME", "0.0.0.0")
-		envVars = addEnvVarIfNotExists(envVars,
-			"GRADIO_SERVER_PORT", "7860")
-	case v2alpha1.FrameworkMosec:
-		envVars = addEnvVarIfNotExists(envVars,
-			"MOSEC_PORT", strconv.Itoa(defaultPort))
-	case v2alpha1.FrameworkStreamlit:
-		envVars = addEnvVarIfNotExists(envVars, "STREAMLIT_SERVER_ENABLE_CORS", "false")
-		envVars = addEnvVarIfNotExists(envVars, "STREAMLIT_SERVER_ADDRESS", "0.0.0.0")
-		envVars = addEnvVarIfNotExists(envVars, "STREAMLIT_SERVER_ENABLE_XSRF_PROTECTION", "false")
+	// Set the default environment variables of the framework.
+	if f, ok := framework.Get(string(inference.Spec.Framework)); ok {
+		env := f.EnvWithPort()
+		names := make([]string, 0, len(env))
+		for name := range env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			envVars = addEnvVarIfNotExists(envVars, name, env[name])
+		}
 	}
 
 	return envVars
@@ -272,6 +250,9 @@ func makePort(inference *v2alpha1.Inference) int {
 	if inference.Spec.Port != nil {
 		return int(*inference.Spec.Port)
 	}
+	if f, ok := framework.Get(string(inference.Spec.Framework)); ok && f.Port != 0 {
+		return int(f.Port)
+	}
 
 	return defaultPort
 }
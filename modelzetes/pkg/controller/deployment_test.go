@@ -267,6 +267,79 @@ func Test_newDeployment_WithNonZeroResource(t *testing.T) {
 	}
 }
 
+func Test_newDeployment_WithMIGResource(t *testing.T) {
+	quantity, _ := resource.ParseQuantity("1")
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kubesec",
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:          "kubesec",
+			Image:         "docker.io/kubesec/kubesec",
+			HTTPProbePath: Ptr("/"),
+			Annotations:   map[string]string{},
+			Resources: &v1.ResourceRequirements{
+				Limits: v1.ResourceList{consts.ResourceNvidiaMIGPrefix + "1g.10gb": quantity},
+			},
+		},
+	}
+
+	config := defaultK8sConfig
+	config.RuntimeClassNvidia = true
+	factory := NewFunctionFactory(fake.NewSimpleClientset(), config)
+
+	deployment := newDeployment(inference, nil, map[string]*corev1.Secret{}, factory)
+
+	spec := deployment.Spec.Template.Spec
+	if len(spec.Tolerations) != 2 || spec.Tolerations[0].Key != consts.TolerationGPU {
+		t.Errorf("Tolerations should contain %s, got %v", consts.TolerationGPU, spec.Tolerations)
+	}
+	if spec.RuntimeClassName == nil || *spec.RuntimeClassName != "nvidia" {
+		t.Errorf("RuntimeClassName should be set to nvidia")
+	}
+}
+
+func Test_newDeployment_WithAMDResource(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kubesec",
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:          "kubesec",
+			Image:         "docker.io/kubesec/kubesec",
+			HTTPProbePath: Ptr("/"),
+			Annotations:   map[string]string{},
+			Resources: &v1.ResourceRequirements{
+				Limits: v1.ResourceList{consts.ResourceAMDGPU: resource.MustParse("1")},
+			},
+		},
+	}
+
+	config := defaultK8sConfig
+	config.RuntimeClassNvidia = true
+	factory := NewFunctionFactory(fake.NewSimpleClientset(), config)
+
+	deployment := newDeployment(inference, nil, map[string]*corev1.Secret{}, factory)
+
+	spec := deployment.Spec.Template.Spec
+	if len(spec.Tolerations) != 2 || spec.Tolerations[1].Key != consts.ResourceAMDGPU {
+		t.Errorf("Tolerations should contain %s, got %v", consts.ResourceAMDGPU, spec.Tolerations)
+	}
+	if spec.RuntimeClassName != nil {
+		t.Errorf("RuntimeClassName should not be set for AMD GPUs")
+	}
+
+	inference.Spec.Resources.Limits[consts.ResourceAMDGPU] = resource.MustParse("0")
+	deployment = newDeployment(inference, nil, map[string]*corev1.Secret{}, factory)
+	env := map[string]string{}
+	for _, e := range deployment.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if v, ok := env["HIP_VISIBLE_DEVICES"]; !ok || v != "" {
+		t.Errorf("HIP_VISIBLE_DEVICES should be set to empty string")
+	}
+}
+
 func Test_newDeployment_WithCommandsAndEnvVars(t *testing.T) {
 	expectEnv := map[string]string{"MOCK": "TEST"}
 	expectCommand := "python main.py"
@@ -298,3 +371,160 @@ func Test_newDeployment_WithCommandsAndEnvVars(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func Test_newDeployment_WithModel(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bloomz",
+			Namespace: "team-a",
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:        "bloomz",
+			Image:       "docker.io/modelzai/vllm",
+			Annotations: map[string]string{},
+			EnvVars:     map[string]string{"HF_TOKEN": "token"},
+			Model: &v2alpha1.ModelSource{
+				Type:     v2alpha1.ModelSourceHuggingFace,
+				URI:      "bigscience/bloomz-560m",
+				Revision: "v1.0",
+			},
+		},
+	}
+
+	config := defaultK8sConfig
+	config.ModelDownloaderImage = "modelzai/modelzetes:latest"
+	config.ModelCacheDir = "/var/lib/modelz/models"
+	config.ModelCacheMaxSize = "200Gi"
+	config.HuggingfacePullThroughCache = true
+	config.HuggingfacePullThroughCacheEndpoint = "http://hf-proxy"
+	factory := NewFunctionFactory(fake.NewSimpleClientset(), config)
+
+	deployment := newDeployment(inference, nil, map[string]*corev1.Secret{}, factory)
+	spec := deployment.Spec.Template.Spec
+
+	if len(spec.InitContainers) != 1 {
+		t.Fatalf("expected the model downloader init container, got %d", len(spec.InitContainers))
+	}
+	downloader := spec.InitContainers[0]
+	if downloader.Name != consts.ModelDownloaderContainerName ||
+		downloader.Image != config.ModelDownloaderImage {
+		t.Errorf("unexpected init container %s:%s", downloader.Name, downloader.Image)
+	}
+	expectArgs := "--type huggingface --uri bigscience/bloomz-560m --namespace team-a " +
+		"--cache-dir /cache --revision v1.0 --max-cache-size 200Gi"
+	if strings.Join(downloader.Args, " ") != expectArgs {
+		t.Errorf("expected args %s, got %v", expectArgs, downloader.Args)
+	}
+	// The pod is set by the downward API to report the progress.
+	assertEnv(t, map[string]string{
+		"HF_TOKEN":      "token",
+		"HF_ENDPOINT":   "http://hf-proxy",
+		"POD_NAME":      "",
+		"POD_NAMESPACE": "",
+	}, downloader.Env)
+	for _, env := range downloader.Env {
+		if env.Name == "POD_NAME" && (env.ValueFrom == nil || env.ValueFrom.FieldRef == nil ||
+			env.ValueFrom.FieldRef.FieldPath != "metadata.name") {
+			t.Errorf("expected POD_NAME from the pod name, got %+v", env)
+		}
+	}
+
+	var cache *corev1.Volume
+	for i := range spec.Volumes {
+		if spec.Volumes[i].Name == modelCacheVolumeName {
+			cache = &spec.Volumes[i]
+		}
+	}
+	if cache == nil || cache.HostPath == nil || cache.HostPath.Path != config.ModelCacheDir {
+		t.Fatalf("expected the host path cache volume, got %+v", cache)
+	}
+
+	var mount *corev1.VolumeMount
+	for i, m := range spec.Containers[0].VolumeMounts {
+		if m.Name == modelCacheVolumeName {
+			mount = &spec.Containers[0].VolumeMounts[i]
+		}
+	}
+	if mount == nil || !mount.ReadOnly || mount.MountPath != "/models" ||
+		mount.SubPath != "team-a/huggingface/bigscience--bloomz-560m/v1.0" {
+		t.Errorf("unexpected model mount %+v", mount)
+	}
+}
+
+func Test_newDeployment_WithScheduling(t *testing.T) {
+	quantity, _ := resource.ParseQuantity("1")
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bloomz",
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:        "bloomz",
+			Image:       "docker.io/modelzai/vllm",
+			Annotations: map[string]string{},
+			Resources: &v1.ResourceRequirements{
+				Limits: v1.ResourceList{consts.ResourceNvidiaGPU: quantity},
+			},
+			Scheduling: &v2alpha1.SchedulingConfig{
+				NodeAffinity: &v2alpha1.NodeAffinity{
+					Required: []v1.NodeSelectorTerm{{
+						MatchExpressions: []v1.NodeSelectorRequirement{{
+							Key:      "tensorchord.ai/gpu",
+							Operator: v1.NodeSelectorOpIn,
+							Values:   []string{"a100", "h100"},
+						}},
+					}},
+				},
+				Tolerations: []v1.Toleration{{
+					Key:      "dedicated",
+					Operator: v1.TolerationOpEqual,
+					Value:    "inference",
+					Effect:   v1.TaintEffectNoSchedule,
+				}},
+				PodAntiAffinity: &v2alpha1.PodAntiAffinity{
+					TopologyKey: "kubernetes.io/hostname",
+				},
+				TopologySpreadConstraints: []v2alpha1.TopologySpreadConstraint{{
+					TopologyKey: "topology.kubernetes.io/zone",
+				}},
+			},
+		},
+	}
+
+	factory := NewFunctionFactory(fake.NewSimpleClientset(), defaultK8sConfig)
+	deployment := newDeployment(inference, nil, map[string]*corev1.Secret{}, factory)
+	spec := deployment.Spec.Template.Spec
+
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil ||
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		t.Fatalf("expected the required node affinity, got %+v", spec.Affinity)
+	}
+	terms := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) != 1 || terms[0].MatchExpressions[0].Key != "tensorchord.ai/gpu" {
+		t.Errorf("unexpected node selector terms %+v", terms)
+	}
+
+	// The user tolerations are appended to the GPU tolerations.
+	if len(spec.Tolerations) != 3 || spec.Tolerations[0].Key != consts.TolerationGPU ||
+		spec.Tolerations[2].Key != "dedicated" {
+		t.Errorf("unexpected tolerations %+v", spec.Tolerations)
+	}
+
+	anti := spec.Affinity.PodAntiAffinity
+	if anti == nil || len(anti.PreferredDuringSchedulingIgnoredDuringExecution) != 1 {
+		t.Fatalf("expected the preferred pod anti affinity, got %+v", anti)
+	}
+	preferred := anti.PreferredDuringSchedulingIgnoredDuringExecution[0]
+	if preferred.Weight != defaultAntiAffinityWeight ||
+		preferred.PodAffinityTerm.LabelSelector.MatchLabels["app"] != "bloomz" {
+		t.Errorf("unexpected pod anti affinity %+v", preferred)
+	}
+
+	if len(spec.TopologySpreadConstraints) != 1 {
+		t.Fatalf("expected 1 topology spread constraint, got %d", len(spec.TopologySpreadConstraints))
+	}
+	spread := spec.TopologySpreadConstraints[0]
+	if spread.MaxSkew != defaultMaxSkew || spread.WhenUnsatisfiable != v1.ScheduleAnyway ||
+		spread.LabelSelector.MatchLabels["app"] != "bloomz" {
+		t.Errorf("unexpected topology spread constraint %+v", spread)
+	}
+}
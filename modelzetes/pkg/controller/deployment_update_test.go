@@ -68,6 +68,26 @@ func Test_Deployment_Need_Update(t *testing.T) {
 				}},
 			true,
 		},
+		{
+			"scheduling change need update",
+			&v2alpha1.Inference{
+				Spec: v2alpha1.InferenceSpec{
+					Scheduling: &v2alpha1.SchedulingConfig{
+						PodAntiAffinity: &v2alpha1.PodAntiAffinity{
+							TopologyKey: "kubernetes.io/hostname",
+							Required:    true,
+						},
+					},
+				},
+			},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationInferenceSpec: "{\"name\":\"\",\"image\":\"\",\"scheduling\":{\"pod_anti_affinity\":{\"topology_key\":\"kubernetes.io/hostname\"}}}",
+					},
+				}},
+			true,
+		},
 	}
 
 	for _, s := range scenarios {
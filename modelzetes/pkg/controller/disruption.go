@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	glog "k8s.io/klog"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
+)
+
+// syncPodDisruptionBudget creates or updates the PodDisruptionBudget of the
+// inference if it keeps more than 1 replica, so that node drains do not
+// evict all the replicas at once. The budget is deleted if it is no longer
+// needed.
+func (c *Controller) syncPodDisruptionBudget(inference *v2alpha1.Inference) error {
+	existing, err := c.pdbsLister.PodDisruptionBudgets(inference.Namespace).
+		Get(inference.Spec.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if existing != nil && !metav1.IsControlledBy(existing, inference) {
+		// Do not touch the budgets created by the users.
+		return nil
+	}
+
+	client := c.kubeclientset.PolicyV1().PodDisruptionBudgets(inference.Namespace)
+	expected := newPodDisruptionBudget(inference, c.factory)
+	switch {
+	case expected == nil && existing == nil:
+		return nil
+	case expected == nil:
+		glog.Infof("Deleting pod disruption budget for '%s'", inference.Spec.Name)
+		if err := client.Delete(context.TODO(), existing.Name,
+			metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	case existing == nil:
+		glog.Infof("Creating pod disruption budget for '%s'", inference.Spec.Name)
+		if _, err := client.Create(context.TODO(), expected,
+			metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+	case !equality.Semantic.DeepEqual(existing.Spec, expected.Spec):
+		glog.Infof("Updating pod disruption budget for '%s'", inference.Spec.Name)
+		pdb := existing.DeepCopy()
+		pdb.Spec = expected.Spec
+		if _, err := client.Update(context.TODO(), pdb, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newPodDisruptionBudget returns the PodDisruptionBudget of the inference,
+// or nil if the inference does not keep more than 1 replica or the budget
+// is disabled.
+func newPodDisruptionBudget(inference *v2alpha1.Inference,
+	factory FunctionFactory) *policyv1.PodDisruptionBudget {
+	if inference.Spec.Scaling == nil || inference.Spec.Scaling.MinReplicas == nil ||
+		*inference.Spec.Scaling.MinReplicas <= 1 {
+		return nil
+	}
+
+	maxUnavailable := factory.Factory.Config.DisruptionBudgetMaxUnavailable
+	if b := inference.Spec.DisruptionBudget; b != nil {
+		if b.Disabled {
+			return nil
+		}
+		if b.MaxUnavailable != nil {
+			maxUnavailable = *b.MaxUnavailable
+		}
+	}
+	if maxUnavailable < 1 {
+		maxUnavailable = 1
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      inference.Spec.Name,
+			Namespace: inference.Namespace,
+			Labels:    makeLabels(inference),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(inference, schema.GroupVersionKind{
+					Group:   v2alpha1.SchemeGroupVersion.Group,
+					Version: v2alpha1.SchemeGroupVersion.Version,
+					Kind:    v2alpha1.Kind,
+				}),
+			},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &intstr.IntOrString{
+				Type:   intstr.Int,
+				IntVal: maxUnavailable,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: k8s.MakeLabelSelector(inference.Spec.Name),
+			},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	policylisters "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+func Test_syncPodDisruptionBudget(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bloomz",
+			Namespace: "default",
+			UID:       "uid",
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:    "bloomz",
+			Image:   "modelzai/bloomz",
+			Scaling: &v2alpha1.ScalingConfig{MinReplicas: Ptr(int32(1))},
+		},
+	}
+
+	config := defaultK8sConfig
+	config.DisruptionBudgetMaxUnavailable = 1
+	client := fake.NewSimpleClientset()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	c := &Controller{
+		kubeclientset: client,
+		pdbsLister:    policylisters.NewPodDisruptionBudgetLister(indexer),
+		factory:       NewFunctionFactory(client, config),
+	}
+	// sync syncs the budget and updates the lister like the informer.
+	sync := func() *policyv1.PodDisruptionBudget {
+		if err := c.syncPodDisruptionBudget(inference); err != nil {
+			t.Fatal(err)
+		}
+		for _, obj := range indexer.List() {
+			_ = indexer.Delete(obj)
+		}
+		pdb, err := client.PolicyV1().PodDisruptionBudgets("default").
+			Get(context.TODO(), "bloomz", metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		_ = indexer.Add(pdb)
+		return pdb
+	}
+
+	if pdb := sync(); pdb != nil {
+		t.Fatalf("expected no budget for a single replica, got %+v", pdb)
+	}
+
+	inference.Spec.Scaling.MinReplicas = Ptr(int32(3))
+	pdb := sync()
+	if pdb == nil || pdb.Spec.MaxUnavailable.IntVal != 1 ||
+		!metav1.IsControlledBy(pdb, inference) {
+		t.Fatalf("expected the default budget, got %+v", pdb)
+	}
+
+	inference.Spec.DisruptionBudget = &v2alpha1.DisruptionBudgetConfig{
+		MaxUnavailable: Ptr(int32(2)),
+	}
+	if pdb := sync(); pdb == nil || pdb.Spec.MaxUnavailable.IntVal != 2 {
+		t.Fatalf("expected the max unavailable to be updated, got %+v", pdb)
+	}
+
+	inference.Spec.DisruptionBudget.Disabled = true
+	if pdb := sync(); pdb != nil {
+		t.Fatalf("expected the disabled budget to be deleted, got %+v", pdb)
+	}
+}
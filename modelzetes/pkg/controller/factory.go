@@ -8,6 +8,7 @@ import (
 
 	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
 )
 
@@ -73,11 +74,18 @@ func (f *FunctionFactory) MakeProbes(function *v2alpha1.Inference, port int) (
 	*k8s.FunctionProbes, error) {
 	// For old version inference without HTTPProbePath
 	httpProbePath := consts.DefaultHTTPProbePath
+	if f, ok := framework.Get(string(function.Spec.Framework)); ok && f.HTTPProbePath != "" {
+		httpProbePath = f.HTTPProbePath
+	}
 	if (function.Spec.HTTPProbePath != nil) && (*function.Spec.HTTPProbePath != "") {
 		httpProbePath = *function.Spec.HTTPProbePath
 	}
 
-	return f.Factory.MakeProbes(port, httpProbePath)
+	probes, err := f.Factory.MakeProbes(port, httpProbePath)
+	if err != nil {
+		return nil, err
+	}
+	return overrideProbes(function, probes, port, httpProbePath, f.Factory.Config), nil
 }
 
 func (f *FunctionFactory) ConfigureReadOnlyRootFilesystem(function *v2alpha1.Inference, deployment *appsv1.Deployment) {
@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	glog "k8s.io/klog"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+const (
+	// reasonCleanupFailed is the reason of the event when the resources of
+	// the deleted inference cannot be cleaned up, the cleanup is retried.
+	reasonCleanupFailed = "CleanupFailed"
+	// reasonCleanedUp is the reason of the event when the resources of the
+	// deleted inference are cleaned up.
+	reasonCleanedUp = "CleanedUp"
+)
+
+// syncFinalizer adds the cleanup finalizer to the inference, or cleans up
+// the resources of the deleted inference and removes the finalizer. It
+// returns true if the inference is updated or being deleted, the rest of
+// the sync is skipped then.
+func (c *Controller) syncFinalizer(inference *v2alpha1.Inference) (bool, error) {
+	if inference.DeletionTimestamp.IsZero() {
+		if hasFinalizer(inference) {
+			return false, nil
+		}
+		// The patch requeues the inference.
+		finalizers := append([]string{}, inference.Finalizers...)
+		return true, c.patchFinalizers(inference,
+			append(finalizers, consts.FinalizerCleanup))
+	}
+
+	if !hasFinalizer(inference) {
+		return true, nil
+	}
+
+	glog.Infof("Cleaning up the resources of '%s'", inference.Spec.Name)
+	if err := c.cleanup(inference); err != nil {
+		c.recorder.Eventf(inference, corev1.EventTypeWarning, reasonCleanupFailed,
+			"Failed to clean up the resources: %v", err)
+		return true, err
+	}
+
+	if err := c.patchFinalizers(inference, removeFinalizer(inference.Finalizers)); err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return true, err
+	}
+	c.recorder.Event(inference, corev1.EventTypeNormal, reasonCleanedUp,
+		"Cleaned up the resources")
+	return true, nil
+}
+
+// patchFinalizers replaces the finalizers of the inference with a merge
+// patch, so that the rest of the inference is not overwritten by the copy
+// in the cache. The resource version makes the patch fail if the
+// finalizers are changed by others meanwhile.
+func (c *Controller) patchFinalizers(inference *v2alpha1.Inference, finalizers []string) error {
+	metadata := map[string]interface{}{
+		"finalizers": finalizers,
+	}
+	if inference.ResourceVersion != "" {
+		metadata["resourceVersion"] = inference.ResourceVersion
+	}
+	patch, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return err
+	}
+	_, err = c.faasclientset.TensorchordV2alpha1().Inferences(inference.Namespace).
+		Patch(context.TODO(), inference.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// cleanup deletes the resources which are not garbage collected with the
+// inference: the ingress and the image caches may live in other
+// namespaces, and the build jobs and secrets are deleted before the
+// finalizer is removed so that nothing is left if the namespace is reused.
+func (c *Controller) cleanup(inference *v2alpha1.Inference) error {
+	if err := c.cleanupIngresses(inference); err != nil {
+		return fmt.Errorf("failed to delete the ingress: %w", err)
+	}
+	if err := c.cleanupImageCaches(inference); err != nil {
+		return fmt.Errorf("failed to delete the image caches: %w", err)
+	}
+	if err := c.cleanupJobs(inference); err != nil {
+		return fmt.Errorf("failed to delete the build jobs: %w", err)
+	}
+	if err := c.cleanupSecrets(inference); err != nil {
+		return fmt.Errorf("failed to delete the secrets: %w", err)
+	}
+	return nil
+}
+
+// cleanupIngresses deletes the ingresses which route to the inference, the
+// routes of the ingress controller are removed with them.
+func (c *Controller) cleanupIngresses(inference *v2alpha1.Inference) error {
+	if c.ingressclientset == nil {
+		return nil
+	}
+	selector := labels.SelectorFromSet(labels.Set{
+		consts.LabelInferenceName:      inference.Spec.Name,
+		consts.LabelInferenceNamespace: inference.Namespace,
+	})
+	ingresses, err := c.ingressclientset.TensorchordV1().InferenceIngresses(metav1.NamespaceAll).
+		List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		// The ingress CRD is not installed.
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	for _, ingress := range ingresses.Items {
+		if err := c.ingressclientset.TensorchordV1().InferenceIngresses(ingress.Namespace).
+			Delete(context.TODO(), ingress.Name, metav1.DeleteOptions{}); err != nil &&
+			!errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanupImageCaches deletes the image caches of the inference.
+func (c *Controller) cleanupImageCaches(inference *v2alpha1.Inference) error {
+	if c.kubefledgedclientset == nil {
+		return nil
+	}
+	caches, err := c.kubefledgedclientset.KubefledgedV1alpha3().ImageCaches(metav1.NamespaceAll).
+		List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		// The image cache CRD is not installed.
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	for i := range caches.Items {
+		cache := &caches.Items[i]
+		if !metav1.IsControlledBy(cache, inference) {
+			continue
+		}
+		if err := c.kubefledgedclientset.KubefledgedV1alpha3().ImageCaches(cache.Namespace).
+			Delete(context.TODO(), cache.Name, metav1.DeleteOptions{}); err != nil &&
+			!errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanupJobs deletes the build jobs of the inference with their pods.
+func (c *Controller) cleanupJobs(inference *v2alpha1.Inference) error {
+	jobs, err := c.kubeclientset.BatchV1().Jobs(inference.Namespace).
+		List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	background := metav1.DeletePropagationBackground
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if !metav1.IsControlledBy(job, inference) {
+			continue
+		}
+		if err := c.kubeclientset.BatchV1().Jobs(job.Namespace).
+			Delete(context.TODO(), job.Name, metav1.DeleteOptions{
+				PropagationPolicy: &background,
+			}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanupSecrets deletes the secrets created for the inference. The
+// secrets managed by the users and mounted by the inference are kept. They
+// are listed from the API server since the cache only has the secrets
+// managed by modelz.
+func (c *Controller) cleanupSecrets(inference *v2alpha1.Inference) error {
+	secrets, err := c.kubeclientset.CoreV1().Secrets(inference.Namespace).
+		List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if !metav1.IsControlledBy(secret, inference) {
+			continue
+		}
+		if err := c.kubeclientset.CoreV1().Secrets(secret.Namespace).
+			Delete(context.TODO(), secret.Name, metav1.DeleteOptions{}); err != nil &&
+			!errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasFinalizer(inference *v2alpha1.Inference) bool {
+	for _, f := range inference.Finalizers {
+		if f == consts.FinalizerCleanup {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFinalizer(finalizers []string) []string {
+	res := []string{}
+	for _, f := range finalizers {
+		if f != consts.FinalizerCleanup {
+			res = append(res, f)
+		}
+	}
+	return res
+}
@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	kubefledgedv1alpha3 "github.com/senthilrch/kube-fledged/pkg/apis/kubefledged/v1alpha3"
+	kubefledgedfake "github.com/senthilrch/kube-fledged/pkg/client/clientset/versioned/fake"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	ingressv1 "github.com/tensorchord/openmodelz/ingress-operator/pkg/apis/modelzetes/v1"
+	ingressfake "github.com/tensorchord/openmodelz/ingress-operator/pkg/client/clientset/versioned/fake"
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	inferencefake "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/fake"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+func Test_syncFinalizer(t *testing.T) {
+	newInference := func(deleted bool) *v2alpha1.Inference {
+		inference := &v2alpha1.Inference{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bloomz",
+				Namespace: "modelz-uid",
+				UID:       "uid",
+			},
+			Spec: v2alpha1.InferenceSpec{
+				Name:  "bloomz",
+				Image: "modelzai/bloomz",
+			},
+		}
+		if deleted {
+			now := metav1.Now()
+			inference.DeletionTimestamp = &now
+			inference.Finalizers = []string{consts.FinalizerCleanup}
+		}
+		return inference
+	}
+	owned := func(inference *v2alpha1.Inference, name, namespace string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(inference, schema.GroupVersionKind{
+					Group:   v2alpha1.SchemeGroupVersion.Group,
+					Version: v2alpha1.SchemeGroupVersion.Version,
+					Kind:    v2alpha1.Kind,
+				}),
+			},
+		}
+	}
+	newController := func(inference *v2alpha1.Inference) (*Controller, *record.FakeRecorder) {
+		kubeClient := fake.NewSimpleClientset(
+			&batchv1.Job{ObjectMeta: owned(inference, "bloomz-build", inference.Namespace)},
+			&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: inference.Namespace}},
+		)
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		for _, secret := range []*corev1.Secret{
+			{ObjectMeta: owned(inference, "bloomz-token", inference.Namespace)},
+			{ObjectMeta: metav1.ObjectMeta{Name: "hf-token", Namespace: inference.Namespace}},
+		} {
+			_ = indexer.Add(secret)
+			_, _ = kubeClient.CoreV1().Secrets(secret.Namespace).
+				Create(context.TODO(), secret, metav1.CreateOptions{})
+		}
+		recorder := record.NewFakeRecorder(10)
+		return &Controller{
+			kubeclientset: kubeClient,
+			faasclientset: inferencefake.NewSimpleClientset(inference),
+			ingressclientset: ingressfake.NewSimpleClientset(
+				&ingressv1.InferenceIngress{ObjectMeta: metav1.ObjectMeta{
+					Name:      "bloomz",
+					Namespace: "default",
+					Labels: map[string]string{
+						consts.LabelInferenceName:      "bloomz",
+						consts.LabelInferenceNamespace: inference.Namespace,
+					},
+				}},
+				&ingressv1.InferenceIngress{ObjectMeta: metav1.ObjectMeta{
+					Name:      "bloomz",
+					Namespace: "other",
+					Labels: map[string]string{
+						consts.LabelInferenceName:      "bloomz",
+						consts.LabelInferenceNamespace: "modelz-other",
+					},
+				}},
+			),
+			kubefledgedclientset: kubefledgedfake.NewSimpleClientset(
+				&kubefledgedv1alpha3.ImageCache{ObjectMeta: owned(inference, "bloomz", "kube-fledged")},
+				&kubefledgedv1alpha3.ImageCache{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "kube-fledged"}},
+			),
+			secretsLister: corelisters.NewSecretLister(indexer),
+			recorder:      recorder,
+		}, recorder
+	}
+
+	t.Run("add", func(t *testing.T) {
+		inference := newInference(false)
+		c, _ := newController(inference)
+		done, err := c.syncFinalizer(inference)
+		if err != nil || !done {
+			t.Fatalf("expected the inference to be updated, got %v, %v", done, err)
+		}
+		updated, _ := c.faasclientset.TensorchordV2alpha1().Inferences(inference.Namespace).
+			Get(context.TODO(), inference.Name, metav1.GetOptions{})
+		if !hasFinalizer(updated) {
+			t.Fatalf("expected the finalizer, got %v", updated.Finalizers)
+		}
+		for _, action := range c.faasclientset.(*inferencefake.Clientset).Actions() {
+			if action.GetVerb() == "update" {
+				t.Errorf("expected the finalizer to be patched, got %v", action)
+			}
+		}
+		if done, err := c.syncFinalizer(updated); err != nil || done {
+			t.Fatalf("expected the sync to continue, got %v, %v", done, err)
+		}
+	})
+
+	t.Run("cleanup", func(t *testing.T) {
+		inference := newInference(true)
+		c, recorder := newController(inference)
+		if done, err := c.syncFinalizer(inference); err != nil || !done {
+			t.Fatalf("expected the cleanup to succeed, got %v, %v", done, err)
+		}
+
+		ingresses, _ := c.ingressclientset.TensorchordV1().InferenceIngresses(metav1.NamespaceAll).
+			List(context.TODO(), metav1.ListOptions{})
+		if len(ingresses.Items) != 1 || ingresses.Items[0].Namespace != "other" {
+			t.Errorf("expected only the ingress of the other inference, got %v", ingresses.Items)
+		}
+		caches, _ := c.kubefledgedclientset.KubefledgedV1alpha3().ImageCaches("kube-fledged").
+			List(context.TODO(), metav1.ListOptions{})
+		if len(caches.Items) != 1 || caches.Items[0].Name != "other" {
+			t.Errorf("expected only the other image cache, got %v", caches.Items)
+		}
+		jobs, _ := c.kubeclientset.BatchV1().Jobs(inference.Namespace).
+			List(context.TODO(), metav1.ListOptions{})
+		if len(jobs.Items) != 1 || jobs.Items[0].Name != "other" {
+			t.Errorf("expected only the other job, got %v", jobs.Items)
+		}
+		if _, err := c.kubeclientset.CoreV1().Secrets(inference.Namespace).
+			Get(context.TODO(), "bloomz-token", metav1.GetOptions{}); !errors.IsNotFound(err) {
+			t.Errorf("expected the owned secret to be deleted, got %v", err)
+		}
+		if _, err := c.kubeclientset.CoreV1().Secrets(inference.Namespace).
+			Get(context.TODO(), "hf-token", metav1.GetOptions{}); err != nil {
+			t.Errorf("expected the user secret to be kept, got %v", err)
+		}
+
+		updated, _ := c.faasclientset.TensorchordV2alpha1().Inferences(inference.Namespace).
+			Get(context.TODO(), inference.Name, metav1.GetOptions{})
+		if hasFinalizer(updated) {
+			t.Errorf("expected the finalizer to be removed, got %v", updated.Finalizers)
+		}
+		if event := <-recorder.Events; !strings.Contains(event, reasonCleanedUp) {
+			t.Errorf("unexpected event %s", event)
+		}
+	})
+
+	t.Run("cleanup failed", func(t *testing.T) {
+		inference := newInference(true)
+		c, recorder := newController(inference)
+		c.ingressclientset.(*ingressfake.Clientset).PrependReactor("delete", "inferenceingresses",
+			func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf("connection refused")
+			})
+		if _, err := c.syncFinalizer(inference); err == nil {
+			t.Fatal("expected the cleanup to fail")
+		}
+		updated, _ := c.faasclientset.TensorchordV2alpha1().Inferences(inference.Namespace).
+			Get(context.TODO(), inference.Name, metav1.GetOptions{})
+		if !hasFinalizer(updated) {
+			t.Errorf("expected the finalizer to be kept, got %v", updated.Finalizers)
+		}
+		if event := <-recorder.Events; !strings.Contains(event, reasonCleanupFailed) {
+			t.Errorf("unexpected event %s", event)
+		}
+	})
+}
@@ -1,10 +1,12 @@
 package controller
 
 import (
+	"reflect"
 	"strconv"
 	"testing"
 
 	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
 	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -88,3 +90,64 @@ func Test_newDeployment_FrameworkStreamlit(t *testing.T) {
 
 	assertEnv(t, expectEnv, deployment.Spec.Template.Spec.Containers[0].Env)
 }
+
+func Test_newDeployment_FrameworkDefaults(t *testing.T) {
+	if err := framework.Register(framework.Framework{
+		Name:          "comfyui",
+		Port:          8188,
+		Command:       "python main.py --listen",
+		HTTPProbePath: "/system_stats",
+		Env:           map[string]string{"COMFYUI_PATH": "/app"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { framework.Unregister("comfyui") })
+
+	for _, tc := range []struct {
+		framework v2alpha1.Framework
+		command   []string
+		port      int32
+		probePath string
+		env       map[string]string
+	}{
+		{
+			framework: "vllm",
+			command:   []string{"python3", "-m", "vllm.entrypoints.openai.api_server"},
+			port:      8000,
+			probePath: "/health",
+			env:       map[string]string{},
+		},
+		{
+			framework: "comfyui",
+			command:   []string{"python", "main.py", "--listen"},
+			port:      8188,
+			probePath: "/system_stats",
+			env:       map[string]string{"COMFYUI_PATH": "/app"},
+		},
+	} {
+		t.Run(string(tc.framework), func(t *testing.T) {
+			inference := &v2alpha1.Inference{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm"},
+				Spec: v2alpha1.InferenceSpec{
+					Name:      "llm",
+					Image:     "modelzai/llm",
+					Framework: tc.framework,
+				},
+			}
+			factory := NewFunctionFactory(fake.NewSimpleClientset(), defaultK8sConfig)
+			container := newDeployment(inference, nil, nil, factory).
+				Spec.Template.Spec.Containers[0]
+
+			if !reflect.DeepEqual(container.Command, tc.command) {
+				t.Errorf("unexpected command %v", container.Command)
+			}
+			if container.Ports[0].ContainerPort != tc.port {
+				t.Errorf("unexpected port %d", container.Ports[0].ContainerPort)
+			}
+			if container.ReadinessProbe.HTTPGet.Path != tc.probePath {
+				t.Errorf("unexpected probe path %s", container.ReadinessProbe.HTTPGet.Path)
+			}
+			assertEnv(t, tc.env, container.Env)
+		})
+	}
+}
@@ -4,19 +4,25 @@ import (
 	"errors"
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 
+	kubefledgedclientset "github.com/senthilrch/kube-fledged/pkg/client/clientset/versioned"
+	ingressclientset "github.com/tensorchord/openmodelz/ingress-operator/pkg/client/clientset/versioned"
 	clientset "github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned"
 	informers "github.com/tensorchord/openmodelz/modelzetes/pkg/client/informers/externalversions"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/config"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/metrics"
 )
 
-func New(c config.Config, stopCh <-chan struct{}) (*Controller, error) {
+// New creates the controller from the config, the informers are added to
+// the readiness check of the metrics server.
+func New(c config.Config, server *metrics.Server, stopCh <-chan struct{}) (*Controller, error) {
 	clientCmdConfig, err := clientcmd.BuildConfigFromFlags(
 		c.KubeConfig.MasterURL, c.KubeConfig.Kubeconfig)
 	if err != nil {
@@ -36,6 +42,16 @@ func New(c config.Config, stopCh <-chan struct{}) (*Controller, error) {
 		return nil, fmt.Errorf("error building Inference clientset: %s", err.Error())
 	}
 
+	ingressClient, err := ingressclientset.NewForConfig(clientCmdConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error building Ingress clientset: %s", err.Error())
+	}
+
+	kubefledgedClient, err := kubefledgedclientset.NewForConfig(clientCmdConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error building Kubefledged clientset: %s", err.Error())
+	}
+
 	deployConfig := k8s.DeploymentConfig{
 		HTTPProbe:      true,
 		SetNonRootUser: false,
@@ -54,9 +70,16 @@ func New(c config.Config, stopCh <-chan struct{}) (*Controller, error) {
 			TimeoutSeconds:      int32(c.Probes.Startup.TimeoutSeconds),
 			PeriodSeconds:       int32(c.Probes.Startup.PeriodSeconds),
 		},
-		ImagePullPolicy:    c.Inference.ImagePullPolicy,
-		RuntimeClassNvidia: c.Inference.SetUpRuntimeClassNvidia,
-		ProfilesNamespace:  "default",
+		ImagePullPolicy:      c.Inference.ImagePullPolicy,
+		RuntimeClassNvidia:   c.Inference.SetUpRuntimeClassNvidia,
+		ModelDownloaderImage: c.Inference.ModelDownloaderImage,
+		ModelCacheDir:        c.Inference.ModelCacheDir,
+		ModelCacheMaxSize:    c.Inference.ModelCacheMaxSize,
+		ProfilesNamespace:    "default",
+
+		TerminationGracePeriodSeconds:  c.Inference.TerminationGracePeriodSeconds,
+		PreStopSleepSeconds:            c.Inference.PreStopSleepSeconds,
+		DisruptionBudgetMaxUnavailable: c.Inference.DisruptionBudgetMaxUnavailable,
 	}
 
 	if c.HuggingfaceProxy.Endpoint == "" {
@@ -68,10 +91,19 @@ func New(c config.Config, stopCh <-chan struct{}) (*Controller, error) {
 
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, c.KubeConfig.ResyncPeriod)
 
+	// Only the secrets managed by modelz are watched, the cluster may have
+	// lots of other secrets, e.g. the releases of helm.
+	secretInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(
+		kubeClient, c.KubeConfig.ResyncPeriod,
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = k8s.ManagedSecretSelector
+		}))
+
 	inferenceInformerFactory := informers.NewSharedInformerFactoryWithOptions(inferenceClient, c.KubeConfig.ResyncPeriod)
 
 	inferences := inferenceInformerFactory.Tensorchord().V2alpha1().Inferences()
 	go inferences.Informer().Run(stopCh)
+	server.AddInformer("inferences", inferences.Informer().HasSynced)
 	if ok := cache.WaitForNamedCacheSync(
 		fmt.Sprintf("%s:inferences", consts.ProviderName),
 		stopCh, inferences.Informer().HasSynced); !ok {
@@ -80,16 +112,85 @@ func New(c config.Config, stopCh <-chan struct{}) (*Controller, error) {
 
 	deployments := kubeInformerFactory.Apps().V1().Deployments()
 	go deployments.Informer().Run(stopCh)
+	server.AddInformer("deployments", deployments.Informer().HasSynced)
 	if ok := cache.WaitForNamedCacheSync(
 		fmt.Sprintf("%s:deployments", consts.ProviderName),
 		stopCh, deployments.Informer().HasSynced); !ok {
 		return nil, errors.New("failed to wait for deployment caches to sync")
 	}
 
+	pods := kubeInformerFactory.Core().V1().Pods()
+	go pods.Informer().Run(stopCh)
+	server.AddInformer("pods", pods.Informer().HasSynced)
+	if ok := cache.WaitForNamedCacheSync(
+		fmt.Sprintf("%s:pods", consts.ProviderName),
+		stopCh, pods.Informer().HasSynced); !ok {
+		return nil, errors.New("failed to wait for pod caches to sync")
+	}
+
+	revisions := kubeInformerFactory.Apps().V1().ControllerRevisions()
+	go revisions.Informer().Run(stopCh)
+	server.AddInformer("controllerrevisions", revisions.Informer().HasSynced)
+	if ok := cache.WaitForNamedCacheSync(
+		fmt.Sprintf("%s:controllerrevisions", consts.ProviderName),
+		stopCh, revisions.Informer().HasSynced); !ok {
+		return nil, errors.New("failed to wait for controller revision caches to sync")
+	}
+
+	replicaSets := kubeInformerFactory.Apps().V1().ReplicaSets()
+	go replicaSets.Informer().Run(stopCh)
+	server.AddInformer("replicasets", replicaSets.Informer().HasSynced)
+	if ok := cache.WaitForNamedCacheSync(
+		fmt.Sprintf("%s:replicasets", consts.ProviderName),
+		stopCh, replicaSets.Informer().HasSynced); !ok {
+		return nil, errors.New("failed to wait for replica set caches to sync")
+	}
+
+	pdbs := kubeInformerFactory.Policy().V1().PodDisruptionBudgets()
+	go pdbs.Informer().Run(stopCh)
+	server.AddInformer("poddisruptionbudgets", pdbs.Informer().HasSynced)
+	if ok := cache.WaitForNamedCacheSync(
+		fmt.Sprintf("%s:poddisruptionbudgets", consts.ProviderName),
+		stopCh, pdbs.Informer().HasSynced); !ok {
+		return nil, errors.New("failed to wait for pod disruption budget caches to sync")
+	}
+
+	secrets := secretInformerFactory.Core().V1().Secrets()
+	go secrets.Informer().Run(stopCh)
+	server.AddInformer("secrets", secrets.Informer().HasSynced)
+	if ok := cache.WaitForNamedCacheSync(
+		fmt.Sprintf("%s:secrets", consts.ProviderName),
+		stopCh, secrets.Informer().HasSynced); !ok {
+		return nil, errors.New("failed to wait for secret caches to sync")
+	}
+
+	batchInferences := inferenceInformerFactory.Tensorchord().V2alpha1().BatchInferences()
+	go batchInferences.Informer().Run(stopCh)
+	server.AddInformer("batchinferences", batchInferences.Informer().HasSynced)
+	if ok := cache.WaitForNamedCacheSync(
+		fmt.Sprintf("%s:batchinferences", consts.ProviderName),
+		stopCh, batchInferences.Informer().HasSynced); !ok {
+		return nil, errors.New("failed to wait for batch inference caches to sync")
+	}
+
+	jobs := kubeInformerFactory.Batch().V1().Jobs()
+	go jobs.Informer().Run(stopCh)
+	server.AddInformer("jobs", jobs.Informer().HasSynced)
+	if ok := cache.WaitForNamedCacheSync(
+		fmt.Sprintf("%s:jobs", consts.ProviderName),
+		stopCh, jobs.Informer().HasSynced); !ok {
+		return nil, errors.New("failed to wait for job caches to sync")
+	}
+
 	controllerFactory := NewFunctionFactory(kubeClient, deployConfig)
 
 	ctr := NewController(
 		kubeClient, inferenceClient, kubeInformerFactory,
-		inferenceInformerFactory, controllerFactory)
+		secretInformerFactory, inferenceInformerFactory, controllerFactory)
+	ctr.ingressclientset = ingressClient
+	ctr.kubefledgedclientset = kubefledgedClient
+	if c.Rollout.PrometheusURL != "" {
+		ctr.errorRates = newPrometheusErrorRates(c.Rollout.PrometheusURL)
+	}
 	return ctr, nil
 }
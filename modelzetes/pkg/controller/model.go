@@ -0,0 +1,123 @@
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/model"
+)
+
+const (
+	modelCacheVolumeName = "model-cache"
+	modelCacheMountPath  = "/cache"
+)
+
+// addPodEnvVars adds the env vars of the pod name and namespace set by the
+// downward API, which are used by the containers to report their progress
+// in the pod annotations. The ones set by the users are kept.
+func addPodEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
+	fields := []struct{ name, path string }{
+		{k8s.EnvPodName, "metadata.name"},
+		{k8s.EnvPodNamespace, "metadata.namespace"},
+	}
+	for _, f := range fields {
+		exists := false
+		for _, env := range envVars {
+			if env.Name == f.name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			envVars = append(envVars, corev1.EnvVar{
+				Name: f.name,
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: f.path},
+				},
+			})
+		}
+	}
+	return envVars
+}
+
+// configureModel adds the init container which downloads the model into the
+// cache of the node, and mounts the model read-only into the inference
+// container. The init container gets the same env vars as the inference, so
+// that the credentials of huggingface and s3 could be set there.
+func configureModel(inference *v2alpha1.Inference,
+	deployment *appsv1.Deployment, envVars []corev1.EnvVar, factory FunctionFactory) {
+	m := inference.Spec.Model
+	if m == nil {
+		return
+	}
+
+	source := model.Source{
+		Type:      string(m.Type),
+		URI:       m.URI,
+		Revision:  m.Revision,
+		Endpoint:  m.Endpoint,
+		Namespace: inference.Namespace,
+	}
+	mountPath := m.MountPath
+	if mountPath == "" {
+		mountPath = model.DefaultMountPath
+	}
+
+	args := []string{
+		"--type", source.Type,
+		"--uri", source.URI,
+		"--namespace", source.Namespace,
+		"--cache-dir", modelCacheMountPath,
+	}
+	if source.Revision != "" {
+		args = append(args, "--revision", source.Revision)
+	}
+	if source.Endpoint != "" {
+		args = append(args, "--endpoint", source.Endpoint)
+	}
+	if size := factory.Factory.Config.ModelCacheMaxSize; size != "" {
+		args = append(args, "--max-cache-size", size)
+	}
+
+	allowPrivilegeEscalation := false
+	spec := &deployment.Spec.Template.Spec
+	spec.InitContainers = append(spec.InitContainers, corev1.Container{
+		Name:                     consts.ModelDownloaderContainerName,
+		Image:                    factory.Factory.Config.ModelDownloaderImage,
+		Command:                  []string{"modelzetes", "download"},
+		Args:                     args,
+		Env:                      addPodEnvVars(append([]corev1.EnvVar{}, envVars...)),
+		ImagePullPolicy:          corev1.PullPolicy(factory.Factory.Config.ImagePullPolicy),
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      modelCacheVolumeName,
+				MountPath: modelCacheMountPath,
+			},
+		},
+	})
+
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: modelCacheVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: factory.Factory.Config.ModelCacheDir,
+				Type: &hostPathType,
+			},
+		},
+	})
+	spec.Containers[0].VolumeMounts = append(spec.Containers[0].VolumeMounts,
+		corev1.VolumeMount{
+			Name:      modelCacheVolumeName,
+			MountPath: mountPath,
+			SubPath:   source.CacheKey(),
+			ReadOnly:  true,
+		})
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
+)
+
+// overrideProbes applies the per-inference probes on top of the default
+// ones. A probe missing in the defaults, e.g. the http probes are disabled
+// globally, is built from the default settings of the probe.
+func overrideProbes(inference *v2alpha1.Inference, probes *k8s.FunctionProbes,
+	port int, httpProbePath string, config k8s.DeploymentConfig) *k8s.FunctionProbes {
+	spec := inference.Spec.Probes
+	if spec == nil {
+		return probes
+	}
+	if probes == nil {
+		probes = &k8s.FunctionProbes{}
+	}
+	probes.Startup = overrideProbe(spec.Startup, probes.Startup,
+		config.StartupProbe, 30, port, httpProbePath)
+	probes.Readiness = overrideProbe(spec.Readiness, probes.Readiness,
+		config.ReadinessProbe, 3, port, httpProbePath)
+	probes.Liveness = overrideProbe(spec.Liveness, probes.Liveness,
+		config.LivenessProbe, 3, port, httpProbePath)
+	return probes
+}
+
+func overrideProbe(override *v2alpha1.Probe, probe *corev1.Probe,
+	defaults *k8s.ProbeConfig, failureThreshold int32,
+	port int, httpProbePath string) *corev1.Probe {
+	if override == nil {
+		return probe
+	}
+	if probe == nil {
+		probe = &corev1.Probe{
+			ProbeHandler:     httpGetHandler(port, httpProbePath),
+			SuccessThreshold: 1,
+			FailureThreshold: failureThreshold,
+		}
+		if defaults != nil {
+			probe.InitialDelaySeconds = defaults.InitialDelaySeconds
+			probe.TimeoutSeconds = defaults.TimeoutSeconds
+			probe.PeriodSeconds = defaults.PeriodSeconds
+		}
+	} else {
+		probe = probe.DeepCopy()
+	}
+
+	switch {
+	case override.HTTPGet != nil:
+		path := httpProbePath
+		if override.HTTPGet.Path != "" {
+			path = override.HTTPGet.Path
+		}
+		probe.ProbeHandler = httpGetHandler(probePort(override.HTTPGet.Port, port), path)
+	case override.TCPSocket != nil:
+		probe.ProbeHandler = corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(probePort(override.TCPSocket.Port, port)),
+			},
+		}
+	case override.GRPC != nil:
+		probe.ProbeHandler = corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{
+				Port:    int32(probePort(override.GRPC.Port, port)),
+				Service: override.GRPC.Service,
+			},
+		}
+	case override.Exec != nil:
+		probe.ProbeHandler = corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: append([]string{}, override.Exec.Command...),
+			},
+		}
+	}
+
+	if override.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *override.InitialDelaySeconds
+	}
+	if override.PeriodSeconds != nil {
+		probe.PeriodSeconds = *override.PeriodSeconds
+	}
+	if override.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *override.TimeoutSeconds
+	}
+	if override.SuccessThreshold != nil {
+		probe.SuccessThreshold = *override.SuccessThreshold
+	}
+	if override.FailureThreshold != nil {
+		probe.FailureThreshold = *override.FailureThreshold
+	}
+	return probe
+}
+
+func httpGetHandler(port int, path string) corev1.ProbeHandler {
+	return corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path: path,
+			Port: intstr.FromInt(port),
+		},
+	}
+}
+
+// probePort returns the port of the probe handler, it defaults to the port
+// of the inference.
+func probePort(port *int32, defaultPort int) int {
+	if port != nil {
+		return int(*port)
+	}
+	return defaultPort
+}
+
+// hasStartupFailureThreshold returns true if the failure threshold of the
+// startup probe is set explicitly, it takes precedence over the startup
+// duration.
+func hasStartupFailureThreshold(inference *v2alpha1.Inference) bool {
+	probes := inference.Spec.Probes
+	return probes != nil && probes.Startup != nil &&
+		probes.Startup.FailureThreshold != nil
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+func Test_overrideProbes(t *testing.T) {
+	httpProbesDisabled := defaultK8sConfig
+	httpProbesDisabled.HTTPProbe = false
+
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{Name: "bloomz"},
+		Spec: v2alpha1.InferenceSpec{
+			Name:  "bloomz",
+			Image: "modelzai/bloomz",
+			Port:  Ptr(int32(8080)),
+			Scaling: &v2alpha1.ScalingConfig{
+				StartupDuration: Ptr(int32(600)),
+			},
+			Probes: &v2alpha1.Probes{
+				Startup: &v2alpha1.Probe{
+					TCPSocket:        &v2alpha1.TCPSocketAction{},
+					PeriodSeconds:    Ptr(int32(5)),
+					FailureThreshold: Ptr(int32(60)),
+				},
+				Liveness: &v2alpha1.Probe{
+					Exec:          &v2alpha1.ExecAction{Command: []string{"cat", "/tmp/healthy"}},
+					PeriodSeconds: Ptr(int32(10)),
+				},
+				Readiness: &v2alpha1.Probe{
+					GRPC: &v2alpha1.GRPCAction{Port: Ptr(int32(9000))},
+				},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		factory FunctionFactory
+	}{
+		{
+			name:    "override the defaults",
+			factory: NewFunctionFactory(fake.NewSimpleClientset(), defaultK8sConfig),
+		},
+		{
+			name:    "http probes disabled",
+			factory: NewFunctionFactory(fake.NewSimpleClientset(), httpProbesDisabled),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			container := newDeployment(inference, nil, nil, tc.factory).
+				Spec.Template.Spec.Containers[0]
+
+			expected := &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8080)},
+				},
+				TimeoutSeconds:   3,
+				PeriodSeconds:    5,
+				SuccessThreshold: 1,
+				// The failure threshold is not overridden by the startup duration.
+				FailureThreshold: 60,
+			}
+			if diff := cmp.Diff(expected, container.StartupProbe); diff != "" {
+				t.Errorf("unexpected startup probe: %s", diff)
+			}
+
+			expected = &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					Exec: &corev1.ExecAction{Command: []string{"cat", "/tmp/healthy"}},
+				},
+				TimeoutSeconds:   3,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 3,
+			}
+			if diff := cmp.Diff(expected, container.LivenessProbe); diff != "" {
+				t.Errorf("unexpected liveness probe: %s", diff)
+			}
+
+			if grpc := container.ReadinessProbe.GRPC; grpc == nil || grpc.Port != 9000 ||
+				container.ReadinessProbe.HTTPGet != nil {
+				t.Errorf("unexpected readiness probe handler %v", container.ReadinessProbe.ProbeHandler)
+			}
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"testing"
 
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
 	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
 	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
 )
@@ -86,6 +88,22 @@ func Test_Replicas(t *testing.T) {
 			}, &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: int32p(0)}},
 			int32p(2),
 		},
+		{
+			"return crash loop min replicas when the inference is suppressed",
+			&v2alpha1.Inference{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						consts.AnnotationCrashLoop: `{"originalMinReplicas":2,"minReplicas":0}`,
+					},
+				},
+				Spec: v2alpha1.InferenceSpec{
+					Scaling: &v2alpha1.ScalingConfig{
+						MinReplicas: Ptr(int32(2)),
+					},
+				},
+			}, &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: int32p(0)}},
+			int32p(0),
+		},
 	}
 
 	factory := NewFunctionFactory(fake.NewSimpleClientset(),
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	glog "k8s.io/klog"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/revision"
+)
+
+// syncRevision records the spec of the inference as a new revision if it is
+// different from the latest one, and removes the revisions exceeding the
+// history limit.
+func (c *Controller) syncRevision(inference *v2alpha1.Inference) error {
+	list, err := c.revisionsLister.ControllerRevisions(inference.Namespace).List(
+		labels.SelectorFromSet(revision.Labels(inference)))
+	if err != nil {
+		return err
+	}
+	revisions := revision.Owned(inference, list)
+
+	var next int64 = 1
+	if len(revisions) > 0 {
+		latest := revisions[len(revisions)-1]
+		spec, err := revision.Spec(latest)
+		if err == nil && equality.Semantic.DeepEqual(*spec, inference.Spec) {
+			return nil
+		}
+		next = latest.Revision + 1
+	}
+
+	r, err := revision.New(inference, next)
+	if err != nil {
+		return err
+	}
+	glog.Infof("Recording revision %d of '%s'", next, inference.Spec.Name)
+	if _, err := c.kubeclientset.AppsV1().ControllerRevisions(inference.Namespace).
+		Create(context.TODO(), r, metav1.CreateOptions{}); err != nil {
+		// The revision is created but not observed by the lister yet.
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	revisions = append(revisions, r)
+
+	return c.pruneRevisions(revisions)
+}
+
+// pruneRevisions deletes the oldest revisions exceeding the history limit.
+func (c *Controller) pruneRevisions(revisions []*appsv1.ControllerRevision) error {
+	for i := 0; i < len(revisions)-revision.HistoryLimit; i++ {
+		r := revisions[i]
+		if err := c.kubeclientset.AppsV1().ControllerRevisions(r.Namespace).
+			Delete(context.TODO(), r.Name, metav1.DeleteOptions{}); err != nil &&
+			!errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
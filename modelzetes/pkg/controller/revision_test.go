@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/revision"
+)
+
+func Test_syncRevision(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "bloomz",
+			Namespace:   "default",
+			UID:         "uid",
+			Annotations: map[string]string{consts.AnnotationChangeAuthor: "alice"},
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:  "bloomz",
+			Image: "modelzai/bloomz:v0",
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	c := &Controller{
+		kubeclientset:   client,
+		revisionsLister: appslisters.NewControllerRevisionLister(indexer),
+	}
+	// sync records the revision and updates the lister like the informer.
+	sync := func() {
+		if err := c.syncRevision(inference); err != nil {
+			t.Fatal(err)
+		}
+		list, err := client.AppsV1().ControllerRevisions("default").
+			List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, obj := range indexer.List() {
+			_ = indexer.Delete(obj)
+		}
+		for i := range list.Items {
+			_ = indexer.Add(&list.Items[i])
+		}
+	}
+
+	sync()
+	// The same spec does not create a new revision.
+	sync()
+	if n := len(indexer.List()); n != 1 {
+		t.Fatalf("expected 1 revision, got %d", n)
+	}
+
+	for i := 1; i <= revision.HistoryLimit+2; i++ {
+		inference.Spec.Image = "modelzai/bloomz:v" + strconv.Itoa(i)
+		sync()
+	}
+	list, _ := c.revisionsLister.List(labels.Everything())
+	if len(list) != revision.HistoryLimit {
+		t.Fatalf("expected %d revisions, got %d", revision.HistoryLimit, len(list))
+	}
+	revisions := revision.Owned(inference, list)
+	latest := revisions[len(revisions)-1]
+	if latest.Revision != int64(revision.HistoryLimit+3) ||
+		latest.Annotations[consts.AnnotationChangeAuthor] != "alice" {
+		t.Errorf("unexpected latest revision %+v", latest)
+	}
+	if revisions[0].Revision != 4 {
+		t.Errorf("expected the oldest revisions to be pruned, got %d", revisions[0].Revision)
+	}
+}
@@ -0,0 +1,295 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	glog "k8s.io/klog"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/revision"
+)
+
+const (
+	defaultProgressDeadlineSeconds = 600
+	defaultMinReadyPercent         = 100
+	defaultErrorRateWindowSeconds  = 60
+	defaultErrorRateMinRequests    = 10
+
+	// annotationDeploymentRevision is set by the deployment controller on
+	// the deployment and its replica sets.
+	annotationDeploymentRevision = "deployment.kubernetes.io/revision"
+	// autoRollbackCause is the prefix of the change cause of the automatic
+	// rollback, the rollback itself is never rolled back to avoid flapping
+	// between two broken revisions.
+	autoRollbackCause = "Automatic rollback"
+
+	reasonRolloutSucceeded = "RolloutSucceeded"
+	reasonRolloutFailed    = "RolloutFailed"
+	reasonRolledBack       = "RolledBack"
+)
+
+// errorRate is the number of the requests of the inference in the window of
+// the error rate check.
+type errorRate struct {
+	Failed float64
+	Total  float64
+}
+
+func progressDeadlineSeconds(policy *v2alpha1.RolloutPolicy) int32 {
+	if policy.ProgressDeadlineSeconds > 0 {
+		return policy.ProgressDeadlineSeconds
+	}
+	return defaultProgressDeadlineSeconds
+}
+
+func errorRateWindow(check *v2alpha1.ErrorRateCheck) time.Duration {
+	seconds := check.WindowSeconds
+	if seconds <= 0 {
+		seconds = defaultErrorRateWindowSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// evaluateRollout checks the new replica set against the rollout policy. It
+// returns the phase of the rollout, the message and the duration after
+// which the rollout should be checked again if it is still progressing.
+func evaluateRollout(policy *v2alpha1.RolloutPolicy, deployment *appsv1.Deployment,
+	rs *appsv1.ReplicaSet, elapsed time.Duration,
+	rate *errorRate) (v2alpha1.RolloutPhase, string, time.Duration) {
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing &&
+			cond.Status == corev1.ConditionFalse &&
+			cond.Reason == "ProgressDeadlineExceeded" {
+			return v2alpha1.RolloutPhaseFailed,
+				fmt.Sprintf("The rollout exceeded the progress deadline: %s", cond.Message), 0
+		}
+	}
+
+	if check := policy.ErrorRate; check != nil && rate != nil {
+		minRequests := check.MinRequests
+		if minRequests <= 0 {
+			minRequests = defaultErrorRateMinRequests
+		}
+		if rate.Total >= float64(minRequests) {
+			percent := rate.Failed / rate.Total * 100
+			if percent > float64(check.MaxErrorPercent) {
+				return v2alpha1.RolloutPhaseFailed, fmt.Sprintf(
+					"%.1f%% of the requests failed in the last %s, the maximum is %d%%",
+					percent, errorRateWindow(check), check.MaxErrorPercent), 0
+			}
+		}
+	}
+
+	var desired int32
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	if desired == 0 {
+		return v2alpha1.RolloutPhaseSucceeded, "The inference has no replicas", 0
+	}
+
+	minReadyPercent := policy.MinReadyPercent
+	if minReadyPercent <= 0 {
+		minReadyPercent = defaultMinReadyPercent
+	}
+	required := (desired*minReadyPercent + 99) / 100
+	ready := rs.Status.ReadyReplicas
+	deadline := time.Duration(progressDeadlineSeconds(policy)) * time.Second
+
+	// The error rate is observed for a whole window before the rollout
+	// succeeds.
+	observed := policy.ErrorRate == nil || elapsed >= errorRateWindow(policy.ErrorRate)
+	complete := ready >= desired &&
+		deployment.Status.Replicas == deployment.Status.UpdatedReplicas
+	switch {
+	case complete && observed:
+		return v2alpha1.RolloutPhaseSucceeded,
+			fmt.Sprintf("%d/%d replicas of the new replica set are ready", ready, desired), 0
+	case elapsed >= deadline && ready >= required:
+		return v2alpha1.RolloutPhaseSucceeded,
+			fmt.Sprintf("%d/%d replicas of the new replica set are ready before the deadline",
+				ready, desired), 0
+	case elapsed >= deadline:
+		return v2alpha1.RolloutPhaseFailed, fmt.Sprintf(
+			"%d/%d replicas of the new replica set are ready after %s, %d are required",
+			ready, desired, deadline, required), 0
+	}
+
+	next := deadline - elapsed
+	if policy.ErrorRate != nil {
+		// Check the error rate periodically during the rollout.
+		if window := errorRateWindow(policy.ErrorRate); window < next {
+			next = window
+		}
+	}
+	return v2alpha1.RolloutPhaseProgressing,
+		fmt.Sprintf("%d/%d replicas of the new replica set are ready", ready, desired), next
+}
+
+// syncRollout tracks the rollout of the new replica set of the deployment
+// if the rollout policy is specified. The inference is rolled back to the
+// previous revision if the rollout fails, the updated inference is
+// returned in this case.
+func (c *Controller) syncRollout(inference *v2alpha1.Inference,
+	deployment *appsv1.Deployment) (*v2alpha1.Inference, *v2alpha1.RolloutStatus, error) {
+	policy := inference.Spec.Rollout
+	if policy == nil {
+		return inference, nil, nil
+	}
+
+	rs, err := c.newReplicaSet(deployment)
+	if err != nil {
+		return inference, nil, err
+	}
+	if rs == nil {
+		// The deployment is not observed by the deployment controller yet.
+		return inference, inference.Status.Rollout, nil
+	}
+
+	status := inference.Status.Rollout.DeepCopy()
+	if status == nil || status.ReplicaSet != rs.Name {
+		status = &v2alpha1.RolloutStatus{
+			ReplicaSet: rs.Name,
+			Phase:      v2alpha1.RolloutPhaseProgressing,
+			StartedAt:  metav1.Now(),
+		}
+	}
+	if status.Phase != v2alpha1.RolloutPhaseProgressing {
+		return inference, status, nil
+	}
+	status.ReadyReplicas = rs.Status.ReadyReplicas
+
+	var rate *errorRate
+	if check := policy.ErrorRate; check != nil && c.errorRates != nil {
+		r, err := c.errorRates.ErrorRate(inference.Namespace, inference.Spec.Name,
+			errorRateWindow(check))
+		if err != nil {
+			glog.Warningf("Failed to get the error rate of '%s': %v", inference.Spec.Name, err)
+		} else {
+			rate = &r
+		}
+	}
+
+	phase, message, requeueAfter := evaluateRollout(policy, deployment, rs,
+		time.Since(status.StartedAt.Time), rate)
+	status.Phase, status.Message = phase, message
+	switch phase {
+	case v2alpha1.RolloutPhaseProgressing:
+		if key, err := cache.MetaNamespaceKeyFunc(inference); err == nil {
+			c.workqueue.AddAfter(key, requeueAfter)
+		}
+	case v2alpha1.RolloutPhaseSucceeded:
+		c.recorder.Event(inference, corev1.EventTypeNormal, reasonRolloutSucceeded, message)
+	case v2alpha1.RolloutPhaseFailed:
+		glog.Infof("Rollout of '%s' failed: %s", inference.Spec.Name, message)
+		c.recorder.Event(inference, corev1.EventTypeWarning, reasonRolloutFailed, message)
+		updated, target, err := c.rollback(inference, message)
+		if err != nil {
+			return inference, nil, fmt.Errorf("failed to roll back: %v", err)
+		}
+		if updated != nil {
+			status.Phase = v2alpha1.RolloutPhaseRolledBack
+			status.Message = fmt.Sprintf("%s, rolled back to revision %d", message, target)
+			c.recorder.Eventf(inference, corev1.EventTypeNormal, reasonRolledBack,
+				"Rolled back to revision %d", target)
+			return updated, status, nil
+		}
+	}
+	return inference, status, nil
+}
+
+// newReplicaSet returns the replica set of the current revision of the
+// deployment, it is nil if the deployment is not observed by the
+// deployment controller yet.
+func (c *Controller) newReplicaSet(deployment *appsv1.Deployment) (*appsv1.ReplicaSet, error) {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return nil, nil
+	}
+	current, ok := deployment.Annotations[annotationDeploymentRevision]
+	if !ok {
+		return nil, nil
+	}
+	list, err := c.replicaSetsLister.ReplicaSets(deployment.Namespace).List(
+		labels.SelectorFromSet(k8s.MakeLabelSelector(deployment.Name)))
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range list {
+		if metav1.IsControlledBy(rs, deployment) &&
+			rs.Annotations[annotationDeploymentRevision] == current {
+			return rs, nil
+		}
+	}
+	return nil, nil
+}
+
+// rollback restores the spec of the inference to the previous revision. It
+// returns nil if there is no revision to roll back to.
+func (c *Controller) rollback(inference *v2alpha1.Inference,
+	reason string) (*v2alpha1.Inference, int64, error) {
+	list, err := c.revisionsLister.ControllerRevisions(inference.Namespace).List(
+		labels.SelectorFromSet(revision.Labels(inference)))
+	if err != nil {
+		return nil, 0, err
+	}
+	revisions := revision.Owned(inference, list)
+	if len(revisions) < 2 {
+		glog.Infof("No previous revision of '%s' to roll back to", inference.Spec.Name)
+		return nil, 0, nil
+	}
+
+	current := revisions[len(revisions)-1]
+	spec, err := revision.Spec(current)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !equality.Semantic.DeepEqual(*spec, inference.Spec) {
+		// The spec is changed after the rollout started.
+		return nil, 0, nil
+	}
+	if strings.HasPrefix(current.Annotations[consts.AnnotationChangeCause], autoRollbackCause) {
+		glog.Infof("Revision %d of '%s' is an automatic rollback, skip rolling back",
+			current.Revision, inference.Spec.Name)
+		return nil, 0, nil
+	}
+
+	target := revisions[len(revisions)-2]
+	if spec, err = revision.Spec(target); err != nil {
+		return nil, 0, err
+	}
+	previous := spec.DeepCopy()
+	previous.Rollout = inference.Spec.Rollout
+	if equality.Semantic.DeepEqual(*previous, inference.Spec) {
+		// The rollout policy is added or changed without a new rollout, the
+		// replicas were created before the policy.
+		glog.Infof("Revision %d of '%s' only changes the rollout policy, skip rolling back",
+			current.Revision, inference.Spec.Name)
+		return nil, 0, nil
+	}
+	expected := inference.DeepCopy()
+	expected.Spec = *spec
+	if expected.Annotations == nil {
+		expected.Annotations = map[string]string{}
+	}
+	expected.Annotations[consts.AnnotationChangeCause] = fmt.Sprintf(
+		"%s to revision %d: %s", autoRollbackCause, target.Revision, reason)
+
+	glog.Infof("Rolling back '%s' to revision %d", inference.Spec.Name, target.Revision)
+	updated, err := c.faasclientset.TensorchordV2alpha1().Inferences(inference.Namespace).
+		Update(context.TODO(), expected, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, 0, err
+	}
+	return updated, target.Revision, nil
+}
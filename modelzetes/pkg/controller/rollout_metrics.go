@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errorRateFetcher returns the requests of the inference in the window.
+type errorRateFetcher interface {
+	ErrorRate(namespace, name string, window time.Duration) (errorRate, error)
+}
+
+// prometheusErrorRates reads the requests from the metrics of the gateway
+// scraped by prometheus.
+type prometheusErrorRates struct {
+	url    string
+	client *http.Client
+}
+
+func newPrometheusErrorRates(prometheusURL string) *prometheusErrorRates {
+	return &prometheusErrorRates{
+		url:    strings.TrimSuffix(prometheusURL, "/"),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ErrorRate counts the requests responded with 5xx and all the finished
+// requests. The gateway labels the inference with <name>.<namespace>.
+func (p *prometheusErrorRates) ErrorRate(namespace, name string,
+	window time.Duration) (errorRate, error) {
+	selector := fmt.Sprintf(`inference_name="%s.%s"`, name, namespace)
+	seconds := int(window.Seconds())
+	failed, err := p.query(fmt.Sprintf(
+		`sum(increase(gateway_inference_invocation_total{%s,code=~"5.."}[%ds]))`,
+		selector, seconds))
+	if err != nil {
+		return errorRate{}, err
+	}
+	// 102 is recorded for the requests which are not finished.
+	total, err := p.query(fmt.Sprintf(
+		`sum(increase(gateway_inference_invocation_total{%s,code!="102"}[%ds]))`,
+		selector, seconds))
+	if err != nil {
+		return errorRate{}, err
+	}
+	return errorRate{Failed: failed, Total: total}, nil
+}
+
+// query returns the value of the instant query, it is 0 if the result is
+// empty.
+func (p *prometheusErrorRates) query(query string) (float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.client.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		p.url+"/api/v1/query?query="+url.QueryEscape(query), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code from prometheus: %d, body: %s",
+			resp.StatusCode, string(body))
+	}
+
+	var res struct {
+		Data struct {
+			Result []struct {
+				Value []interface{} `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return 0, fmt.Errorf("failed to decode the prometheus response: %v", err)
+	}
+	if len(res.Data.Result) == 0 || len(res.Data.Result[0].Value) != 2 {
+		return 0, nil
+	}
+	value, ok := res.Data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected value %v from prometheus", res.Data.Result[0].Value[1])
+	}
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,215 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/client/clientset/versioned/fake"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/revision"
+)
+
+func Test_evaluateRollout(t *testing.T) {
+	deployment := func(replicas, updated, total int32,
+		conditions ...appsv1.DeploymentCondition) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{Replicas: Ptr(replicas)},
+			Status: appsv1.DeploymentStatus{
+				Replicas:        total,
+				UpdatedReplicas: updated,
+				Conditions:      conditions,
+			},
+		}
+	}
+	rs := func(ready int32) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{Status: appsv1.ReplicaSetStatus{ReadyReplicas: ready}}
+	}
+	deadlineExceeded := appsv1.DeploymentCondition{
+		Type:   appsv1.DeploymentProgressing,
+		Status: corev1.ConditionFalse,
+		Reason: "ProgressDeadlineExceeded",
+	}
+	errorRateCheck := &v2alpha1.ErrorRateCheck{MaxErrorPercent: 5}
+
+	scenarios := []struct {
+		name       string
+		policy     v2alpha1.RolloutPolicy
+		deployment *appsv1.Deployment
+		rs         *appsv1.ReplicaSet
+		elapsed    time.Duration
+		rate       *errorRate
+		expected   v2alpha1.RolloutPhase
+	}{
+		{"all ready", v2alpha1.RolloutPolicy{}, deployment(2, 2, 2), rs(2),
+			time.Minute, nil, v2alpha1.RolloutPhaseSucceeded},
+		{"old replicas remain", v2alpha1.RolloutPolicy{}, deployment(2, 2, 3), rs(2),
+			time.Minute, nil, v2alpha1.RolloutPhaseProgressing},
+		{"not ready before the deadline", v2alpha1.RolloutPolicy{}, deployment(2, 1, 3), rs(0),
+			time.Minute, nil, v2alpha1.RolloutPhaseProgressing},
+		{"not ready after the deadline", v2alpha1.RolloutPolicy{ProgressDeadlineSeconds: 30},
+			deployment(2, 1, 3), rs(0), time.Minute, nil, v2alpha1.RolloutPhaseFailed},
+		{"enough ready after the deadline",
+			v2alpha1.RolloutPolicy{ProgressDeadlineSeconds: 30, MinReadyPercent: 50},
+			deployment(4, 4, 5), rs(2), time.Minute, nil, v2alpha1.RolloutPhaseSucceeded},
+		{"deployment deadline exceeded", v2alpha1.RolloutPolicy{},
+			deployment(2, 1, 3, deadlineExceeded), rs(0), time.Second, nil,
+			v2alpha1.RolloutPhaseFailed},
+		{"no replicas", v2alpha1.RolloutPolicy{}, deployment(0, 0, 0), rs(0),
+			time.Second, nil, v2alpha1.RolloutPhaseSucceeded},
+		{"error rate exceeded", v2alpha1.RolloutPolicy{ErrorRate: errorRateCheck},
+			deployment(2, 2, 2), rs(2), time.Minute, &errorRate{Failed: 10, Total: 100},
+			v2alpha1.RolloutPhaseFailed},
+		{"too few requests", v2alpha1.RolloutPolicy{ErrorRate: errorRateCheck},
+			deployment(2, 2, 2), rs(2), 2 * time.Minute, &errorRate{Failed: 2, Total: 2},
+			v2alpha1.RolloutPhaseSucceeded},
+		{"error rate observed for less than a window",
+			v2alpha1.RolloutPolicy{ErrorRate: errorRateCheck},
+			deployment(2, 2, 2), rs(2), time.Second, &errorRate{Total: 100},
+			v2alpha1.RolloutPhaseProgressing},
+	}
+	for _, s := range scenarios {
+		phase, message, _ := evaluateRollout(&s.policy, s.deployment, s.rs, s.elapsed, s.rate)
+		if phase != s.expected {
+			t.Errorf("%s: expected %s, got %s: %s", s.name, s.expected, phase, message)
+		}
+	}
+}
+
+func Test_rollback(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bloomz",
+			Namespace: "default",
+			UID:       "uid",
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:    "bloomz",
+			Image:   "modelzai/bloomz:v0",
+			Rollout: &v2alpha1.RolloutPolicy{},
+		},
+	}
+	client := fake.NewSimpleClientset(inference)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	c := &Controller{
+		faasclientset:   client,
+		revisionsLister: appslisters.NewControllerRevisionLister(indexer),
+	}
+
+	updated, _, err := c.rollback(inference, "broken")
+	if err != nil || updated != nil {
+		t.Fatalf("expected no rollback without the previous revision, got %v, %v", updated, err)
+	}
+
+	addRevision := func(inference *v2alpha1.Inference, n int64) {
+		r, err := revision.New(inference, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := indexer.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addRevision(inference, 1)
+	current := inference.DeepCopy()
+	current.Spec.Image = "modelzai/bloomz:v1"
+	addRevision(current, 2)
+
+	updated, target, err := c.rollback(current, "broken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated == nil || target != 1 || updated.Spec.Image != "modelzai/bloomz:v0" {
+		t.Fatalf("expected rollback to revision 1, got %d: %+v", target, updated)
+	}
+	cause := updated.Annotations[consts.AnnotationChangeCause]
+	if !strings.HasPrefix(cause, autoRollbackCause) || !strings.Contains(cause, "broken") {
+		t.Errorf("unexpected change cause %q", cause)
+	}
+
+	// The automatic rollback is not rolled back again.
+	addRevision(updated, 3)
+	again, _, err := c.rollback(updated, "broken")
+	if err != nil || again != nil {
+		t.Errorf("expected no rollback of the rollback, got %v, %v", again, err)
+	}
+	got, err := client.TensorchordV2alpha1().Inferences("default").
+		Get(context.TODO(), "bloomz", metav1.GetOptions{})
+	if err != nil || got.Spec.Image != "modelzai/bloomz:v0" {
+		t.Errorf("unexpected inference %+v, %v", got, err)
+	}
+}
+
+func Test_rollbackPolicyAdded(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bloomz",
+			Namespace: "default",
+			UID:       "uid",
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:  "bloomz",
+			Image: "modelzai/bloomz:v0",
+		},
+	}
+	client := fake.NewSimpleClientset(inference)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	c := &Controller{
+		faasclientset:   client,
+		revisionsLister: appslisters.NewControllerRevisionLister(indexer),
+	}
+
+	current := inference.DeepCopy()
+	current.Spec.Rollout = &v2alpha1.RolloutPolicy{}
+	for n, inf := range []*v2alpha1.Inference{inference, current} {
+		r, err := revision.New(inf, int64(n+1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := indexer.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Adding the policy to a degraded inference does not revert it.
+	updated, _, err := c.rollback(current, "broken")
+	if err != nil || updated != nil {
+		t.Errorf("expected no rollback of the policy change, got %v, %v", updated, err)
+	}
+}
+
+func Test_prometheusErrorRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("query")
+		if !strings.Contains(query, `inference_name="bloomz.default"`) {
+			t.Errorf("unexpected query %s", query)
+		}
+		value := "100"
+		if strings.Contains(query, `code=~"5.."`) {
+			value = "7"
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"%s"]}]}}`, value)
+	}))
+	defer server.Close()
+
+	rate, err := newPrometheusErrorRates(server.URL+"/").
+		ErrorRate("default", "bloomz", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate.Failed != 7 || rate.Total != 100 {
+		t.Errorf("unexpected error rate %+v", rate)
+	}
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/k8s"
+)
+
+const (
+	defaultAntiAffinityWeight = 100
+	defaultMaxSkew            = 1
+)
+
+// configureScheduling sets the affinity, tolerations and topology spread
+// constraints of the pods from the scheduling config. The user tolerations
+// are appended to the GPU tolerations, and the pod anti affinity and the
+// topology spread constraints select the pods of the same inference.
+func configureScheduling(inference *v2alpha1.Inference, deployment *appsv1.Deployment) {
+	s := inference.Spec.Scheduling
+	if s == nil {
+		return
+	}
+
+	spec := &deployment.Spec.Template.Spec
+	selector := &metav1.LabelSelector{
+		MatchLabels: k8s.MakeLabelSelector(inference.Spec.Name),
+	}
+
+	if s.NodeAffinity != nil &&
+		(len(s.NodeAffinity.Required) > 0 || len(s.NodeAffinity.Preferred) > 0) {
+		if spec.Affinity == nil {
+			spec.Affinity = &corev1.Affinity{}
+		}
+		affinity := &corev1.NodeAffinity{}
+		if len(s.NodeAffinity.Required) > 0 {
+			affinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+				NodeSelectorTerms: s.NodeAffinity.Required,
+			}
+		}
+		affinity.PreferredDuringSchedulingIgnoredDuringExecution = s.NodeAffinity.Preferred
+		spec.Affinity.NodeAffinity = affinity
+	}
+
+	if s.PodAntiAffinity != nil {
+		if spec.Affinity == nil {
+			spec.Affinity = &corev1.Affinity{}
+		}
+		term := corev1.PodAffinityTerm{
+			LabelSelector: selector,
+			TopologyKey:   s.PodAntiAffinity.TopologyKey,
+		}
+		antiAffinity := &corev1.PodAntiAffinity{}
+		if s.PodAntiAffinity.Required {
+			antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = []corev1.PodAffinityTerm{term}
+		} else {
+			weight := s.PodAntiAffinity.Weight
+			if weight == 0 {
+				weight = defaultAntiAffinityWeight
+			}
+			antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = []corev1.WeightedPodAffinityTerm{
+				{Weight: weight, PodAffinityTerm: term},
+			}
+		}
+		spec.Affinity.PodAntiAffinity = antiAffinity
+	}
+
+	spec.Tolerations = append(spec.Tolerations, s.Tolerations...)
+
+	for _, c := range s.TopologySpreadConstraints {
+		maxSkew := c.MaxSkew
+		if maxSkew == 0 {
+			maxSkew = defaultMaxSkew
+		}
+		whenUnsatisfiable := c.WhenUnsatisfiable
+		if whenUnsatisfiable == "" {
+			whenUnsatisfiable = corev1.ScheduleAnyway
+		}
+		spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints,
+			corev1.TopologySpreadConstraint{
+				MaxSkew:           maxSkew,
+				TopologyKey:       c.TopologyKey,
+				WhenUnsatisfiable: whenUnsatisfiable,
+				LabelSelector:     selector,
+			})
+	}
+}
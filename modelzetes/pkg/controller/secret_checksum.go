@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	glog "k8s.io/klog"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+const (
+	reasonSecretChanged  = "SecretChanged"
+	reasonSecretNotFound = "SecretNotFound"
+)
+
+// configureSecrets mounts the secrets of the inference and stamps their
+// checksums into the pod template, so that a change of the secrets rolls
+// out the replicas like a change of the spec. The missing secrets are not
+// mounted, the replicas are rolled out again once they are created.
+func configureSecrets(inference *v2alpha1.Inference, deployment *appsv1.Deployment,
+	secrets map[string]*corev1.Secret, factory FunctionFactory) {
+	if len(inference.Spec.Secrets) == 0 {
+		return
+	}
+
+	mounted := *inference
+	mounted.Spec.Secrets = []string{}
+	for _, name := range inference.Spec.Secrets {
+		if _, ok := secrets[name]; ok {
+			mounted.Spec.Secrets = append(mounted.Spec.Secrets, name)
+		}
+	}
+	if err := factory.Factory.ConfigureSecrets(mounted, deployment, secrets); err != nil {
+		glog.Warningf("Function %s secrets configuring failed: %v",
+			inference.Spec.Name, err)
+		return
+	}
+
+	data, err := json.Marshal(secretChecksums(secrets))
+	if err != nil {
+		glog.Errorf("Failed to marshal secret checksums: %s", err.Error())
+		return
+	}
+
+	// The annotations of the template are shared with the deployment.
+	annotations := make(map[string]string, len(deployment.Spec.Template.Annotations)+1)
+	for k, v := range deployment.Spec.Template.Annotations {
+		annotations[k] = v
+	}
+	annotations[consts.AnnotationSecretChecksums] = string(data)
+	deployment.Spec.Template.Annotations = annotations
+}
+
+// secretChecksums returns the checksums of the type and the data of the
+// secrets by the names.
+func secretChecksums(secrets map[string]*corev1.Secret) map[string]string {
+	res := make(map[string]string, len(secrets))
+	for name, secret := range secrets {
+		keys := make([]string, 0, len(secret.Data))
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		h := sha256.New()
+		fmt.Fprintf(h, "%s\n", secret.Type)
+		for _, key := range keys {
+			fmt.Fprintf(h, "%s\n%d\n", key, len(secret.Data[key]))
+			h.Write(secret.Data[key])
+		}
+		res[name] = hex.EncodeToString(h.Sum(nil))[:16]
+	}
+	return res
+}
+
+// secretsChanged returns true if the checksums of the secrets differ from the
+// ones stamped into the pod template of the deployment, with the names of the
+// secrets which are changed, created or deleted. The deployments created
+// before the checksums were stamped are compared with the checksums
+// backfilled into their annotations, they are never changed if neither is
+// set.
+func secretsChanged(deployment *appsv1.Deployment,
+	secrets map[string]*corev1.Secret) (bool, []string) {
+	value, ok := deployment.Spec.Template.Annotations[consts.AnnotationSecretChecksums]
+	if !ok {
+		value, ok = deployment.Annotations[consts.AnnotationSecretChecksums]
+	}
+	if !ok {
+		return false, nil
+	}
+	previous := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &previous); err != nil {
+		glog.Errorf("Failed to parse previous secret checksums: %s", err.Error())
+		return true, nil
+	}
+
+	checksums := secretChecksums(secrets)
+	changed := []string{}
+	for name, checksum := range checksums {
+		if previous[name] != checksum {
+			changed = append(changed, name)
+		}
+	}
+	for name := range previous {
+		if _, ok := checksums[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return len(changed) > 0, changed
+}
+
+// backfillSecretChecksums records the checksums of the secrets in the
+// annotations of the deployment if they are not stamped into its pod
+// template, e.g. the deployment is created by an older modelzetes. Unlike
+// the pod template, the annotations are updated without restarting the
+// replicas.
+func (c *Controller) backfillSecretChecksums(inference *v2alpha1.Inference,
+	deployment *appsv1.Deployment, secrets map[string]*corev1.Secret) (*appsv1.Deployment, error) {
+	if len(inference.Spec.Secrets) == 0 {
+		return deployment, nil
+	}
+	if _, ok := deployment.Spec.Template.Annotations[consts.AnnotationSecretChecksums]; ok {
+		return deployment, nil
+	}
+	data, err := json.Marshal(secretChecksums(secrets))
+	if err != nil {
+		return deployment, err
+	}
+	if deployment.Annotations[consts.AnnotationSecretChecksums] == string(data) {
+		return deployment, nil
+	}
+
+	expected := deployment.DeepCopy()
+	if expected.Annotations == nil {
+		expected.Annotations = map[string]string{}
+	}
+	expected.Annotations[consts.AnnotationSecretChecksums] = string(data)
+	return c.kubeclientset.AppsV1().Deployments(deployment.Namespace).Update(
+		context.TODO(), expected, metav1.UpdateOptions{})
+}
+
+// handleSecret enqueues the inferences mounting the secret.
+func (c *Controller) handleSecret(obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		secret, ok = tombstone.Obj.(*corev1.Secret)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+	}
+
+	inferences, err := c.inferenceLister.Inferences(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, inference := range inferences {
+		for _, name := range inference.Spec.Secrets {
+			if name == secret.Name {
+				c.enqueueFunction(inference)
+				break
+			}
+		}
+	}
+}
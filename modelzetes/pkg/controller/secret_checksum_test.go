@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	listers "github.com/tensorchord/openmodelz/modelzetes/pkg/client/listers/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+func Test_configureSecrets(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bloomz",
+			Namespace: "default",
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:    "bloomz",
+			Image:   "modelzai/bloomz",
+			Secrets: []string{"hf-token"},
+		},
+	}
+	secrets := map[string]*corev1.Secret{
+		"hf-token": {
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{"HF_TOKEN": []byte("hf_1")},
+		},
+	}
+	factory := NewFunctionFactory(fake.NewSimpleClientset(), defaultK8sConfig)
+
+	deployment := newDeployment(inference, nil, secrets, factory)
+	if _, ok := deployment.Annotations[consts.AnnotationSecretChecksums]; ok {
+		t.Errorf("expected the checksums only on the pod template")
+	}
+	if _, ok := deployment.Spec.Template.Annotations[consts.AnnotationSecretChecksums]; !ok {
+		t.Fatalf("expected the checksums on the pod template, got %v",
+			deployment.Spec.Template.Annotations)
+	}
+	if len(deployment.Spec.Template.Spec.Volumes) != 2 {
+		t.Errorf("expected the secrets volume, got %+v", deployment.Spec.Template.Spec.Volumes)
+	}
+	if updated, changed := secretsChanged(deployment, secrets); updated || len(changed) > 0 {
+		t.Errorf("expected no change, got %v", changed)
+	}
+
+	secrets["hf-token"] = &corev1.Secret{
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{"HF_TOKEN": []byte("hf_2")},
+	}
+	updated, changed := secretsChanged(deployment, secrets)
+	if !updated || len(changed) != 1 || changed[0] != "hf-token" {
+		t.Errorf("expected hf-token to be changed, got %v", changed)
+	}
+
+	// The deployments created without the checksums are not restarted, the
+	// checksums are backfilled into the annotations of the deployment.
+	delete(deployment.Spec.Template.Annotations, consts.AnnotationSecretChecksums)
+	if updated, changed := secretsChanged(deployment, secrets); updated || len(changed) > 0 {
+		t.Errorf("expected no change without the checksums, got %v", changed)
+	}
+	deployment.Namespace = inference.Namespace
+	c := &Controller{kubeclientset: fake.NewSimpleClientset(deployment)}
+	deployment, err := c.backfillSecretChecksums(inference, deployment, secrets)
+	if err != nil {
+		t.Fatalf("failed to backfill the checksums: %v", err)
+	}
+	if _, ok := deployment.Annotations[consts.AnnotationSecretChecksums]; !ok {
+		t.Fatalf("expected the checksums on the deployment, got %v", deployment.Annotations)
+	}
+	if _, ok := deployment.Spec.Template.Annotations[consts.AnnotationSecretChecksums]; ok {
+		t.Errorf("expected the pod template to be unchanged")
+	}
+	if updated, changed := secretsChanged(deployment, secrets); updated || len(changed) > 0 {
+		t.Errorf("expected no change after the backfill, got %v", changed)
+	}
+	secrets["hf-token"] = &corev1.Secret{
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{"HF_TOKEN": []byte("hf_3")},
+	}
+	updated, changed = secretsChanged(deployment, secrets)
+	if !updated || len(changed) != 1 || changed[0] != "hf-token" {
+		t.Errorf("expected hf-token to be changed, got %v", changed)
+	}
+}
+
+func Test_configureSecrets_Missing(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bloomz",
+			Namespace: "default",
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:    "bloomz",
+			Image:   "modelzai/bloomz",
+			Secrets: []string{"hf-token", "wandb"},
+		},
+	}
+	secrets := map[string]*corev1.Secret{
+		"hf-token": {
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{"HF_TOKEN": []byte("hf_1")},
+		},
+	}
+	factory := NewFunctionFactory(fake.NewSimpleClientset(), defaultK8sConfig)
+
+	deployment := newDeployment(inference, nil, secrets, factory)
+	if len(deployment.Spec.Template.Spec.Volumes) != 2 {
+		t.Fatalf("expected the secrets volume, got %+v", deployment.Spec.Template.Spec.Volumes)
+	}
+	for _, source := range deployment.Spec.Template.Spec.Volumes[1].Projected.Sources {
+		if source.Secret.Name != "hf-token" {
+			t.Errorf("expected only hf-token to be mounted, got %s", source.Secret.Name)
+		}
+	}
+
+	// The replicas are rolled out once the missing secret is created.
+	secrets["wandb"] = &corev1.Secret{
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{"WANDB_API_KEY": []byte("key")},
+	}
+	updated, changed := secretsChanged(deployment, secrets)
+	if !updated || len(changed) != 1 || changed[0] != "wandb" {
+		t.Errorf("expected wandb to be changed, got %v", changed)
+	}
+}
+
+func Test_handleSecret(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, inference := range []*v2alpha1.Inference{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bloomz", Namespace: "default"},
+			Spec:       v2alpha1.InferenceSpec{Name: "bloomz", Secrets: []string{"hf-token"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+			Spec:       v2alpha1.InferenceSpec{Name: "llama"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bloomz", Namespace: "other"},
+			Spec:       v2alpha1.InferenceSpec{Name: "bloomz", Secrets: []string{"hf-token"}},
+		},
+	} {
+		_ = indexer.Add(inference)
+	}
+	c := &Controller{
+		inferenceLister: listers.NewInferenceLister(indexer),
+		workqueue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Functions"),
+	}
+	defer c.workqueue.ShutDown()
+
+	c.handleSecret(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hf-token", Namespace: "default"},
+	})
+	key, _ := c.workqueue.Get()
+	if key != "default/bloomz" || c.workqueue.Len() != 0 {
+		t.Errorf("expected only default/bloomz to be enqueued, got %v", key)
+	}
+}
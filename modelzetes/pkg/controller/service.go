@@ -1,10 +1,14 @@
 package controller
 
 import (
+	"context"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	glog "k8s.io/klog"
 
 	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
@@ -44,3 +48,27 @@ func newService(function *v2alpha1.Inference) *corev1.Service {
 		},
 	}
 }
+
+// syncService creates the ClusterIP service of the inference if it does not
+// exist. The service is looked up by its own name, which has the prefix.
+func (c *Controller) syncService(function *v2alpha1.Inference) error {
+	svcName := consts.DefaultServicePrefix + function.Spec.Name
+	_, err := c.kubeclientset.CoreV1().Services(function.Namespace).
+		Get(context.TODO(), svcName, metav1.GetOptions{})
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	glog.Infof("Creating ClusterIP service for '%s'", function.Spec.Name)
+	if _, err := c.kubeclientset.CoreV1().Services(function.Namespace).
+		Create(context.TODO(), newService(function), metav1.CreateOptions{}); err != nil {
+		if errors.IsAlreadyExists(err) {
+			glog.V(2).Infof("ClusterIP service '%s' already exists. Skipping creation.", function.Spec.Name)
+			return nil
+		}
+		return err
+	}
+	c.recorder.Eventf(function, corev1.EventTypeNormal, reasonServiceCreated,
+		"Created service %s", svcName)
+	return nil
+}
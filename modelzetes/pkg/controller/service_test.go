@@ -7,6 +7,8 @@ import (
 	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
 	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 )
 
 func Test_newService(t *testing.T) {
@@ -36,3 +38,47 @@ func Test_newService(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func Test_syncService(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kubesec",
+			Namespace: "mock-space",
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:  "kubesec",
+			Image: "docker.io/kubesec/kubesec",
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10)
+	c := &Controller{
+		kubeclientset: client,
+		recorder:      recorder,
+	}
+
+	if err := c.syncService(inference); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected the service created event, got %d events", len(recorder.Events))
+	}
+	<-recorder.Events
+
+	// The existing service is found by its name, thus it is not created
+	// again on every sync.
+	client.ClearActions()
+	if err := c.syncService(inference); err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "create" {
+			t.Errorf("expected the existing service to be found, got %s %s",
+				action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no event for the existing service, got %d", len(recorder.Events))
+	}
+}
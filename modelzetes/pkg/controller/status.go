@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	glog "k8s.io/klog"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+const (
+	reasonMinimumReplicasAvailable   = "MinimumReplicasAvailable"
+	reasonMinimumReplicasUnavailable = "MinimumReplicasUnavailable"
+	reasonNewReplicaSetAvailable     = "NewReplicaSetAvailable"
+	reasonRollingOut                 = "RollingOut"
+	reasonScaledToZero               = "ScaledToZero"
+	reasonReplicasRequested          = "ReplicasRequested"
+	reasonImagePullFailing           = "ImagePullFailing"
+	reasonImagePulled                = "ImagePulled"
+)
+
+// computeStatus computes the status of the inference from the owned
+// deployment and pods.
+func computeStatus(inference *v2alpha1.Inference,
+	deployment *appsv1.Deployment, pods []*corev1.Pod) v2alpha1.InferenceStatus {
+	status := v2alpha1.InferenceStatus{
+		ObservedGeneration: inference.Generation,
+		Conditions:         copyConditions(inference.Status.Conditions),
+	}
+
+	var desired int32
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	status.Replicas = deployment.Status.Replicas
+	status.AvailableReplicas = deployment.Status.AvailableReplicas
+
+	setCondition := func(conditionType string, value bool, reason, message string) {
+		conditionStatus := metav1.ConditionFalse
+		if value {
+			conditionStatus = metav1.ConditionTrue
+		}
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             conditionStatus,
+			ObservedGeneration: inference.Generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	scaledToZero := desired == 0 && deployment.Status.Replicas == 0
+	if scaledToZero {
+		setCondition(v2alpha1.InferenceConditionScaledToZero, true,
+			reasonScaledToZero, "The inference has no replicas")
+	} else {
+		setCondition(v2alpha1.InferenceConditionScaledToZero, false,
+			reasonReplicasRequested, fmt.Sprintf("%d replicas are requested", desired))
+	}
+
+	ready := desired > 0 && deployment.Status.AvailableReplicas >= desired
+	if ready {
+		setCondition(v2alpha1.InferenceConditionReady, true,
+			reasonMinimumReplicasAvailable, fmt.Sprintf("%d/%d replicas are available",
+				deployment.Status.AvailableReplicas, desired))
+	} else {
+		setCondition(v2alpha1.InferenceConditionReady, false,
+			reasonMinimumReplicasUnavailable, fmt.Sprintf("%d/%d replicas are available",
+				deployment.Status.AvailableReplicas, desired))
+	}
+
+	progressing := deployment.Status.ObservedGeneration < deployment.Generation ||
+		deployment.Status.UpdatedReplicas < desired ||
+		deployment.Status.Replicas > deployment.Status.UpdatedReplicas
+	if progressing {
+		setCondition(v2alpha1.InferenceConditionProgressing, true,
+			reasonRollingOut, fmt.Sprintf("%d/%d replicas are updated",
+				deployment.Status.UpdatedReplicas, desired))
+	} else {
+		setCondition(v2alpha1.InferenceConditionProgressing, false,
+			reasonNewReplicaSetAvailable, "The deployment has been rolled out")
+	}
+
+	degraded := deployment.Status.AvailableReplicas < desired && !progressing
+	if degraded {
+		setCondition(v2alpha1.InferenceConditionDegraded, true,
+			reasonMinimumReplicasUnavailable, fmt.Sprintf("%d replicas are unavailable",
+				desired-deployment.Status.AvailableReplicas))
+	} else {
+		setCondition(v2alpha1.InferenceConditionDegraded, false,
+			reasonMinimumReplicasAvailable, "No replicas are unavailable")
+	}
+
+	pullMessage := imagePullFailure(pods)
+	if pullMessage != "" {
+		setCondition(v2alpha1.InferenceConditionImagePullFailing, true,
+			reasonImagePullFailing, pullMessage)
+	} else {
+		setCondition(v2alpha1.InferenceConditionImagePullFailing, false,
+			reasonImagePulled, "No image pull failures")
+	}
+
+	switch {
+	case pullMessage != "":
+		status.Phase = v2alpha1.InferencePhaseFailed
+	case scaledToZero:
+		status.Phase = v2alpha1.InferencePhaseScaledToZero
+	case ready && !progressing:
+		status.Phase = v2alpha1.InferencePhaseReady
+	case progressing || desired != deployment.Status.Replicas:
+		status.Phase = v2alpha1.InferencePhaseScaling
+	default:
+		status.Phase = v2alpha1.InferencePhaseNotReady
+	}
+	return status
+}
+
+// imagePullFailure returns the message of the first container which fails
+// to pull the image.
+func imagePullFailure(pods []*corev1.Pod) string {
+	for _, pod := range pods {
+		statuses := append([]corev1.ContainerStatus{},
+			pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			if cs.State.Waiting == nil {
+				continue
+			}
+			switch cs.State.Waiting.Reason {
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+				return fmt.Sprintf("%s: %s", cs.State.Waiting.Reason,
+					cs.State.Waiting.Message)
+			}
+		}
+	}
+	return ""
+}
+
+func copyConditions(conditions []metav1.Condition) []metav1.Condition {
+	if conditions == nil {
+		return nil
+	}
+	res := make([]metav1.Condition, len(conditions))
+	copy(res, conditions)
+	return res
+}
+
+// updateStatus updates the status subresource of the inference if it
+// changes.
+func (c *Controller) updateStatus(inference *v2alpha1.Inference,
+	deployment *appsv1.Deployment, rollout *v2alpha1.RolloutStatus) error {
+	pods, err := c.podsLister.Pods(inference.Namespace).List(
+		labels.SelectorFromSet(map[string]string{"controller": inference.Name}))
+	if err != nil {
+		return err
+	}
+
+	status := computeStatus(inference, deployment, pods)
+	status.Rollout = rollout
+	if equality.Semantic.DeepEqual(status, inference.Status) {
+		return nil
+	}
+
+	updated := inference.DeepCopy()
+	updated.Status = status
+	glog.V(4).Infof("Updating status of '%s': %s", inference.Name, status.Phase)
+	_, err = c.faasclientset.TensorchordV2alpha1().Inferences(inference.Namespace).
+		UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// handlePod enqueues the inference which the pod belongs to, pods are owned
+// by the replica sets so the controller label is used instead.
+func (c *Controller) handlePod(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return
+		}
+		pod, ok = tombstone.Obj.(*corev1.Pod)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return
+		}
+	}
+	if name, ok := pod.Labels[consts.LabelBatchInferenceName]; ok {
+		if bi, err := c.batchInferencesLister.BatchInferences(pod.Namespace).Get(name); err == nil {
+			c.enqueueBatch(bi)
+		}
+		return
+	}
+	name, ok := pod.Labels["controller"]
+	if !ok {
+		return
+	}
+	inference, err := c.inferenceLister.Inferences(pod.Namespace).Get(name)
+	if err != nil {
+		return
+	}
+	c.enqueueFunction(inference)
+}
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+func Test_computeStatus(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{Name: "bert", Generation: 3},
+	}
+	pullingPod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: "Back-off pulling image",
+					},
+				},
+			}},
+		},
+	}
+
+	scenarios := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		pods       []*corev1.Pod
+		phase      v2alpha1.InferencePhase
+		conditions map[string]metav1.ConditionStatus
+	}{
+		{
+			name: "ready",
+			deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: Ptr(int32(2))},
+				Status: appsv1.DeploymentStatus{
+					Replicas:          2,
+					UpdatedReplicas:   2,
+					AvailableReplicas: 2,
+				},
+			},
+			phase: v2alpha1.InferencePhaseReady,
+			conditions: map[string]metav1.ConditionStatus{
+				v2alpha1.InferenceConditionReady:            metav1.ConditionTrue,
+				v2alpha1.InferenceConditionProgressing:      metav1.ConditionFalse,
+				v2alpha1.InferenceConditionDegraded:         metav1.ConditionFalse,
+				v2alpha1.InferenceConditionScaledToZero:     metav1.ConditionFalse,
+				v2alpha1.InferenceConditionImagePullFailing: metav1.ConditionFalse,
+			},
+		},
+		{
+			name: "scaled to zero",
+			deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: Ptr(int32(0))},
+			},
+			phase: v2alpha1.InferencePhaseScaledToZero,
+			conditions: map[string]metav1.ConditionStatus{
+				v2alpha1.InferenceConditionReady:        metav1.ConditionFalse,
+				v2alpha1.InferenceConditionScaledToZero: metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "scaling up",
+			deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: Ptr(int32(2))},
+				Status: appsv1.DeploymentStatus{
+					Replicas:          2,
+					UpdatedReplicas:   1,
+					AvailableReplicas: 1,
+				},
+			},
+			phase: v2alpha1.InferencePhaseScaling,
+			conditions: map[string]metav1.ConditionStatus{
+				v2alpha1.InferenceConditionReady:       metav1.ConditionFalse,
+				v2alpha1.InferenceConditionProgressing: metav1.ConditionTrue,
+				v2alpha1.InferenceConditionDegraded:    metav1.ConditionFalse,
+			},
+		},
+		{
+			name: "degraded",
+			deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: Ptr(int32(2))},
+				Status: appsv1.DeploymentStatus{
+					Replicas:          2,
+					UpdatedReplicas:   2,
+					AvailableReplicas: 1,
+				},
+			},
+			phase: v2alpha1.InferencePhaseNotReady,
+			conditions: map[string]metav1.ConditionStatus{
+				v2alpha1.InferenceConditionReady:    metav1.ConditionFalse,
+				v2alpha1.InferenceConditionDegraded: metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "image pull failing",
+			deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: Ptr(int32(1))},
+				Status: appsv1.DeploymentStatus{
+					Replicas:        1,
+					UpdatedReplicas: 1,
+				},
+			},
+			pods:  []*corev1.Pod{pullingPod},
+			phase: v2alpha1.InferencePhaseFailed,
+			conditions: map[string]metav1.ConditionStatus{
+				v2alpha1.InferenceConditionImagePullFailing: metav1.ConditionTrue,
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			status := computeStatus(inference, s.deployment, s.pods)
+			if status.ObservedGeneration != 3 {
+				t.Errorf("expected observed generation 3, got %d", status.ObservedGeneration)
+			}
+			if status.Phase != s.phase {
+				t.Errorf("expected phase %s, got %s", s.phase, status.Phase)
+			}
+			for conditionType, expected := range s.conditions {
+				condition := meta.FindStatusCondition(status.Conditions, conditionType)
+				if condition == nil {
+					t.Errorf("condition %s not found", conditionType)
+					continue
+				}
+				if condition.Status != expected {
+					t.Errorf("expected condition %s to be %s, got %s",
+						conditionType, expected, condition.Status)
+				}
+			}
+		})
+	}
+}
+
+func Test_computeStatusKeepsTransitionTime(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{Replicas: Ptr(int32(1))},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          1,
+			UpdatedReplicas:   1,
+			AvailableReplicas: 1,
+		},
+	}
+	inference := &v2alpha1.Inference{}
+	inference.Status = computeStatus(inference, deployment, nil)
+
+	status := computeStatus(inference, deployment, nil)
+	for i := range status.Conditions {
+		if !status.Conditions[i].LastTransitionTime.Equal(
+			&inference.Status.Conditions[i].LastTransitionTime) {
+			t.Errorf("transition time of %s should not change",
+				status.Conditions[i].Type)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+// configureTermination sets the termination grace period and the preStop
+// hook of the pods. The hook delays SIGTERM until the pod is removed from
+// the endpoints, so that the gateway stops routing to it before the process
+// stops accepting requests. The hook runs sleep with sh, thus it is only set
+// if it is enabled explicitly. The per-inference config overrides the
+// defaults.
+func configureTermination(inference *v2alpha1.Inference,
+	deployment *appsv1.Deployment, factory FunctionFactory) {
+	gracePeriod := factory.Factory.Config.TerminationGracePeriodSeconds
+	preStopSleep := factory.Factory.Config.PreStopSleepSeconds
+	if t := inference.Spec.Termination; t != nil {
+		if t.GracePeriodSeconds != nil {
+			gracePeriod = *t.GracePeriodSeconds
+		}
+		if t.PreStopSleepSeconds != nil {
+			preStopSleep = *t.PreStopSleepSeconds
+		}
+	}
+
+	spec := &deployment.Spec.Template.Spec
+	if gracePeriod > 0 {
+		spec.TerminationGracePeriodSeconds = Ptr(gracePeriod)
+	}
+	if preStopSleep > 0 {
+		spec.Containers[0].Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"sh", "-c", fmt.Sprintf("sleep %d", preStopSleep)},
+				},
+			},
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+func Test_configureTermination(t *testing.T) {
+	config := defaultK8sConfig
+	config.TerminationGracePeriodSeconds = 120
+	config.PreStopSleepSeconds = 5
+	factory := NewFunctionFactory(fake.NewSimpleClientset(), config)
+
+	testCases := []struct {
+		name         string
+		termination  *v2alpha1.TerminationConfig
+		gracePeriod  int64
+		preStopSleep []string
+	}{
+		{
+			name:         "defaults",
+			gracePeriod:  120,
+			preStopSleep: []string{"sh", "-c", "sleep 5"},
+		},
+		{
+			name: "override",
+			termination: &v2alpha1.TerminationConfig{
+				GracePeriodSeconds:  Ptr(int64(300)),
+				PreStopSleepSeconds: Ptr(int32(10)),
+			},
+			gracePeriod:  300,
+			preStopSleep: []string{"sh", "-c", "sleep 10"},
+		},
+		{
+			name: "disable the hook",
+			termination: &v2alpha1.TerminationConfig{
+				PreStopSleepSeconds: Ptr(int32(0)),
+			},
+			gracePeriod: 120,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inference := &v2alpha1.Inference{
+				ObjectMeta: metav1.ObjectMeta{Name: "bloomz"},
+				Spec: v2alpha1.InferenceSpec{
+					Name:        "bloomz",
+					Image:       "modelzai/bloomz",
+					Termination: tc.termination,
+				},
+			}
+			deployment := newDeployment(inference, nil, nil, factory)
+			spec := deployment.Spec.Template.Spec
+			if spec.TerminationGracePeriodSeconds == nil ||
+				*spec.TerminationGracePeriodSeconds != tc.gracePeriod {
+				t.Errorf("expected grace period %d, got %v",
+					tc.gracePeriod, spec.TerminationGracePeriodSeconds)
+			}
+			lifecycle := spec.Containers[0].Lifecycle
+			if tc.preStopSleep == nil {
+				if lifecycle != nil {
+					t.Errorf("expected no preStop hook, got %+v", lifecycle)
+				}
+				return
+			}
+			if lifecycle == nil || lifecycle.PreStop == nil ||
+				!reflect.DeepEqual(lifecycle.PreStop.Exec.Command, tc.preStopSleep) {
+				t.Errorf("expected preStop hook %v, got %+v", tc.preStopSleep, lifecycle)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package election
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// Config is the configuration of the lease based leader election among the
+// replicas, only the leader runs the workers.
+type Config struct {
+	Enabled        bool   `json:"enabled,omitempty"`
+	LeaseName      string `json:"lease_name,omitempty"`
+	LeaseNamespace string `json:"lease_namespace,omitempty"`
+	Identity       string `json:"identity,omitempty"`
+
+	LeaseDuration time.Duration `json:"lease_duration,omitempty"`
+	RenewDeadline time.Duration `json:"renew_deadline,omitempty"`
+	RetryPeriod   time.Duration `json:"retry_period,omitempty"`
+}
+
+// Status is the leadership status of the instance.
+type Status struct {
+	Enabled  bool   `json:"enabled"`
+	IsLeader bool   `json:"is_leader"`
+	Identity string `json:"identity,omitempty"`
+	Leader   string `json:"leader,omitempty"`
+}
+
+// Elector elects one leader among the replicas, only the leader runs the
+// autoscaling loop or the controller workers.
+type Elector struct {
+	config Config
+	client kubernetes.Interface
+
+	mu       sync.RWMutex
+	isLeader bool
+	leader   string
+}
+
+func New(client kubernetes.Interface, config Config) (*Elector, error) {
+	if config.LeaseName == "" || config.LeaseNamespace == "" {
+		return nil, errors.New("lease name and namespace are required")
+	}
+	if config.Identity == "" {
+		return nil, errors.New("identity is required")
+	}
+
+	return &Elector{
+		config: config,
+		client: client,
+	}, nil
+}
+
+// Run campaigns for leadership until the context is done. The run function
+// is called every time the instance becomes the leader, the context passed
+// to it is canceled once the leadership is lost.
+func (e *Elector) Run(ctx context.Context, run func(ctx context.Context)) error {
+	le, err := e.newLeaderElector(run)
+	if err != nil {
+		return err
+	}
+
+	// The leader elector returns once the leadership is lost,
+	// campaign again unless we are shutting down.
+	for {
+		le.Run(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+	}
+}
+
+// RunOnce runs the function once the instance becomes the leader, until
+// stopCh is closed. It is used by the controllers whose workers cannot be
+// restarted: unlike Run, it does not campaign again once the leadership is
+// lost but returns the error, so that the process restarts as a standby.
+// The lease is released on the shutdown, the standby takes over without
+// waiting for the lease to expire.
+func (e *Elector) RunOnce(stopCh <-chan struct{},
+	run func(stopCh <-chan struct{}) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	started := make(chan struct{})
+	done := make(chan error, 1)
+	le, err := e.newLeaderElector(func(ctx context.Context) {
+		close(started)
+		done <- run(ctx.Done())
+		// Stop renewing the lease if the function returns early.
+		cancel()
+	})
+	if err != nil {
+		return err
+	}
+	le.Run(ctx)
+
+	// Wait for the function to return if we were the leader.
+	select {
+	case <-started:
+		if err := <-done; err != nil {
+			return err
+		}
+	default:
+	}
+	select {
+	case <-stopCh:
+		return nil
+	default:
+		return errors.New("leader election lost")
+	}
+}
+
+func (e *Elector) newLeaderElector(run func(ctx context.Context)) (*leaderelection.LeaderElector, error) {
+	lock, err := resourcelock.New(resourcelock.LeasesResourceLock,
+		e.config.LeaseNamespace, e.config.LeaseName,
+		e.client.CoreV1(), e.client.CoordinationV1(),
+		resourcelock.ResourceLockConfig{
+			Identity: e.config.Identity,
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create resource lock")
+	}
+
+	le, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   e.config.LeaseDuration,
+		RenewDeadline:   e.config.RenewDeadline,
+		RetryPeriod:     e.config.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            e.config.LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				logrus.WithField("identity", e.config.Identity).
+					Info("started leading")
+				e.setLeader(true)
+				run(ctx)
+			},
+			OnStoppedLeading: func() {
+				logrus.WithField("identity", e.config.Identity).
+					Info("stopped leading")
+				e.setLeader(false)
+			},
+			OnNewLeader: func(identity string) {
+				logrus.WithField("leader", identity).Info("new leader elected")
+				e.mu.Lock()
+				e.leader = identity
+				e.mu.Unlock()
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create leader elector")
+	}
+	return le, nil
+}
+
+func (e *Elector) setLeader(isLeader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.isLeader = isLeader
+}
+
+// Status returns the leadership status. A nil elector means that the leader
+// election is disabled, thus the instance is always the leader.
+func (e *Elector) Status() Status {
+	if e == nil {
+		return Status{
+			Enabled:  false,
+			IsLeader: true,
+		}
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return Status{
+		Enabled:  true,
+		IsLeader: e.isLeader,
+		Identity: e.config.Identity,
+		Leader:   e.leader,
+	}
+}
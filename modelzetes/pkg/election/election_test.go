@@ -0,0 +1,147 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestElectorBecomesLeader(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	e, err := New(client, Config{
+		Enabled:        true,
+		LeaseName:      "modelz-autoscaler",
+		LeaseNamespace: "default",
+		Identity:       "autoscaler-0",
+		LeaseDuration:  time.Second,
+		RenewDeadline:  500 * time.Millisecond,
+		RetryPeriod:    100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create elector: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leading := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := e.Run(ctx, func(ctx context.Context) {
+			close(leading)
+			<-ctx.Done()
+		}); err != nil {
+			t.Errorf("failed to run elector: %v", err)
+		}
+	}()
+
+	select {
+	case <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for leadership")
+	}
+
+	status := e.Status()
+	if !status.Enabled || !status.IsLeader || status.Identity != "autoscaler-0" {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	cancel()
+	<-done
+	if e.Status().IsLeader {
+		t.Error("expected leadership to be released")
+	}
+}
+
+func TestElectorRunOnce(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	e, err := New(client, Config{
+		Enabled:        true,
+		LeaseName:      "modelzetes",
+		LeaseNamespace: "default",
+		Identity:       "modelzetes-0",
+		LeaseDuration:  time.Second,
+		RenewDeadline:  500 * time.Millisecond,
+		RetryPeriod:    100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create elector: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	leading := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- e.RunOnce(stopCh, func(stopCh <-chan struct{}) error {
+			close(leading)
+			<-stopCh
+			return nil
+		})
+	}()
+
+	select {
+	case <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for leadership")
+	}
+
+	close(stopCh)
+	if err := <-done; err != nil {
+		t.Fatalf("expected the shutdown to succeed, got %v", err)
+	}
+	lease, err := client.CoordinationV1().Leases("default").
+		Get(context.Background(), "modelzetes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get lease: %v", err)
+	}
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" {
+		t.Errorf("expected the lease to be released, got %s", *lease.Spec.HolderIdentity)
+	}
+
+	// The error of the function is returned.
+	err = e.RunOnce(make(chan struct{}), func(stopCh <-chan struct{}) error {
+		return errors.New("failed to sync caches")
+	})
+	if err == nil || err.Error() != "failed to sync caches" {
+		t.Errorf("expected the error of the function, got %v", err)
+	}
+}
+
+func TestStatusDisabled(t *testing.T) {
+	var e *Elector
+	status := e.Status()
+	if status.Enabled || !status.IsLeader {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := Config{
+		Enabled:        true,
+		LeaseName:      "modelzetes",
+		LeaseNamespace: "default",
+		Identity:       "modelzetes-0",
+		LeaseDuration:  15 * time.Second,
+		RenewDeadline:  10 * time.Second,
+		RetryPeriod:    2 * time.Second,
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected the config to be valid, got %v", err)
+	}
+
+	invalid := valid
+	invalid.RenewDeadline = invalid.LeaseDuration
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected the renew deadline to be shorter than the lease duration")
+	}
+	// The config is not validated if the leader election is disabled.
+	invalid.Enabled = false
+	if err := invalid.Validate(); err != nil {
+		t.Errorf("expected the disabled config to be valid, got %v", err)
+	}
+}
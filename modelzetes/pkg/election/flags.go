@@ -0,0 +1,117 @@
+package election
+
+import (
+	"os"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	cli "github.com/urfave/cli/v2"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	FlagLeaderElect              = "leader-elect"
+	FlagLeaderElectLeaseName     = "leader-elect-lease-name"
+	FlagLeaderElectNamespace     = "leader-elect-namespace"
+	FlagLeaderElectIdentity      = "leader-elect-identity"
+	FlagLeaderElectLeaseDuration = "leader-elect-lease-duration"
+	FlagLeaderElectRenewDeadline = "leader-elect-renew-deadline"
+	FlagLeaderElectRetryPeriod   = "leader-elect-retry-period"
+)
+
+// Flags returns the leader election flags shared by the components. The
+// env vars are prefixed with envPrefix, e.g. MODELZETES, and the lease is
+// named leaseName by default.
+func Flags(envPrefix, leaseName string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    FlagLeaderElect,
+			Usage:   "enable lease based leader election, only the leader runs the workers",
+			EnvVars: []string{envPrefix + "_LEADER_ELECT"},
+			Aliases: []string{"le"},
+		},
+		&cli.StringFlag{
+			Name:    FlagLeaderElectLeaseName,
+			Usage:   "name of the lease used for leader election",
+			Value:   leaseName,
+			EnvVars: []string{envPrefix + "_LEADER_ELECT_LEASE_NAME"},
+		},
+		&cli.StringFlag{
+			Name:    FlagLeaderElectNamespace,
+			Usage:   "namespace of the lease used for leader election",
+			Value:   "default",
+			EnvVars: []string{envPrefix + "_LEADER_ELECT_NAMESPACE", "POD_NAMESPACE"},
+		},
+		&cli.StringFlag{
+			Name:    FlagLeaderElectIdentity,
+			Usage:   "identity of this instance in leader election, defaults to the hostname",
+			EnvVars: []string{envPrefix + "_LEADER_ELECT_IDENTITY", "POD_NAME"},
+		},
+		&cli.DurationFlag{
+			Name:    FlagLeaderElectLeaseDuration,
+			Usage:   "duration that non-leader candidates will wait to force acquire leadership",
+			Value:   15 * time.Second,
+			EnvVars: []string{envPrefix + "_LEADER_ELECT_LEASE_DURATION"},
+		},
+		&cli.DurationFlag{
+			Name:    FlagLeaderElectRenewDeadline,
+			Usage:   "duration that the leader will retry refreshing leadership before giving up",
+			Value:   10 * time.Second,
+			EnvVars: []string{envPrefix + "_LEADER_ELECT_RENEW_DEADLINE"},
+		},
+		&cli.DurationFlag{
+			Name:    FlagLeaderElectRetryPeriod,
+			Usage:   "duration the candidates should wait between tries of actions",
+			Value:   2 * time.Second,
+			EnvVars: []string{envPrefix + "_LEADER_ELECT_RETRY_PERIOD"},
+		},
+	}
+}
+
+// ConfigFromCLI returns the config from the flags of Flags, the identity
+// defaults to the hostname.
+func ConfigFromCLI(c *cli.Context) Config {
+	cfg := Config{
+		Enabled:        c.Bool(FlagLeaderElect),
+		LeaseName:      c.String(FlagLeaderElectLeaseName),
+		LeaseNamespace: c.String(FlagLeaderElectNamespace),
+		Identity:       c.String(FlagLeaderElectIdentity),
+		LeaseDuration:  c.Duration(FlagLeaderElectLeaseDuration),
+		RenewDeadline:  c.Duration(FlagLeaderElectRenewDeadline),
+		RetryPeriod:    c.Duration(FlagLeaderElectRetryPeriod),
+	}
+	if cfg.Identity == "" {
+		cfg.Identity, _ = os.Hostname()
+	}
+	return cfg
+}
+
+// Validate returns an error if the leader election is enabled and the
+// config is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.LeaseName == "" || c.LeaseNamespace == "" || c.Identity == "" ||
+		c.RetryPeriod <= 0 ||
+		c.RenewDeadline <= c.RetryPeriod ||
+		c.LeaseDuration <= c.RenewDeadline {
+		return errors.New("invalid leader election config")
+	}
+	return nil
+}
+
+// NewFromKubeConfig creates the elector with the client built from the
+// kubeconfig, the in-cluster config is used if both are empty.
+func NewFromKubeConfig(masterURL, kubeconfig string, config Config) (*Elector, error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build kubeconfig")
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build kubernetes clientset")
+	}
+	return New(client, config)
+}
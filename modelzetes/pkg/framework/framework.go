@@ -0,0 +1,247 @@
+// Package framework is the registry of the inference frameworks. A framework
+// declares the defaults of the inferences using it, e.g. the port, command,
+// env vars and probe path, and how the gateway proxies the requests to them.
+// The registry is shared by the agent, the admission webhook and the
+// controller, and the operators could add the custom frameworks from a
+// config file.
+package framework
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	Gradio    = "gradio"
+	Streamlit = "streamlit"
+	Mosec     = "mosec"
+	VLLM      = "vllm"
+	TGI       = "tgi"
+	Triton    = "triton"
+	Jupyter   = "jupyter"
+	Other     = "other"
+)
+
+// ColdStart is the behavior of the requests when no replica is ready.
+type ColdStart string
+
+const (
+	// ColdStartWait holds the requests until a replica is ready.
+	ColdStartWait ColdStart = "wait"
+	// ColdStartLoadingPage returns the loading page immediately, it is used
+	// by the web UIs which are opened in the browser.
+	ColdStartLoadingPage ColdStart = "loading_page"
+)
+
+// Framework is the defaults and the proxy behavior of the inferences using
+// the framework.
+type Framework struct {
+	// Name is the name used in the framework field of the inference.
+	Name string `json:"name"`
+	// Port is the port which the framework listens on by default. 0 means
+	// the port of the inference is required.
+	Port int32 `json:"port,omitempty"`
+	// PortEnv is the env var used to change the port. If it is set, the
+	// port of the inference must be consistent with it.
+	PortEnv string `json:"port_env,omitempty"`
+	// Command is the default command if the inference does not set one.
+	Command string `json:"command,omitempty"`
+	// Env are the default env vars, they are overridden by the ones of
+	// the inference.
+	Env map[string]string `json:"env,omitempty"`
+	// HTTPProbePath is the default path of the http probes.
+	HTTPProbePath string `json:"http_probe_path,omitempty"`
+	// WebSocket means the framework serves a web UI over websockets.
+	WebSocket bool `json:"websocket,omitempty"`
+	// NoAuth means the inferences are proxied without the API key, e.g. the
+	// web UIs opened in the browser which could not set the header. It must
+	// not be set for the frameworks which execute the code of the requests
+	// without their own authentication, e.g. jupyter.
+	NoAuth bool `json:"no_auth,omitempty"`
+	// Streaming means the responses are streamed, e.g. server-sent events,
+	// they are flushed to the client immediately.
+	Streaming bool `json:"streaming,omitempty"`
+	// ColdStart is the behavior of the requests when no replica is ready.
+	// Default is wait.
+	ColdStart ColdStart `json:"cold_start,omitempty"`
+}
+
+// Builtin are the frameworks supported out of the box.
+var Builtin = []Framework{
+	{
+		Name:    Gradio,
+		Port:    7860,
+		PortEnv: "GRADIO_SERVER_PORT",
+		Env: map[string]string{
+			"GRADIO_SERVER_NAME": "0.0.0.0",
+		},
+		WebSocket: true,
+		NoAuth:    true,
+		ColdStart: ColdStartLoadingPage,
+	},
+	{
+		// The port env var is not set, the image may configure the port in
+		// its streamlit config.
+		Name: Streamlit,
+		Port: 8501,
+		Env: map[string]string{
+			"STREAMLIT_SERVER_ENABLE_CORS":            "false",
+			"STREAMLIT_SERVER_ADDRESS":                "0.0.0.0",
+			"STREAMLIT_SERVER_ENABLE_XSRF_PROTECTION": "false",
+		},
+		WebSocket: true,
+		NoAuth:    true,
+		ColdStart: ColdStartLoadingPage,
+	},
+	{
+		Name:    Mosec,
+		Port:    8080,
+		PortEnv: "MOSEC_PORT",
+	},
+	{
+		Name:          VLLM,
+		Port:          8000,
+		Command:       "python3 -m vllm.entrypoints.openai.api_server",
+		HTTPProbePath: "/health",
+		Streaming:     true,
+	},
+	{
+		// The text-generation-inference image runs the launcher which
+		// reads the port from the env var.
+		Name:          TGI,
+		Port:          80,
+		PortEnv:       "PORT",
+		HTTPProbePath: "/health",
+		Streaming:     true,
+	},
+	{
+		Name:          Triton,
+		Port:          8000,
+		Command:       "tritonserver --model-repository=/models",
+		HTTPProbePath: "/v2/health/ready",
+	},
+	{
+		Name:    Jupyter,
+		Port:    8888,
+		Command: "jupyter lab --ip=0.0.0.0 --port=8888 --no-browser --allow-root --ServerApp.token= --ServerApp.allow_origin=*",
+		// The API returns the version without the token. The server runs
+		// without its own token, thus it is only proxied with the API key.
+		HTTPProbePath: "/api",
+		WebSocket:     true,
+		ColdStart:     ColdStartLoadingPage,
+	},
+	{
+		Name: Other,
+	},
+}
+
+var (
+	mu         sync.RWMutex
+	frameworks = map[string]Framework{}
+)
+
+func init() {
+	for _, f := range Builtin {
+		frameworks[f.Name] = f
+	}
+}
+
+// Get returns the registered framework by the name.
+func Get(name string) (Framework, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	f, ok := frameworks[name]
+	return f, ok
+}
+
+// Names returns the names of the registered frameworks in order.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(frameworks))
+	for name := range frameworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Unregister removes the framework from the registry.
+func Unregister(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(frameworks, name)
+}
+
+// Register adds the framework to the registry, it replaces the registered
+// one with the same name, including the builtin ones.
+func Register(f Framework) error {
+	if f.Name == "" {
+		return fmt.Errorf("framework name is required")
+	}
+	if f.Port < 0 || f.Port > 65535 {
+		return fmt.Errorf("framework %s: port (%d) is invalid, must be between 1 and 65535",
+			f.Name, f.Port)
+	}
+	if f.PortEnv != "" && f.Port == 0 {
+		return fmt.Errorf("framework %s: port is required if port_env is set", f.Name)
+	}
+	switch f.ColdStart {
+	case "", ColdStartWait, ColdStartLoadingPage:
+	default:
+		return fmt.Errorf("framework %s: cold_start (%s) is invalid, must be %s or %s",
+			f.Name, f.ColdStart, ColdStartWait, ColdStartLoadingPage)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	frameworks[f.Name] = f
+	return nil
+}
+
+// config is the file of the custom frameworks.
+type config struct {
+	Frameworks []Framework `json:"frameworks"`
+}
+
+// LoadFile registers the custom frameworks in the YAML or JSON file, e.g.
+//
+//	frameworks:
+//	- name: comfyui
+//	  port: 8188
+//	  websocket: true
+//	  no_auth: true
+//	  cold_start: loading_page
+func LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the framework config: %w", err)
+	}
+	cfg := config{}
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return fmt.Errorf("failed to parse the framework config %s: %w", path, err)
+	}
+	for _, f := range cfg.Frameworks {
+		if err := Register(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnvWithPort returns the default env vars of the framework, including the
+// port env var.
+func (f Framework) EnvWithPort() map[string]string {
+	env := map[string]string{}
+	for k, v := range f.Env {
+		env[k] = v
+	}
+	if f.PortEnv != "" {
+		env[f.PortEnv] = fmt.Sprint(f.Port)
+	}
+	return env
+}
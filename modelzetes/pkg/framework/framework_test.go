@@ -0,0 +1,73 @@
+package framework
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_LoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frameworks.yaml")
+	if err := os.WriteFile(path, []byte(`
+frameworks:
+- name: comfyui
+  port: 8188
+  websocket: true
+  cold_start: loading_page
+- name: tgi
+  port: 8080
+  port_env: PORT
+  http_probe_path: /health
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		Unregister("comfyui")
+		for _, f := range Builtin {
+			if f.Name == TGI {
+				_ = Register(f)
+			}
+		}
+	})
+
+	f, ok := Get("comfyui")
+	if !ok || f.Port != 8188 || !f.WebSocket || f.NoAuth || f.ColdStart != ColdStartLoadingPage {
+		t.Errorf("unexpected framework %+v", f)
+	}
+	// The builtin frameworks could be overridden.
+	if f, _ := Get(TGI); f.Port != 8080 {
+		t.Errorf("unexpected port %d of tgi", f.Port)
+	}
+}
+
+func Test_Register(t *testing.T) {
+	for _, f := range []Framework{
+		{},
+		{Name: "flask", Port: 70000},
+		{Name: "flask", PortEnv: "FLASK_RUN_PORT"},
+		{Name: "flask", Port: 5000, ColdStart: "never"},
+	} {
+		if err := Register(f); err == nil {
+			t.Errorf("framework %+v should be invalid", f)
+		}
+	}
+	if _, ok := Get("flask"); ok {
+		t.Errorf("invalid framework should not be registered")
+	}
+}
+
+func Test_Builtin_NoAuth(t *testing.T) {
+	for name, noAuth := range map[string]bool{
+		Gradio:    true,
+		Streamlit: true,
+		Jupyter:   false,
+		VLLM:      false,
+	} {
+		if f, _ := Get(name); f.NoAuth != noAuth {
+			t.Errorf("expected no_auth of %s to be %v", name, noAuth)
+		}
+	}
+}
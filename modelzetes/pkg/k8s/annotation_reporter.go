@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	glog "k8s.io/klog"
+)
+
+const (
+	// EnvPodName and EnvPodNamespace are set by the downward API on the
+	// containers which report their progress in the pod annotations.
+	EnvPodName      = "POD_NAME"
+	EnvPodNamespace = "POD_NAMESPACE"
+)
+
+// AnnotationReporter patches an annotation of the pod with the latest
+// reported value periodically, e.g. the progress of the model download. The
+// service account of the pod needs the permission to patch the pods, the
+// reporter stops after the patch is forbidden. The methods are no-op on the
+// nil AnnotationReporter.
+type AnnotationReporter struct {
+	client    kubernetes.Interface
+	namespace string
+	pod       string
+	key       string
+
+	mu       sync.Mutex
+	value    string
+	reported string
+	disabled bool
+}
+
+// NewAnnotationReporter returns the reporter of the annotation of the pod.
+func NewAnnotationReporter(client kubernetes.Interface,
+	namespace, pod, key string) *AnnotationReporter {
+	return &AnnotationReporter{
+		client:    client,
+		namespace: namespace,
+		pod:       pod,
+		key:       key,
+	}
+}
+
+// NewAnnotationReporterFromEnv returns the reporter of the pod which the
+// process runs in, it is nil if the pod is not set by the downward API or
+// the process does not run in the cluster.
+func NewAnnotationReporterFromEnv(key string) *AnnotationReporter {
+	namespace, pod := os.Getenv(EnvPodNamespace), os.Getenv(EnvPodName)
+	if namespace == "" || pod == "" {
+		return nil
+	}
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		glog.Warningf("Failed to report %s of the pod: %v", key, err)
+		return nil
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		glog.Warningf("Failed to report %s of the pod: %v", key, err)
+		return nil
+	}
+	return NewAnnotationReporter(client, namespace, pod, key)
+}
+
+// Report records the value, it is patched on the next flush.
+func (r *AnnotationReporter) Report(value string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.value = value
+}
+
+// Run flushes the reported value every interval until the context is done.
+func (r *AnnotationReporter) Run(ctx context.Context, interval time.Duration) {
+	if r == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Flush(ctx)
+		}
+	}
+}
+
+// Flush patches the annotation if the value is changed since the last
+// patch.
+func (r *AnnotationReporter) Flush(ctx context.Context) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	value, changed := r.value, !r.disabled && r.value != r.reported
+	r.mu.Unlock()
+	if !changed {
+		return
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{r.key: value},
+		},
+	})
+	if err != nil {
+		return
+	}
+	_, err = r.client.CoreV1().Pods(r.namespace).Patch(ctx, r.pod,
+		types.MergePatchType, patch, metav1.PatchOptions{})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case k8serrors.IsForbidden(err):
+		glog.Warningf("Stop reporting %s, the service account of the pod could not patch the pods: %v",
+			r.key, err)
+		r.disabled = true
+	case err != nil:
+		glog.Warningf("Failed to report %s of the pod: %v", r.key, err)
+	default:
+		r.reported = value
+	}
+}
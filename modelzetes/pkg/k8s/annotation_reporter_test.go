@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_AnnotationReporter(t *testing.T) {
+	kube := fake.NewSimpleClientset(&apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "bloomz-0", Namespace: "default"},
+	})
+	r := NewAnnotationReporter(kube, "default", "bloomz-0", "progress")
+	get := func() string {
+		pod, err := kube.CoreV1().Pods("default").Get(context.TODO(), "bloomz-0", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pod.Annotations["progress"]
+	}
+
+	r.Flush(context.TODO())
+	if got := get(); got != "" {
+		t.Errorf("expected nothing reported, got %q", got)
+	}
+	r.Report("1/2")
+	r.Report("2/2")
+	r.Flush(context.TODO())
+	if got := get(); got != "2/2" {
+		t.Errorf("expected the latest value to be reported, got %q", got)
+	}
+
+	var nilReporter *AnnotationReporter
+	nilReporter.Report("1/2")
+	nilReporter.Flush(context.TODO())
+}
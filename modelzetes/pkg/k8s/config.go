@@ -20,6 +20,22 @@ type DeploymentConfig struct {
 	HuggingfacePullThroughCacheEndpoint string
 	ImagePullPolicy                     string
 	RuntimeClassNvidia                  bool
+	// ModelDownloaderImage is the image of the init container which
+	// downloads the model artifacts.
+	ModelDownloaderImage string
+	// ModelCacheDir is the directory on the nodes to cache the models.
+	ModelCacheDir string
+	// ModelCacheMaxSize is the max size of the model cache on each node.
+	ModelCacheMaxSize string
+	// TerminationGracePeriodSeconds is the default grace period of the pods.
+	TerminationGracePeriodSeconds int64
+	// PreStopSleepSeconds is the default delay of SIGTERM in the preStop
+	// hook, 0 disables the hook. It is off by default since the hook needs
+	// a shell in the image.
+	PreStopSleepSeconds int32
+	// DisruptionBudgetMaxUnavailable is the default max unavailable replicas
+	// of the PodDisruptionBudget.
+	DisruptionBudgetMaxUnavailable int32
 	// SetNonRootUser will override the function image user to ensure that it is not root. When
 	// true, the user will set to 12000 for all functions.
 	SetNonRootUser bool
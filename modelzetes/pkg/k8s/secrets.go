@@ -15,6 +15,7 @@ import (
 	"github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	typedV1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -25,6 +26,9 @@ const (
 	secretLabel                  = "app.kubernetes.io/managed-by"
 	secretLabelValue             = "modelz"
 	secretsProjectVolumeNameTmpl = "projected-secrets"
+
+	// ManagedSecretSelector selects the secrets managed by modelz.
+	ManagedSecretSelector = secretLabel + "=" + secretLabelValue
 )
 
 // SecretsClient exposes the standardized CRUD behaviors for Kubernetes secrets.  These methods
@@ -37,10 +41,12 @@ type SecretsClient interface {
 	// Create adds a new secret, with the appropriate labels and structure to be
 	// used as a function secret.
 	Create(secret types.Secret) error
-	// Replace updates the value of a function secret
+	// Replace updates the value of a function secret, the secrets not
+	// labelled by modelz are reported as not found.
 	Replace(secret types.Secret) error
-	// Delete removes a function secret
-	Delete(name string, namespace string) error
+	// Delete removes a function secret, the secrets not labelled by modelz
+	// are reported as not found.
+	Delete(namespace string, name string) error
 	// GetSecrets queries Kubernetes for a list of secrets by name in the given k8s namespace.
 	// This should only be used if you need access to the actual secret structure/value. Specifically,
 	// inside the FunctionFactory.
@@ -120,7 +126,7 @@ func (c secretClient) Replace(secret types.Secret) error {
 	}
 
 	kube := c.kube.Secrets(secret.Namespace)
-	found, err := kube.Get(context.TODO(), secret.Name, metav1.GetOptions{})
+	found, err := c.getManaged(secret.Namespace, secret.Name)
 	if err != nil {
 		log.Printf("can not retrieve secret for update %s.%s: %v\n", secret.Name, secret.Namespace, err)
 		return err
@@ -138,7 +144,17 @@ func (c secretClient) Replace(secret types.Secret) error {
 }
 
 func (c secretClient) Delete(namespace string, name string) error {
-	err := c.kube.Secrets(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	found, err := c.getManaged(namespace, name)
+	if err != nil {
+		log.Printf("can not retrieve secret for delete %s.%s: %v\n", name, namespace, err)
+		return err
+	}
+
+	// The precondition makes sure the secret is not replaced by an
+	// unmanaged one with the same name after the check.
+	err = c.kube.Secrets(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{
+		Preconditions: metav1.NewUIDPreconditions(string(found.UID)),
+	})
 	if err != nil {
 		log.Printf("can not delete %s.%s: %v\n", name, namespace, err)
 	}
@@ -161,9 +177,23 @@ func (c secretClient) GetSecrets(namespace string, secretNames []string) (map[st
 	return secrets, nil
 }
 
+// getManaged returns the secret if it is managed by modelz. The other
+// secrets in the namespace, e.g. the service account tokens, are reported as
+// not found, so that they are never changed through the modelz API.
+func (c secretClient) getManaged(namespace, name string) (*apiv1.Secret, error) {
+	secret, err := c.kube.Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if secret.Labels[secretLabel] != secretLabelValue {
+		return nil, k8serrors.NewNotFound(apiv1.Resource("secrets"), name)
+	}
+	return secret, nil
+}
+
 func (c secretClient) selector() metav1.ListOptions {
 	return metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("%s=%s", secretLabel, secretLabelValue),
+		LabelSelector: ManagedSecretSelector,
 	}
 }
 
@@ -181,6 +211,14 @@ func (c secretClient) validateSecret(secret types.Secret) error {
 
 func (c secretClient) getValidSecretData(secret types.Secret) map[string][]byte {
 
+	if len(secret.Data) > 0 {
+		data := make(map[string][]byte, len(secret.Data))
+		for key, value := range secret.Data {
+			data[key] = value
+		}
+		return data
+	}
+
 	if len(secret.RawValue) > 0 {
 		return map[string][]byte{
 			secret.Name: secret.RawValue,
@@ -224,6 +262,11 @@ func (f *FunctionFactory) ConfigureSecrets(request v2alpha1.Inference, deploymen
 			for secretKey := range deployedSecret.Data {
 				projectedPaths = append(projectedPaths, apiv1.KeyToPath{Key: secretKey, Path: secretKey})
 			}
+			// Keep the pod template stable, otherwise every update of the
+			// deployment rolls out a new replica set.
+			sort.Slice(projectedPaths, func(i, j int) bool {
+				return projectedPaths[i].Key < projectedPaths[j].Key
+			})
 
 			projection := &apiv1.SecretProjection{Items: projectedPaths}
 			projection.Name = secretName
@@ -0,0 +1,55 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	types "github.com/tensorchord/openmodelz/agent/api/types"
+)
+
+func Test_secretClient_OnlyChangesManagedSecrets(t *testing.T) {
+	kube := fake.NewSimpleClientset(
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "hf-token",
+				Namespace: "default",
+				Labels:    map[string]string{secretLabel: secretLabelValue},
+			},
+			Data: map[string][]byte{"hf-token": []byte("old")},
+		},
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "default"},
+			Data:       map[string][]byte{"registry": []byte("unmanaged")},
+		},
+	)
+	client := NewSecretsClient(kube)
+
+	if err := client.Replace(types.Secret{
+		Name: "registry", Namespace: "default", Value: "new"}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the unmanaged secret not to be replaced, got %v", err)
+	}
+	if err := client.Delete("default", "registry"); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the unmanaged secret not to be deleted, got %v", err)
+	}
+	unmanaged, err := kube.CoreV1().Secrets("default").Get(context.TODO(), "registry", metav1.GetOptions{})
+	if err != nil || string(unmanaged.Data["registry"]) != "unmanaged" {
+		t.Errorf("expected the unmanaged secret to be kept, got %v", err)
+	}
+
+	if err := client.Replace(types.Secret{
+		Name: "hf-token", Namespace: "default", Value: "new"}); err != nil {
+		t.Fatal(err)
+	}
+	managed, err := kube.CoreV1().Secrets("default").Get(context.TODO(), "hf-token", metav1.GetOptions{})
+	if err != nil || string(managed.Data["hf-token"]) != "new" {
+		t.Errorf("expected the managed secret to be replaced, got %v", err)
+	}
+	if err := client.Delete("default", "hf-token"); err != nil {
+		t.Fatal(err)
+	}
+}
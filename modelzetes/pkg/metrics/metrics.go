@@ -0,0 +1,53 @@
+// Package metrics exposes the prometheus metrics and the health endpoints
+// of the controllers, it is shared by modelzetes and the ingress operator.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+var (
+	// ReconcileTotal counts the reconciles by the result.
+	ReconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "controller",
+			Name:      "reconcile_total",
+			Help:      "Count of the reconciles by the controller and the result",
+		},
+		[]string{"controller", "result"},
+	)
+
+	// ReconcileDuration is the time taken by one reconcile.
+	ReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "controller",
+			Name:      "reconcile_duration_seconds",
+			Help:      "Time taken to reconcile an object by the controller and the result",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+		},
+		[]string{"controller", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(ReconcileTotal, ReconcileDuration, pendingCollector{})
+}
+
+// ObserveReconcile records the reconcile started at start, the result is
+// an error if err is not nil.
+func ObserveReconcile(controller string, start time.Time, err error) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultError
+	}
+	ReconcileTotal.WithLabelValues(controller, result).Inc()
+	ReconcileDuration.WithLabelValues(controller, result).
+		Observe(time.Since(start).Seconds())
+}
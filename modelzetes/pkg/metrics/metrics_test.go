@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_Pending(t *testing.T) {
+	p := NewPending("test-pending")
+	if p.Oldest() != 0 {
+		t.Fatalf("expected no pending objects, got %s", p.Oldest())
+	}
+
+	// The objects are not tracked before the workers start.
+	p.Add("default/a")
+	if p.Oldest() != 0 {
+		t.Fatalf("expected no pending objects before start, got %s", p.Oldest())
+	}
+
+	p.Start()
+	p.Add("default/a")
+	time.Sleep(10 * time.Millisecond)
+	oldest := p.Oldest()
+	// Adding the pending object again keeps the first time.
+	p.Add("default/a")
+	p.Add("default/b")
+	if p.Oldest() < oldest {
+		t.Errorf("expected the oldest to be kept, got %s < %s", p.Oldest(), oldest)
+	}
+
+	p.Done("default/a")
+	if p.Oldest() >= oldest {
+		t.Errorf("expected the oldest to be removed, got %s", p.Oldest())
+	}
+	p.Done("default/b")
+	if p.Oldest() != 0 {
+		t.Errorf("expected no pending objects, got %s", p.Oldest())
+	}
+
+	var nilPending *Pending
+	nilPending.Start()
+	nilPending.Add("default/a")
+	nilPending.Done("default/a")
+}
+
+func Test_ObserveReconcile(t *testing.T) {
+	ObserveReconcile("test-observe", time.Now(), nil)
+	ObserveReconcile("test-observe", time.Now(), errors.New("failed"))
+	ObserveReconcile("test-observe", time.Now(), errors.New("failed"))
+	if v := testutil.ToFloat64(ReconcileTotal.WithLabelValues("test-observe", ResultSuccess)); v != 1 {
+		t.Errorf("expected 1 successful reconcile, got %v", v)
+	}
+	if v := testutil.ToFloat64(ReconcileTotal.WithLabelValues("test-observe", ResultError)); v != 2 {
+		t.Errorf("expected 2 failed reconciles, got %v", v)
+	}
+}
+
+func Test_Server(t *testing.T) {
+	s := NewServer(0)
+	get := func(path string) (int, string) {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	if code, _ := get("/healthz"); code != http.StatusOK {
+		t.Errorf("expected healthz to be ok, got %d", code)
+	}
+	if code, _ := get("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("expected not ready without informers, got %d", code)
+	}
+
+	synced := false
+	s.AddInformer("inferences", func() bool { return synced })
+	s.AddInformer("pods", func() bool { return true })
+	code, body := get("/readyz")
+	if code != http.StatusServiceUnavailable || !strings.Contains(body, "inferences") ||
+		strings.Contains(body, "pods") {
+		t.Errorf("expected inferences not synced, got %d %s", code, body)
+	}
+
+	synced = true
+	if code, _ := get("/readyz"); code != http.StatusOK {
+		t.Errorf("expected ready, got %d", code)
+	}
+
+	NewPending("test-server").Add("default/a")
+	code, body = get("/metrics")
+	if code != http.StatusOK ||
+		!strings.Contains(body, `controller_oldest_unreconciled_seconds{controller="test-server"}`) {
+		t.Errorf("expected the oldest unreconciled metric, got %d", code)
+	}
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var oldestUnreconciledDesc = prometheus.NewDesc(
+	"controller_oldest_unreconciled_seconds",
+	"Age of the oldest object which is changed but not reconciled successfully yet",
+	[]string{"controller"}, nil,
+)
+
+var (
+	pendingMu sync.Mutex
+	pendings  = map[string]*Pending{}
+)
+
+// Pending tracks the objects which are enqueued but not reconciled
+// successfully yet. The methods are no-op on the nil Pending.
+type Pending struct {
+	controller string
+
+	mu    sync.Mutex
+	since map[string]time.Time
+	// started is set once the workers run, the standby replica of the
+	// leader election enqueues the objects without reconciling them.
+	started bool
+}
+
+// NewPending returns the Pending of the controller, its oldest object is
+// exported as controller_oldest_unreconciled_seconds.
+func NewPending(controller string) *Pending {
+	p := &Pending{
+		controller: controller,
+		since:      map[string]time.Time{},
+	}
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	pendings[controller] = p
+	return p
+}
+
+// Start begins tracking the objects, it is called when the workers start.
+func (p *Pending) Start() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started = true
+}
+
+// Add records the object if it is not pending already. It is ignored until
+// the Pending is started.
+func (p *Pending) Add(key string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.started {
+		return
+	}
+	if _, ok := p.since[key]; !ok {
+		p.since[key] = time.Now()
+	}
+}
+
+// Done removes the reconciled object.
+func (p *Pending) Done(key string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.since, key)
+}
+
+// Oldest returns the age of the oldest pending object, 0 if there is none.
+func (p *Pending) Oldest() time.Duration {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var oldest time.Duration
+	for _, since := range p.since {
+		if age := time.Since(since); age > oldest {
+			oldest = age
+		}
+	}
+	return oldest
+}
+
+// pendingCollector computes the age of the oldest objects when scraped.
+type pendingCollector struct{}
+
+func (pendingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- oldestUnreconciledDesc
+}
+
+func (pendingCollector) Collect(ch chan<- prometheus.Metric) {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	for controller, p := range pendings {
+		ch <- prometheus.MustNewConstMetric(oldestUnreconciledDesc,
+			prometheus.GaugeValue, p.Oldest().Seconds(), controller)
+	}
+}
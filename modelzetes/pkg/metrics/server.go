@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/client-go/tools/cache"
+	glog "k8s.io/klog"
+)
+
+// Server serves the prometheus metrics on /metrics, the liveness on
+// /healthz and the readiness on /readyz. The controller is ready once all
+// the added informers are synced.
+type Server struct {
+	port int
+
+	mu        sync.RWMutex
+	informers map[string]cache.InformerSynced
+}
+
+func NewServer(port int) *Server {
+	return &Server{
+		port:      port,
+		informers: map[string]cache.InformerSynced{},
+	}
+}
+
+// AddInformer adds the informer to the readiness check.
+func (s *Server) AddInformer(name string, synced cache.InformerSynced) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.informers[name] = synced
+}
+
+// Unsynced returns the names of the informers which are not synced yet in
+// order.
+func (s *Server) Unsynced() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := []string{}
+	for name, synced := range s.informers {
+		if !synced() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Handler returns the http handler of the metrics and health endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", s.healthz)
+	mux.HandleFunc("/readyz", s.readyz)
+	return mux
+}
+
+// healthz reports the process is alive. It does not wait for the informers,
+// the initial sync of a large cluster should not restart the controller.
+func (s *Server) healthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (s *Server) readyz(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	registered := len(s.informers)
+	s.mu.RUnlock()
+	if registered == 0 {
+		http.Error(w, "informers are not started", http.StatusServiceUnavailable)
+		return
+	}
+	if unsynced := s.Unsynced(); len(unsynced) > 0 {
+		http.Error(w, fmt.Sprintf("informers are not synced: %s",
+			strings.Join(unsynced, ", ")), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// Run serves the endpoints until stopCh is closed.
+func (s *Server) Run(stopCh <-chan struct{}) error {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-stopCh
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	glog.Infof("Starting metrics server on :%d", s.port)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cacheEntry is a directory of the downloaded model in the cache.
+type cacheEntry struct {
+	dir     string
+	size    int64
+	modTime time.Time
+}
+
+// evict removes the least recently used models until the cache fits in
+// MaxCacheBytes. The model just downloaded into keep is never evicted. The
+// models are touched when the pods start, thus the ones used by the running
+// pods are kept unless the limit is smaller than them.
+func (d Downloader) evict(keep string) error {
+	if d.MaxCacheBytes <= 0 {
+		return nil
+	}
+
+	// The models are stored in <namespace>/<type>/<id>/<revision>.
+	matches, err := filepath.Glob(filepath.Join(d.CacheDir, "*", "*", "*", "*"))
+	if err != nil {
+		return err
+	}
+	// The dot entries, e.g. the downloads in progress in tmpDir, are not
+	// models and may be used by the other pods on the node.
+	dirs := []string{}
+	for _, dir := range matches {
+		if !isHidden(d.CacheDir, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	entries := []cacheEntry{}
+	var total int64
+	for _, dir := range dirs {
+		info, err := os.Lstat(dir)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		size, err := dirSize(dir)
+		if err != nil {
+			return err
+		}
+		total += size
+		entries = append(entries, cacheEntry{dir: dir, size: size, modTime: info.ModTime()})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	for _, e := range entries {
+		if total <= d.MaxCacheBytes {
+			break
+		}
+		if e.dir == keep {
+			continue
+		}
+		d.printf("Evicting %s (%s) from the cache\n", e.dir, formatBytes(e.size))
+		if err := os.RemoveAll(e.dir); err != nil {
+			return err
+		}
+		total -= e.size
+	}
+
+	// Remove the symlinks of the revisions whose commits are evicted.
+	for _, dir := range dirs {
+		if info, err := os.Lstat(dir); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				_ = os.Remove(dir)
+			}
+		}
+	}
+	return nil
+}
+
+// isHidden returns true if any element of the path in the cache starts
+// with a dot.
+func isHidden(cacheDir, dir string) bool {
+	rel, err := filepath.Rel(cacheDir, dir)
+	if err != nil {
+		return true
+	}
+	for _, elem := range strings.Split(rel, string(filepath.Separator)) {
+		if strings.HasPrefix(elem, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// dirSize returns the size of the regular files in the directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// touch updates the modification time of the model, which is used to evict
+// the least recently used ones.
+func touch(dir string) error {
+	now := time.Now()
+	return os.Chtimes(dir, now, now)
+}
+
+// replaceSymlink points the symlink to the target atomically.
+func replaceSymlink(target, link string) error {
+	if current, err := os.Readlink(link); err == nil && current == target {
+		return nil
+	}
+	tmp := fmt.Sprintf("%s.tmp-%d", link, time.Now().UnixNano())
+	if err := os.Symlink(target, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHuggingFaceEndpoint is used if the huggingface proxy is not
+	// configured.
+	DefaultHuggingFaceEndpoint = "https://huggingface.co"
+	// DefaultS3Endpoint is used if the endpoint of the source is not set.
+	DefaultS3Endpoint = "https://s3.amazonaws.com"
+
+	tmpDir           = ".tmp"
+	progressInterval = 5 * time.Second
+)
+
+// Downloader downloads the model artifacts into the cache directory. The
+// download is skipped if the model is already cached.
+type Downloader struct {
+	// CacheDir is the root of the cache, the models are stored in the
+	// directories of their cache keys.
+	CacheDir string
+	// HuggingFaceEndpoint is the endpoint of the huggingface API.
+	HuggingFaceEndpoint string
+	// HuggingFaceToken is the token used to download private repositories.
+	HuggingFaceToken string
+	// S3Credentials are used to sign the requests to s3, the requests are
+	// anonymous if they are not set.
+	S3Credentials S3Credentials
+	// MaxCacheBytes is the max size of the cache, the least recently used
+	// models are evicted after a download exceeds it. The cache is not
+	// limited if it is 0.
+	MaxCacheBytes int64
+	// Progress receives the progress of the download line by line.
+	Progress io.Writer
+	// Report receives the latest progress of the download periodically.
+	Report func(progress string)
+	Client *http.Client
+}
+
+// Download downloads the model if it is not cached and returns the directory
+// of the model. A mutable huggingface revision is resolved to the commit
+// every time, which also checks that the token could access the repository.
+func (d Downloader) Download(ctx context.Context, source Source) (string, error) {
+	if err := source.Validate(); err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(d.CacheDir, filepath.FromSlash(source.CacheKey()))
+	if source.Type != TypeHuggingFace || isCommit(source.Revision) {
+		return dir, d.download(ctx, source, dir)
+	}
+
+	info, err := d.huggingFaceModel(ctx, source)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the revision of %s: %w", source.ID(), err)
+	}
+	if !isCommit(info.SHA) {
+		return "", fmt.Errorf("failed to resolve the revision of %s: invalid commit %q",
+			source.ID(), info.SHA)
+	}
+	pinned := source
+	pinned.Revision = info.SHA
+	if err := d.download(ctx, pinned, filepath.Join(
+		filepath.Dir(dir), keyComponent(info.SHA))); err != nil {
+		return "", err
+	}
+	// The pods mount the revision by the sub path, the symlink is resolved
+	// when they start, thus the running pods keep the commit they started
+	// with.
+	if err := replaceSymlink(keyComponent(info.SHA), dir); err != nil {
+		return "", err
+	}
+	d.printf("Revision %s of %s is resolved to %s\n",
+		source.revision(), source.ID(), info.SHA)
+	return dir, nil
+}
+
+// download downloads the model into the directory if it does not exist.
+func (d Downloader) download(ctx context.Context, source Source, dir string) error {
+	if _, err := os.Stat(dir); err == nil {
+		d.printf("Model %s is cached in %s\n", source.ID(), dir)
+		return touch(dir)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	// Download into a temporary directory and rename it at the end, so that
+	// the pods on the same node never see a partial model.
+	if err := os.MkdirAll(filepath.Join(d.CacheDir, tmpDir), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Join(d.CacheDir, tmpDir), "download-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	progress := newProgress(d.Progress, d.Report, source.ID())
+	switch source.Type {
+	case TypeHuggingFace:
+		err = d.downloadHuggingFace(ctx, source, tmp, progress)
+	case TypeS3:
+		err = d.downloadS3(ctx, source, tmp, progress)
+	case TypeHTTP:
+		err = d.downloadHTTP(ctx, source, tmp, progress)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", source.ID(), err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		// Another pod on the node has finished the download first.
+		if _, statErr := os.Stat(dir); statErr == nil {
+			d.printf("Model %s is cached in %s\n", source.ID(), dir)
+			return nil
+		}
+		return err
+	}
+	progress.done()
+	d.printf("Downloaded %s into %s\n", source.ID(), dir)
+	return d.evict(dir)
+}
+
+func (d Downloader) client() *http.Client {
+	if d.Client != nil {
+		return d.Client
+	}
+	return http.DefaultClient
+}
+
+func (d Downloader) printf(format string, args ...interface{}) {
+	if d.Progress != nil {
+		fmt.Fprintf(d.Progress, format, args...)
+	}
+}
+
+// get sends the GET request and returns the body if the status is 200.
+func (d Downloader) get(ctx context.Context, url string,
+	header http.Header, sign func(*http.Request)) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if sign != nil {
+		sign(req)
+	}
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s: %s", url, resp.Status,
+			strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// writeFile writes the content into the file under the directory.
+func writeFile(dir, name string, r io.Reader) error {
+	path, err := safeJoin(dir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// safeJoin joins the relative name to the directory, the names escaping the
+// directory are rejected.
+func safeJoin(dir, name string) (string, error) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if path == dir || !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", errors.New("invalid file name " + name)
+	}
+	return path, nil
+}
+
+// progress prints and reports the progress of the download periodically.
+type progress struct {
+	mu          sync.Mutex
+	w           io.Writer
+	report      func(string)
+	id          string
+	files       int
+	totalFiles  int
+	bytes       int64
+	totalBytes  int64
+	lastPrinted time.Time
+}
+
+func newProgress(w io.Writer, report func(string), id string) *progress {
+	return &progress{w: w, report: report, id: id, lastPrinted: time.Now()}
+}
+
+// add adds the expected files and bytes to download.
+func (p *progress) add(files int, bytes int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.totalFiles += files
+	p.totalBytes += bytes
+}
+
+// fileDone marks a file as downloaded.
+func (p *progress) fileDone() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.files++
+	p.printLocked(false)
+}
+
+// reader counts the bytes read from r.
+func (p *progress) reader(r io.Reader) io.Reader {
+	return &progressReader{r: r, p: p}
+}
+
+func (p *progress) done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.printLocked(true)
+}
+
+func (p *progress) printLocked(force bool) {
+	if (p.w == nil && p.report == nil) ||
+		(!force && time.Since(p.lastPrinted) < progressInterval) {
+		return
+	}
+	p.lastPrinted = time.Now()
+	msg := fmt.Sprintf("Downloading %s: %s", p.id, formatBytes(p.bytes))
+	if p.totalBytes > 0 {
+		msg += fmt.Sprintf("/%s (%d%%)", formatBytes(p.totalBytes),
+			p.bytes*100/p.totalBytes)
+	}
+	if p.totalFiles > 0 {
+		msg += fmt.Sprintf(", %d/%d files", p.files, p.totalFiles)
+	}
+	if p.w != nil {
+		fmt.Fprintln(p.w, msg)
+	}
+	if p.report != nil {
+		p.report(msg)
+	}
+}
+
+type progressReader struct {
+	r io.Reader
+	p *progress
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.p.mu.Lock()
+	r.p.bytes += int64(n)
+	r.p.printLocked(false)
+	r.p.mu.Unlock()
+	return n, err
+}
+
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
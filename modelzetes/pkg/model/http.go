@@ -0,0 +1,79 @@
+package model
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// downloadHTTP downloads the file of the URL. The tarballs are extracted
+// into the directory.
+func (d Downloader) downloadHTTP(ctx context.Context,
+	source Source, dir string, p *progress) error {
+	resp, err := d.get(ctx, source.URI, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	p.add(1, resp.ContentLength)
+	body := p.reader(resp.Body)
+
+	u, err := url.Parse(source.URI)
+	if err != nil {
+		return err
+	}
+	name := path.Base(u.Path)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		err = extractTar(dir, gz)
+		if err != nil {
+			return err
+		}
+	case strings.HasSuffix(name, ".tar"):
+		if err := extractTar(dir, body); err != nil {
+			return err
+		}
+	default:
+		if name == "/" || name == "." {
+			name = "model"
+		}
+		if err := writeFile(dir, name, body); err != nil {
+			return err
+		}
+	}
+	p.fileDone()
+	return nil
+}
+
+// extractTar extracts the regular files and directories of the tarball.
+func extractTar(dir string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeReg:
+			if err := writeFile(dir, hdr.Name, tr); err != nil {
+				return err
+			}
+		default:
+			// Directories are created with the files, links are skipped
+			// since they may point outside of the cache.
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// huggingFaceModel is the model info returned by the huggingface API.
+type huggingFaceModel struct {
+	SHA      string `json:"sha"`
+	Siblings []struct {
+		RFilename string `json:"rfilename"`
+		Size      int64  `json:"size"`
+	} `json:"siblings"`
+}
+
+// huggingFaceModel returns the files and the commit of the repository at
+// the revision. The request fails if the token has no access to the
+// repository.
+func (d Downloader) huggingFaceModel(ctx context.Context,
+	source Source) (huggingFaceModel, error) {
+	info := huggingFaceModel{}
+	resp, err := d.get(ctx, fmt.Sprintf("%s/api/models/%s/revision/%s?blobs=true",
+		d.huggingFaceEndpoint(), strings.Trim(source.URI, "/"),
+		url.PathEscape(source.revision())), d.huggingFaceHeader(), nil)
+	if err != nil {
+		return info, err
+	}
+	err = json.NewDecoder(resp.Body).Decode(&info)
+	resp.Body.Close()
+	if err != nil {
+		return info, fmt.Errorf("failed to decode the model info: %w", err)
+	}
+	return info, nil
+}
+
+// downloadHuggingFace downloads all files of the repository at the revision.
+func (d Downloader) downloadHuggingFace(ctx context.Context,
+	source Source, dir string, p *progress) error {
+	info, err := d.huggingFaceModel(ctx, source)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range info.Siblings {
+		p.add(1, s.Size)
+	}
+	// Pin the files to the commit, the branch may move during the download.
+	revision := source.revision()
+	if info.SHA != "" {
+		revision = info.SHA
+	}
+	repo := strings.Trim(source.URI, "/")
+	for _, s := range info.Siblings {
+		resp, err := d.get(ctx, fmt.Sprintf("%s/%s/resolve/%s/%s",
+			d.huggingFaceEndpoint(), repo, url.PathEscape(revision),
+			escapePath(s.RFilename)), d.huggingFaceHeader(), nil)
+		if err != nil {
+			return err
+		}
+		err = writeFile(dir, s.RFilename, p.reader(resp.Body))
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		p.fileDone()
+	}
+	return nil
+}
+
+func (d Downloader) huggingFaceEndpoint() string {
+	endpoint := strings.TrimSuffix(d.HuggingFaceEndpoint, "/")
+	if endpoint == "" {
+		return DefaultHuggingFaceEndpoint
+	}
+	return endpoint
+}
+
+func (d Downloader) huggingFaceHeader() http.Header {
+	header := http.Header{}
+	if d.HuggingFaceToken != "" {
+		header.Set("Authorization", "Bearer "+d.HuggingFaceToken)
+	}
+	return header
+}
+
+// escapePath escapes the segments of the path.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,260 @@
+package model
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// S3Credentials are the credentials of the S3 compatible storage.
+type S3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// Region is used in the signature. Default is us-east-1.
+	Region string
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key  string `xml:"Key"`
+		Size int64  `xml:"Size"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+type s3Object struct {
+	key  string
+	size int64
+}
+
+// downloadS3 downloads all objects under the prefix with path-style
+// requests, so that it works with the S3 compatible storages like minio.
+func (d Downloader) downloadS3(ctx context.Context,
+	source Source, dir string, p *progress) error {
+	u, err := url.Parse(source.URI)
+	if err != nil {
+		return err
+	}
+	endpoint := strings.TrimSuffix(source.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = DefaultS3Endpoint
+	}
+	bucket := u.Host
+	prefix := strings.TrimPrefix(u.Path, "/")
+
+	objects, err := d.listS3(ctx, endpoint, bucket, prefix)
+	if err != nil {
+		return err
+	}
+	// The prefix is a directory or the key of a single object.
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		filtered := []s3Object{}
+		for _, o := range objects {
+			if o.key == prefix || strings.HasPrefix(o.key, prefix+"/") {
+				filtered = append(filtered, o)
+			}
+		}
+		objects = filtered
+	}
+	if len(objects) == 0 {
+		return fmt.Errorf("no objects found in %s", source.URI)
+	}
+	for _, o := range objects {
+		p.add(1, o.size)
+	}
+
+	for _, o := range objects {
+		resp, err := d.get(ctx, S3ObjectURL(endpoint, bucket, o.key), nil, d.signS3)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(o.key, prefix), "/")
+		if name == "" {
+			name = o.key[strings.LastIndex(o.key, "/")+1:]
+		}
+		err = writeFile(dir, name, p.reader(resp.Body))
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		p.fileDone()
+	}
+	return nil
+}
+
+// listS3 lists the objects under the prefix with ListObjectsV2.
+func (d Downloader) listS3(ctx context.Context,
+	endpoint, bucket, prefix string) ([]s3Object, error) {
+	objects := []s3Object{}
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := d.get(ctx, fmt.Sprintf("%s/%s?%s", endpoint, bucket,
+			s3Query(query)), nil, d.signS3)
+		if err != nil {
+			return nil, err
+		}
+		result := listBucketResult{}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the objects: %w", err)
+		}
+
+		for _, c := range result.Contents {
+			// Skip the directory markers.
+			if strings.HasSuffix(c.Key, "/") {
+				continue
+			}
+			objects = append(objects, s3Object{key: c.Key, size: c.Size})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// signS3 signs the request with AWS signature version 4 if the credentials
+// are set.
+func (d Downloader) signS3(req *http.Request) {
+	signS3(req, d.S3Credentials, time.Now())
+}
+
+// SignS3 signs the request with AWS signature version 4 if the credentials
+// are set, the payload is not signed. It is shared by the batch runner which
+// reads and writes the objects.
+func SignS3(req *http.Request, cred S3Credentials) {
+	signS3(req, cred, time.Now())
+}
+
+// S3ObjectURL returns the path-style URL of the object.
+func S3ObjectURL(endpoint, bucket, key string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(endpoint, "/"), bucket,
+		s3Escape(key, false))
+}
+
+func signS3(req *http.Request, cred S3Credentials, now time.Time) {
+	if cred.AccessKeyID == "" || cred.SecretAccessKey == "" {
+		return
+	}
+	region := cred.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	payloadHash := req.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		payloadHash = unsignedPayload
+		req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+	if cred.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", cred.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k := range req.Header {
+		lower := strings.ToLower(k)
+		if strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(req.Header.Get(k))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	canonicalHeaders := ""
+	for _, k := range names {
+		canonicalHeaders += k + ":" + headers[k] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3Escape(req.URL.Path, false),
+		s3Query(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" +
+		hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+cred.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		cred.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3Query returns the canonical query string.
+func s3Query(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := []string{}
+	for _, k := range keys {
+		for _, v := range query[k] {
+			pairs = append(pairs, s3Escape(k, true)+"="+s3Escape(v, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape escapes the value with the URI encoding of AWS, which only keeps
+// the unreserved characters. The slashes are kept in the paths.
+func s3Escape(value string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+// Package model downloads the model artifacts of the inferences into the
+// per-node cache. It is run by the init container of the inference pods.
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	TypeHuggingFace = "huggingface"
+	TypeS3          = "s3"
+	TypeHTTP        = "http"
+
+	// DefaultRevision is the revision of the huggingface repositories.
+	DefaultRevision = "main"
+	// DefaultMountPath is the path of the model in the inference container.
+	DefaultMountPath = "/models"
+
+	// latestRevision is used in the cache key of the s3 and http sources
+	// without revision.
+	latestRevision = "latest"
+	// maxKeyLength is the max length of a component of the cache key.
+	maxKeyLength = 128
+)
+
+var (
+	invalidKeyChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	commitRegexp    = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// Source is the source of the model artifacts.
+type Source struct {
+	Type     string
+	URI      string
+	Revision string
+	Endpoint string
+	// Namespace is the namespace of the inference. The models are cached
+	// per namespace, since they are downloaded with its credentials.
+	Namespace string
+}
+
+// ID returns the ID of the model, e.g. the repository of huggingface.
+func (s Source) ID() string {
+	switch s.Type {
+	case TypeS3, TypeHTTP:
+		u, err := url.Parse(s.URI)
+		if err != nil {
+			return s.URI
+		}
+		return path.Join(u.Host, u.Path)
+	default:
+		return strings.Trim(s.URI, "/")
+	}
+}
+
+// CacheKey returns the relative directory of the model in the cache, which is
+// keyed by the namespace, the endpoint, the model ID and the revision. The
+// directory of a mutable huggingface revision, e.g. main, is a symlink to the
+// directory of the commit it is resolved to.
+func (s Source) CacheKey() string {
+	id := s.ID()
+	if s.Endpoint != "" {
+		if u, err := url.Parse(s.Endpoint); err == nil && u.Host != "" {
+			id = path.Join(u.Host, id)
+		}
+	}
+	return path.Join(keyComponent(s.Namespace), s.Type,
+		keyComponent(id), keyComponent(s.revision()))
+}
+
+// revision returns the revision of the source, or the default one if it is
+// not set.
+func (s Source) revision() string {
+	switch {
+	case s.Revision != "":
+		return s.Revision
+	case s.Type == TypeHuggingFace:
+		return DefaultRevision
+	default:
+		return latestRevision
+	}
+}
+
+// isCommit returns true if the revision is the sha of a commit, which never
+// changes.
+func isCommit(revision string) bool {
+	return commitRegexp.MatchString(revision)
+}
+
+// Validate validates the source.
+func (s Source) Validate() error {
+	if s.URI == "" {
+		return fmt.Errorf("model.uri: is required")
+	}
+	switch s.Type {
+	case TypeHuggingFace:
+		if strings.Contains(s.URI, "://") {
+			return fmt.Errorf("model.uri: (%s) is invalid, must be a repository ID like org/name", s.URI)
+		}
+	case TypeS3:
+		u, err := url.Parse(s.URI)
+		if err != nil || u.Scheme != "s3" || u.Host == "" {
+			return fmt.Errorf("model.uri: (%s) is invalid, must be in the format of s3://bucket/path", s.URI)
+		}
+	case TypeHTTP:
+		u, err := url.Parse(s.URI)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("model.uri: (%s) is invalid, must be a http or https URL", s.URI)
+		}
+	default:
+		return fmt.Errorf("model.type: (%s) is invalid, must be one of %s, %s or %s",
+			s.Type, TypeHuggingFace, TypeS3, TypeHTTP)
+	}
+	if s.Endpoint != "" {
+		u, err := url.Parse(s.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("model.endpoint: (%s) is invalid, must be a http or https URL", s.Endpoint)
+		}
+	}
+	return nil
+}
+
+// keyComponent converts the value to a single path component, which is used
+// as the sub path of the cache volume.
+func keyComponent(value string) string {
+	key := strings.ReplaceAll(value, "/", "--")
+	key = invalidKeyChars.ReplaceAllString(key, "-")
+	if key == "" || key == "." || key == ".." {
+		key = "_" + key
+	}
+	if len(key) > maxKeyLength {
+		sum := sha256.Sum256([]byte(value))
+		key = key[:maxKeyLength-17] + "-" + hex.EncodeToString(sum[:8])
+	}
+	return key
+}
@@ -0,0 +1,89 @@
+package revision
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+)
+
+// Diff returns the changed fields between the specs in the format of
+// "<field>: <old> -> <new>", sorted by the field. The fields are the JSON
+// paths, e.g. scaling.max_replicas or envVars.HF_TOKEN, and the missing
+// values are <none>. A nil old spec means every field is added.
+func Diff(old, new *v2alpha1.InferenceSpec) ([]string, error) {
+	before := map[string]string{}
+	if old != nil {
+		if err := flattenSpec(old, before); err != nil {
+			return nil, err
+		}
+	}
+	after := map[string]string{}
+	if err := flattenSpec(new, after); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]struct{}{}
+	for k := range before {
+		fields[k] = struct{}{}
+	}
+	for k := range after {
+		fields[k] = struct{}{}
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := []string{}
+	for _, k := range keys {
+		o, hasOld := before[k]
+		n, hasNew := after[k]
+		switch {
+		case hasOld && hasNew && o == n:
+		case !hasOld:
+			res = append(res, fmt.Sprintf("%s: <none> -> %s", k, n))
+		case !hasNew:
+			res = append(res, fmt.Sprintf("%s: %s -> <none>", k, o))
+		default:
+			res = append(res, fmt.Sprintf("%s: %s -> %s", k, o, n))
+		}
+	}
+	return res, nil
+}
+
+func flattenSpec(spec *v2alpha1.InferenceSpec, out map[string]string) error {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	flatten("", value, out)
+	return nil
+}
+
+// flatten collects the leaf values of the decoded JSON by the path.
+func flatten(prefix string, value interface{}, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if prefix == "" {
+				flatten(k, item, out)
+			} else {
+				flatten(prefix+"."+k, item, out)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), item, out)
+		}
+	default:
+		data, _ := json.Marshal(v)
+		out[prefix] = string(data)
+	}
+}
@@ -0,0 +1,99 @@
+// Package revision records the changes of the inference spec as
+// ControllerRevisions owned by the inference. The revisions are created by
+// the controller and read by the agent to show the history and roll back.
+package revision
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+// HistoryLimit is the number of the revisions kept for each inference.
+const HistoryLimit = 10
+
+// New returns the revision of the current spec of the inference. The author
+// and the change cause are copied from the annotations of the inference.
+func New(inference *v2alpha1.Inference, revision int64) (*appsv1.ControllerRevision, error) {
+	data, err := json.Marshal(inference.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := map[string]string{}
+	for _, key := range []string{
+		consts.AnnotationChangeAuthor, consts.AnnotationChangeCause} {
+		if value, ok := inference.Annotations[key]; ok {
+			annotations[key] = value
+		}
+	}
+
+	return &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        Name(inference, revision),
+			Namespace:   inference.Namespace,
+			Labels:      Labels(inference),
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(inference, schema.GroupVersionKind{
+					Group:   v2alpha1.SchemeGroupVersion.Group,
+					Version: v2alpha1.SchemeGroupVersion.Version,
+					Kind:    v2alpha1.Kind,
+				}),
+			},
+		},
+		Data:     runtime.RawExtension{Raw: data},
+		Revision: revision,
+	}, nil
+}
+
+// Name returns the name of the revision. The UID of the inference is hashed
+// into the name, so that it does not conflict with the revisions of a
+// deleted inference with the same name.
+func Name(inference *v2alpha1.Inference, revision int64) string {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s/%d", inference.UID, revision)
+	return fmt.Sprintf("%s-%s", inference.Spec.Name, rand.SafeEncodeString(fmt.Sprint(h.Sum32())))
+}
+
+// Labels returns the labels used to select the revisions of the inference.
+func Labels(inference *v2alpha1.Inference) map[string]string {
+	return map[string]string{
+		consts.LabelInferenceName: inference.Spec.Name,
+	}
+}
+
+// Owned returns the revisions controlled by the inference, sorted by the
+// revision number in ascending order.
+func Owned(inference *v2alpha1.Inference,
+	revisions []*appsv1.ControllerRevision) []*appsv1.ControllerRevision {
+	res := []*appsv1.ControllerRevision{}
+	for _, r := range revisions {
+		if metav1.IsControlledBy(r, inference) {
+			res = append(res, r)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Revision < res[j].Revision
+	})
+	return res
+}
+
+// Spec decodes the inference spec recorded in the revision.
+func Spec(r *appsv1.ControllerRevision) (*v2alpha1.InferenceSpec, error) {
+	spec := &v2alpha1.InferenceSpec{}
+	if err := json.Unmarshal(r.Data.Raw, spec); err != nil {
+		return nil, fmt.Errorf("failed to decode revision %d: %w", r.Revision, err)
+	}
+	return spec, nil
+}
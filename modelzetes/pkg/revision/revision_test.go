@@ -0,0 +1,86 @@
+package revision
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+func Test_NewAndSpec(t *testing.T) {
+	inference := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bloomz",
+			Namespace: "default",
+			UID:       "uid",
+			Annotations: map[string]string{
+				consts.AnnotationChangeAuthor: "alice",
+				consts.AnnotationCrashLoop:    "{}",
+			},
+		},
+		Spec: v2alpha1.InferenceSpec{
+			Name:  "bloomz",
+			Image: "modelzai/bloomz:v1",
+		},
+	}
+
+	r, err := New(inference, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Revision != 3 || !metav1.IsControlledBy(r, inference) {
+		t.Errorf("unexpected revision %+v", r)
+	}
+	if r.Name == Name(inference, 4) {
+		t.Errorf("expected different names of the revisions")
+	}
+	if len(r.Annotations) != 1 || r.Annotations[consts.AnnotationChangeAuthor] != "alice" {
+		t.Errorf("unexpected annotations %v", r.Annotations)
+	}
+
+	spec, err := Spec(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*spec, inference.Spec) {
+		t.Errorf("expected %+v, got %+v", inference.Spec, *spec)
+	}
+}
+
+func Test_Diff(t *testing.T) {
+	old := &v2alpha1.InferenceSpec{
+		Name:    "bloomz",
+		Image:   "modelzai/bloomz:v1",
+		EnvVars: map[string]string{"A": "1", "B": "2"},
+		Scaling: &v2alpha1.ScalingConfig{MaxReplicas: Ptr(int32(1))},
+	}
+	new := &v2alpha1.InferenceSpec{
+		Name:    "bloomz",
+		Image:   "modelzai/bloomz:v2",
+		EnvVars: map[string]string{"A": "1", "C": "3"},
+		Scaling: &v2alpha1.ScalingConfig{MaxReplicas: Ptr(int32(2))},
+	}
+
+	diff, err := Diff(old, new)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`envVars.B: "2" -> <none>`,
+		`envVars.C: <none> -> "3"`,
+		`image: "modelzai/bloomz:v1" -> "modelzai/bloomz:v2"`,
+		`scaling.max_replicas: 1 -> 2`,
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("expected %v, got %v", expected, diff)
+	}
+
+	diff, err = Diff(new, new)
+	if err != nil || len(diff) != 0 {
+		t.Errorf("expected no diff, got %v, %v", diff, err)
+	}
+}
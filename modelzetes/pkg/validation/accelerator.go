@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/accelerator"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+// migProfileRegexp matches the MIG profiles, e.g. 1g.10gb, 1c.2g.20gb or
+// 1g.10gb+me.
+var migProfileRegexp = regexp.MustCompile(`^([0-9]+c\.)?[0-9]+g\.[0-9]+gb(\+me)?$`)
+
+// ValidateAcceleratorResources validates the accelerator resources of the
+// inference. Only one kind of the accelerators could be requested, and the
+// quantities must be integers since the devices could not be shared across
+// the containers.
+func ValidateAcceleratorResources(requests, limits corev1.ResourceList) error {
+	var kind corev1.ResourceName
+	for _, l := range []struct {
+		field string
+		list  corev1.ResourceList
+	}{{"limits", limits}, {"requests", requests}} {
+		field := l.field
+		for name, qty := range l.list {
+			if !accelerator.IsResource(name) {
+				continue
+			}
+			if accelerator.IsMIG(name) {
+				profile := string(name)[len(consts.ResourceNvidiaMIGPrefix):]
+				if !migProfileRegexp.MatchString(profile) {
+					return fmt.Errorf("resources.%s.%s: (%s) is not a valid MIG profile, e.g. mig-1g.10gb",
+						field, accelerator.ShortName(name), profile)
+				}
+			}
+			if qty.MilliValue()%1000 != 0 {
+				return fmt.Errorf("resources.%s.%s: (%s) must be an integer",
+					field, accelerator.ShortName(name), qty.String())
+			}
+			if kind != "" && kind != name {
+				return fmt.Errorf("resources.%s: only one of %s and %s could be requested",
+					field, accelerator.ShortName(kind), accelerator.ShortName(name))
+			}
+			kind = name
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	DefaultBatchParallelism  = 1
+	DefaultBatchMaxRetries   = 3
+	DefaultBatchBackoffLimit = 3
+	// MaxBatchParallelism is the maximum number of the shards of a batch
+	// inference.
+	MaxBatchParallelism = 100
+)
+
+// ValidateBatchLocation validates the input or output location of the batch
+// inference. The location is on the object store if the uri is
+// s3://<bucket>/<prefix>, otherwise it is the path in the volume claim.
+func ValidateBatchLocation(field, uri, volumeClaim string) error {
+	if uri == "" {
+		return fmt.Errorf("%s.uri: is required", field)
+	}
+	if strings.HasPrefix(uri, "s3://") {
+		u, err := url.Parse(uri)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("%s.uri: (%s) is invalid, must be s3://<bucket>/<prefix>", field, uri)
+		}
+		if volumeClaim != "" {
+			return fmt.Errorf("%s.volume_claim: must not be set with the object store", field)
+		}
+		return nil
+	}
+	if strings.Contains(uri, "://") {
+		return fmt.Errorf("%s.uri: (%s) is invalid, must be s3://<bucket>/<prefix> or the path in the volume claim",
+			field, uri)
+	}
+	if volumeClaim == "" {
+		return fmt.Errorf("%s.volume_claim: is required if the uri is not on the object store", field)
+	}
+	if strings.Contains(uri, "..") {
+		return fmt.Errorf("%s.uri: (%s) must not contain ..", field, uri)
+	}
+	return nil
+}
+
+// ValidateBatchInput validates that the input is either a JSONL file or a
+// list of files.
+func ValidateBatchInput(jsonl string, files []string) error {
+	if jsonl == "" && len(files) == 0 {
+		return fmt.Errorf("input: one of jsonl and files is required")
+	}
+	if jsonl != "" && len(files) > 0 {
+		return fmt.Errorf("input: only one of jsonl and files could be set")
+	}
+	for _, f := range files {
+		if f == "" || strings.HasPrefix(f, "/") || strings.Contains(f, "..") {
+			return fmt.Errorf("input.files: (%s) is invalid, must be a relative path", f)
+		}
+	}
+	return nil
+}
+
+// ValidateBatchLimits validates the parallelism and the retries of the batch
+// inference.
+func ValidateBatchLimits(parallelism, maxRetries, backoffLimit *int32) error {
+	if parallelism != nil && (*parallelism < 1 || *parallelism > MaxBatchParallelism) {
+		return fmt.Errorf("parallelism: (%d) is invalid, must be between 1 and %d",
+			*parallelism, MaxBatchParallelism)
+	}
+	if maxRetries != nil && *maxRetries < 0 {
+		return fmt.Errorf("max_retries: must be greater than or equal to 0")
+	}
+	if backoffLimit != nil && *backoffLimit < 0 {
+		return fmt.Errorf("backoff_limit: must be greater than or equal to 0")
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package validation
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// CustomMetricQuery renders the PromQL template of a custom metric,
+// {{.Name}} and {{.Namespace}} are replaced with the inference name and namespace.
+func CustomMetricQuery(query, name, namespace string) (string, error) {
+	tmpl, err := template.New("query").Option("missingkey=error").Parse(query)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct {
+		Name      string
+		Namespace string
+	}{
+		Name:      name,
+		Namespace: namespace,
+	}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
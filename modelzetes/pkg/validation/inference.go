@@ -0,0 +1,229 @@
+package validation
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+)
+
+// ValidateInferenceSpec validates the spec of the inference in the
+// namespace. The agent converts its requests into the spec, thus the
+// inferences created by the agent, kubectl or GitOps are checked by the same
+// rules. The startup duration is checked against the period of the startup
+// probe, it is 0 if the period is unknown.
+func ValidateInferenceSpec(spec *v2alpha1.InferenceSpec, namespace string,
+	startupProbePeriodSeconds int32) error {
+	if spec.Image == "" {
+		return fmt.Errorf("image: is required")
+	}
+
+	if p := spec.Probes; p != nil && p.Startup != nil && p.Startup.PeriodSeconds != nil {
+		startupProbePeriodSeconds = *p.Startup.PeriodSeconds
+	}
+
+	if spec.Scaling != nil {
+		scaling := spec.Scaling
+		if err := ValidateReplicas(
+			scaling.MinReplicas, scaling.MaxReplicas); err != nil {
+			return err
+		}
+		if err := ValidateDurations(scaling.TargetLoad,
+			scaling.ZeroDuration, scaling.StartupDuration,
+			startupProbePeriodSeconds); err != nil {
+			return err
+		}
+		if scaling.Type != nil && *scaling.Type == v2alpha1.ScalingTypeCustom {
+			if scaling.CustomMetric == nil {
+				return fmt.Errorf("custom_metric: is required for custom scaling type")
+			}
+			if err := ValidateCustomMetric(scaling.CustomMetric.Query,
+				scaling.CustomMetric.TargetValue.String(),
+				spec.Name, namespace); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := ValidateResourceRequirements(spec.Resources); err != nil {
+		return err
+	}
+
+	if err := ValidateFramework(string(spec.Framework),
+		spec.Port, spec.EnvVars); err != nil {
+		return err
+	}
+
+	if spec.Model != nil {
+		if err := ValidateModel(string(spec.Model.Type),
+			spec.Model.URI, spec.Model.Endpoint, spec.Model.MountPath); err != nil {
+			return err
+		}
+	}
+
+	if spec.Scheduling != nil {
+		if err := validateScheduling(spec.Scheduling); err != nil {
+			return err
+		}
+	}
+
+	if rollout := spec.Rollout; rollout != nil {
+		if err := ValidateRolloutPolicy(rollout.ProgressDeadlineSeconds,
+			rollout.MinReadyPercent); err != nil {
+			return err
+		}
+		if c := rollout.ErrorRate; c != nil {
+			if err := ValidateErrorRateCheck(c.MaxErrorPercent,
+				c.WindowSeconds, c.MinRequests); err != nil {
+				return err
+			}
+		}
+	}
+
+	if t := spec.Termination; t != nil {
+		if err := ValidateTermination(t.GracePeriodSeconds,
+			t.PreStopSleepSeconds); err != nil {
+			return err
+		}
+	}
+
+	if b := spec.DisruptionBudget; b != nil {
+		if err := ValidateDisruptionBudget(b.MaxUnavailable); err != nil {
+			return err
+		}
+	}
+
+	if spec.Probes != nil {
+		if err := validateProbes(spec.Probes); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateSecretNames(spec.Secrets); err != nil {
+		return err
+	}
+
+	return ValidateConstraints(spec.Constraints)
+}
+
+// validateProbes validates the handlers and the settings of the startup,
+// readiness and liveness probes.
+func validateProbes(p *v2alpha1.Probes) error {
+	for _, probe := range []struct {
+		field         string
+		probe         *v2alpha1.Probe
+		singleSuccess bool
+	}{
+		{"probes.startup", p.Startup, true},
+		{"probes.readiness", p.Readiness, false},
+		{"probes.liveness", p.Liveness, true},
+	} {
+		field, pr := probe.field, probe.probe
+		if pr == nil {
+			continue
+		}
+		handlers := []string{}
+		if pr.HTTPGet != nil {
+			handlers = append(handlers, "http_get")
+			if err := ValidateProbePort(
+				field+".http_get.port", pr.HTTPGet.Port); err != nil {
+				return err
+			}
+		}
+		if pr.TCPSocket != nil {
+			handlers = append(handlers, "tcp_socket")
+			if err := ValidateProbePort(
+				field+".tcp_socket.port", pr.TCPSocket.Port); err != nil {
+				return err
+			}
+		}
+		if pr.GRPC != nil {
+			handlers = append(handlers, "grpc")
+			if err := ValidateProbePort(
+				field+".grpc.port", pr.GRPC.Port); err != nil {
+				return err
+			}
+		}
+		if pr.Exec != nil {
+			handlers = append(handlers, "exec")
+			if err := ValidateProbeExec(
+				field+".exec.command", pr.Exec.Command); err != nil {
+				return err
+			}
+		}
+		if err := ValidateProbeHandlers(field, handlers); err != nil {
+			return err
+		}
+		if err := ValidateProbeSettings(field, pr.InitialDelaySeconds,
+			pr.PeriodSeconds, pr.TimeoutSeconds, pr.SuccessThreshold,
+			pr.FailureThreshold, probe.singleSuccess); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateScheduling validates the node affinity expressions, tolerations,
+// pod anti affinity and topology spread constraints.
+func validateScheduling(s *v2alpha1.SchedulingConfig) error {
+	validateTerm := func(field string, term corev1.NodeSelectorTerm) error {
+		for i, r := range term.MatchExpressions {
+			if err := ValidateNodeSelectorRequirement(
+				fmt.Sprintf("%s.match_expressions[%d]", field, i),
+				r.Key, string(r.Operator), r.Values); err != nil {
+				return err
+			}
+		}
+		for i, r := range term.MatchFields {
+			if err := ValidateNodeSelectorRequirement(
+				fmt.Sprintf("%s.match_fields[%d]", field, i),
+				r.Key, string(r.Operator), r.Values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if s.NodeAffinity != nil {
+		for i, term := range s.NodeAffinity.Required {
+			if err := validateTerm(fmt.Sprintf(
+				"scheduling.node_affinity.required[%d]", i), term); err != nil {
+				return err
+			}
+		}
+		for i, term := range s.NodeAffinity.Preferred {
+			field := fmt.Sprintf("scheduling.node_affinity.preferred[%d]", i)
+			if err := ValidateWeight(field+".weight", term.Weight); err != nil {
+				return err
+			}
+			if err := validateTerm(field+".preference", term.Preference); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, t := range s.Tolerations {
+		if err := ValidateToleration(
+			fmt.Sprintf("scheduling.tolerations[%d]", i), t.Key,
+			string(t.Operator), t.Value, string(t.Effect)); err != nil {
+			return err
+		}
+	}
+
+	if a := s.PodAntiAffinity; a != nil {
+		if err := ValidatePodAntiAffinity(
+			a.TopologyKey, a.Required, a.Weight); err != nil {
+			return err
+		}
+	}
+
+	for i, c := range s.TopologySpreadConstraints {
+		if err := ValidateTopologySpreadConstraint(
+			fmt.Sprintf("scheduling.topology_spread_constraints[%d]", i),
+			c.TopologyKey, c.MaxSkew, string(c.WhenUnsatisfiable)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateProbeHandlers validates that at most one handler of the probe is
+// set, the handlers are the names of the set ones.
+func ValidateProbeHandlers(field string, handlers []string) error {
+	if len(handlers) > 1 {
+		return fmt.Errorf("%s: only one of %s can be specified",
+			field, strings.Join(handlers, ", "))
+	}
+	return nil
+}
+
+// ValidateProbePort validates the port of the probe handler, nil uses the
+// port of the inference.
+func ValidateProbePort(field string, port *int32) error {
+	if port != nil && (*port < 1 || *port > 65535) {
+		return fmt.Errorf("%s: (%d) is invalid, must be between 1 and 65535", field, *port)
+	}
+	return nil
+}
+
+// ValidateProbeExec validates the command of the exec handler.
+func ValidateProbeExec(field string, command []string) error {
+	if len(command) == 0 || command[0] == "" {
+		return fmt.Errorf("%s: is required", field)
+	}
+	return nil
+}
+
+// ValidateProbeSettings validates the timing and the thresholds of the probe.
+// The success threshold of the startup and liveness probes must be 1.
+func ValidateProbeSettings(field string,
+	initialDelaySeconds, periodSeconds, timeoutSeconds,
+	successThreshold, failureThreshold *int32, singleSuccess bool) error {
+	if initialDelaySeconds != nil && *initialDelaySeconds < 0 {
+		return fmt.Errorf("%s.initial_delay_seconds: must be greater than or equal to 0", field)
+	}
+	for name, value := range map[string]*int32{
+		"period_seconds":    periodSeconds,
+		"timeout_seconds":   timeoutSeconds,
+		"success_threshold": successThreshold,
+		"failure_threshold": failureThreshold,
+	} {
+		if value != nil && *value < 1 {
+			return fmt.Errorf("%s.%s: (%d) is invalid, must be greater than 0",
+				field, name, *value)
+		}
+	}
+	if singleSuccess && successThreshold != nil && *successThreshold != 1 {
+		return fmt.Errorf("%s.success_threshold: (%d) is invalid, must be 1",
+			field, *successThreshold)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package validation
+
+import "fmt"
+
+// ValidateRolloutPolicy validates the rollout policy, zero values use the
+// defaults.
+func ValidateRolloutPolicy(progressDeadlineSeconds, minReadyPercent int32) error {
+	if progressDeadlineSeconds < 0 {
+		return fmt.Errorf("rollout.progress_deadline_seconds: must be greater than 0")
+	}
+	if minReadyPercent < 0 || minReadyPercent > 100 {
+		return fmt.Errorf("rollout.min_ready_percent: (%d) is invalid, must be between 1 and 100",
+			minReadyPercent)
+	}
+	return nil
+}
+
+// ValidateErrorRateCheck validates the error rate check of the rollout
+// policy.
+func ValidateErrorRateCheck(maxErrorPercent, windowSeconds, minRequests int32) error {
+	if maxErrorPercent < 0 || maxErrorPercent > 100 {
+		return fmt.Errorf("rollout.error_rate.max_error_percent: (%d) is invalid, must be between 0 and 100",
+			maxErrorPercent)
+	}
+	if windowSeconds < 0 {
+		return fmt.Errorf("rollout.error_rate.window_seconds: must be greater than 0")
+	}
+	if minRequests < 0 {
+		return fmt.Errorf("rollout.error_rate.min_requests: must be greater than or equal to 0")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateNodeSelectorRequirement validates the expression of the node
+// affinity, the values must match the operator.
+func ValidateNodeSelectorRequirement(field, key, operator string, values []string) error {
+	if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("%s.key: (%s) is invalid: %s", field, key, strings.Join(errs, "; "))
+	}
+	switch corev1.NodeSelectorOperator(operator) {
+	case corev1.NodeSelectorOpIn, corev1.NodeSelectorOpNotIn:
+		if len(values) == 0 {
+			return fmt.Errorf("%s.values: must be specified for operator %s", field, operator)
+		}
+	case corev1.NodeSelectorOpExists, corev1.NodeSelectorOpDoesNotExist:
+		if len(values) > 0 {
+			return fmt.Errorf("%s.values: must be empty for operator %s", field, operator)
+		}
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if len(values) != 1 {
+			return fmt.Errorf("%s.values: must have a single element for operator %s", field, operator)
+		}
+		if _, err := strconv.ParseInt(values[0], 10, 64); err != nil {
+			return fmt.Errorf("%s.values: (%s) must be an integer for operator %s",
+				field, values[0], operator)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s.operator: (%s) is invalid, must be one of In, NotIn, Exists, DoesNotExist, Gt or Lt",
+			field, operator)
+	}
+	for _, value := range values {
+		if errs := k8svalidation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("%s.values: (%s) is invalid: %s", field, value, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// ValidateWeight validates that the weight of the preferred term is in the
+// range 1-100.
+func ValidateWeight(field string, weight int32) error {
+	if weight < 1 || weight > 100 {
+		return fmt.Errorf("%s: (%d) is invalid, must be between 1 and 100", field, weight)
+	}
+	return nil
+}
+
+// ValidateToleration validates the toleration. An empty key tolerates all
+// taints, thus the operator must be Exists.
+func ValidateToleration(field, key, operator, value, effect string) error {
+	if key != "" {
+		if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("%s.key: (%s) is invalid: %s", field, key, strings.Join(errs, "; "))
+		}
+	}
+	switch corev1.TolerationOperator(operator) {
+	case "", corev1.TolerationOpEqual:
+		if key == "" {
+			return fmt.Errorf("%s.operator: must be Exists if the key is empty", field)
+		}
+		if errs := k8svalidation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("%s.value: (%s) is invalid: %s", field, value, strings.Join(errs, "; "))
+		}
+	case corev1.TolerationOpExists:
+		if value != "" {
+			return fmt.Errorf("%s.value: must be empty for operator Exists", field)
+		}
+	default:
+		return fmt.Errorf("%s.operator: (%s) is invalid, must be Equal or Exists", field, operator)
+	}
+	switch corev1.TaintEffect(effect) {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule,
+		corev1.TaintEffectNoExecute:
+	default:
+		return fmt.Errorf("%s.effect: (%s) is invalid, must be NoSchedule, PreferNoSchedule or NoExecute",
+			field, effect)
+	}
+	return nil
+}
+
+// ValidatePodAntiAffinity validates the topology key and the weight of the
+// pod anti affinity. The weight is only used if it is preferred.
+func ValidatePodAntiAffinity(topologyKey string, required bool, weight int32) error {
+	if err := validateTopologyKey("scheduling.pod_anti_affinity.topology_key",
+		topologyKey); err != nil {
+		return err
+	}
+	if !required && weight != 0 {
+		return ValidateWeight("scheduling.pod_anti_affinity.weight", weight)
+	}
+	return nil
+}
+
+// ValidateTopologySpreadConstraint validates the topology spread constraint,
+// zero max skew and empty whenUnsatisfiable use the defaults.
+func ValidateTopologySpreadConstraint(field, topologyKey string,
+	maxSkew int32, whenUnsatisfiable string) error {
+	if err := validateTopologyKey(field+".topology_key", topologyKey); err != nil {
+		return err
+	}
+	if maxSkew < 0 {
+		return fmt.Errorf("%s.max_skew: must be greater than 0", field)
+	}
+	switch corev1.UnsatisfiableConstraintAction(whenUnsatisfiable) {
+	case "", corev1.DoNotSchedule, corev1.ScheduleAnyway:
+	default:
+		return fmt.Errorf("%s.when_unsatisfiable: (%s) is invalid, must be DoNotSchedule or ScheduleAnyway",
+			field, whenUnsatisfiable)
+	}
+	return nil
+}
+
+func validateTopologyKey(field, key string) error {
+	if key == "" {
+		return fmt.Errorf("%s: is required", field)
+	}
+	if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("%s: (%s) is invalid: %s", field, key, strings.Join(errs, "; "))
+	}
+	return nil
+}
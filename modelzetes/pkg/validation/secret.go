@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateSecretNames validates the names of the secrets mounted to the
+// inference.
+func ValidateSecretNames(names []string) error {
+	for i, name := range names {
+		if errs := k8svalidation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("secrets[%d]: (%s) is invalid: %s", i, name, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// ValidateSecret validates the name and the keys of the secret. The keys are
+// used as the file names under the secrets mount path.
+func ValidateSecret(name string, keys []string) error {
+	if name == "" {
+		return fmt.Errorf("name: is required")
+	}
+	if errs := k8svalidation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("name: (%s) is invalid: %s", name, strings.Join(errs, "; "))
+	}
+	for _, key := range keys {
+		if errs := k8svalidation.IsConfigMapKey(key); len(errs) > 0 {
+			return fmt.Errorf("data.%s: is invalid: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package validation
+
+import "fmt"
+
+// ValidateTermination validates the termination config. The preStop hook
+// must finish in the grace period, otherwise the process is killed without
+// SIGTERM.
+func ValidateTermination(gracePeriodSeconds *int64, preStopSleepSeconds *int32) error {
+	if gracePeriodSeconds != nil && *gracePeriodSeconds < 0 {
+		return fmt.Errorf("termination.grace_period_seconds: must be greater than or equal to 0")
+	}
+	if preStopSleepSeconds != nil && *preStopSleepSeconds < 0 {
+		return fmt.Errorf("termination.pre_stop_sleep_seconds: must be greater than or equal to 0")
+	}
+	if gracePeriodSeconds != nil && preStopSleepSeconds != nil &&
+		*preStopSleepSeconds > 0 && int64(*preStopSleepSeconds) >= *gracePeriodSeconds {
+		return fmt.Errorf("termination.pre_stop_sleep_seconds: (%d) must be less than the grace period (%d)",
+			*preStopSleepSeconds, *gracePeriodSeconds)
+	}
+	return nil
+}
+
+// ValidateDisruptionBudget validates the max unavailable replicas of the
+// disruption budget.
+func ValidateDisruptionBudget(maxUnavailable *int32) error {
+	if maxUnavailable != nil && *maxUnavailable < 1 {
+		return fmt.Errorf("disruption_budget.max_unavailable: (%d) is invalid, must be greater than 0",
+			*maxUnavailable)
+	}
+	return nil
+}
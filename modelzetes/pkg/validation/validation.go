@@ -0,0 +1,231 @@
+// Package validation contains the defaults and validation rules of the
+// inference spec. They are shared by the agent and the admission webhook, so
+// that inferences created by kubectl or GitOps are checked the same way.
+package validation
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/model"
+)
+
+const (
+	DefaultMinReplicas     = 0
+	DefaultMaxReplicas     = 1
+	DefaultTargetLoad      = 100
+	DefaultZeroDuration    = 300
+	DefaultStartupDuration = 600
+	// DefaultPort is the port of the inference if the framework is unknown.
+	DefaultPort = 8080
+)
+
+var validDNS = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// FrameworkPort returns the port which the framework listens on. It is
+// overridden by the env var of the framework if it is set.
+func FrameworkPort(name string, envVars map[string]string) (int32, bool) {
+	f, ok := framework.Get(name)
+	if !ok || f.Port == 0 {
+		return 0, false
+	}
+	if value, ok := envVars[f.PortEnv]; ok && f.PortEnv != "" {
+		port, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return 0, false
+		}
+		return int32(port), true
+	}
+	return f.Port, true
+}
+
+// ValidateName validates that the name is a valid DNS label.
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name: is required")
+	}
+	if len(name) > k8svalidation.DNS1035LabelMaxLength ||
+		!validDNS.MatchString(name) {
+		return fmt.Errorf("name: (%s) is invalid, must be a valid DNS entry", name)
+	}
+	return nil
+}
+
+// ValidateReplicas validates the replica bounds of the scaling config.
+func ValidateReplicas(minReplicas, maxReplicas *int32) error {
+	if minReplicas != nil && *minReplicas < 0 {
+		return fmt.Errorf("scaling.min_replicas: must be greater than or equal to 0")
+	}
+	if maxReplicas != nil && *maxReplicas < 0 {
+		return fmt.Errorf("scaling.max_replicas: must be greater than or equal to 0")
+	}
+	if minReplicas != nil && maxReplicas != nil && *minReplicas > *maxReplicas {
+		return fmt.Errorf("scaling.max_replicas: (%d) must be greater than or equal to min_replicas (%d)",
+			*maxReplicas, *minReplicas)
+	}
+	return nil
+}
+
+// ValidateDurations validates the durations of the scaling config. The
+// startup duration is divided by the period of the startup probe to get the
+// failure threshold, thus it cannot be less than the period.
+func ValidateDurations(targetLoad, zeroDuration, startupDuration *int32,
+	startupProbePeriodSeconds int32) error {
+	if targetLoad != nil && *targetLoad <= 0 {
+		return fmt.Errorf("scaling.target_load: must be greater than 0")
+	}
+	if zeroDuration != nil && *zeroDuration < 0 {
+		return fmt.Errorf("scaling.zero_duration: must be greater than or equal to 0")
+	}
+	if startupDuration == nil {
+		return nil
+	}
+	if *startupDuration <= 0 {
+		return fmt.Errorf("scaling.startup_duration: must be greater than 0")
+	}
+	if startupProbePeriodSeconds > 0 && *startupDuration < startupProbePeriodSeconds {
+		return fmt.Errorf("scaling.startup_duration: (%d) must be greater than or equal to the startup probe period (%d)",
+			*startupDuration, startupProbePeriodSeconds)
+	}
+	return nil
+}
+
+// ParseQuantity parses the quantity of the field, it must not be negative.
+func ParseQuantity(field, value string) (resource.Quantity, error) {
+	qty, err := resource.ParseQuantity(value)
+	if err != nil {
+		return qty, fmt.Errorf("%s: (%s) is invalid: %w", field, value, err)
+	}
+	if qty.Sign() < 0 {
+		return qty, fmt.Errorf("%s: (%s) must not be negative", field, value)
+	}
+	return qty, nil
+}
+
+// ValidateResources validates that the quantities are not negative and the
+// requests do not exceed the limits.
+func ValidateResources(requests, limits corev1.ResourceList) error {
+	for name, qty := range limits {
+		if qty.Sign() < 0 {
+			return fmt.Errorf("resources.limits.%s: must not be negative", name)
+		}
+	}
+	for name, qty := range requests {
+		if qty.Sign() < 0 {
+			return fmt.Errorf("resources.requests.%s: must not be negative", name)
+		}
+		limit, ok := limits[name]
+		if ok && qty.Cmp(limit) > 0 {
+			return fmt.Errorf("resources.requests.%s: (%s) must be less than or equal to the limit (%s)",
+				name, qty.String(), limit.String())
+		}
+	}
+	return nil
+}
+
+// ValidateResourceRequirements validates the quantities of the resources
+// and the accelerators requested.
+func ValidateResourceRequirements(resources *corev1.ResourceRequirements) error {
+	if resources == nil {
+		return nil
+	}
+	if err := ValidateResources(resources.Requests, resources.Limits); err != nil {
+		return err
+	}
+	return ValidateAcceleratorResources(resources.Requests, resources.Limits)
+}
+
+// ValidateFramework validates that the framework is registered and the port
+// is consistent with the framework and its env vars. An empty framework
+// listens on DefaultPort.
+func ValidateFramework(name string, port *int32, envVars map[string]string) error {
+	if port != nil && (*port <= 0 || *port > 65535) {
+		return fmt.Errorf("port: (%d) is invalid, must be between 1 and 65535", *port)
+	}
+	if name == "" {
+		return nil
+	}
+
+	f, ok := framework.Get(name)
+	if !ok {
+		return fmt.Errorf("framework: (%s) is invalid, must be one of %s",
+			name, strings.Join(framework.Names(), ", "))
+	}
+	if f.Port == 0 && port == nil {
+		return fmt.Errorf("port: is required for %s framework", name)
+	}
+
+	if f.PortEnv == "" {
+		return nil
+	}
+	expected, ok := FrameworkPort(name, envVars)
+	if !ok {
+		return fmt.Errorf("env.%s: (%s) is invalid, must be a port number",
+			f.PortEnv, envVars[f.PortEnv])
+	}
+	if port != nil && *port != expected {
+		return fmt.Errorf("port: (%d) is inconsistent with the %s framework which listens on %d, set %s to change it",
+			*port, name, expected, f.PortEnv)
+	}
+	return nil
+}
+
+// ValidateConstraints validates that the constraints are in the format of
+// <label>=<value>.
+func ValidateConstraints(constraints []string) error {
+	for _, constraint := range constraints {
+		parts := strings.Split(constraint, "=")
+		if len(parts) != 2 {
+			return fmt.Errorf("constraints: (%s) is invalid, must be in the format of <label>=<value>",
+				constraint)
+		}
+		if errs := k8svalidation.IsQualifiedName(parts[0]); len(errs) > 0 {
+			return fmt.Errorf("constraints: (%s) has an invalid label: %s",
+				constraint, strings.Join(errs, "; "))
+		}
+		if errs := k8svalidation.IsValidLabelValue(parts[1]); len(errs) > 0 {
+			return fmt.Errorf("constraints: (%s) has an invalid value: %s",
+				constraint, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// ValidateModel validates the source of the model artifacts and the path
+// where it is mounted.
+func ValidateModel(typ, uri, endpoint, mountPath string) error {
+	if mountPath != "" && !path.IsAbs(mountPath) {
+		return fmt.Errorf("model.mount_path: (%s) is invalid, must be an absolute path", mountPath)
+	}
+	return model.Source{Type: typ, URI: uri, Endpoint: endpoint}.Validate()
+}
+
+// ValidateCustomMetric validates the query template and the target value of
+// the custom metric.
+func ValidateCustomMetric(query, targetValue, name, namespace string) error {
+	if query == "" {
+		return fmt.Errorf("custom_metric.query: is required")
+	}
+
+	if _, err := CustomMetricQuery(query, name, namespace); err != nil {
+		return fmt.Errorf("custom_metric.query: (%s) is invalid: %w", query, err)
+	}
+
+	qty, err := resource.ParseQuantity(targetValue)
+	if err != nil {
+		return fmt.Errorf("custom_metric.target_value: (%s) is invalid: %w",
+			targetValue, err)
+	}
+	if qty.Sign() <= 0 {
+		return fmt.Errorf("custom_metric.target_value: must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,386 @@
+package validation
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/framework"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+func Test_ValidateReplicas(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		min     *int32
+		max     *int32
+		invalid bool
+	}{
+		{"not set", nil, nil, false},
+		{"min equals max", Ptr(int32(1)), Ptr(int32(1)), false},
+		{"negative min", Ptr(int32(-1)), nil, true},
+		{"min greater than max", Ptr(int32(3)), Ptr(int32(2)), true},
+	}
+	for _, s := range scenarios {
+		err := ValidateReplicas(s.min, s.max)
+		if (err != nil) != s.invalid {
+			t.Errorf("%s: unexpected error %v", s.name, err)
+		}
+	}
+}
+
+func Test_ValidateDurations(t *testing.T) {
+	if err := ValidateDurations(nil, nil, Ptr(int32(1)), 2); err == nil {
+		t.Errorf("startup duration less than the probe period should be invalid")
+	}
+	if err := ValidateDurations(nil, nil, Ptr(int32(1)), 0); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateDurations(Ptr(int32(0)), nil, nil, 2); err == nil {
+		t.Errorf("zero target load should be invalid")
+	}
+}
+
+func Test_ValidateResources(t *testing.T) {
+	err := ValidateResources(corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("2"),
+	}, corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("1"),
+	})
+	if err == nil {
+		t.Errorf("requests greater than limits should be invalid")
+	}
+
+	if _, err := ParseQuantity("resources.limits.cpu", "-1"); err == nil {
+		t.Errorf("negative quantity should be invalid")
+	}
+	if _, err := ParseQuantity("resources.limits.cpu", "1x"); err == nil {
+		t.Errorf("malformed quantity should be invalid")
+	}
+}
+
+func Test_ValidateFramework(t *testing.T) {
+	scenarios := []struct {
+		name      string
+		framework string
+		port      *int32
+		envVars   map[string]string
+		invalid   bool
+	}{
+		{"gradio default port", framework.Gradio, Ptr(int32(7860)), nil, false},
+		{"gradio wrong port", framework.Gradio, Ptr(int32(8080)), nil, true},
+		{"gradio port from env", framework.Gradio, Ptr(int32(8080)),
+			map[string]string{"GRADIO_SERVER_PORT": "8080"}, false},
+		{"mosec invalid env", framework.Mosec, nil,
+			map[string]string{"MOSEC_PORT": "http"}, true},
+		{"other without port", framework.Other, nil, nil, true},
+		{"port out of range", framework.Other, Ptr(int32(70000)), nil, true},
+		{"vllm custom port", framework.VLLM, Ptr(int32(9000)), nil, false},
+		{"unknown framework", "flask", Ptr(int32(8080)), nil, true},
+	}
+	for _, s := range scenarios {
+		err := ValidateFramework(s.framework, s.port, s.envVars)
+		if (err != nil) != s.invalid {
+			t.Errorf("%s: unexpected error %v", s.name, err)
+		}
+	}
+}
+
+func Test_ValidateConstraints(t *testing.T) {
+	if err := ValidateConstraints([]string{"tensorchord.ai/gpu=a100"}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	for _, constraint := range []string{"gpu", "gpu=a=b", "=a100", "gpu=a 100"} {
+		if err := ValidateConstraints([]string{constraint}); err == nil {
+			t.Errorf("constraint %s should be invalid", constraint)
+		}
+	}
+}
+
+func Test_ValidateModel(t *testing.T) {
+	if err := ValidateModel("huggingface", "bigscience/bloomz-560m", "", "/models"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateModel("huggingface", "bigscience/bloomz-560m", "", "models"); err == nil {
+		t.Errorf("relative mount path should be invalid")
+	}
+	if err := ValidateModel("git", "bigscience/bloomz-560m", "", ""); err == nil {
+		t.Errorf("unknown type should be invalid")
+	}
+}
+
+func Test_ValidateName(t *testing.T) {
+	if err := ValidateName("bert-1"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	for _, name := range []string{"", "Bert", "bert_1", "-bert"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("name %s should be invalid", name)
+		}
+	}
+}
+
+func Test_ValidateNodeSelectorRequirement(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		key      string
+		operator string
+		values   []string
+		invalid  bool
+	}{
+		{"in", "tensorchord.ai/gpu", "In", []string{"a100", "h100"}, false},
+		{"exists", "tensorchord.ai/gpu", "Exists", nil, false},
+		{"gt", "tensorchord.ai/gpu-memory", "Gt", []string{"40"}, false},
+		{"in without values", "tensorchord.ai/gpu", "In", nil, true},
+		{"exists with values", "tensorchord.ai/gpu", "Exists", []string{"a100"}, true},
+		{"gt with non-integer", "tensorchord.ai/gpu-memory", "Gt", []string{"40G"}, true},
+		{"unknown operator", "tensorchord.ai/gpu", "Equals", []string{"a100"}, true},
+		{"invalid key", "", "Exists", nil, true},
+	}
+	for _, s := range scenarios {
+		err := ValidateNodeSelectorRequirement("scheduling.node_affinity", s.key, s.operator, s.values)
+		if (err != nil) != s.invalid {
+			t.Errorf("%s: unexpected error %v", s.name, err)
+		}
+	}
+}
+
+func Test_ValidateToleration(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		key      string
+		operator string
+		value    string
+		effect   string
+		invalid  bool
+	}{
+		{"equal", "dedicated", "Equal", "inference", "NoSchedule", false},
+		{"default operator", "dedicated", "", "inference", "", false},
+		{"tolerate all", "", "Exists", "", "", false},
+		{"empty key with equal", "", "Equal", "inference", "", true},
+		{"exists with value", "dedicated", "Exists", "inference", "", true},
+		{"unknown effect", "dedicated", "Equal", "inference", "NoRun", true},
+	}
+	for _, s := range scenarios {
+		err := ValidateToleration("scheduling.tolerations[0]", s.key, s.operator, s.value, s.effect)
+		if (err != nil) != s.invalid {
+			t.Errorf("%s: unexpected error %v", s.name, err)
+		}
+	}
+}
+
+func Test_ValidateTopology(t *testing.T) {
+	if err := ValidatePodAntiAffinity("kubernetes.io/hostname", false, 0); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidatePodAntiAffinity("kubernetes.io/hostname", false, 101); err == nil {
+		t.Errorf("weight out of range should be invalid")
+	}
+	if err := ValidatePodAntiAffinity("", true, 0); err == nil {
+		t.Errorf("empty topology key should be invalid")
+	}
+	if err := ValidateTopologySpreadConstraint("scheduling.topology_spread_constraints[0]",
+		"topology.kubernetes.io/zone", 1, "DoNotSchedule"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateTopologySpreadConstraint("scheduling.topology_spread_constraints[0]",
+		"topology.kubernetes.io/zone", -1, ""); err == nil {
+		t.Errorf("negative max skew should be invalid")
+	}
+	if err := ValidateTopologySpreadConstraint("scheduling.topology_spread_constraints[0]",
+		"topology.kubernetes.io/zone", 1, "Never"); err == nil {
+		t.Errorf("unknown when_unsatisfiable should be invalid")
+	}
+}
+
+func Test_ValidateRollout(t *testing.T) {
+	if err := ValidateRolloutPolicy(0, 0); err != nil {
+		t.Errorf("defaults should be valid: %v", err)
+	}
+	if err := ValidateRolloutPolicy(-1, 0); err == nil {
+		t.Errorf("negative deadline should be invalid")
+	}
+	if err := ValidateRolloutPolicy(600, 101); err == nil {
+		t.Errorf("ready percent greater than 100 should be invalid")
+	}
+	if err := ValidateErrorRateCheck(5, 60, 10); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateErrorRateCheck(120, 0, 0); err == nil {
+		t.Errorf("error percent greater than 100 should be invalid")
+	}
+}
+
+func Test_ValidateTermination(t *testing.T) {
+	if err := ValidateTermination(nil, nil); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateTermination(Ptr(int64(30)), Ptr(int32(0))); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateTermination(Ptr(int64(-1)), nil); err == nil {
+		t.Errorf("negative grace period should be invalid")
+	}
+	if err := ValidateTermination(Ptr(int64(10)), Ptr(int32(10))); err == nil {
+		t.Errorf("preStop sleep longer than the grace period should be invalid")
+	}
+	if err := ValidateDisruptionBudget(Ptr(int32(0))); err == nil {
+		t.Errorf("zero max unavailable should be invalid")
+	}
+}
+
+func Test_ValidateAcceleratorResources(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		limits  corev1.ResourceList
+		invalid bool
+	}{
+		{"whole gpu", corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("2")}, false},
+		{"shared gpu", corev1.ResourceList{"nvidia.com/gpu.shared": resource.MustParse("1")}, false},
+		{"mig", corev1.ResourceList{"nvidia.com/mig-1g.10gb": resource.MustParse("1")}, false},
+		{"mig with compute instance", corev1.ResourceList{"nvidia.com/mig-1c.3g.40gb": resource.MustParse("1")}, false},
+		{"invalid mig profile", corev1.ResourceList{"nvidia.com/mig-10gb": resource.MustParse("1")}, true},
+		{"fractional", corev1.ResourceList{"nvidia.com/gpu.shared": resource.MustParse("500m")}, true},
+		{"amd", corev1.ResourceList{"amd.com/gpu": resource.MustParse("1")}, false},
+		{"mixed vendors", corev1.ResourceList{
+			"amd.com/gpu":        resource.MustParse("1"),
+			"gpu.intel.com/i915": resource.MustParse("1"),
+		}, true},
+		{"mixed", corev1.ResourceList{
+			"nvidia.com/gpu":         resource.MustParse("1"),
+			"nvidia.com/mig-1g.10gb": resource.MustParse("1"),
+		}, true},
+	}
+	for _, s := range scenarios {
+		err := ValidateAcceleratorResources(nil, s.limits)
+		if (err != nil) != s.invalid {
+			t.Errorf("%s: unexpected error %v", s.name, err)
+		}
+	}
+}
+
+func Test_ValidateSecret(t *testing.T) {
+	if err := ValidateSecret("hf-token", []string{"HF_TOKEN", "config.json"}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateSecret("", nil); err == nil {
+		t.Errorf("empty name should be invalid")
+	}
+	if err := ValidateSecret("HF_TOKEN", nil); err == nil {
+		t.Errorf("name with upper case letters should be invalid")
+	}
+	if err := ValidateSecret("hf-token", []string{"../token"}); err == nil {
+		t.Errorf("key with path separators should be invalid")
+	}
+	if err := ValidateSecretNames([]string{"hf-token", "s3.credentials"}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateSecretNames([]string{"hf_token"}); err == nil {
+		t.Errorf("name with underscores should be invalid")
+	}
+}
+
+func Test_ValidateProbe(t *testing.T) {
+	if err := ValidateProbeHandlers("probes.startup", []string{"exec"}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateProbeHandlers("probes.startup", []string{"http_get", "exec"}); err == nil {
+		t.Errorf("multiple handlers should be invalid")
+	}
+	if err := ValidateProbePort("probes.startup.grpc.port", Ptr(int32(0))); err == nil {
+		t.Errorf("zero port should be invalid")
+	}
+	if err := ValidateProbeExec("probes.liveness.exec.command", nil); err == nil {
+		t.Errorf("empty command should be invalid")
+	}
+	if err := ValidateProbeSettings("probes.readiness", Ptr(int32(0)), Ptr(int32(5)),
+		Ptr(int32(1)), Ptr(int32(2)), Ptr(int32(3)), false); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateProbeSettings("probes.readiness", nil, Ptr(int32(0)),
+		nil, nil, nil, false); err == nil {
+		t.Errorf("zero period should be invalid")
+	}
+	if err := ValidateProbeSettings("probes.liveness", nil, nil,
+		nil, Ptr(int32(2)), nil, true); err == nil {
+		t.Errorf("success threshold other than 1 should be invalid for liveness")
+	}
+}
+
+func Test_ValidateBatch(t *testing.T) {
+	locations := []struct {
+		name        string
+		uri         string
+		volumeClaim string
+		invalid     bool
+	}{
+		{"s3", "s3://bucket/prefix", "", false},
+		{"s3 without bucket", "s3:///prefix", "", true},
+		{"s3 with volume claim", "s3://bucket/prefix", "data", true},
+		{"volume claim", "datasets/a", "data", false},
+		{"path without volume claim", "datasets/a", "", true},
+		{"path out of the volume", "../a", "data", true},
+		{"unsupported scheme", "gs://bucket", "", true},
+	}
+	for _, l := range locations {
+		err := ValidateBatchLocation("input", l.uri, l.volumeClaim)
+		if (err != nil) != l.invalid {
+			t.Errorf("%s: unexpected error %v", l.name, err)
+		}
+	}
+
+	if err := ValidateBatchInput("", nil); err == nil {
+		t.Errorf("empty input should be invalid")
+	}
+	if err := ValidateBatchInput("a.jsonl", []string{"a.png"}); err == nil {
+		t.Errorf("both jsonl and files should be invalid")
+	}
+	if err := ValidateBatchInput("", []string{"/etc/passwd"}); err == nil {
+		t.Errorf("absolute file should be invalid")
+	}
+	if err := ValidateBatchLimits(Ptr(int32(MaxBatchParallelism+1)), nil, nil); err == nil {
+		t.Errorf("parallelism greater than the maximum should be invalid")
+	}
+	if err := ValidateBatchLimits(Ptr(int32(2)), Ptr(int32(0)), Ptr(int32(0))); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func Test_ValidateInferenceSpec(t *testing.T) {
+	spec := v2alpha1.InferenceSpec{
+		Name:  "llm",
+		Image: "modelzai/llm",
+		Port:  Ptr(int32(8080)),
+		Scaling: &v2alpha1.ScalingConfig{
+			StartupDuration: Ptr(int32(5)),
+		},
+	}
+	if err := ValidateInferenceSpec(&spec, "default", 0); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateInferenceSpec(&spec, "default", 10); err == nil {
+		t.Errorf("startup duration less than the probe period should be invalid")
+	}
+
+	spec.Probes = &v2alpha1.Probes{Startup: &v2alpha1.Probe{
+		PeriodSeconds: Ptr(int32(1)),
+		TCPSocket:     &v2alpha1.TCPSocketAction{Port: Ptr(int32(8080))},
+	}}
+	if err := ValidateInferenceSpec(&spec, "default", 10); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	spec.Probes.Startup.Exec = &v2alpha1.ExecAction{Command: []string{"true"}}
+	if err := ValidateInferenceSpec(&spec, "default", 10); err == nil {
+		t.Errorf("multiple handlers should be invalid")
+	}
+	spec.Probes = nil
+
+	spec.Scheduling = &v2alpha1.SchedulingConfig{
+		Tolerations: []corev1.Toleration{{Key: "gpu", Operator: "Equal", Effect: "Evict"}},
+	}
+	if err := ValidateInferenceSpec(&spec, "default", 0); err == nil {
+		t.Errorf("invalid toleration effect should be invalid")
+	}
+}
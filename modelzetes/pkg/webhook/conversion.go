@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	glog "k8s.io/klog"
+
+	v1beta1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v1beta1"
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+)
+
+const PathConvert = "/convert"
+
+// ConversionReview is the apiextensions.k8s.io/v1 ConversionReview. It is
+// declared here to avoid depending on k8s.io/apiextensions-apiserver.
+type ConversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *ConversionRequest  `json:"request,omitempty"`
+	Response        *ConversionResponse `json:"response,omitempty"`
+}
+
+type ConversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type ConversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+// convert serves the conversion webhook of the Inference CRD.
+func (s *Server) convert(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review := ConversionReview{}
+	if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		http.Error(w, "invalid conversion review", http.StatusBadRequest)
+		return
+	}
+
+	resp := &ConversionResponse{
+		UID:    review.Request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, obj := range review.Request.Objects {
+		converted, err := convertInference(obj.Raw, review.Request.DesiredAPIVersion)
+		if err != nil {
+			glog.V(2).Infof("Failed to convert inference: %v", err)
+			resp.ConvertedObjects = nil
+			resp.Result = metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+			}
+			break
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects,
+			runtime.RawExtension{Raw: converted})
+	}
+	review.Response = resp
+	review.Request = nil
+
+	res, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(res)
+}
+
+// convertInference converts the raw inference to the desired api version.
+func convertInference(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to decode object: %w", err)
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+
+	switch {
+	case typeMeta.APIVersion == v2alpha1.SchemeGroupVersion.String() &&
+		desiredAPIVersion == v1beta1.SchemeGroupVersion.String():
+		src := &v2alpha1.Inference{}
+		if err := json.Unmarshal(raw, src); err != nil {
+			return nil, fmt.Errorf("failed to decode inference: %w", err)
+		}
+		dst := &v1beta1.Inference{}
+		if err := dst.ConvertFrom(src); err != nil {
+			return nil, err
+		}
+		return json.Marshal(dst)
+	case typeMeta.APIVersion == v1beta1.SchemeGroupVersion.String() &&
+		desiredAPIVersion == v2alpha1.SchemeGroupVersion.String():
+		src := &v1beta1.Inference{}
+		if err := json.Unmarshal(raw, src); err != nil {
+			return nil, fmt.Errorf("failed to decode inference: %w", err)
+		}
+		dst := &v2alpha1.Inference{}
+		if err := src.ConvertTo(dst); err != nil {
+			return nil, err
+		}
+		return json.Marshal(dst)
+	default:
+		return nil, fmt.Errorf("unsupported conversion from %s to %s",
+			typeMeta.APIVersion, desiredAPIVersion)
+	}
+}
@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1beta1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v1beta1"
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+func convertReview(t *testing.T, desiredAPIVersion string, objs ...interface{}) *ConversionResponse {
+	req := &ConversionRequest{UID: "uid", DesiredAPIVersion: desiredAPIVersion}
+	for _, obj := range objs {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Objects = append(req.Objects, runtime.RawExtension{Raw: raw})
+	}
+	body, err := json.Marshal(ConversionReview{Request: req})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	New(0, "", "", 2).Handler().ServeHTTP(rec,
+		httptest.NewRequest(http.MethodPost, PathConvert, bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code %d", rec.Code)
+	}
+
+	res := ConversionReview{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Response == nil || res.Response.UID != "uid" {
+		t.Fatalf("unexpected response %+v", res.Response)
+	}
+	return res.Response
+}
+
+func Test_Convert(t *testing.T) {
+	alpha := &v2alpha1.Inference{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v2alpha1.SchemeGroupVersion.String(),
+			Kind:       v2alpha1.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "bert", Namespace: "default"},
+		Spec: v2alpha1.InferenceSpec{
+			Name:          "bert",
+			Image:         "bert:latest",
+			Command:       Ptr("python main.py"),
+			Constraints:   []string{"tensorchord.ai/gpu=a100"},
+			HTTPProbePath: Ptr("/healthz"),
+		},
+	}
+
+	resp := convertReview(t, v1beta1.SchemeGroupVersion.String(), alpha)
+	if resp.Result.Status != metav1.StatusSuccess || len(resp.ConvertedObjects) != 1 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	beta := &v1beta1.Inference{}
+	if err := json.Unmarshal(resp.ConvertedObjects[0].Raw, beta); err != nil {
+		t.Fatal(err)
+	}
+	if beta.APIVersion != v1beta1.SchemeGroupVersion.String() ||
+		len(beta.Spec.Command) != 2 ||
+		beta.Spec.NodeSelector["tensorchord.ai/gpu"] != "a100" ||
+		*beta.Spec.Probes.HTTPPath != "/healthz" {
+		t.Errorf("unexpected v1beta1 inference %+v", beta)
+	}
+
+	resp = convertReview(t, v2alpha1.SchemeGroupVersion.String(), beta)
+	if resp.Result.Status != metav1.StatusSuccess || len(resp.ConvertedObjects) != 1 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	back := &v2alpha1.Inference{}
+	if err := json.Unmarshal(resp.ConvertedObjects[0].Raw, back); err != nil {
+		t.Fatal(err)
+	}
+	if *back.Spec.Command != "python main.py" ||
+		back.Spec.Constraints[0] != "tensorchord.ai/gpu=a100" ||
+		len(back.Annotations) != 0 {
+		t.Errorf("unexpected v2alpha1 inference %+v", back)
+	}
+}
+
+func Test_ConvertUnsupportedVersion(t *testing.T) {
+	resp := convertReview(t, "tensorchord.ai/v1", &v2alpha1.Inference{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v2alpha1.SchemeGroupVersion.String(),
+			Kind:       v2alpha1.Kind,
+		},
+	})
+	if resp.Result.Status != metav1.StatusFailure || resp.ConvertedObjects != nil {
+		t.Errorf("expected failure, got %+v", resp)
+	}
+}
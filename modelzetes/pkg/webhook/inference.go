@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"fmt"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/validation"
+)
+
+// DefaultInference sets the same defaults as the agent.
+func DefaultInference(inference *v2alpha1.Inference) {
+	spec := &inference.Spec
+	if spec.Name == "" {
+		spec.Name = inference.Name
+	}
+
+	if spec.Scaling == nil {
+		spec.Scaling = &v2alpha1.ScalingConfig{}
+	}
+	scaling := spec.Scaling
+	if scaling.MinReplicas == nil {
+		scaling.MinReplicas = Ptr(int32(validation.DefaultMinReplicas))
+	}
+	if scaling.MaxReplicas == nil {
+		scaling.MaxReplicas = Ptr(int32(validation.DefaultMaxReplicas))
+		if *scaling.MinReplicas > *scaling.MaxReplicas {
+			scaling.MaxReplicas = Ptr(*scaling.MinReplicas)
+		}
+	}
+	if scaling.TargetLoad == nil {
+		scaling.TargetLoad = Ptr(int32(validation.DefaultTargetLoad))
+	}
+	if scaling.Type == nil {
+		scaling.Type = Ptr(v2alpha1.ScalingTypeCapacity)
+	}
+	if scaling.ZeroDuration == nil {
+		scaling.ZeroDuration = Ptr(int32(validation.DefaultZeroDuration))
+	}
+	if scaling.StartupDuration == nil {
+		scaling.StartupDuration = Ptr(int32(validation.DefaultStartupDuration))
+	}
+
+	if spec.Framework == "" {
+		spec.Framework = v2alpha1.FrameworkOther
+	}
+
+	if spec.Port == nil {
+		if port, ok := validation.FrameworkPort(
+			string(spec.Framework), spec.EnvVars); ok {
+			spec.Port = &port
+		}
+	}
+}
+
+// ValidateInference validates the inference with the same rules as the
+// agent. The startup duration is also checked against the period of the
+// startup probe configured in modelzetes.
+func ValidateInference(inference *v2alpha1.Inference,
+	startupProbePeriodSeconds int32) error {
+	if err := validation.ValidateName(inference.Name); err != nil {
+		return err
+	}
+	if err := validation.ValidateName(inference.Spec.Name); err != nil {
+		return fmt.Errorf("spec.%w", err)
+	}
+	return validation.ValidateInferenceSpec(&inference.Spec,
+		inference.Namespace, startupProbePeriodSeconds)
+}
@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	glog "k8s.io/klog"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+)
+
+const (
+	PathMutate   = "/mutate-inference"
+	PathValidate = "/validate-inference"
+)
+
+// Server serves the defaulting and validating admission webhooks and the
+// conversion webhook of the Inference resources.
+type Server struct {
+	port     int
+	certFile string
+	keyFile  string
+	// startupProbePeriodSeconds is the period of the startup probe of the
+	// inference deployments.
+	startupProbePeriodSeconds int32
+}
+
+func New(port int, certFile, keyFile string, startupProbePeriodSeconds int) *Server {
+	return &Server{
+		port:                      port,
+		certFile:                  certFile,
+		keyFile:                   keyFile,
+		startupProbePeriodSeconds: int32(startupProbePeriodSeconds),
+	}
+}
+
+// Handler returns the http handler of the webhooks.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathMutate, s.serve(s.mutate))
+	mux.HandleFunc(PathValidate, s.serve(s.validate))
+	mux.HandleFunc(PathConvert, s.convert)
+	return mux
+}
+
+// Run serves the webhooks with TLS until stopCh is closed.
+func (s *Server) Run(stopCh <-chan struct{}) error {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-stopCh
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	glog.Infof("Starting admission webhook server on :%d", s.port)
+	if err := srv.ListenAndServeTLS(s.certFile, s.keyFile); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+type admitFunc func(req *admissionv1.AdmissionRequest,
+	inference *v2alpha1.Inference) *admissionv1.AdmissionResponse
+
+// serve decodes the admission review, admits the inference and writes the
+// response back.
+func (s *Server) serve(admit admitFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		review := admissionv1.AdmissionReview{}
+		if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+			http.Error(w, "invalid admission review", http.StatusBadRequest)
+			return
+		}
+
+		var resp *admissionv1.AdmissionResponse
+		inference := &v2alpha1.Inference{}
+		if err := json.Unmarshal(review.Request.Object.Raw, inference); err != nil {
+			resp = deny(fmt.Errorf("failed to decode inference: %w", err))
+		} else {
+			resp = admit(review.Request, inference)
+		}
+		resp.UID = review.Request.UID
+		review.Response = resp
+		review.Request = nil
+
+		res, err := json.Marshal(review)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(res)
+	}
+}
+
+// mutate sets the defaults of the inference spec with a JSON patch. The
+// author of the spec change is recorded in the annotations. The updates
+// which keep the spec, e.g. the finalizer or the status updates, and the
+// inferences being deleted are not mutated, since defaulting the spec of
+// the existing inferences would roll them out.
+func (s *Server) mutate(req *admissionv1.AdmissionRequest,
+	inference *v2alpha1.Inference) *admissionv1.AdmissionResponse {
+	if inference.DeletionTimestamp != nil {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	if changed, err := specChanged(req, inference); err != nil {
+		return deny(err)
+	} else if !changed {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	defaulted := inference.DeepCopy()
+	DefaultInference(defaulted)
+
+	ops := []map[string]interface{}{}
+	if !equality.Semantic.DeepEqual(defaulted.Spec, inference.Spec) {
+		ops = append(ops, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/spec",
+			"value": defaulted.Spec,
+		})
+	}
+	if annotations, changed := recordChange(req, defaulted); changed {
+		ops = append(ops, map[string]interface{}{
+			"op":    "add",
+			"path":  "/metadata/annotations",
+			"value": annotations,
+		})
+	}
+	if len(ops) == 0 {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return deny(err)
+	}
+	patchType := admissionv1.PatchTypeJSONPatch
+	return &admissionv1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// specChanged returns true if the inference is created or its spec is
+// updated.
+func specChanged(req *admissionv1.AdmissionRequest,
+	inference *v2alpha1.Inference) (bool, error) {
+	if req.Operation != admissionv1.Update {
+		return true, nil
+	}
+	old := &v2alpha1.Inference{}
+	if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+		return false, fmt.Errorf("failed to decode the old inference: %w", err)
+	}
+	return !equality.Semantic.DeepEqual(old.Spec, inference.Spec), nil
+}
+
+// recordChange returns the annotations with the author of the change if the
+// spec is created or changed. The change cause is removed if it is not
+// updated along with the spec, since it describes the previous change.
+func recordChange(req *admissionv1.AdmissionRequest,
+	inference *v2alpha1.Inference) (map[string]string, bool) {
+	old := &v2alpha1.Inference{}
+	if req.Operation == admissionv1.Update {
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return nil, false
+		}
+		if equality.Semantic.DeepEqual(old.Spec, inference.Spec) {
+			return nil, false
+		}
+	}
+
+	annotations := map[string]string{}
+	for k, v := range inference.Annotations {
+		annotations[k] = v
+	}
+	annotations[consts.AnnotationChangeAuthor] = req.UserInfo.Username
+	if cause, ok := old.Annotations[consts.AnnotationChangeCause]; ok &&
+		cause == annotations[consts.AnnotationChangeCause] {
+		delete(annotations, consts.AnnotationChangeCause)
+	}
+	return annotations, true
+}
+
+// validate validates the inference if it is created or its spec is updated.
+// The existing inferences which do not pass the current rules could still
+// be updated without the spec changes, e.g. adding or removing the
+// finalizer, and deleted.
+func (s *Server) validate(req *admissionv1.AdmissionRequest,
+	inference *v2alpha1.Inference) *admissionv1.AdmissionResponse {
+	if inference.DeletionTimestamp != nil {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	if changed, err := specChanged(req, inference); err != nil {
+		return deny(err)
+	} else if !changed {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	if err := ValidateInference(inference, s.startupProbePeriodSeconds); err != nil {
+		glog.V(2).Infof("Inference '%s' is rejected: %v", inference.Name, err)
+		return deny(err)
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+func deny(err error) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Reason:  metav1.StatusReasonInvalid,
+			Message: err.Error(),
+			Code:    http.StatusUnprocessableEntity,
+		},
+	}
+}
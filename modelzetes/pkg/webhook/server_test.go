@@ -0,0 +1,243 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v2alpha1 "github.com/tensorchord/openmodelz/modelzetes/pkg/apis/modelzetes/v2alpha1"
+	"github.com/tensorchord/openmodelz/modelzetes/pkg/consts"
+	. "github.com/tensorchord/openmodelz/modelzetes/pkg/pointer"
+)
+
+func review(t *testing.T, path string, inference *v2alpha1.Inference) *admissionv1.AdmissionResponse {
+	return reviewUpdate(t, path, nil, inference)
+}
+
+// reviewUpdate reviews the update from the old inference, or the creation if
+// the old one is nil.
+func reviewUpdate(t *testing.T, path string,
+	old, inference *v2alpha1.Inference) *admissionv1.AdmissionResponse {
+	raw, err := json.Marshal(inference)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &admissionv1.AdmissionRequest{
+		UID:       "uid",
+		Operation: admissionv1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+	if old != nil {
+		oldRaw, err := json.Marshal(old)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Operation = admissionv1.Update
+		req.OldObject = runtime.RawExtension{Raw: oldRaw}
+	}
+	body, err := json.Marshal(admissionv1.AdmissionReview{Request: req})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	New(0, "", "", 2).Handler().ServeHTTP(rec,
+		httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code %d", rec.Code)
+	}
+
+	res := admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Response == nil || res.Response.UID != "uid" {
+		t.Fatalf("unexpected response %+v", res.Response)
+	}
+	return res.Response
+}
+
+func Test_Mutate(t *testing.T) {
+	resp := review(t, PathMutate, &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{Name: "bert"},
+		Spec: v2alpha1.InferenceSpec{
+			Image:     "bert:latest",
+			Framework: v2alpha1.FrameworkGradio,
+		},
+	})
+	if !resp.Allowed {
+		t.Fatalf("expected allowed, got %+v", resp.Result)
+	}
+
+	patch := []struct {
+		Op    string                 `json:"op"`
+		Path  string                 `json:"path"`
+		Value v2alpha1.InferenceSpec `json:"value"`
+	}{}
+	if err := json.Unmarshal(resp.Patch, &patch); err != nil {
+		t.Fatal(err)
+	}
+	spec := patch[0].Value
+	if spec.Name != "bert" || *spec.Port != 7860 ||
+		*spec.Scaling.MaxReplicas != 1 || *spec.Scaling.StartupDuration != 600 {
+		t.Errorf("unexpected defaults %+v", spec)
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	valid := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{Name: "bert"},
+		Spec: v2alpha1.InferenceSpec{
+			Name:  "bert",
+			Image: "bert:latest",
+			Port:  Ptr(int32(8080)),
+			Scaling: &v2alpha1.ScalingConfig{
+				StartupDuration: Ptr(int32(60)),
+			},
+		},
+	}
+	if resp := review(t, PathValidate, valid); !resp.Allowed {
+		t.Errorf("expected allowed, got %+v", resp.Result)
+	}
+
+	invalid := valid.DeepCopy()
+	invalid.Spec.Scaling.StartupDuration = Ptr(int32(1))
+	if resp := review(t, PathValidate, invalid); resp.Allowed {
+		t.Errorf("startup duration less than the probe period should be rejected")
+	}
+
+	invalid = valid.DeepCopy()
+	invalid.Spec.Constraints = []string{"gpu"}
+	if resp := review(t, PathValidate, invalid); resp.Allowed {
+		t.Errorf("invalid constraint should be rejected")
+	}
+
+	invalid = valid.DeepCopy()
+	invalid.Spec.Scheduling = &v2alpha1.SchedulingConfig{
+		TopologySpreadConstraints: []v2alpha1.TopologySpreadConstraint{
+			{TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: "Never"},
+		},
+	}
+	if resp := review(t, PathValidate, invalid); resp.Allowed {
+		t.Errorf("invalid topology spread constraint should be rejected")
+	}
+}
+
+func Test_MutateKeepsUnchangedSpec(t *testing.T) {
+	// The existing inference created before the defaults are added.
+	old := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{Name: "bert"},
+		Spec: v2alpha1.InferenceSpec{
+			Name:    "bert",
+			Image:   "bert:latest",
+			Scaling: &v2alpha1.ScalingConfig{MinReplicas: Ptr(int32(1))},
+		},
+	}
+	updated := old.DeepCopy()
+	updated.Finalizers = []string{"tensorchord.ai/cleanup"}
+	if resp := reviewUpdate(t, PathMutate, old, updated); !resp.Allowed || resp.Patch != nil {
+		t.Errorf("expected the finalizer update not to be mutated, got %s", resp.Patch)
+	}
+
+	deleting := updated.DeepCopy()
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	if resp := reviewUpdate(t, PathMutate, updated, deleting); !resp.Allowed || resp.Patch != nil {
+		t.Errorf("expected the deleting inference not to be mutated, got %s", resp.Patch)
+	}
+
+	// The defaulted spec is not replaced again, only the author is
+	// recorded.
+	defaulted := old.DeepCopy()
+	DefaultInference(defaulted)
+	resp := review(t, PathMutate, defaulted)
+	patch := []struct {
+		Path string `json:"path"`
+	}{}
+	if err := json.Unmarshal(resp.Patch, &patch); err != nil {
+		t.Fatal(err)
+	}
+	if len(patch) != 1 || patch[0].Path != "/metadata/annotations" {
+		t.Errorf("expected no patch of the defaulted spec, got %s", resp.Patch)
+	}
+}
+
+func Test_ValidateUnchangedSpec(t *testing.T) {
+	// The existing inference which does not pass the current rules.
+	old := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{Name: "bert"},
+		Spec: v2alpha1.InferenceSpec{
+			Name:        "bert",
+			Image:       "bert:latest",
+			Constraints: []string{"gpu"},
+		},
+	}
+	if resp := review(t, PathValidate, old); resp.Allowed {
+		t.Fatalf("expected the invalid inference to be rejected")
+	}
+
+	updated := old.DeepCopy()
+	updated.Finalizers = []string{"tensorchord.ai/cleanup"}
+	if resp := reviewUpdate(t, PathValidate, old, updated); !resp.Allowed {
+		t.Errorf("expected the finalizer update to be allowed, got %+v", resp.Result)
+	}
+
+	deleting := updated.DeepCopy()
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	deleting.Spec.Image = ""
+	if resp := reviewUpdate(t, PathValidate, updated, deleting); !resp.Allowed {
+		t.Errorf("expected the deleting inference to be allowed, got %+v", resp.Result)
+	}
+
+	changed := updated.DeepCopy()
+	changed.Spec.Image = "bert:v2"
+	if resp := reviewUpdate(t, PathValidate, updated, changed); resp.Allowed {
+		t.Errorf("expected the spec update to be validated")
+	}
+}
+
+func Test_recordChange(t *testing.T) {
+	old := &v2alpha1.Inference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bert",
+			Annotations: map[string]string{
+				consts.AnnotationChangeCause: "Rollback to revision 1",
+			},
+		},
+		Spec: v2alpha1.InferenceSpec{Name: "bert", Image: "bert:v1"},
+	}
+	raw, _ := json.Marshal(old)
+	req := &admissionv1.AdmissionRequest{
+		Operation: admissionv1.Update,
+		OldObject: runtime.RawExtension{Raw: raw},
+		UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+	}
+
+	if _, changed := recordChange(req, old.DeepCopy()); changed {
+		t.Errorf("expected no change if the spec is the same")
+	}
+
+	updated := old.DeepCopy()
+	updated.Spec.Image = "bert:v2"
+	annotations, changed := recordChange(req, updated)
+	if !changed || annotations[consts.AnnotationChangeAuthor] != "alice" {
+		t.Errorf("expected the author to be recorded, got %v", annotations)
+	}
+	if _, ok := annotations[consts.AnnotationChangeCause]; ok {
+		t.Errorf("expected the stale change cause to be removed")
+	}
+
+	updated.Annotations[consts.AnnotationChangeCause] = "Upgrade to v2"
+	annotations, _ = recordChange(req, updated)
+	if annotations[consts.AnnotationChangeCause] != "Upgrade to v2" {
+		t.Errorf("expected the new change cause to be kept, got %v", annotations)
+	}
+}